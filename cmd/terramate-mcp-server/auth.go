@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// authRealm is announced to clients that fail authentication.
+const authRealm = "terramate-mcp-server"
+
+// authenticate rejects HTTP requests that do not carry one of the configured
+// bearer tokens. The tokens are read from the current configuration, so they
+// can be rotated with a reload; without tokens every request is accepted.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.RLock()
+		tokens := s.config.HTTPAuthTokens
+		s.mu.RUnlock()
+		if len(tokens) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := bearerToken(r)
+		if !ok || !validToken(tokens, token) {
+			slog.Warn("Rejected unauthenticated HTTP request", "remote_addr", r.RemoteAddr, "path", r.URL.Path, "token_present", ok)
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm=%q`, authRealm))
+			http.Error(w, "unauthorized: a valid bearer token is required", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bearerToken returns the token of the request's Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// validToken reports whether token is one of tokens. The comparison takes
+// the same time whichever token matches, and regardless of their lengths.
+func validToken(tokens []string, token string) bool {
+	got := sha256.Sum256([]byte(token))
+	valid := 0
+	for _, t := range tokens {
+		want := sha256.Sum256([]byte(t))
+		valid |= subtle.ConstantTimeCompare(got[:], want[:])
+	}
+	return valid == 1
+}
+
+// loopbackAddr reports whether the listen address addr only accepts local
+// connections.
+func loopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// readTokenFile reads the tokens of path, one per line. Blank lines and
+// lines starting with # are ignored.
+func readTokenFile(path string) ([]string, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var tokens []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens = append(tokens, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("token file %s has no tokens", path)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAuthenticate(t *testing.T) {
+	s := &Server{config: &Config{HTTPAuthTokens: []string{"alpha", "beta"}}}
+	handler := s.authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	serve := func(authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, httpEndpointPath, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, authorization := range []string{"Bearer alpha", "bearer beta"} {
+		if rec := serve(authorization); rec.Code != http.StatusNoContent {
+			t.Fatalf("%q: expected the request to pass, got %d", authorization, rec.Code)
+		}
+	}
+	for _, authorization := range []string{"", "Bearer gamma", "Bearer ", "Basic alpha", "alpha"} {
+		rec := serve(authorization)
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("%q: expected 401, got %d", authorization, rec.Code)
+		}
+		if got := rec.Header().Get("WWW-Authenticate"); got != `Bearer realm="terramate-mcp-server"` {
+			t.Fatalf("%q: unexpected WWW-Authenticate %q", authorization, got)
+		}
+	}
+
+	// Tokens follow the reloaded configuration
+	s.config = &Config{HTTPAuthTokens: []string{"gamma"}}
+	if rec := serve("Bearer alpha"); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected a rotated-out token to be rejected, got %d", rec.Code)
+	}
+	if rec := serve("Bearer gamma"); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected the new token to pass, got %d", rec.Code)
+	}
+
+	s.config = &Config{}
+	if rec := serve(""); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected requests to pass without configured tokens, got %d", rec.Code)
+	}
+}
+
+func TestReadTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens")
+	if err := os.WriteFile(path, []byte("# agents\nalpha\n\n  beta  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := readTokenFile(path)
+	if err != nil {
+		t.Fatalf("readTokenFile error: %v", err)
+	}
+	if len(tokens) != 2 || tokens[0] != "alpha" || tokens[1] != "beta" {
+		t.Fatalf("unexpected tokens: %q", tokens)
+	}
+
+	if err := os.WriteFile(path, []byte("# none yet\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readTokenFile(path); err == nil {
+		t.Fatal("expected a file without tokens to fail")
+	}
+}
+
+func TestLoopbackAddr(t *testing.T) {
+	cases := map[string]bool{
+		"127.0.0.1:8080": true,
+		"localhost:8080": true,
+		"[::1]:8080":     true,
+		"0.0.0.0:8080":   false,
+		":8080":          false,
+		"10.0.0.5:8080":  false,
+	}
+	for addr, want := range cases {
+		if got := loopbackAddr(addr); got != want {
+			t.Errorf("loopbackAddr(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestLoadConfig_HTTPAuth(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens")
+	if err := os.WriteFile(tokenFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	args := func(content string, extra ...string) []string {
+		return append([]string{"terramate-mcp-server", "--config", writeConfigFile(t, content)}, extra...)
+	}
+
+	config, err := reloadConfig(appFlags, args("", "--transport", "http", "--http-auth-token", "from-flag", "--http-auth-token-file", tokenFile))
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if len(config.HTTPAuthTokens) != 2 || config.HTTPAuthTokens[0] != "from-flag" || config.HTTPAuthTokens[1] != "from-file" {
+		t.Fatalf("unexpected tokens: %q", config.HTTPAuthTokens)
+	}
+
+	t.Setenv("AGENT_TOKEN", "from-env")
+	config, err = reloadConfig(appFlags, args("transport: http\nhttp_auth:\n  token_env: AGENT_TOKEN\n  token_file: "+tokenFile+"\n"))
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if len(config.HTTPAuthTokens) != 2 || config.HTTPAuthTokens[0] != "from-env" || config.HTTPAuthTokens[1] != "from-file" {
+		t.Fatalf("expected the config file tokens, got %q", config.HTTPAuthTokens)
+	}
+
+	if _, err := reloadConfig(appFlags, args("transport: http\nhttp_auth:\n  token_env: UNSET_AGENT_TOKEN\n")); err == nil {
+		t.Fatal("expected an unset token variable to fail rather than disable authentication")
+	}
+	if _, err := reloadConfig(appFlags, args("", "--http-auth-token", "from-flag")); err == nil {
+		t.Fatal("expected authentication on the stdio transport to fail")
+	}
+}
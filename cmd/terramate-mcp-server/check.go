@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+	"github.com/urfave/cli/v2"
+)
+
+// checkTimeout bounds the API requests of the check command.
+const checkTimeout = time.Minute
+
+// checkCommand validates the configuration against Terramate Cloud without
+// serving, e.g. in CI before rolling the server out to developers.
+var checkCommand = &cli.Command{
+	Name:   "check",
+	Usage:  "Validate the configuration, credential and API access, and list the tools that would be registered",
+	Flags:  appFlags,
+	Action: runCheck,
+}
+
+// runCheck loads the configuration and credential the way the server does,
+// resolves the memberships, probes the APIs of each organization and prints
+// the tools that would be registered. It fails on the first blocking problem.
+func runCheck(c *cli.Context) error {
+	out := c.App.Writer
+	config, err := loadConfig(c)
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	if config.Profile != "" {
+		_, _ = fmt.Fprintf(out, "Configuration: ok (profile %s)\n", config.Profile)
+	} else {
+		_, _ = fmt.Fprintln(out, "Configuration: ok")
+	}
+
+	if !injectedCredential(config) && !config.LocalOnly {
+		if credErr := credentialAvailable(config); credErr != nil {
+			return credErr
+		}
+	}
+	var callLog *tmc.CallLog
+	if config.DebugTools {
+		callLog = tmc.NewCallLog(tmc.DefaultCallLogSize)
+	}
+	b, err := newBackend(config, nil, newFreshnessMetrics(), callLog, authHooks{})
+	if err != nil {
+		return err
+	}
+	if config.LocalOnly {
+		_, _ = fmt.Fprintln(out, "API: skipped in local-only mode")
+		printTools(out, b, config)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context, checkTimeout)
+	defer cancel()
+	memberships, _, err := b.client.Memberships.List(ctx)
+	var apiErr *terramate.APIError
+	if errors.As(err, &apiErr) && apiErr.IsUnauthorized() {
+		return fmt.Errorf("%s rejected the credential: %s (check the region, or run 'terramate cloud login' again)", b.client.BaseURL(), terramate.ErrAuthenticationFailed)
+	}
+	if err != nil {
+		return fmt.Errorf("%s is not reachable: %w", b.client.BaseURL(), err)
+	}
+	if len(memberships) == 0 {
+		return fmt.Errorf("the credential has no organization memberships")
+	}
+	_, _ = fmt.Fprintf(out, "API: %s accepted the credential\n", b.client.BaseURL())
+
+	if err = checkOrganizations(ctx, out, b.client, memberships, config.DefaultOrganization); err != nil {
+		return err
+	}
+	printTools(out, b, config)
+	return nil
+}
+
+// checkOrganizations prints the memberships with the APIs available in each
+// organization. It fails if the default organization is not a membership.
+func checkOrganizations(ctx context.Context, out io.Writer, client *terramate.Client, memberships []terramate.Membership, defaultOrg string) error {
+	_, _ = fmt.Fprintf(out, "Organizations (%d):\n", len(memberships))
+	found := defaultOrg == ""
+	for _, m := range memberships {
+		found = found || m.OrgUUID == defaultOrg
+		available, err := client.ProbeServices(ctx, m.OrgUUID)
+		services := make([]string, 0, len(available))
+		for service, ok := range available {
+			state := "ok"
+			if !ok {
+				state = "unavailable"
+			}
+			services = append(services, fmt.Sprintf("%s %s", service, state))
+		}
+		sort.Strings(services)
+		_, _ = fmt.Fprintf(out, "  %s (%s): role %s; %s\n", m.OrgName, m.OrgUUID, m.Role, strings.Join(services, ", "))
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "    warning: %v\n", err)
+		}
+	}
+	if !found {
+		return fmt.Errorf("default organization %s is not one of the credential's memberships", defaultOrg)
+	}
+	return nil
+}
+
+// printTools prints the tools the server would register with config.
+func printTools(out io.Writer, b *backend, config *Config) {
+	tools := b.toolHandlers.Tools()
+	_, _ = fmt.Fprintf(out, "Tools (%d):\n", len(tools))
+	for _, tool := range tools {
+		_, _ = fmt.Fprintf(out, "  %s\n", tool.Tool.Name)
+	}
+	if config.IndexPath != "" {
+		_, _ = fmt.Fprintf(out, "  (and the index tools, served from %s)\n", config.IndexPath)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+// runCheckCommand runs the check command with args, returning its output.
+func runCheckCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	app := &cli.App{Flags: appFlags, Commands: []*cli.Command{checkCommand}, Writer: &out}
+	args = append([]string{"terramate-mcp-server", "check", "--config", writeConfigFile(t, "")}, args...)
+	err := app.Run(args)
+	return out.String(), err
+}
+
+func TestCheckCommand(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/memberships":
+			_, _ = w.Write([]byte(`[{"org_uuid":"org-uuid","org_name":"acme","role":"admin","status":"active"}]`))
+		case strings.HasPrefix(r.URL.Path, "/v1/stack_deployments/"):
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer api.Close()
+
+	out, err := runCheckCommand(t, "--api-key", "key", "--base-url", api.URL, "--toolsets", "stacks")
+	if err != nil {
+		t.Fatalf("check error: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"API: " + api.URL + " accepted the credential",
+		"acme (org-uuid): role admin; deployments unavailable, resources ok, review_requests ok, stacks ok",
+		"Tools (7):", "  tmc_list_stacks\n", "  tmc_server_info\n", "  tmc_credential_status\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in the output:\n%s", want, out)
+		}
+	}
+
+	if _, err := runCheckCommand(t, "--api-key", "key", "--base-url", api.URL, "--default-organization", "other-org"); err == nil ||
+		!strings.Contains(err.Error(), "other-org is not one of the credential's memberships") {
+		t.Fatalf("expected an unknown default organization to fail, got %v", err)
+	}
+}
+
+func TestCheckCommand_RejectedCredential(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer api.Close()
+
+	if _, err := runCheckCommand(t, "--api-key", "key", "--base-url", api.URL); err == nil || !strings.Contains(err.Error(), "rejected the credential") {
+		t.Fatalf("expected the rejected credential to fail the check, got %v", err)
+	}
+}
+
+func TestCheckCommand_LocalOnly(t *testing.T) {
+	// The stored credential is ignored, and no API is contacted
+	credFile := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	writeTestCredentialFile(t, credFile)
+
+	out, err := runCheckCommand(t, "--local-only", "--debug-tools", "--login-client-id", "client-id", "--credential-file", credFile)
+	if err != nil {
+		t.Fatalf("check error: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "API: skipped in local-only mode\nTools (3):\n  tmc_replay_last\n  tmc_inspect_call\n  tmc_server_info\n") {
+		t.Fatalf("expected only the local tools:\n%s", out)
+	}
+
+	if _, err := runCheckCommand(t, "--local-only", "--api-key", "key"); err == nil || !strings.Contains(err.Error(), "--local-only loads no credential") {
+		t.Fatalf("expected --api-key to be rejected with --local-only, got %v", err)
+	}
+}
@@ -0,0 +1,397 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/terramate-io/terramate-mcp-server/tools/guardrail"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigPath is where the server looks for its config file.
+const defaultConfigPath = "~/.terramate.d/mcp-server.yaml"
+
+// fileConfig mirrors the YAML config file. Every setting except the guardrail
+// policy has a flag counterpart; flags and environment variables take
+// precedence over values from the file.
+type fileConfig struct {
+	// APIKeyEnv names an environment variable holding the API key.
+	APIKeyEnv string `yaml:"api_key_env"`
+	// APIKeyFile is a file holding the API key.
+	APIKeyFile string `yaml:"api_key_file"`
+	// GitHubTokenEnv names an environment variable holding the GitHub token.
+	GitHubTokenEnv string `yaml:"github_token_env"`
+
+	// Profile selects the profile used when --profile is not given.
+	Profile string `yaml:"profile"`
+	// Profiles are named sets of connection settings, e.g. one per region
+	// or environment, overriding the top-level ones when selected.
+	Profiles map[string]profileConfig `yaml:"profiles"`
+
+	CredentialFile          string            `yaml:"credential_file"`
+	CredentialStore         string            `yaml:"credential_store"` // file or keychain
+	ReadOnlyCredentialFile  *bool             `yaml:"read_only_credential_file"`
+	Region                  string            `yaml:"region"`
+	BaseURL                 string            `yaml:"base_url"`
+	TokenRefreshEndpoint    string            `yaml:"token_refresh_endpoint"`
+	TokenRefreshClientID    string            `yaml:"token_refresh_client_id"`
+	TokenRevocationEndpoint string            `yaml:"token_revocation_endpoint"`
+	LoginClientID           string            `yaml:"login_client_id"`
+	Proxy                   string            `yaml:"proxy"` // e.g. http://proxy.corp:3128
+	DefaultOrganization     string            `yaml:"default_organization"`
+	ActAs                   string            `yaml:"act_as"`
+	Toolsets                []string          `yaml:"toolsets"`
+	MaxConcurrentAPICalls   *int              `yaml:"max_concurrent_api_calls"`
+	MaxConcurrentTools      *int              `yaml:"max_concurrent_tools"`
+	MaxResponseSize         *int              `yaml:"max_response_size"` // MiB
+	DefaultToolConcurrency  *int              `yaml:"default_tool_concurrency"`
+	ToolConcurrency         map[string]int    `yaml:"tool_concurrency"`
+	ToolTimeouts            map[string]string `yaml:"tool_timeouts"` // e.g. tmc_get_deployment_logs: 2m
+	Transport               string            `yaml:"transport"`
+	HTTPAddr                string            `yaml:"http_addr"`
+	ShutdownTimeout         string            `yaml:"shutdown_timeout"` // e.g. 30s
+	AllowCredentialOverride *bool             `yaml:"allow_credential_override"`
+	DebugTools              *bool             `yaml:"debug_tools"`
+	Demo                    *bool             `yaml:"demo"`
+	LocalOnly               *bool             `yaml:"local_only"`
+
+	TLS      tlsConfig      `yaml:"tls"`
+	HTTPAuth httpAuthConfig `yaml:"http_auth"`
+	Index    indexConfig    `yaml:"index"`
+	Log      logConfig      `yaml:"log"`
+
+	// Guardrails is the apply policy checked by tmc_advise_apply.
+	Guardrails guardrail.Policy `yaml:"guardrails"`
+}
+
+// profileConfig holds the connection settings of a profile.
+type profileConfig struct {
+	APIKeyEnv               string `yaml:"api_key_env"`
+	APIKeyFile              string `yaml:"api_key_file"`
+	CredentialFile          string `yaml:"credential_file"`
+	Region                  string `yaml:"region"`
+	BaseURL                 string `yaml:"base_url"`
+	TokenRefreshEndpoint    string `yaml:"token_refresh_endpoint"`
+	TokenRefreshClientID    string `yaml:"token_refresh_client_id"`
+	TokenRevocationEndpoint string `yaml:"token_revocation_endpoint"`
+	DefaultOrganization     string `yaml:"default_organization"`
+	// Organizations are the UUIDs of the organizations whose tool calls use
+	// the credential of the profile (see organizationProfiles).
+	Organizations []string `yaml:"organizations"`
+}
+
+// tlsConfig holds the TLS settings of the http transport from the config file.
+type tlsConfig struct {
+	Cert  string `yaml:"cert"`
+	Key   string `yaml:"key"`
+	Watch *bool  `yaml:"watch"`
+}
+
+// httpAuthConfig references the bearer tokens accepted by the http transport.
+type httpAuthConfig struct {
+	// TokenEnv names an environment variable holding a token.
+	TokenEnv string `yaml:"token_env"`
+	// TokenFile is a file holding tokens, one per line.
+	TokenFile string `yaml:"token_file"`
+}
+
+// indexConfig holds local index settings from the config file.
+type indexConfig struct {
+	Path         string `yaml:"path"`
+	Organization string `yaml:"organization"`
+	SyncInterval string `yaml:"sync_interval"` // e.g. 5m
+}
+
+// logConfig holds log settings from the config file.
+type logConfig struct {
+	Level  string `yaml:"level"`  // debug, info, warn, error
+	Format string `yaml:"format"` // text, json
+	// File receives server logs instead of stderr.
+	File string `yaml:"file"`
+}
+
+// loadFileConfig reads the config file at path. A missing file is only an
+// error when the path was set explicitly.
+func loadFileConfig(path string, explicit bool) (*fileConfig, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return &fileConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var cfg fileConfig
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// selectProfile overrides the top-level connection settings with those of
+// the named profile (the file's default profile when name is empty). A
+// profile setting any credential replaces the top-level credential.
+func (cfg *fileConfig) selectProfile(name string) (string, error) {
+	if name == "" {
+		name = cfg.Profile
+	}
+	if name == "" {
+		return "", nil
+	}
+	p, ok := cfg.Profiles[name]
+	if !ok {
+		return "", fmt.Errorf("unknown profile %q (available: %s)", name, profileNames(cfg.Profiles))
+	}
+
+	if p.APIKeyEnv != "" || p.APIKeyFile != "" || p.CredentialFile != "" {
+		cfg.APIKeyEnv, cfg.APIKeyFile, cfg.CredentialFile = p.APIKeyEnv, p.APIKeyFile, p.CredentialFile
+	}
+	if p.Region != "" {
+		cfg.Region = p.Region
+	}
+	if p.BaseURL != "" {
+		cfg.BaseURL = p.BaseURL
+	}
+	if p.TokenRefreshEndpoint != "" {
+		cfg.TokenRefreshEndpoint, cfg.TokenRefreshClientID = p.TokenRefreshEndpoint, p.TokenRefreshClientID
+	}
+	if p.TokenRevocationEndpoint != "" {
+		cfg.TokenRevocationEndpoint = p.TokenRevocationEndpoint
+	}
+	if p.DefaultOrganization != "" {
+		cfg.DefaultOrganization = p.DefaultOrganization
+	}
+	return name, nil
+}
+
+// credentialProfile is the credential of a config file profile, selected for
+// single tool calls with the credential_profile argument.
+type credentialProfile struct {
+	APIKey         string
+	CredentialFile string
+	Region         string
+	BaseURL        string
+}
+
+// credentialProfiles resolves the credentials of the profiles, keyed by name.
+func (cfg *fileConfig) credentialProfiles() (map[string]credentialProfile, error) {
+	profiles := make(map[string]credentialProfile, len(cfg.Profiles))
+	for name, p := range cfg.Profiles {
+		apiKey, err := cfg.secret(p.APIKeyEnv, p.APIKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: api key: %w", name, err)
+		}
+		profiles[name] = credentialProfile{APIKey: apiKey, CredentialFile: p.CredentialFile, Region: p.Region, BaseURL: p.BaseURL}
+	}
+	return profiles, nil
+}
+
+// organizationProfiles maps the UUIDs of the organizations listed by the
+// profiles to their profile. An organization must not be listed twice.
+func (cfg *fileConfig) organizationProfiles() (map[string]string, error) {
+	orgs := map[string]string{}
+	for name, p := range cfg.Profiles {
+		for _, orgUUID := range p.Organizations {
+			if other, ok := orgs[orgUUID]; ok {
+				first, second := min(name, other), max(name, other)
+				return nil, fmt.Errorf("organization %s is listed by profiles %s and %s", orgUUID, first, second)
+			}
+			orgs[orgUUID] = name
+		}
+	}
+	if len(orgs) == 0 {
+		return nil, nil
+	}
+	return orgs, nil
+}
+
+// profileNames lists the names of profiles, sorted.
+func profileNames[P any](profiles map[string]P) string {
+	if len(profiles) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// applyFileConfig sets every flag that was not given on the command line or
+// through its environment variable to the value from the config file.
+func applyFileConfig(c *cli.Context, cfg *fileConfig) error {
+	values, err := cfg.secrets()
+	if err != nil {
+		return err
+	}
+	settings := map[string]string{
+		credentialFileFlag.Name:          cfg.CredentialFile,
+		credentialStoreFlag.Name:         cfg.CredentialStore,
+		regionFlag.Name:                  cfg.Region,
+		baseURLFlag.Name:                 cfg.BaseURL,
+		tokenRefreshEndpointFlag.Name:    cfg.TokenRefreshEndpoint,
+		tokenRefreshClientIDFlag.Name:    cfg.TokenRefreshClientID,
+		tokenRevocationEndpointFlag.Name: cfg.TokenRevocationEndpoint,
+		loginClientIDFlag.Name:           cfg.LoginClientID,
+		proxyFlag.Name:                   cfg.Proxy,
+		defaultOrganizationFlag.Name:     cfg.DefaultOrganization,
+		actAsFlag.Name:                   cfg.ActAs,
+		transportFlag.Name:               cfg.Transport,
+		httpAddrFlag.Name:                cfg.HTTPAddr,
+		tlsCertFlag.Name:                 cfg.TLS.Cert,
+		tlsKeyFlag.Name:                  cfg.TLS.Key,
+		shutdownTimeoutFlag.Name:         cfg.ShutdownTimeout,
+		indexPathFlag.Name:               cfg.Index.Path,
+		indexOrganizationFlag.Name:       cfg.Index.Organization,
+		indexSyncIntervalFlag.Name:       cfg.Index.SyncInterval,
+		logLevelFlag.Name:                cfg.Log.Level,
+		logFormatFlag.Name:               cfg.Log.Format,
+		logFileFlag.Name:                 cfg.Log.File,
+		httpAuthTokenFileFlag.Name:       cfg.HTTPAuth.TokenFile,
+	}
+	for name, value := range settings {
+		values[name] = value
+	}
+	numbers := map[string]*int{
+		maxConcurrentAPICallsFlag.Name:  cfg.MaxConcurrentAPICalls,
+		maxConcurrentToolsFlag.Name:     cfg.MaxConcurrentTools,
+		maxResponseSizeFlag.Name:        cfg.MaxResponseSize,
+		defaultToolConcurrencyFlag.Name: cfg.DefaultToolConcurrency,
+	}
+	for name, value := range numbers {
+		if value != nil {
+			values[name] = strconv.Itoa(*value)
+		}
+	}
+	switches := map[string]*bool{
+		tlsWatchFlag.Name:                cfg.TLS.Watch,
+		readOnlyCredentialFileFlag.Name:  cfg.ReadOnlyCredentialFile,
+		allowCredentialOverrideFlag.Name: cfg.AllowCredentialOverride,
+		debugToolsFlag.Name:              cfg.DebugTools,
+		demoFlag.Name:                    cfg.Demo,
+		localOnlyFlag.Name:               cfg.LocalOnly,
+	}
+	for name, value := range switches {
+		if value != nil {
+			values[name] = strconv.FormatBool(*value)
+		}
+	}
+	for name, value := range values {
+		if value == "" || c.IsSet(name) {
+			continue
+		}
+		if err := c.Set(name, value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	lists := map[string][]string{
+		toolsetsFlag.Name:        cfg.Toolsets,
+		toolConcurrencyFlag.Name: toolLimitEntries(cfg.ToolConcurrency),
+		toolTimeoutFlag.Name:     toolTimeoutEntries(cfg.ToolTimeouts),
+	}
+	for name, entries := range lists {
+		if c.IsSet(name) {
+			continue
+		}
+		for _, entry := range entries {
+			if err := c.Set(name, entry); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// secrets resolves the secrets referenced by the config file, keyed by the
+// name of their flag.
+func (cfg *fileConfig) secrets() (map[string]string, error) {
+	apiKey, err := cfg.secret(cfg.APIKeyEnv, cfg.APIKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("api key: %w", err)
+	}
+	githubToken, err := cfg.secret(cfg.GitHubTokenEnv, "")
+	if err != nil {
+		return nil, fmt.Errorf("github token: %w", err)
+	}
+	// An unset variable must not silently disable authentication
+	authToken := os.Getenv(cfg.HTTPAuth.TokenEnv)
+	if cfg.HTTPAuth.TokenEnv != "" && authToken == "" {
+		return nil, fmt.Errorf("http auth token: environment variable %s is not set", cfg.HTTPAuth.TokenEnv)
+	}
+	return map[string]string{
+		apiKeyFlag.Name:        apiKey,
+		githubTokenFlag.Name:   githubToken,
+		httpAuthTokenFlag.Name: authToken,
+	}, nil
+}
+
+// secret resolves a value referenced by environment variable name or file.
+func (cfg *fileConfig) secret(envName, file string) (string, error) {
+	switch {
+	case envName != "" && file != "":
+		return "", fmt.Errorf("set either an environment variable or a file, not both")
+	case envName != "":
+		return os.Getenv(envName), nil
+	case file != "":
+		path, err := expandHome(file)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	default:
+		return "", nil
+	}
+}
+
+// toolLimitEntries renders limits as sorted "tool=n" entries.
+func toolLimitEntries(limits map[string]int) []string {
+	entries := make([]string, 0, len(limits))
+	for name, n := range limits {
+		entries = append(entries, fmt.Sprintf("%s=%d", name, n))
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+// toolTimeoutEntries renders timeouts as sorted "tool=duration" entries.
+func toolTimeoutEntries(timeouts map[string]string) []string {
+	entries := make([]string, 0, len(timeouts))
+	for name, d := range timeouts {
+		entries = append(entries, name+"="+d)
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+// expandHome expands a leading ~ to the user's home directory.
+func expandHome(path string) (string, error) {
+	if !strings.HasPrefix(path, "~") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return filepath.Join(home, path[1:]), nil
+}
@@ -0,0 +1,400 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/urfave/cli/v2"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mcp-server.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+// runWithFileConfig runs the CLI flags through applyFileConfig and buildConfig.
+func runWithFileConfig(t *testing.T, cfg *fileConfig, args ...string) *Config {
+	t.Helper()
+	var got *Config
+	app := &cli.App{
+		Flags: appFlags,
+		Action: func(c *cli.Context) error {
+			if err := applyFileConfig(c, cfg); err != nil {
+				return err
+			}
+			var err error
+			got, err = buildConfig(c)
+			return err
+		},
+	}
+	if err := app.Run(append([]string{"terramate-mcp-server"}, args...)); err != nil {
+		t.Fatalf("run error: %v", err)
+	}
+	return got
+}
+
+func TestLoadFileConfig(t *testing.T) {
+	path := writeConfigFile(t, `
+api_key_env: TEST_TMC_KEY
+region: us
+default_organization: org-uuid
+toolsets: [stacks, drifts]
+max_concurrent_api_calls: 8
+tool_concurrency:
+  tmc_list_repositories: 2
+log:
+  level: debug
+  format: json
+  file: /tmp/mcp.log
+`)
+	cfg, err := loadFileConfig(path, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Region != "us" || cfg.DefaultOrganization != "org-uuid" || len(cfg.Toolsets) != 2 ||
+		*cfg.MaxConcurrentAPICalls != 8 || cfg.ToolConcurrency["tmc_list_repositories"] != 2 || cfg.Log.File != "/tmp/mcp.log" ||
+		cfg.Log.Level != "debug" || cfg.Log.Format != "json" {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadFileConfig_Errors(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+	if cfg, err := loadFileConfig(missing, false); err != nil || cfg == nil {
+		t.Fatalf("expected missing default config to be ignored, got %v", err)
+	}
+	if _, err := loadFileConfig(missing, true); err == nil {
+		t.Fatal("expected error for missing explicit config")
+	}
+	if _, err := loadFileConfig(writeConfigFile(t, "regoin: eu\n"), true); err == nil {
+		t.Fatal("expected error for unknown key")
+	}
+	if cfg, err := loadFileConfig(writeConfigFile(t, ""), true); err != nil || cfg == nil {
+		t.Fatalf("expected empty config file to be accepted, got %v", err)
+	}
+}
+
+func TestApplyFileConfig_FlagsTakePrecedence(t *testing.T) {
+	t.Setenv("TEST_TMC_KEY", "key-from-env-ref")
+	maxCalls, maxTools := 8, 4
+	cfg := &fileConfig{
+		APIKeyEnv:             "TEST_TMC_KEY",
+		Region:                "us",
+		DefaultOrganization:   "org-from-file",
+		Toolsets:              []string{"stacks"},
+		MaxConcurrentAPICalls: &maxCalls,
+		MaxConcurrentTools:    &maxTools,
+		ToolConcurrency:       map[string]int{"tmc_list_repositories": 2},
+		ToolTimeouts:          map[string]string{"tmc_get_deployment_logs": "2m"},
+		HTTPAddr:              "0.0.0.0:9000",
+		ShutdownTimeout:       "5s",
+	}
+	t.Setenv("TERRAMATE_MCP_HTTP_ADDR", "127.0.0.1:7000")
+
+	got := runWithFileConfig(t, cfg, "--region", "eu", "--toolsets", "drifts")
+
+	if got.APIKey != "key-from-env-ref" {
+		t.Fatalf("expected API key from referenced env var, got %q", got.APIKey)
+	}
+	if got.Region != "eu" || len(got.Toolsets) != 1 || got.Toolsets[0] != "drifts" {
+		t.Fatalf("expected flags to take precedence, got region %q toolsets %v", got.Region, got.Toolsets)
+	}
+	if got.HTTPAddr != "127.0.0.1:7000" {
+		t.Fatalf("expected environment to take precedence, got %q", got.HTTPAddr)
+	}
+	if got.DefaultOrganization != "org-from-file" || got.MaxConcurrentAPICalls != 8 || got.MaxConcurrentTools != 4 ||
+		got.ToolConcurrency["tmc_list_repositories"] != 2 || got.ToolTimeouts["tmc_get_deployment_logs"] != 2*time.Minute ||
+		got.ShutdownTimeout != 5*time.Second {
+		t.Fatalf("expected unset flags to come from the file, got %+v", got)
+	}
+}
+
+func TestFileConfigSecret(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(keyFile, []byte("file-key\n"), 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	cfg := &fileConfig{}
+	if got, err := cfg.secret("", keyFile); err != nil || got != "file-key" {
+		t.Fatalf("expected key from file, got %q, %v", got, err)
+	}
+	if _, err := cfg.secret("ENV", keyFile); err == nil {
+		t.Fatal("expected error when both references are set")
+	}
+}
+
+func TestReloadConfig_RereadsConfigFile(t *testing.T) {
+	path := writeConfigFile(t, "region: eu\ntoolsets: [drifts]\n")
+	args := []string{"terramate-mcp-server", "--config", path, "--api-key", "key"}
+
+	config, err := reloadConfig(appFlags, args)
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if config.Region != "eu" || len(config.Toolsets) != 1 || config.Toolsets[0] != "drifts" {
+		t.Fatalf("unexpected config: %+v", config)
+	}
+
+	if err := os.WriteFile(path, []byte("region: us\n"), 0o600); err != nil {
+		t.Fatalf("failed to update config: %v", err)
+	}
+	config, err = reloadConfig(appFlags, args)
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if config.Region != "us" || len(config.Toolsets) != 0 || config.APIKey != "key" {
+		t.Fatalf("expected updated config file to be applied, got %+v", config)
+	}
+
+	if err := os.WriteFile(path, []byte("unknown_key: true\n"), 0o600); err != nil {
+		t.Fatalf("failed to update config: %v", err)
+	}
+	if _, err := reloadConfig(appFlags, args); err == nil {
+		t.Fatal("expected error for invalid config file")
+	}
+}
+
+func TestApplyFileConfig_Index(t *testing.T) {
+	cfg := &fileConfig{
+		DefaultOrganization: "org-from-file",
+		Index:               indexConfig{Path: "/tmp/index.db", SyncInterval: "90s"},
+	}
+	got := runWithFileConfig(t, cfg, "--api-key", "key")
+	if got.IndexPath != "/tmp/index.db" || got.IndexSyncInterval != 90*time.Second {
+		t.Fatalf("unexpected index settings: %+v", got)
+	}
+	if got.IndexOrganization != "org-from-file" {
+		t.Fatalf("expected the index to default to the default organization, got %q", got.IndexOrganization)
+	}
+
+	if _, err := reloadConfig(appFlags, []string{"terramate-mcp-server", "--config", writeConfigFile(t, ""), "--index-path", "/tmp/index.db"}); err == nil {
+		t.Fatal("expected error for an index without organization")
+	}
+}
+
+func TestApplyFileConfig_DebugTools(t *testing.T) {
+	enabled := true
+	if got := runWithFileConfig(t, &fileConfig{DebugTools: &enabled}, "--api-key", "key"); !got.DebugTools {
+		t.Fatal("expected debug_tools from the config file to enable the debug tools")
+	}
+	if got := runWithFileConfig(t, &fileConfig{}, "--api-key", "key"); got.DebugTools {
+		t.Fatal("expected the debug tools to be disabled by default")
+	}
+}
+
+func TestLoadConfig_Proxy(t *testing.T) {
+	if got := runWithFileConfig(t, &fileConfig{Proxy: "http://proxy.corp:3128"}, "--api-key", "key"); got.Proxy != "http://proxy.corp:3128" {
+		t.Fatalf("expected the proxy from the config file, got %q", got.Proxy)
+	}
+	args := []string{"terramate-mcp-server", "--config", writeConfigFile(t, ""), "--api-key", "key", "--proxy", "ftp://proxy.corp"}
+	if _, err := reloadConfig(appFlags, args); err == nil {
+		t.Fatal("expected an unsupported proxy scheme to fail")
+	}
+}
+
+func TestLoadConfig_DefaultToolConcurrency(t *testing.T) {
+	if got := runWithFileConfig(t, &fileConfig{}, "--api-key", "key"); got.DefaultToolConcurrency != 4 {
+		t.Fatalf("expected a default of 4, got %d", got.DefaultToolConcurrency)
+	}
+	limit := 8
+	if got := runWithFileConfig(t, &fileConfig{DefaultToolConcurrency: &limit}, "--api-key", "key"); got.DefaultToolConcurrency != 8 {
+		t.Fatalf("expected the limit from the config file, got %d", got.DefaultToolConcurrency)
+	}
+	for _, value := range []string{"0", "-1"} {
+		args := []string{"terramate-mcp-server", "--config", writeConfigFile(t, ""), "--api-key", "key", "--default-tool-concurrency", value}
+		if _, err := reloadConfig(appFlags, args); err == nil {
+			t.Fatalf("expected a limit of %s to fail", value)
+		}
+	}
+}
+
+func TestLoadConfig_MaxResponseSize(t *testing.T) {
+	if got := runWithFileConfig(t, &fileConfig{}, "--api-key", "key"); got.MaxResponseSize != 10 {
+		t.Fatalf("expected a default of 10 MiB, got %d", got.MaxResponseSize)
+	}
+	size := 64
+	if got := runWithFileConfig(t, &fileConfig{MaxResponseSize: &size}, "--api-key", "key"); got.MaxResponseSize != 64 {
+		t.Fatalf("expected the size from the config file, got %d", got.MaxResponseSize)
+	}
+	args := []string{"terramate-mcp-server", "--config", writeConfigFile(t, ""), "--api-key", "key", "--max-response-size", "-1"}
+	if _, err := reloadConfig(appFlags, args); err == nil {
+		t.Fatal("expected a negative size to fail")
+	}
+}
+
+func TestLoadConfig_DetectRegion(t *testing.T) {
+	if got := runWithFileConfig(t, &fileConfig{}, "--api-key", "key"); !got.DetectRegion {
+		t.Fatal("expected the region to be detected when neither region nor base URL is set")
+	}
+	if got := runWithFileConfig(t, &fileConfig{Region: "us"}, "--api-key", "key"); got.DetectRegion || got.Region != "us" {
+		t.Fatalf("expected the configured region to be used, got %q (detect %v)", got.Region, got.DetectRegion)
+	}
+	if got := runWithFileConfig(t, &fileConfig{}, "--api-key", "key", "--base-url", "https://api.terramate.io"); got.DetectRegion {
+		t.Fatal("expected an explicit base URL to disable region detection")
+	}
+}
+
+func TestLoadConfig_CredentialStore(t *testing.T) {
+	if got := runWithFileConfig(t, &fileConfig{}, "--api-key", "key"); got.CredentialStore != credentialStoreFile {
+		t.Fatalf("expected the credential file by default, got %q", got.CredentialStore)
+	}
+	if got := runWithFileConfig(t, &fileConfig{CredentialStore: "keychain"}, "--api-key", "key"); got.CredentialStore != credentialStoreKeychain {
+		t.Fatalf("expected the keychain from the config file, got %q", got.CredentialStore)
+	}
+	args := []string{"terramate-mcp-server", "--config", writeConfigFile(t, ""), "--api-key", "key", "--credential-store", "vault"}
+	if _, err := reloadConfig(appFlags, args); err == nil {
+		t.Fatal("expected an unknown credential store to fail")
+	}
+}
+
+func TestLoadConfig_RefreshTokenRequiresJWT(t *testing.T) {
+	args := []string{"terramate-mcp-server", "--config", writeConfigFile(t, ""), "--refresh-token", "refresh"}
+	if _, err := reloadConfig(appFlags, args); err == nil {
+		t.Fatal("expected a refresh token without a JWT to fail")
+	}
+	args = append(args, "--jwt", "token")
+	config, err := reloadConfig(appFlags, args)
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if config.JWT != "token" || config.RefreshToken != "refresh" {
+		t.Fatalf("expected the JWT and refresh token, got %q and %q", config.JWT, config.RefreshToken)
+	}
+}
+
+func TestLoadConfig_TokenRefreshClientID(t *testing.T) {
+	var clientID string
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID = r.PostFormValue("client_id")
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()}).SignedString([]byte("test-secret"))
+		_, _ = fmt.Fprintf(w, `{"id_token":%q}`, token)
+	}))
+	defer idp.Close()
+
+	credFile := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	writeTestCredentialFile(t, credFile)
+
+	args := []string{"terramate-mcp-server", "--config", writeConfigFile(t, ""), "--credential-file", credFile, "--token-refresh-client-id", "mcp-server"}
+	if _, err := reloadConfig(appFlags, args); err == nil {
+		t.Fatal("expected a token refresh client ID without an endpoint to fail")
+	}
+	config, err := reloadConfig(appFlags, append(args, "--token-refresh-endpoint", idp.URL))
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	credential, _, err := loadCredential(config, idp.Client())
+	if err != nil {
+		t.Fatalf("loadCredential error: %v", err)
+	}
+	if err := credential.(*terramate.JWTCredential).Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	if clientID != "mcp-server" {
+		t.Fatalf("expected a refresh through the OAuth token endpoint, got client_id %q", clientID)
+	}
+}
+
+func TestLoadConfig_WorkloadIdentity(t *testing.T) {
+	if got := runWithFileConfig(t, &fileConfig{}, "--workload-identity", "aws"); got.WorkloadIdentity != workloadIdentityAWS {
+		t.Fatalf("expected the AWS workload identity, got %q", got.WorkloadIdentity)
+	}
+	args := []string{"terramate-mcp-server", "--config", writeConfigFile(t, ""), "--workload-identity", "azure"}
+	if _, err := reloadConfig(appFlags, args); err == nil {
+		t.Fatal("expected an unknown workload identity to fail")
+	}
+}
+
+func TestLoadConfig_ToolsetList(t *testing.T) {
+	got := runWithFileConfig(t, &fileConfig{}, "--api-key", "key", "--toolsets", "stacks,drifts,reviews")
+	if strings.Join(got.Toolsets, ",") != "stacks,drifts,reviews" {
+		t.Fatalf("expected a comma-separated toolset list, got %v", got.Toolsets)
+	}
+}
+
+func TestLoadConfig_Guardrails(t *testing.T) {
+	args := func(content string) []string {
+		return []string{"terramate-mcp-server", "--config", writeConfigFile(t, content), "--api-key", "key"}
+	}
+
+	config, err := reloadConfig(appFlags, args(`
+guardrails:
+  protected_resources:
+    - address: aws_db_instance.*
+      reason: production database
+  change_windows:
+    - days: [mon, tue, wed, thu, fri]
+      start: "09:00"
+      end: "17:00"
+      timezone: Europe/Berlin
+`))
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if config.Guardrails == nil || len(config.Guardrails.ProtectedResources) != 1 || len(config.Guardrails.ChangeWindows) != 1 {
+		t.Fatalf("unexpected guardrails: %+v", config.Guardrails)
+	}
+
+	if config, err := reloadConfig(appFlags, args("region: eu\n")); err != nil || config.Guardrails != nil {
+		t.Fatalf("expected no guardrails without a policy, got %+v (err %v)", config, err)
+	}
+	if _, err := reloadConfig(appFlags, args("guardrails:\n  change_windows:\n    - start: 9am\n      end: \"17:00\"\n")); err == nil {
+		t.Fatal("expected error for an invalid guardrail policy")
+	}
+}
+
+func TestLoadConfig_Profiles(t *testing.T) {
+	t.Setenv("TEST_TMC_STAGING_KEY", "staging-key")
+	t.Setenv("TEST_TMC_PROD_KEY", "prod-key")
+	path := writeConfigFile(t, `
+api_key_file: /nonexistent/key
+region: eu
+toolsets: [drifts]
+profile: staging-eu
+profiles:
+  staging-eu:
+    api_key_env: TEST_TMC_STAGING_KEY
+    default_organization: staging-org
+  prod-us:
+    api_key_env: TEST_TMC_PROD_KEY
+    region: us
+    default_organization: prod-org
+`)
+	load := func(args ...string) (*Config, error) {
+		return reloadConfig(appFlags, append([]string{"terramate-mcp-server", "--config", path}, args...))
+	}
+
+	config, err := load()
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if config.Profile != "staging-eu" || config.APIKey != "staging-key" || config.Region != "eu" ||
+		config.DefaultOrganization != "staging-org" || len(config.Toolsets) != 1 {
+		t.Fatalf("expected the default profile over the top-level settings, got %+v", config)
+	}
+
+	config, err = load("--profile", "prod-us", "--default-organization", "flag-org")
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if config.Profile != "prod-us" || config.APIKey != "prod-key" || config.Region != "us" ||
+		config.DefaultOrganization != "flag-org" {
+		t.Fatalf("expected the selected profile with flags taking precedence, got %+v", config)
+	}
+
+	if _, err := load("--profile", "prod-eu"); err == nil || !strings.Contains(err.Error(), "prod-us, staging-eu") {
+		t.Fatalf("expected unknown profile error listing the profiles, got %v", err)
+	}
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/internal/demo"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/urfave/cli/v2"
+)
+
+// maskedValue replaces the value of secret settings in config show.
+const maskedValue = "********"
+
+// configCommand inspects the configuration without serving.
+var configCommand = &cli.Command{
+	Name:  "config",
+	Usage: "Inspect the server configuration",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "show",
+			Usage:  "Print the effective configuration from flags, environment variables and the config file, with secrets masked",
+			Flags:  appFlags,
+			Action: runConfigShow,
+		},
+	},
+}
+
+// runConfigShow prints the resolved credential source and API endpoint,
+// followed by the value of every setting and where it comes from. Nothing is
+// sent to Terramate Cloud; the region is only resolved when it does not
+// depend on probing the API.
+func runConfigShow(c *cli.Context) error {
+	// Flags set before the config file is applied come from the command line
+	// or the environment
+	sources := map[string]string{}
+	for _, f := range appFlags {
+		if name := f.Names()[0]; c.IsSet(name) {
+			sources[name] = argSource(c, f)
+		}
+	}
+	config, err := loadConfig(c)
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	fileCfg, err := loadFileConfig(c.String(configFlag.Name), c.IsSet(configFlag.Name))
+	if err == nil {
+		_, err = fileCfg.selectProfile(config.Profile)
+	}
+	if err != nil {
+		return err
+	}
+	for _, f := range appFlags {
+		name := f.Names()[0]
+		if _, ok := sources[name]; !ok && c.IsSet(name) {
+			sources[name] = "config file" + fileSourceDetail(fileCfg, name)
+		}
+	}
+
+	out := c.App.Writer
+	_, _ = fmt.Fprintf(out, "Config file: %s\n", configFileState(c.String(configFlag.Name)))
+	if config.Profile != "" {
+		_, _ = fmt.Fprintf(out, "Profile:     %s\n", config.Profile)
+	}
+	_, _ = fmt.Fprintf(out, "Credential:  %s\n", credentialSource(config, sources))
+	_, _ = fmt.Fprintf(out, "API:         %s\n", endpointSource(config, sources))
+	if config.Guardrails != nil {
+		_, _ = fmt.Fprintln(out, "Guardrails:  configured")
+	}
+	_, _ = fmt.Fprintln(out, "Settings:")
+	printSettings(out, c, sources)
+	return nil
+}
+
+// argSource describes where a flag set before the config file was applied
+// comes from: its environment variable when the value matches it, or else
+// the command line, which takes precedence over the environment.
+func argSource(c *cli.Context, f cli.Flag) string {
+	withEnv, ok := f.(interface{ GetEnvVars() []string })
+	if !ok {
+		return "flag"
+	}
+	for _, name := range withEnv.GetEnvVars() {
+		value, set := os.LookupEnv(name)
+		if set && envValue(f, value) == flagValue(c, f) {
+			return "env " + name
+		}
+	}
+	return "flag"
+}
+
+// envValue renders the raw value of an environment variable the way
+// flagValue renders the parsed value of f.
+func envValue(f cli.Flag, raw string) string {
+	raw = strings.TrimSpace(raw)
+	switch f.(type) {
+	case *cli.BoolFlag:
+		b, _ := strconv.ParseBool(raw)
+		return strconv.FormatBool(b)
+	case *cli.IntFlag:
+		n, _ := strconv.Atoi(raw)
+		return strconv.Itoa(n)
+	case *cli.DurationFlag:
+		d, _ := time.ParseDuration(raw)
+		return d.String()
+	case *cli.StringSliceFlag:
+		values := strings.Split(raw, ",")
+		for i, value := range values {
+			values[i] = strings.TrimSpace(value)
+		}
+		return strings.Join(values, ",")
+	default:
+		return raw
+	}
+}
+
+// flagValue renders the value of f.
+func flagValue(c *cli.Context, f cli.Flag) string {
+	name := f.Names()[0]
+	switch f.(type) {
+	case *cli.BoolFlag:
+		return strconv.FormatBool(c.Bool(name))
+	case *cli.IntFlag:
+		return strconv.Itoa(c.Int(name))
+	case *cli.DurationFlag:
+		return c.Duration(name).String()
+	case *cli.StringSliceFlag:
+		return strings.Join(c.StringSlice(name), ",")
+	default:
+		return c.String(name)
+	}
+}
+
+// fileSourceDetail names the reference a secret was resolved from, as the
+// config file holds references rather than the secrets themselves.
+func fileSourceDetail(cfg *fileConfig, name string) string {
+	switch {
+	case name == apiKeyFlag.Name && cfg.APIKeyEnv != "":
+		return " (api_key_env " + cfg.APIKeyEnv + ")"
+	case name == apiKeyFlag.Name && cfg.APIKeyFile != "":
+		return " (api_key_file " + cfg.APIKeyFile + ")"
+	case name == githubTokenFlag.Name:
+		return " (github_token_env " + cfg.GitHubTokenEnv + ")"
+	case name == httpAuthTokenFlag.Name:
+		return " (http_auth.token_env " + cfg.HTTPAuth.TokenEnv + ")"
+	}
+	return ""
+}
+
+// configFileState describes the config file at path.
+func configFileState(path string) string {
+	expanded, err := expandHome(path)
+	if err != nil {
+		return path
+	}
+	if _, statErr := os.Stat(expanded); errors.Is(statErr, os.ErrNotExist) {
+		return expanded + " (not found, using flags and environment variables only)"
+	}
+	return expanded
+}
+
+// credentialSource describes the credential the server would use. The stored
+// credential is parsed but not refreshed.
+func credentialSource(config *Config, sources map[string]string) string {
+	if config.Demo {
+		return "none needed in demo mode"
+	}
+	if config.LocalOnly {
+		return "none loaded in local-only mode"
+	}
+	if config.APIKey != "" {
+		return "API key from " + sources[apiKeyFlag.Name]
+	}
+	if config.JWT != "" {
+		return jwtSource(config, sources)
+	}
+	if config.WorkloadIdentity != "" {
+		return fmt.Sprintf("%s workload identity token for audience %s (from %s)",
+			strings.ToUpper(config.WorkloadIdentity), config.OIDCAudience, sources[workloadIdentityFlag.Name])
+	}
+	if config.GitHubOIDC {
+		return fmt.Sprintf("GitHub Actions OIDC token for audience %s (from %s)", config.OIDCAudience, terramate.GitHubOIDCRequestURLEnv)
+	}
+	jwtCred, location, err := storedCredential(config)
+	if errors.Is(err, errNoCredential) || location == "" {
+		return "none: " + err.Error()
+	}
+	if err != nil {
+		return fmt.Sprintf("%s (invalid: %v)", location, firstLine(err.Error()))
+	}
+	desc := fmt.Sprintf("%s (%s", location, jwtCred.Name())
+	if jwtCred.Region() != "" {
+		desc += ", region hint " + jwtCred.Region()
+	}
+	return desc + ")"
+}
+
+// jwtSource describes the JWT of --jwt.
+func jwtSource(config *Config, sources map[string]string) string {
+	desc := "JWT from " + sources[jwtFlag.Name]
+	jwtCred, err := terramate.NewJWTCredential(config.JWT, "")
+	if err != nil {
+		return fmt.Sprintf("%s (invalid: %v)", desc, err)
+	}
+	desc += " (" + jwtCred.Name()
+	if config.RefreshToken == "" {
+		desc += ", no refresh token"
+	}
+	return desc + ")"
+}
+
+// endpointSource describes the API the server would send requests to.
+func endpointSource(config *Config, sources map[string]string) string {
+	switch {
+	case config.LocalOnly:
+		return "none in local-only mode"
+	case config.Demo:
+		return "demo API " + demo.BaseURL + " (in-process)"
+	case config.BaseURL != "" && config.BaseURL != baseURLFlag.Value:
+		return fmt.Sprintf("base URL %s from %s", config.BaseURL, sources[baseURLFlag.Name])
+	case config.Region != "":
+		return fmt.Sprintf("region %s from %s", config.Region, sources[regionFlag.Name])
+	case !config.DetectRegion:
+		return fmt.Sprintf("base URL %s from %s", baseURLFlag.Value, sources[baseURLFlag.Name])
+	}
+	if !injectedCredential(config) {
+		if jwtCred, _, err := storedCredential(config); err == nil && jwtCred.Region() != "" {
+			return "region " + jwtCred.Region() + " from the region hint of the stored credential"
+		}
+	}
+	return "region detected at startup by probing eu, then us (run check to resolve it, or set --region)"
+}
+
+// printSettings prints every flag with its value and source. Secrets are
+// masked, as are proxy passwords.
+func printSettings(out io.Writer, c *cli.Context, sources map[string]string) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, f := range appFlags {
+		name := f.Names()[0]
+		value := flagValue(c, f)
+		switch {
+		case value == "":
+		case name == apiKeyFlag.Name || name == jwtFlag.Name || name == refreshTokenFlag.Name ||
+			name == githubTokenFlag.Name || name == httpAuthTokenFlag.Name:
+			value = maskedValue
+		case name == proxyFlag.Name:
+			if u, err := url.Parse(value); err == nil {
+				value = u.Redacted()
+			}
+		}
+		source, ok := sources[name]
+		if !ok {
+			source = "default"
+		}
+		_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\n", name, value, source)
+	}
+	_ = w.Flush()
+}
+
+// firstLine returns the first line of s, e.g. of an error with instructions.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
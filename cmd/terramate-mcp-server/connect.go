@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// errNoCredential reports that neither an API key nor the default credential
+// file is available. The server then serves only the local tools until a
+// credential shows up.
+var errNoCredential = errors.New("no Terramate Cloud credential configured")
+
+// Credential stores of --credential-store.
+const (
+	credentialStoreFile     = "file"
+	credentialStoreKeychain = "keychain"
+)
+
+// Workload identities of --workload-identity.
+const (
+	workloadIdentityAWS = "aws"
+	workloadIdentityGCP = "gcp"
+)
+
+// oidcTimeout bounds fetching the first OIDC token of the workload.
+const oidcTimeout = 30 * time.Second
+
+// credentialPollInterval is how often a server started without a credential
+// looks for the credential file written by 'terramate cloud login'.
+const credentialPollInterval = 10 * time.Second
+
+// keychain returns the OS keychain item of config, with one account per
+// profile.
+func keychain(config *Config) terramate.Keychain {
+	return terramate.Keychain{Account: config.Profile}
+}
+
+// injectedCredential reports whether config has a credential that is not
+// stored: an API key, a JWT from the environment or OIDC tokens of the
+// workload.
+func injectedCredential(config *Config) bool {
+	return config.APIKey != "" || config.JWT != "" || config.WorkloadIdentity != "" || config.GitHubOIDC
+}
+
+// loadOIDCCredential fetches the first OIDC token of the cloud workload of
+// --workload-identity or, without it, of the GitHub Actions job running the
+// server.
+func loadOIDCCredential(config *Config, httpClient *http.Client) (*terramate.OIDCCredential, error) {
+	opts := []terramate.OIDCOption{terramate.WithOIDCAudience(config.OIDCAudience)}
+	// The GCP metadata server is reached directly, never through the proxy
+	if httpClient != nil && config.WorkloadIdentity != workloadIdentityGCP {
+		opts = append(opts, terramate.WithOIDCHTTPClient(httpClient))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), oidcTimeout)
+	defer cancel()
+
+	var (
+		credential *terramate.OIDCCredential
+		err        error
+	)
+	switch config.WorkloadIdentity {
+	case workloadIdentityAWS:
+		credential, err = terramate.NewAWSWorkloadIdentityCredential(ctx, opts...)
+	case workloadIdentityGCP:
+		credential, err = terramate.NewGCPWorkloadIdentityCredential(ctx, opts...)
+	default:
+		credential, err = terramate.NewGitHubOIDCCredential(ctx, opts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// connect loads the credential that was missing when the server started and
+// registers the Terramate Cloud tools; connected clients are notified that
+// the tool list changed. It returns an error wrapping errNoCredential while
+// there is still no credential.
+func (s *Server) connect(ctx context.Context) error {
+	s.connectMu.Lock()
+	defer s.connectMu.Unlock()
+
+	s.mu.RLock()
+	connected, source, config, serveCtx := s.client != nil, s.credentialSource, *s.config, s.serveCtx
+	s.mu.RUnlock()
+	// A connected server only reloads a keychain credential, which is not
+	// watched like the credential file, e.g. after tmc_login stored a new one
+	if connected && source != sourceKeychain {
+		return nil
+	}
+	if err := credentialAvailable(&config); err != nil {
+		return err
+	}
+
+	// The credential watcher and the probes outlive the tool call connecting
+	if serveCtx == nil {
+		serveCtx = context.WithoutCancel(ctx)
+	}
+	if err := s.reload(serveCtx, &config); err != nil {
+		return err
+	}
+	if connected {
+		return nil
+	}
+	slog.Info("Connected to Terramate Cloud")
+	go s.probeServices(serveCtx)
+	return nil
+}
+
+// awaitCredential connects to Terramate Cloud once a credential shows up,
+// checking every credentialPollInterval until it succeeds or ctx is canceled.
+func (s *Server) awaitCredential(ctx context.Context) {
+	ticker := time.NewTicker(credentialPollInterval)
+	defer ticker.Stop()
+
+	var lastErr string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		err := s.connect(ctx)
+		if err == nil {
+			return
+		}
+		// An unusable credential file is reported once, not at every check
+		if !errors.Is(err, errNoCredential) && err.Error() != lastErr {
+			slog.Warn("Failed to connect to Terramate Cloud; retrying", "error", err)
+		}
+		lastErr = err.Error()
+	}
+}
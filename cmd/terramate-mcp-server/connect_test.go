@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInjectedCredential(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   bool
+	}{
+		{name: "API key", config: Config{APIKey: "key"}, want: true},
+		{name: "JWT", config: Config{JWT: "token"}, want: true},
+		{name: "workload identity", config: Config{WorkloadIdentity: workloadIdentityAWS}, want: true},
+		{name: "GitHub OIDC", config: Config{GitHubOIDC: true}, want: true},
+		{name: "stored credential", config: Config{CredentialFile: "/tmp/credentials.tmrc.json"}, want: false},
+	}
+	for _, tt := range tests {
+		if got := injectedCredential(&tt.config); got != tt.want {
+			t.Errorf("%s: injectedCredential() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestServer_ConnectWithoutCredential(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	s, err := newServer(&Config{BaseURL: "http://127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("expected the server to start without a credential, got %v", err)
+	}
+	if err := s.connect(context.Background()); !errors.Is(err, errNoCredential) {
+		t.Fatalf("expected errNoCredential, got %v", err)
+	}
+	if s.client != nil {
+		t.Fatal("expected the server to stay disconnected")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		s.awaitCredential(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected awaitCredential to return once canceled")
+	}
+}
+
+func TestServer_ConnectWhenConnected(t *testing.T) {
+	s, err := newServer(&Config{APIKey: "test-key", BaseURL: "http://127.0.0.1:0"})
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	client := s.client
+	if err := s.connect(context.Background()); err != nil {
+		t.Fatalf("expected connecting a connected server to succeed, got %v", err)
+	}
+	if s.client != client {
+		t.Fatal("expected the client of a connected server to be kept")
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+)
+
+// Kinds of credential sources, in the order of the credential chain.
+const (
+	sourceAPIKey           = "api-key"
+	sourceJWT              = "jwt"
+	sourceWorkloadIdentity = "workload-identity"
+	sourceGitHubOIDC       = "github-oidc"
+	sourceCredentialFile   = "credential-file"
+	sourceKeychain         = "keychain"
+)
+
+// chainSource is a source of the credential chain.
+type chainSource struct {
+	kind string
+	// location describes the source in logs and messages, e.g. "credential
+	// file /home/me/.terramate.d/credentials.tmrc.json".
+	location string
+	// load returns the credential of the source; nil when the source is not
+	// configured, or an unavailableError when it has no credential (yet).
+	load func() (terramate.Credential, error)
+}
+
+// unavailableError reports why a credential source has no credential. It
+// wraps errNoCredential.
+type unavailableError struct {
+	reason string
+}
+
+func (e *unavailableError) Error() string {
+	return errNoCredential.Error() + ": " + e.reason
+}
+
+func (e *unavailableError) Unwrap() error {
+	return errNoCredential
+}
+
+// loadCredential loads the credential of the first source of the credential
+// chain of config having one and logs which source was selected. JWTs are
+// refreshed and OIDC tokens fetched with httpClient unless it is nil.
+func loadCredential(config *Config, httpClient *http.Client) (terramate.Credential, chainSource, error) {
+	if config.Demo {
+		slog.Warn("Demo mode: serving a built-in demo organization; no requests reach Terramate Cloud")
+		return terramate.NewAPIKeyCredential("demo"), chainSource{kind: sourceAPIKey, location: "demo mode"}, nil
+	}
+	credential, source, err := loadFromChain(credentialChain(config, httpClient))
+	if err != nil {
+		return nil, source, err
+	}
+	slog.Info("Using Terramate Cloud credential", "source", source.location, "credential", credential.Name())
+	return credential, source, nil
+}
+
+// loadFromChain returns the credential of the first source of chain having
+// one, and that source. Without any, it returns an error wrapping
+// errNoCredential with the reason of each source. Other errors stop the
+// chain, so a broken credential is reported rather than silently replaced by
+// the next source.
+func loadFromChain(chain []chainSource) (terramate.Credential, chainSource, error) {
+	var reasons []string
+	for _, source := range chain {
+		credential, err := source.load()
+		var unavailable *unavailableError
+		switch {
+		case errors.As(err, &unavailable):
+			slog.Debug("Credential source has no credential", "source", source.location, "reason", unavailable.reason)
+			reasons = append(reasons, unavailable.reason)
+		case err != nil:
+			return nil, source, err
+		case credential != nil:
+			return credential, source, nil
+		}
+	}
+	if len(reasons) == 0 {
+		return nil, chainSource{}, errNoCredential
+	}
+	return nil, chainSource{}, &unavailableError{reason: strings.Join(reasons, ", ")}
+}
+
+// credentialChain returns the credential sources of config in order of
+// precedence: the API key, the JWT from the environment, the workload
+// identity, GitHub Actions OIDC and the stored credential (see
+// storeSources).
+func credentialChain(config *Config, httpClient *http.Client) []chainSource {
+	jwtOpts := jwtOptions(config, httpClient)
+	chain := []chainSource{
+		{kind: sourceAPIKey, location: "API key", load: func() (terramate.Credential, error) {
+			if config.APIKey == "" {
+				return nil, nil
+			}
+			return terramate.NewAPIKeyCredential(config.APIKey), nil
+		}},
+		{kind: sourceJWT, location: "JWT from --" + jwtFlag.Name, load: func() (terramate.Credential, error) {
+			if config.JWT == "" {
+				return nil, nil
+			}
+			credential, err := terramate.NewJWTCredential(config.JWT, "", append(jwtOpts, terramate.WithRefreshToken(config.RefreshToken))...)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --%s: %w", jwtFlag.Name, err)
+			}
+			return credential, nil
+		}},
+		{kind: sourceWorkloadIdentity, location: config.WorkloadIdentity + " workload identity", load: func() (terramate.Credential, error) {
+			if config.WorkloadIdentity == "" {
+				return nil, nil
+			}
+			return loadOIDCCredential(config, httpClient)
+		}},
+		{kind: sourceGitHubOIDC, location: "GitHub Actions OIDC", load: func() (terramate.Credential, error) {
+			if !config.GitHubOIDC {
+				return nil, nil
+			}
+			return loadOIDCCredential(config, httpClient)
+		}},
+	}
+	return append(chain, storeSources(config, jwtOpts, true)...)
+}
+
+// jwtOptions returns the options of the JWT credentials of config, refreshed
+// with httpClient unless it is nil: through the OAuth 2.0 token endpoint with
+// --token-refresh-client-id, through Firebase Auth otherwise.
+func jwtOptions(config *Config, httpClient *http.Client) []terramate.JWTOption {
+	var opts []terramate.JWTOption
+	if config.ReadOnlyCredentialFile {
+		opts = append(opts, terramate.WithReadOnlyCredentialFile())
+	}
+	switch {
+	case config.TokenRefreshClientID != "":
+		opts = append(opts, terramate.WithRefreshTransport(&terramate.OAuthRefreshTransport{
+			Endpoint:   config.TokenRefreshEndpoint,
+			ClientID:   config.TokenRefreshClientID,
+			HTTPClient: httpClient,
+		}))
+	case config.TokenRefreshEndpoint != "" || httpClient != nil:
+		opts = append(opts, terramate.WithRefreshTransport(&terramate.FirebaseRefreshTransport{
+			Endpoint:   config.TokenRefreshEndpoint,
+			HTTPClient: httpClient,
+		}))
+	}
+	return opts
+}
+
+// storeSources returns the sources of the stored JWT credential: the
+// credential store of --credential-store, then the other one. With serve, the
+// credential is loaded to serve requests: a credential file found with the
+// keychain store, e.g. from 'terramate cloud login', is moved into the
+// keychain unless it is read-only, and a missing --credential-file is an
+// error rather than no credential.
+func storeSources(config *Config, jwtOpts []terramate.JWTOption, serve bool) []chainSource {
+	path, explicit, pathErr := credentialFilePath(config)
+	explicit = explicit && serve
+	file := chainSource{kind: sourceCredentialFile, location: "credential file " + path, load: func() (terramate.Credential, error) {
+		if err := credentialFileExists(path, explicit, pathErr); err != nil {
+			return nil, err
+		}
+		credential, err := terramate.LoadJWTFromFile(path, jwtOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load credentials: %w", err)
+		}
+		return credential, nil
+	}}
+
+	kc := keychain(config)
+	fromKeychain := chainSource{kind: sourceKeychain, location: kc.String(), load: func() (terramate.Credential, error) {
+		credential, err := terramate.LoadJWTFromKeychain(kc, jwtOpts...)
+		switch {
+		case errors.Is(err, terramate.ErrKeychainItemNotFound):
+			return nil, &unavailableError{reason: kc.String() + " has no credential"}
+		case err != nil && config.CredentialStore != credentialStoreKeychain:
+			// Only a fallback of the credential file, e.g. without secret-tool
+			slog.Debug("Skipping the OS keychain", "error", err)
+			return nil, nil
+		case err != nil:
+			return nil, err
+		}
+		return credential, nil
+	}}
+
+	if config.CredentialStore != credentialStoreKeychain {
+		return []chainSource{file, fromKeychain}
+	}
+	if serve && !config.ReadOnlyCredentialFile {
+		file = chainSource{kind: sourceKeychain, location: kc.String(), load: func() (terramate.Credential, error) {
+			if err := credentialFileExists(path, explicit, pathErr); err != nil {
+				return nil, err
+			}
+			if err := kc.ImportCredentialFile(path); err != nil {
+				return nil, fmt.Errorf("failed to move %s into the OS keychain: %w", path, err)
+			}
+			slog.Info("Moved the credential file into the OS keychain", "path", path, "keychain", kc.String())
+			return terramate.LoadJWTFromKeychain(kc, jwtOpts...)
+		}}
+	}
+	return []chainSource{fromKeychain, file}
+}
+
+// credentialFilePath returns the path of the JWT credential file of config
+// and whether it was set with --credential-file.
+func credentialFilePath(config *Config) (string, bool, error) {
+	if config.CredentialFile != "" {
+		return config.CredentialFile, true, nil
+	}
+	path, err := terramate.GetDefaultCredentialPath()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to determine default credential path: %w", err)
+	}
+	return path, false, nil
+}
+
+// credentialFileExists returns an unavailableError when the default
+// credential file does not exist. An explicit --credential-file is loaded
+// regardless, so a wrong path still fails loudly.
+func credentialFileExists(path string, explicit bool, pathErr error) error {
+	if pathErr != nil || explicit {
+		return pathErr
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return &unavailableError{reason: path + " does not exist"}
+	}
+	return nil
+}
+
+// storedCredential loads the stored JWT credential of config, without moving
+// a credential file into the keychain, and describes where it is stored. It
+// returns an error wrapping errNoCredential when there is none.
+func storedCredential(config *Config) (*terramate.JWTCredential, string, error) {
+	credential, source, err := loadFromChain(storeSources(config, nil, false))
+	if err != nil {
+		return nil, source.location, err
+	}
+	jwtCred, _ := credential.(*terramate.JWTCredential)
+	return jwtCred, source.location, nil
+}
+
+// credentialAvailable returns an error wrapping errNoCredential while config
+// has no stored JWT credential to load.
+func credentialAvailable(config *Config) error {
+	_, _, err := loadFromChain(storeSources(config, nil, true))
+	return err
+}
+
+// credentialStatus returns the status reported by tmc_credential_status for
+// credential, loaded from source, or for no credential because of reason.
+func credentialStatus(config *Config, credential terramate.Credential, source chainSource, reason error) tmc.CredentialStatusFunc {
+	return func() tmc.CredentialStatus {
+		if credential == nil {
+			status := tmc.CredentialStatus{Type: tmc.CredentialTypeNone}
+			if reason != nil {
+				status.Reason = reason.Error()
+			}
+			return status
+		}
+
+		status := tmc.CredentialStatus{Type: tmc.CredentialTypeAPIKey, Source: source.location, Provider: credential.Name()}
+		var details terramate.CredentialStatus
+		switch c := credential.(type) {
+		case *terramate.JWTCredential:
+			status.Type, details = tmc.CredentialTypeJWT, c.Status()
+		case *terramate.OIDCCredential:
+			status.Type, details = tmc.CredentialTypeOIDC, c.Status()
+		}
+		if config.Demo {
+			status.Type = tmc.CredentialTypeDemo
+		}
+		if !details.ExpiresAt.IsZero() {
+			status.ExpiresAt = &details.ExpiresAt
+		}
+		status.Refreshable = details.Refreshable
+		status.CredentialFile = details.CredentialFile
+		if details.LastRefreshError != nil {
+			status.LastRefreshError = details.LastRefreshError.Error()
+		}
+		return status
+	}
+}
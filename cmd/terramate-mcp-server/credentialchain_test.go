@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+)
+
+func TestLoadFromChain(t *testing.T) {
+	unavailable := func(reason string) chainSource {
+		return chainSource{kind: sourceCredentialFile, location: reason, load: func() (terramate.Credential, error) {
+			return nil, &unavailableError{reason: reason}
+		}}
+	}
+	notConfigured := chainSource{kind: sourceJWT, location: "jwt", load: func() (terramate.Credential, error) {
+		return nil, nil
+	}}
+	apiKey := chainSource{kind: sourceAPIKey, location: "API key", load: func() (terramate.Credential, error) {
+		return terramate.NewAPIKeyCredential("key"), nil
+	}}
+	broken := chainSource{kind: sourceKeychain, location: "keychain", load: func() (terramate.Credential, error) {
+		return nil, errors.New("keychain locked")
+	}}
+
+	credential, source, err := loadFromChain([]chainSource{notConfigured, unavailable("file missing"), apiKey, broken})
+	if err != nil || credential == nil || source.kind != sourceAPIKey {
+		t.Fatalf("expected the first source with a credential, got %v from %q: %v", credential, source.kind, err)
+	}
+
+	_, _, err = loadFromChain([]chainSource{unavailable("file missing"), notConfigured, unavailable("keychain empty")})
+	if !errors.Is(err, errNoCredential) || !strings.Contains(err.Error(), "file missing, keychain empty") {
+		t.Fatalf("expected the reason of each source, got %v", err)
+	}
+
+	if _, _, err = loadFromChain([]chainSource{notConfigured}); !errors.Is(err, errNoCredential) {
+		t.Fatalf("expected no credential, got %v", err)
+	}
+
+	_, source, err = loadFromChain([]chainSource{broken, apiKey})
+	if err == nil || errors.Is(err, errNoCredential) || source.kind != sourceKeychain {
+		t.Fatalf("expected a broken source to stop the chain, got %v from %q", err, source.kind)
+	}
+}
+
+func TestLoadCredential_CredentialFileSource(t *testing.T) {
+	credFile := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	writeTestCredentialFile(t, credFile)
+
+	_, source, err := loadCredential(&Config{CredentialFile: credFile}, nil)
+	if err != nil {
+		t.Fatalf("loadCredential error: %v", err)
+	}
+	if source.kind != sourceCredentialFile || !strings.Contains(source.location, credFile) {
+		t.Fatalf("expected the credential file to be selected, got %+v", source)
+	}
+
+	// An API key wins over the credential file
+	if _, source, err = loadCredential(&Config{APIKey: "key", CredentialFile: credFile}, nil); err != nil || source.kind != sourceAPIKey {
+		t.Fatalf("expected the API key to be selected, got %+v: %v", source, err)
+	}
+}
+
+func TestCredentialStatus(t *testing.T) {
+	credFile := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	writeTestCredentialFile(t, credFile)
+	config := &Config{CredentialFile: credFile}
+	credential, source, err := loadCredential(config, nil)
+	if err != nil {
+		t.Fatalf("loadCredential error: %v", err)
+	}
+
+	status := credentialStatus(config, credential, source, nil)()
+	if status.Type != tmc.CredentialTypeJWT || status.Source != source.location || status.CredentialFile != credFile || status.ExpiresAt == nil {
+		t.Fatalf("expected the status of the JWT, got %+v", status)
+	}
+
+	status = credentialStatus(config, nil, chainSource{}, terramate.ErrLoggedOut)()
+	if status.Type != tmc.CredentialTypeNone || status.Reason != terramate.ErrLoggedOut.Error() {
+		t.Fatalf("expected no credential, got %+v", status)
+	}
+}
+
+func TestStoreSources_ReadOnlyCredentialFile(t *testing.T) {
+	config := &Config{CredentialFile: "credentials.tmrc.json", CredentialStore: credentialStoreKeychain}
+	if sources := storeSources(config, nil, true); sources[1].kind != sourceKeychain {
+		t.Fatalf("expected the credential file to be moved into the keychain, got %q", sources[1].kind)
+	}
+	config.ReadOnlyCredentialFile = true
+	if sources := storeSources(config, nil, true); sources[1].kind != sourceCredentialFile {
+		t.Fatalf("expected the read-only credential file to be loaded in place, got %q", sources[1].kind)
+	}
+	if got := runWithFileConfig(t, &fileConfig{ReadOnlyCredentialFile: &config.ReadOnlyCredentialFile}, "--api-key", "key"); !got.ReadOnlyCredentialFile {
+		t.Fatal("expected read_only_credential_file from the config file")
+	}
+}
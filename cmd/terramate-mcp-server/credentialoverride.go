@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// credentialOverrides resolves the credentials of the api_key and
+// credential_profile tool arguments, accepted with
+// --allow-credential-override (see tools.CredentialOverrides), and of the
+// organizations listed by profiles (see tools.OrganizationCredentials).
+type credentialOverrides struct {
+	config     *Config
+	httpClient *http.Client // refreshes the JWTs of profiles; may be nil
+
+	mu sync.Mutex
+	// profiles are the credentials loaded so far, kept so a JWT is
+	// refreshed once rather than on every call
+	profiles map[string]terramate.Credential
+}
+
+func newCredentialOverrides(config *Config, httpClient *http.Client) *credentialOverrides {
+	return &credentialOverrides{config: config, httpClient: httpClient, profiles: map[string]terramate.Credential{}}
+}
+
+// forOrganization implements tools.OrganizationCredentialResolver with the
+// credential of the profile listing orgUUID, loaded once like a
+// credential_profile.
+func (o *credentialOverrides) forOrganization(ctx context.Context, orgUUID string) (terramate.Credential, error) {
+	profile, ok := o.config.OrganizationProfiles[orgUUID]
+	if !ok {
+		return nil, nil
+	}
+	return o.resolve(ctx, "", profile)
+}
+
+// resolve implements tools.CredentialResolver. A profile must use the
+// Terramate Cloud endpoint of the server, as only the credential of a call
+// is replaced.
+func (o *credentialOverrides) resolve(_ context.Context, apiKey, profile string) (terramate.Credential, error) {
+	if apiKey != "" {
+		return terramate.NewAPIKeyCredential(apiKey), nil
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if credential, ok := o.profiles[profile]; ok {
+		return credential, nil
+	}
+	p, ok := o.config.CredentialProfiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown credential profile %q (available: %s)", profile, profileNames(o.config.CredentialProfiles))
+	}
+	if (p.Region != "" && o.config.Region != "" && p.Region != o.config.Region) || (p.BaseURL != "" && p.BaseURL != o.config.BaseURL) {
+		return nil, fmt.Errorf("profile %s uses another Terramate Cloud endpoint than the server; run a server with --profile %s instead", profile, profile)
+	}
+
+	var credential terramate.Credential
+	switch {
+	case p.APIKey != "":
+		credential = terramate.NewAPIKeyCredential(p.APIKey)
+	case p.CredentialFile != "":
+		path, err := expandHome(p.CredentialFile)
+		if err != nil {
+			return nil, err
+		}
+		if credential, err = terramate.LoadJWTFromFile(path, jwtOptions(o.config, o.httpClient)...); err != nil {
+			return nil, fmt.Errorf("profile %s: %w", profile, err)
+		}
+	default:
+		return nil, fmt.Errorf("profile %s has no API key or credential file", profile)
+	}
+	o.profiles[profile] = credential
+	return credential, nil
+}
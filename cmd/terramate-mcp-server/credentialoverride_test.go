@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestLoadConfig_CredentialProfiles(t *testing.T) {
+	t.Setenv("TEST_TMC_PROD_KEY", "prod-key")
+	path := writeConfigFile(t, `
+allow_credential_override: true
+profiles:
+  prod:
+    api_key_env: TEST_TMC_PROD_KEY
+`)
+	config, err := reloadConfig(appFlags, []string{"terramate-mcp-server", "--config", path, "--api-key", "key"})
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if !config.AllowCredentialOverride || config.CredentialProfiles["prod"].APIKey != "prod-key" {
+		t.Fatalf("expected the credentials of the profiles, got %+v", config)
+	}
+
+	config, err = reloadConfig(appFlags, []string{"terramate-mcp-server", "--config", path, "--api-key", "key", "--allow-credential-override=false"})
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if config.AllowCredentialOverride || config.CredentialProfiles != nil {
+		t.Fatalf("expected no credential profiles without the flag, got %+v", config)
+	}
+}
+
+func TestCredentialOverrides_Resolve(t *testing.T) {
+	credFile := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	writeTestCredentialFile(t, credFile)
+	overrides := newCredentialOverrides(&Config{
+		Region: "eu",
+		CredentialProfiles: map[string]credentialProfile{
+			"staging": {APIKey: "staging-key"},
+			"prod":    {CredentialFile: credFile, Region: "eu"},
+			"us":      {APIKey: "us-key", Region: "us"},
+			"empty":   {},
+		},
+	}, nil)
+	resolve := func(apiKey, profile string) (terramate.Credential, error) {
+		return overrides.resolve(context.Background(), apiKey, profile)
+	}
+
+	if credential, err := resolve("call-key", ""); err != nil || credential.Name() != "API Key" {
+		t.Fatalf("expected the API key of the call, got %v: %v", credential, err)
+	}
+	if credential, err := resolve("", "staging"); err != nil || credential.Name() != "API Key" {
+		t.Fatalf("expected the API key of the profile, got %v: %v", credential, err)
+	}
+	first, err := resolve("", "prod")
+	if err != nil {
+		t.Fatalf("resolve error: %v", err)
+	}
+	if _, ok := first.(*terramate.JWTCredential); !ok {
+		t.Fatalf("expected the JWT of the credential file, got %#v", first)
+	}
+	if again, _ := resolve("", "prod"); again != first {
+		t.Fatal("expected the JWT of a profile to be loaded once")
+	}
+
+	for profile, want := range map[string]string{
+		"unknown": "available: empty, prod, staging, us",
+		"us":      "another Terramate Cloud endpoint",
+		"empty":   "no API key or credential file",
+	} {
+		if _, err := resolve("", profile); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q for profile %s, got %v", want, profile, err)
+		}
+	}
+}
+
+func TestLoadConfig_OrganizationProfiles(t *testing.T) {
+	t.Setenv("TEST_TMC_ACME_KEY", "acme-key")
+	config, err := reloadConfig(appFlags, []string{"terramate-mcp-server", "--api-key", "key", "--config", writeConfigFile(t, `
+profiles:
+  acme:
+    api_key_env: TEST_TMC_ACME_KEY
+    organizations: [acme-uuid, acme-labs-uuid]
+`)})
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if config.OrganizationProfiles["acme-labs-uuid"] != "acme" || config.CredentialProfiles["acme"].APIKey != "acme-key" {
+		t.Fatalf("expected the credentials of the organizations without --allow-credential-override, got %+v", config)
+	}
+
+	_, err = reloadConfig(appFlags, []string{"terramate-mcp-server", "--api-key", "key", "--config", writeConfigFile(t, `
+profiles:
+  acme:
+    organizations: [acme-uuid]
+  staging:
+    organizations: [acme-uuid]
+`)})
+	if err == nil || !strings.Contains(err.Error(), "organization acme-uuid is listed by profiles acme and staging") {
+		t.Fatalf("expected an organization listed twice to fail, got %v", err)
+	}
+}
+
+func TestCredentialOverrides_ForOrganization(t *testing.T) {
+	overrides := newCredentialOverrides(&Config{
+		CredentialProfiles:   map[string]credentialProfile{"acme": {APIKey: "acme-key"}},
+		OrganizationProfiles: map[string]string{"acme-uuid": "acme"},
+	}, nil)
+
+	first, err := overrides.forOrganization(context.Background(), "acme-uuid")
+	if err != nil || first == nil {
+		t.Fatalf("expected the credential of the organization's profile, got %v: %v", first, err)
+	}
+	if again, _ := overrides.forOrganization(context.Background(), "acme-uuid"); again != first {
+		t.Fatal("expected the credential of an organization to be cached")
+	}
+	if credential, err := overrides.forOrganization(context.Background(), "other-uuid"); err != nil || credential != nil {
+		t.Fatalf("expected no credential for an unlisted organization, got %v: %v", credential, err)
+	}
+}
+
+func TestServer_GatesCredentialOverrides(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		mu.Lock()
+		keys = append(keys, user)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"stacks":[],"paginated_result":{"total":0,"page":1,"per_page":10}}`))
+	}))
+	defer api.Close()
+
+	tests := []struct {
+		name   string
+		config Config
+		// want is the API key of the request made by a call setting
+		// api_key, or "" when the call must be rejected without a request
+		want string
+	}{
+		{name: "disabled", config: Config{}},
+		{name: "organization profiles only", config: Config{
+			CredentialProfiles:   map[string]credentialProfile{"acme": {APIKey: "acme-key"}},
+			OrganizationProfiles: map[string]string{"acme-uuid": "acme"},
+		}},
+		{name: "allowed", config: Config{AllowCredentialOverride: true}, want: "call-key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := tt.config
+			config.APIKey, config.BaseURL, config.DefaultOrganization = "server-key", api.URL, "org-uuid"
+			s, err := newServer(&config)
+			if err != nil {
+				t.Fatalf("newServer error: %v", err)
+			}
+			c, err := client.NewInProcessClient(s.mcp)
+			if err != nil {
+				t.Fatalf("client error: %v", err)
+			}
+			defer func() { _ = c.Close() }()
+			ctx := context.Background()
+			if err := c.Start(ctx); err != nil {
+				t.Fatalf("start error: %v", err)
+			}
+			if _, err := c.Initialize(ctx, mcp.InitializeRequest{}); err != nil {
+				t.Fatalf("initialize error: %v", err)
+			}
+
+			mu.Lock()
+			keys = nil
+			mu.Unlock()
+			args := map[string]any{"api_key": "call-key"}
+			result, err := c.CallTool(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "tmc_list_stacks", Arguments: args}})
+			if err != nil {
+				t.Fatalf("tmc_list_stacks error: %v", err)
+			}
+			mu.Lock()
+			got := strings.Join(keys, ",")
+			mu.Unlock()
+			if tt.want == "" {
+				text := result.Content[0].(mcp.TextContent).Text
+				if !result.IsError || !strings.Contains(text, "--allow-credential-override") || got != "" {
+					t.Fatalf("expected the call to be rejected without an API request, got %q after requests %q", text, got)
+				}
+				return
+			}
+			if result.IsError || got != tt.want {
+				t.Fatalf("expected a request with %s, got requests %q (%+v)", tt.want, got, result.Content)
+			}
+		})
+	}
+}
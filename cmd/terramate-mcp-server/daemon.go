@@ -0,0 +1,240 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// defaultPIDFile is where a daemonized server records its process ID.
+const defaultPIDFile = "~/.terramate.d/mcp-server.pid"
+
+// daemonChildEnv marks the background process started by --daemonize.
+const daemonChildEnv = "TERRAMATE_MCP_DAEMON_CHILD"
+
+// daemonStartTimeout bounds how long --daemonize waits for the background
+// process to write its PID file.
+const daemonStartTimeout = 10 * time.Second
+
+// statusNotRunning is the exit code of the status command when the server is
+// not running, following the LSB init script convention.
+const statusNotRunning = 3
+
+var (
+	daemonizeFlag = &cli.BoolFlag{
+		Name:    "daemonize",
+		Usage:   "Run the server in the background (requires --transport http and --log-file); manage it with the stop and status commands",
+		EnvVars: []string{"TERRAMATE_MCP_DAEMONIZE"},
+	}
+
+	pidFileFlag = &cli.StringFlag{
+		Name:    "pid-file",
+		Usage:   "File recording the process ID of the running server (written when daemonized or when set)",
+		EnvVars: []string{"TERRAMATE_MCP_PID_FILE"},
+		Value:   defaultPIDFile,
+	}
+
+	stopTimeoutFlag = &cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "How long to wait for the server to exit",
+		Value: defaultShutdownTimeout + shutdownAbortGrace + 5*time.Second,
+	}
+)
+
+// daemonCommands manage a server started with --daemonize.
+var daemonCommands = []*cli.Command{
+	{
+		Name:   "stop",
+		Usage:  "Stop the background server, letting in-flight tool calls finish",
+		Flags:  []cli.Flag{pidFileFlag, stopTimeoutFlag},
+		Action: stopDaemon,
+	},
+	{
+		Name:   "status",
+		Usage:  "Report whether the background server is running (exit code 3 if not)",
+		Flags:  []cli.Flag{pidFileFlag},
+		Action: daemonStatus,
+	},
+}
+
+// daemonized reports whether this process is the background server started
+// by --daemonize.
+func daemonized() bool {
+	return os.Getenv(daemonChildEnv) != ""
+}
+
+// startDaemon starts the server in the background with the same arguments
+// and waits until it has written its PID file.
+func startDaemon(c *cli.Context, config *Config) error {
+	if config.Transport != transportHTTP {
+		return fmt.Errorf("--daemonize requires --transport http: the stdio transport serves a single client attached to the server's stdin")
+	}
+	if config.LogFile == "" {
+		return fmt.Errorf("--daemonize requires --log-file: the background server has no terminal to log to")
+	}
+	pidFile, err := expandHome(c.String(pidFileFlag.Name))
+	if err != nil {
+		return err
+	}
+	if pid, err := runningPID(pidFile); err != nil {
+		return err
+	} else if pid != 0 {
+		return fmt.Errorf("server is already running (pid %d, pid file %s)", pid, pidFile)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the server executable: %w", err)
+	}
+	devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = devNull.Close() }()
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Env = append(os.Environ(), daemonChildEnv+"=1")
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = devNull, devNull, devNull
+	if err := detach(cmd); err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start the background server: %w", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	deadline := time.After(daemonStartTimeout)
+	for {
+		select {
+		case err := <-exited:
+			return fmt.Errorf("background server exited during startup (%v); see %s", err, config.LogFile)
+		case <-deadline:
+			return fmt.Errorf("background server (pid %d) did not write %s within %s; see %s",
+				cmd.Process.Pid, pidFile, daemonStartTimeout, config.LogFile)
+		case <-ticker.C:
+			if pid, _ := readPIDFile(pidFile); pid == cmd.Process.Pid {
+				_, _ = fmt.Fprintf(c.App.Writer, "Started terramate-mcp-server in the background (pid %d)\n", pid)
+				return nil
+			}
+		}
+	}
+}
+
+// stopDaemon asks the background server to shut down and waits for it to exit.
+func stopDaemon(c *cli.Context) error {
+	pidFile, err := expandHome(c.String(pidFileFlag.Name))
+	if err != nil {
+		return err
+	}
+	pid, err := runningPID(pidFile)
+	if err != nil {
+		return err
+	}
+	if pid == 0 {
+		_, _ = fmt.Fprintln(c.App.Writer, "terramate-mcp-server is not running")
+		return nil
+	}
+
+	if err := terminate(pid); err != nil {
+		return fmt.Errorf("failed to stop pid %d: %w", pid, err)
+	}
+	deadline := time.Now().Add(c.Duration(stopTimeoutFlag.Name))
+	for processRunning(pid) {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("server (pid %d) still running after %s", pid, c.Duration(stopTimeoutFlag.Name))
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	_, _ = fmt.Fprintf(c.App.Writer, "Stopped terramate-mcp-server (pid %d)\n", pid)
+	return nil
+}
+
+// daemonStatus reports whether the background server is running.
+func daemonStatus(c *cli.Context) error {
+	pidFile, err := expandHome(c.String(pidFileFlag.Name))
+	if err != nil {
+		return err
+	}
+	pid, err := runningPID(pidFile)
+	if err != nil {
+		return err
+	}
+	if pid == 0 {
+		return cli.Exit("terramate-mcp-server is not running", statusNotRunning)
+	}
+	_, _ = fmt.Fprintf(c.App.Writer, "terramate-mcp-server is running (pid %d)\n", pid)
+	return nil
+}
+
+// writePIDFile records the process ID in path unless it names another
+// running process. The returned function removes the file if it still holds
+// this process's ID.
+func writePIDFile(path string) (func(), error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+	if pid, err := runningPID(path); err != nil {
+		return nil, err
+	} else if pid != 0 && pid != os.Getpid() {
+		return nil, fmt.Errorf("server is already running (pid %d, pid file %s)", pid, path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create pid file directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(os.Getpid())+"\n"), 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write pid file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return nil, fmt.Errorf("failed to write pid file: %w", err)
+	}
+
+	return func() {
+		if pid, _ := readPIDFile(path); pid == os.Getpid() {
+			_ = os.Remove(path)
+		}
+	}, nil
+}
+
+// readPIDFile returns the process ID recorded in path, or 0 if the file does
+// not exist.
+func readPIDFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read pid file: %w", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid pid file %s", path)
+	}
+	return pid, nil
+}
+
+// runningPID returns the process ID recorded in path if that process is
+// running, or 0 if there is no pid file or it is stale.
+func runningPID(path string) (int, error) {
+	pid, err := readPIDFile(path)
+	if err != nil || pid == 0 {
+		return 0, err
+	}
+	if !processRunning(pid) {
+		return 0, nil
+	}
+	return pid, nil
+}
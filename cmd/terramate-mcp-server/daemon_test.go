@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func TestWritePIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run", "mcp-server.pid")
+
+	remove, err := writePIDFile(path)
+	if err != nil {
+		t.Fatalf("writePIDFile error: %v", err)
+	}
+	if pid, err := readPIDFile(path); err != nil || pid != os.Getpid() {
+		t.Fatalf("expected the pid file to hold this process, got %d (err %v)", pid, err)
+	}
+
+	remove()
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the pid file to be removed, got %v", err)
+	}
+}
+
+func TestWritePIDFile_RunningServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mcp-server.pid")
+	// The parent of the test process is running and is not this process
+	if err := os.WriteFile(path, []byte(strconv.Itoa(os.Getppid())), 0o644); err != nil {
+		t.Fatalf("failed to write pid file: %v", err)
+	}
+	if !processRunning(os.Getppid()) {
+		t.Skip("process checks are not supported on this platform")
+	}
+
+	if _, err := writePIDFile(path); err == nil {
+		t.Fatal("expected error when the pid file names another running process")
+	}
+}
+
+func TestRunningPID_Stale(t *testing.T) {
+	dir := t.TempDir()
+
+	if pid, err := runningPID(filepath.Join(dir, "missing.pid")); err != nil || pid != 0 {
+		t.Fatalf("expected no pid without a pid file, got %d (err %v)", pid, err)
+	}
+
+	stale := filepath.Join(dir, "stale.pid")
+	if err := os.WriteFile(stale, []byte("999999999\n"), 0o644); err != nil {
+		t.Fatalf("failed to write pid file: %v", err)
+	}
+	if pid, err := runningPID(stale); err != nil || pid != 0 {
+		t.Fatalf("expected a stale pid file to be ignored, got %d (err %v)", pid, err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.pid")
+	if err := os.WriteFile(invalid, []byte("not a pid"), 0o644); err != nil {
+		t.Fatalf("failed to write pid file: %v", err)
+	}
+	if _, err := runningPID(invalid); err == nil {
+		t.Fatal("expected error for an invalid pid file")
+	}
+}
+
+func TestDaemonStatus_NotRunning(t *testing.T) {
+	app := &cli.App{Commands: daemonCommands, Writer: io.Discard, ExitErrHandler: func(*cli.Context, error) {}}
+	err := app.Run([]string{"terramate-mcp-server", "status", "--pid-file", filepath.Join(t.TempDir(), "mcp-server.pid")})
+
+	var exitErr cli.ExitCoder
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != statusNotRunning {
+		t.Fatalf("expected exit code %d, got %v", statusNotRunning, err)
+	}
+}
+
+func TestStartDaemon_RequiresHTTPAndLogFile(t *testing.T) {
+	for _, config := range []*Config{
+		{Transport: transportStdio, LogFile: "/tmp/mcp.log"},
+		{Transport: transportHTTP},
+	} {
+		if err := startDaemon(nil, config); err == nil {
+			t.Errorf("expected error for %+v", config)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+//go:build unix
+
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// logRotationSignals make the server reopen its log file, e.g. from a
+// logrotate postrotate script.
+var logRotationSignals = []os.Signal{syscall.SIGUSR1}
+
+// detach starts cmd in a new session, without a controlling terminal.
+func detach(cmd *exec.Cmd) error {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	return nil
+}
+
+// processRunning reports whether a process with pid exists.
+func processRunning(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// terminate asks the process to shut down gracefully.
+func terminate(pid int) error {
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
@@ -0,0 +1,27 @@
+//go:build windows
+
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// errDaemonUnsupported is returned by the daemon features on Windows.
+var errDaemonUnsupported = errors.New("running in the background is not supported on Windows")
+
+// logRotationSignals is empty: Windows has no signal to reopen the log file.
+var logRotationSignals []os.Signal
+
+func detach(*exec.Cmd) error {
+	return errDaemonUnsupported
+}
+
+func processRunning(int) bool {
+	return false
+}
+
+func terminate(int) error {
+	return errDaemonUnsupported
+}
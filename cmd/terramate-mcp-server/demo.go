@@ -0,0 +1,17 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/terramate-io/terramate-mcp-server/internal/demo"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// demoEndpoint routes the API client to the in-process demo API (see
+// --demo), replacing its HTTP client so no request leaves the process.
+func demoEndpoint(c *terramate.Client) error {
+	if err := terramate.WithBaseURL(demo.BaseURL)(c); err != nil {
+		return err
+	}
+	return terramate.WithHTTPClient(&http.Client{Transport: demo.Transport()})(c)
+}
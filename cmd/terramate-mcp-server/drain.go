@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// errShuttingDown is the result of tool calls rejected or aborted because
+// the server is shutting down.
+const errShuttingDown = "The server is shutting down; retry the call once it is available again."
+
+// shutdownAbortGrace is how long aborted tool calls get to return their
+// error result once the shutdown timeout has expired.
+const shutdownAbortGrace = 2 * time.Second
+
+// callTracker tracks in-flight tool calls so shutdown can drain them: new
+// calls are rejected, running calls may finish until the shutdown timeout and
+// are then canceled with a shutting down error instead of being dropped.
+type callTracker struct {
+	mu       sync.Mutex
+	closing  bool
+	inFlight int
+	wg       sync.WaitGroup
+
+	abortCtx context.Context // canceled when draining times out
+	abort    context.CancelFunc
+}
+
+func newCallTracker() *callTracker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &callTracker{abortCtx: ctx, abort: cancel}
+}
+
+// track is a tool handler middleware registering each call with the tracker.
+func (t *callTracker) track(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		t.mu.Lock()
+		if t.closing {
+			t.mu.Unlock()
+			return mcp.NewToolResultError(errShuttingDown), nil
+		}
+		t.inFlight++
+		t.wg.Add(1)
+		t.mu.Unlock()
+		defer func() {
+			t.mu.Lock()
+			t.inFlight--
+			t.mu.Unlock()
+			t.wg.Done()
+		}()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stop := context.AfterFunc(t.abortCtx, cancel)
+		defer stop()
+
+		result, err := next(ctx, request)
+		if t.abortCtx.Err() != nil && (err != nil || result == nil || result.IsError) {
+			return mcp.NewToolResultError(errShuttingDown), nil
+		}
+		return result, err
+	}
+}
+
+// drain rejects new calls and waits for the running ones until ctx is done.
+// Calls still running then are canceled and given shutdownAbortGrace to
+// return. It reports whether all calls finished.
+func (t *callTracker) drain(ctx context.Context) bool {
+	t.mu.Lock()
+	t.closing = true
+	inFlight := t.inFlight
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	if inFlight > 0 {
+		slog.Info("Waiting for in-flight tool calls to finish", "calls", inFlight)
+	}
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+	}
+
+	t.mu.Lock()
+	inFlight = t.inFlight
+	t.mu.Unlock()
+	slog.Warn("Shutdown timeout expired, canceling in-flight tool calls", "calls", inFlight)
+	t.abort()
+
+	select {
+	case <-done:
+	case <-time.After(shutdownAbortGrace):
+	}
+	return false
+}
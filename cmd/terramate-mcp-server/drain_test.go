@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func callText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	return textContent.Text
+}
+
+func TestCallTracker_DrainsInFlightCalls(t *testing.T) {
+	tracker := newCallTracker()
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := tracker.track(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-release
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	results := make(chan *mcp.CallToolResult, 1)
+	go func() {
+		result, _ := handler(context.Background(), mcp.CallToolRequest{})
+		results <- result
+	}()
+	<-started
+
+	drained := make(chan bool, 1)
+	go func() { drained <- tracker.drain(context.Background()) }()
+
+	// Wait until the tracker is closing, then check new calls are rejected
+	for {
+		tracker.mu.Lock()
+		closing := tracker.closing
+		tracker.mu.Unlock()
+		if closing {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	rejected, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil || !rejected.IsError || callText(t, rejected) != errShuttingDown {
+		t.Fatalf("expected new calls to be rejected while draining, got %+v, %v", rejected, err)
+	}
+
+	close(release)
+	if !<-drained {
+		t.Fatal("expected the in-flight call to be drained")
+	}
+	if result := <-results; result.IsError || callText(t, result) != "done" {
+		t.Fatalf("expected the in-flight call to complete, got %q", callText(t, result))
+	}
+}
+
+func TestCallTracker_CancelsCallsAfterTimeout(t *testing.T) {
+	tracker := newCallTracker()
+	started := make(chan struct{})
+	handler := tracker.track(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-ctx.Done()
+		return mcp.NewToolResultError("Failed to list stacks: " + ctx.Err().Error()), nil
+	})
+
+	results := make(chan *mcp.CallToolResult, 1)
+	go func() {
+		result, _ := handler(context.Background(), mcp.CallToolRequest{})
+		results <- result
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if tracker.drain(ctx) {
+		t.Fatal("expected draining to time out")
+	}
+	if result := <-results; !result.IsError || callText(t, result) != errShuttingDown {
+		t.Fatalf("expected a shutting down error, got %q", callText(t, result))
+	}
+}
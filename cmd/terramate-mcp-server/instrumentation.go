@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// logInstrumentation logs SDK events that matter to operators: failed
+// requests, retries and credential refreshes. Successful requests are not
+// logged to keep the output quiet.
+type logInstrumentation struct {
+	terramate.NopInstrumentation
+}
+
+// OnRequestEnd logs requests that failed or ended with a server error.
+func (logInstrumentation) OnRequestEnd(ctx context.Context, info terramate.RequestInfo) {
+	switch {
+	case info.Err != nil:
+		slog.WarnContext(ctx, "Terramate Cloud request failed",
+			"method", info.Method, "path", info.Path, "duration", info.Duration, "error", info.Err)
+	case info.StatusCode >= http.StatusInternalServerError:
+		slog.WarnContext(ctx, "Terramate Cloud request returned a server error",
+			"method", info.Method, "path", info.Path, "status", info.StatusCode, "duration", info.Duration)
+	}
+}
+
+// OnRetry logs retry decisions, and at debug level the waits advertised by
+// Retry-After.
+func (logInstrumentation) OnRetry(ctx context.Context, info terramate.RetryInfo) {
+	reason := http.StatusText(info.StatusCode)
+	if info.Err != nil {
+		reason = info.Err.Error()
+	}
+	if info.RetryAfter {
+		slog.DebugContext(ctx, "Honoring Retry-After of Terramate Cloud",
+			"method", info.Method, "path", info.Path, "wait", info.Wait)
+	}
+	slog.InfoContext(ctx, "Retrying Terramate Cloud request",
+		"method", info.Method, "path", info.Path, "wait", info.Wait, "attempt", info.Attempt+1, "reason", reason)
+}
+
+// OnRefresh logs credential refresh outcomes.
+func (logInstrumentation) OnRefresh(ctx context.Context, info terramate.RefreshInfo) {
+	if info.Err != nil {
+		slog.WarnContext(ctx, "Credential refresh failed", "credential", info.Credential, "duration", info.Duration, "error", info.Err)
+		return
+	}
+	slog.InfoContext(ctx, "Credential refresh succeeded", "credential", info.Credential, "duration", info.Duration)
+}
+
+// instrumentations passes SDK events to each of its hooks in order.
+type instrumentations []terramate.Instrumentation
+
+// OnRequestStart implements terramate.Instrumentation.
+func (is instrumentations) OnRequestStart(ctx context.Context, req *http.Request) {
+	for _, i := range is {
+		i.OnRequestStart(ctx, req)
+	}
+}
+
+// OnRequestEnd implements terramate.Instrumentation.
+func (is instrumentations) OnRequestEnd(ctx context.Context, info terramate.RequestInfo) {
+	for _, i := range is {
+		i.OnRequestEnd(ctx, info)
+	}
+}
+
+// OnRetry implements terramate.Instrumentation.
+func (is instrumentations) OnRetry(ctx context.Context, info terramate.RetryInfo) {
+	for _, i := range is {
+		i.OnRetry(ctx, info)
+	}
+}
+
+// OnRefresh implements terramate.Instrumentation.
+func (is instrumentations) OnRefresh(ctx context.Context, info terramate.RefreshInfo) {
+	for _, i := range is {
+		i.OnRefresh(ctx, info)
+	}
+}
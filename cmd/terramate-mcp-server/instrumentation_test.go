@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	restoreDefaultLogger(t)
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	return &buf
+}
+
+func TestLogInstrumentation(t *testing.T) {
+	buf := captureLog(t)
+	var inst terramate.Instrumentation = logInstrumentation{}
+	ctx := context.Background()
+
+	inst.OnRequestEnd(ctx, terramate.RequestInfo{Method: "GET", Path: "/v1/stacks/org", StatusCode: 200})
+	if buf.Len() != 0 {
+		t.Fatalf("expected successful requests not to be logged, got %q", buf.String())
+	}
+
+	inst.OnRequestEnd(ctx, terramate.RequestInfo{Method: "GET", Path: "/v1/stacks/org", StatusCode: 503, Duration: time.Second})
+	inst.OnRetry(ctx, terramate.RetryInfo{Method: "GET", Path: "/v1/stacks/org", StatusCode: 429, Wait: 100 * time.Millisecond})
+	inst.OnRetry(ctx, terramate.RetryInfo{Method: "GET", Path: "/v1/stacks/org", StatusCode: 429, Wait: 30 * time.Second, RetryAfter: true})
+	inst.OnRefresh(ctx, terramate.RefreshInfo{Credential: "Google", Err: errors.New("invalid_grant")})
+
+	out := buf.String()
+	for _, want := range []string{"status=503", `reason="Too Many Requests"`, "attempt=1", "level=DEBUG msg=\"Honoring Retry-After of Terramate Cloud\"", "wait=30s", "Credential refresh failed", "credential=Google", "invalid_grant"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected log output to contain %q, got %q", want, out)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// Supported log formats.
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// logLevel is the level of the default logger. It is a variable so that a
+// configuration reload can change it without replacing the logger.
+var logLevel = new(slog.LevelVar)
+
+// activeLogFile is the log file of the default logger, or nil when logging to
+// stderr. It is reopened on the log rotation signal.
+var activeLogFile *logFile
+
+// parseLogLevel parses debug, info, warn or error (case-insensitive).
+func parseLogLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("invalid log level: %s (must be 'debug', 'info', 'warn' or 'error')", s)
+	}
+	return level, nil
+}
+
+// parseLogFormat parses text or json (case-insensitive).
+func parseLogFormat(s string) (string, error) {
+	format := strings.ToLower(s)
+	if format != logFormatText && format != logFormatJSON {
+		return "", fmt.Errorf("invalid log format: %s (must be '%s' or '%s')", s, logFormatText, logFormatJSON)
+	}
+	return format, nil
+}
+
+// newLogHandler creates a handler writing records in format to w, filtered
+// by logLevel, with secrets redacted from messages and attributes.
+func newLogHandler(w io.Writer, format string) slog.Handler {
+	opts := &slog.HandlerOptions{Level: logLevel, ReplaceAttr: redactAttr}
+	if format == logFormatJSON {
+		return correlationHandler{slog.NewJSONHandler(w, opts)}
+	}
+	return correlationHandler{slog.NewTextHandler(w, opts)}
+}
+
+// redactAttr redacts secrets (see terramate.Redact) from the message and the
+// string, error and fmt.Stringer attributes of a record, so that no log line,
+// e.g. of a failed token refresh, leaks a credential.
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(terramate.Redact(a.Value.String()))
+	case slog.KindAny:
+		switch v := a.Value.Any().(type) {
+		case error:
+			a.Value = slog.StringValue(terramate.Redact(v.Error()))
+		case fmt.Stringer:
+			a.Value = slog.StringValue(terramate.Redact(v.String()))
+		}
+	}
+	return a
+}
+
+// correlationHandler adds the correlation ID of the record's context, if
+// any, to records logged with the *Context functions.
+type correlationHandler struct {
+	slog.Handler
+}
+
+// Handle implements slog.Handler.
+func (h correlationHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := terramate.CorrelationID(ctx); id != "" {
+		r.AddAttrs(slog.String("correlation_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h correlationHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return correlationHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (h correlationHandler) WithGroup(name string) slog.Handler {
+	return correlationHandler{h.Handler.WithGroup(name)}
+}
+
+// setupLogging makes the default logger write to config.LogFile (stderr when
+// unset) in config.LogFormat. The standard library logger is routed through
+// it as well. The returned function closes the log file.
+func setupLogging(config *Config) (func(), error) {
+	var w io.Writer = os.Stderr
+	closeLog := func() {}
+	activeLogFile = nil
+	if config.LogFile != "" {
+		f, err := openLogFile(config.LogFile)
+		if err != nil {
+			return nil, err
+		}
+		lf := &logFile{path: config.LogFile, f: f}
+		activeLogFile, w = lf, lf
+		closeLog = func() { _ = lf.Close() }
+	}
+
+	logLevel.Set(config.LogLevel)
+	slog.SetDefault(slog.New(newLogHandler(w, config.LogFormat)))
+	return closeLog, nil
+}
+
+// reopenLogFile reopens the log file, if any, so logs continue in a new file
+// after logrotate moved the current one away.
+func reopenLogFile() {
+	if activeLogFile == nil {
+		return
+	}
+	if err := activeLogFile.Reopen(); err != nil {
+		slog.Error("Failed to reopen log file, logging to the previous one", "error", err)
+		return
+	}
+	slog.Info("Reopened log file")
+}
+
+// logFile is an append-only log file that can be reopened while in use.
+type logFile struct {
+	path string
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+// Write implements io.Writer.
+func (l *logFile) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Write(p)
+}
+
+// Reopen replaces the file with a newly opened one at the same path, keeping
+// the current file if that fails.
+func (l *logFile) Reopen() error {
+	f, err := openLogFile(l.path)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	old := l.f
+	l.f = f
+	l.mu.Unlock()
+	return old.Close()
+}
+
+// Close closes the file.
+func (l *logFile) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.f.Close()
+}
+
+// openLogFile opens path for appending, creating it if needed.
+func openLogFile(path string) (*os.File, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	return f, nil
+}
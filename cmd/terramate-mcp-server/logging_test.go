@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// restoreDefaultLogger restores the default loggers, including the standard
+// library logger that slog.SetDefault redirects, when the test ends.
+func restoreDefaultLogger(t *testing.T) {
+	t.Helper()
+	prev, prevLevel := slog.Default(), logLevel.Level()
+	prevWriter, prevFlags := log.Writer(), log.Flags()
+	t.Cleanup(func() {
+		slog.SetDefault(prev)
+		logLevel.Set(prevLevel)
+		log.SetOutput(prevWriter)
+		log.SetFlags(prevFlags)
+	})
+}
+
+func TestBuildConfig_Logging(t *testing.T) {
+	got := runWithFileConfig(t, &fileConfig{Log: logConfig{Level: "warn", Format: "text", File: "/tmp/mcp.log"}}, "--log-format", "JSON")
+	if got.LogLevel != slog.LevelWarn || got.LogFormat != logFormatJSON || got.LogFile != "/tmp/mcp.log" {
+		t.Fatalf("unexpected log config: level %v, format %q, file %q", got.LogLevel, got.LogFormat, got.LogFile)
+	}
+
+	for _, args := range [][]string{{"--log-level", "verbose"}, {"--log-format", "xml"}} {
+		if _, err := reloadConfig(appFlags, append([]string{"terramate-mcp-server", "--config", writeConfigFile(t, "")}, args...)); err == nil {
+			t.Fatalf("expected error for %v", args)
+		}
+	}
+}
+
+func TestSetupLogging(t *testing.T) {
+	restoreDefaultLogger(t)
+
+	path := filepath.Join(t.TempDir(), "mcp.log")
+	closeLog, err := setupLogging(&Config{LogLevel: slog.LevelWarn, LogFormat: logFormatJSON, LogFile: path})
+	if err != nil {
+		t.Fatalf("setupLogging error: %v", err)
+	}
+	slog.Info("hidden")
+	slog.Warn("shown", "organization", "org-uuid")
+	logLevel.Set(slog.LevelDebug) // as on reload
+	slog.Debug("shown after reload")
+	closeLog()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %q", data)
+	}
+	var record map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("expected JSON log lines: %v", err)
+	}
+	if record["msg"] != "shown" || record["level"] != "WARN" || record["organization"] != "org-uuid" {
+		t.Fatalf("unexpected log record: %v", record)
+	}
+}
+
+func TestSetupLogging_CorrelationID(t *testing.T) {
+	restoreDefaultLogger(t)
+
+	path := filepath.Join(t.TempDir(), "mcp.log")
+	closeLog, err := setupLogging(&Config{LogFormat: logFormatText, LogFile: path})
+	if err != nil {
+		t.Fatalf("setupLogging error: %v", err)
+	}
+	slog.With("tool", "tmc_list_stacks").InfoContext(terramate.WithCorrelationID(context.Background(), "CHG-1234"), "correlated")
+	slog.InfoContext(context.Background(), "uncorrelated")
+	closeLog()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "tool=tmc_list_stacks correlation_id=CHG-1234") || strings.Contains(lines[1], "correlation_id") {
+		t.Fatalf("unexpected log lines: %q", data)
+	}
+}
+
+func TestSetupLogging_RedactsSecrets(t *testing.T) {
+	restoreDefaultLogger(t)
+
+	path := filepath.Join(t.TempDir(), "mcp.log")
+	closeLog, err := setupLogging(&Config{LogFormat: logFormatJSON, LogFile: path})
+	if err != nil {
+		t.Fatalf("setupLogging error: %v", err)
+	}
+	slog.Info("Sending Authorization: Bearer opaque-access-token",
+		"error", &terramate.APIError{StatusCode: 401, Message: "invalid token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJtZSJ9.opaque"},
+		"body", `{"refresh_token":"opaque"}`,
+		"wrapped", errors.New("refresh_token=opaque rejected"))
+	closeLog()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if strings.Contains(string(data), "opaque") || strings.Count(string(data), terramate.Redacted) != 4 {
+		t.Fatalf("expected secrets to be redacted: %s", data)
+	}
+}
+
+func TestReopenLogFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("open files cannot be renamed on Windows")
+	}
+	restoreDefaultLogger(t)
+
+	path := filepath.Join(t.TempDir(), "mcp.log")
+	closeLog, err := setupLogging(&Config{LogFormat: logFormatText, LogFile: path})
+	if err != nil {
+		t.Fatalf("setupLogging error: %v", err)
+	}
+	defer closeLog()
+
+	slog.Info("before rotation")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("failed to rotate log file: %v", err)
+	}
+	reopenLogFile()
+	slog.Info("after rotation")
+
+	rotated, _ := os.ReadFile(path + ".1")
+	current, _ := os.ReadFile(path)
+	if !strings.Contains(string(rotated), "before rotation") || strings.Contains(string(rotated), "after rotation") {
+		t.Fatalf("unexpected rotated log file: %q", rotated)
+	}
+	if !strings.Contains(string(current), "after rotation") {
+		t.Fatalf("expected logging to continue in a new file, got %q", current)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/urfave/cli/v2"
+)
+
+// loginCommand logs in to Terramate Cloud with GitHub and writes the
+// credential file, for machines without the Terramate CLI.
+var loginCommand = &cli.Command{
+	Name:   "login",
+	Usage:  "Log in to Terramate Cloud with GitHub and write the credential file (requires --login-client-id)",
+	Flags:  appFlags,
+	Action: runLogin,
+}
+
+// runLogin runs the device login of config: it prints the code to enter on
+// the verification page and waits until the user authorized the login.
+func runLogin(c *cli.Context) error {
+	config, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+	login, err := deviceLogin(config)
+	if err != nil {
+		return err
+	}
+	code, err := login.Start(c.Context)
+	if err != nil {
+		return err
+	}
+	out := c.App.Writer
+	_, _ = fmt.Fprintf(out, "Open %s and enter the code %s\nWaiting for the login to be authorized...\n", code.VerificationURI, code.UserCode)
+	result, err := login.Wait(c.Context, code)
+	if err != nil {
+		return err
+	}
+	path, err := writeLoginCredential(config, result)
+	if err != nil {
+		return err
+	}
+	if result.Email != "" {
+		_, _ = fmt.Fprintf(out, "Logged in as %s; the credential was written to %s\n", result.Email, path)
+	} else {
+		_, _ = fmt.Fprintf(out, "Logged in; the credential was written to %s\n", path)
+	}
+	return nil
+}
+
+// deviceLogin returns the device login of config. The login requests go
+// through the proxy of config.
+func deviceLogin(config *Config) (*terramate.DeviceLogin, error) {
+	if config.LoginClientID == "" {
+		return nil, fmt.Errorf("login requires --%s, the client ID of the GitHub OAuth app accepted by Terramate Cloud", loginClientIDFlag.Name)
+	}
+	httpClient, err := proxyHTTPClient(config.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	return &terramate.DeviceLogin{ClientID: config.LoginClientID, HTTPClient: httpClient}, nil
+}
+
+// writeLoginCredential writes the tokens of a login to the credential store
+// of config, with the configured region as its region hint, returning where
+// they were written.
+func writeLoginCredential(config *Config, result *terramate.LoginResult) (string, error) {
+	if config.CredentialStore == credentialStoreKeychain {
+		kc := keychain(config)
+		if err := kc.Store(result, config.Region); err != nil {
+			return "", err
+		}
+		return kc.String(), nil
+	}
+	path, err := expandHome(config.CredentialFile)
+	if path == "" {
+		path, err = terramate.GetDefaultCredentialPath()
+	}
+	if err != nil {
+		return "", err
+	}
+	if err = terramate.WriteCredentialFile(path, result, config.Region); err != nil {
+		return "", fmt.Errorf("failed to write the credential file: %w", err)
+	}
+	return path, nil
+}
+
+// login starts a device login for tmc_login. Once the user authorized it, the
+// credential is stored in the background; a server without a credential
+// connects right away, and a running JWT credential reloads the file.
+func (s *Server) login(ctx context.Context) (*terramate.DeviceCode, error) {
+	s.mu.RLock()
+	config, serveCtx := *s.config, s.serveCtx
+	s.mu.RUnlock()
+	if serveCtx == nil {
+		serveCtx = context.WithoutCancel(ctx)
+	}
+
+	login, err := deviceLogin(&config)
+	if err != nil {
+		return nil, err
+	}
+	code, err := login.Start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		result, loginErr := login.Wait(serveCtx, code)
+		if loginErr == nil {
+			_, loginErr = writeLoginCredential(&config, result)
+		}
+		if loginErr != nil {
+			if !errors.Is(loginErr, context.Canceled) {
+				slog.Warn("Login did not complete", "error", loginErr)
+			}
+			return
+		}
+		slog.Info("Logged in to Terramate Cloud", "email", result.Email)
+		if connErr := s.connect(serveCtx); connErr != nil {
+			slog.Warn("Failed to connect to Terramate Cloud after the login", "error", connErr)
+		}
+	}()
+	return code, nil
+}
+
+// sessionExpired returns the last refresh error of the JWT credential of the
+// server, if any, for tools.Reauthentication.
+func (s *Server) sessionExpired() error {
+	s.mu.RLock()
+	cred := s.jwtCred
+	s.mu.RUnlock()
+	if cred == nil {
+		return nil
+	}
+	return cred.Status().LastRefreshError
+}
+
+// relogin starts the device login offered by tools.Reauthentication when the
+// login session expired. It returns a nil code when tmc_login is not served.
+func (s *Server) relogin(ctx context.Context) (*terramate.DeviceCode, error) {
+	s.mu.RLock()
+	config := s.config
+	s.mu.RUnlock()
+	if config.LoginClientID == "" || injectedCredential(config) || config.Demo {
+		return nil, nil
+	}
+	return s.login(ctx)
+}
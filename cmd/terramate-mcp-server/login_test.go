@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestWriteLoginCredential(t *testing.T) {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "https://securetoken.google.com/terramate",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+	config := &Config{CredentialFile: filepath.Join(t.TempDir(), "tmc", "credentials.tmrc.json"), Region: terramate.RegionUS}
+
+	path, err := writeLoginCredential(config, &terramate.LoginResult{IDToken: token, RefreshToken: "refresh"})
+	if err != nil {
+		t.Fatalf("writeLoginCredential error: %v", err)
+	}
+	cred, err := terramate.LoadJWTFromFile(path)
+	if err != nil {
+		t.Fatalf("expected a loadable credential file, got %v", err)
+	}
+	if path != config.CredentialFile || cred.Name() != "GitHub" || cred.Region() != terramate.RegionUS {
+		t.Fatalf("unexpected credential %s: provider %q region %q", path, cred.Name(), cred.Region())
+	}
+}
+
+func TestNewBackend_LoginTool(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	login := func(context.Context) (*terramate.DeviceCode, error) { return &terramate.DeviceCode{}, nil }
+	hasLogin := func(config *Config) bool {
+		t.Helper()
+		b, err := newBackend(config, nil, newFreshnessMetrics(), nil, authHooks{connect: func(context.Context) error { return errNoCredential }, login: login})
+		if err != nil {
+			t.Fatalf("newBackend error: %v", err)
+		}
+		for _, tool := range b.toolHandlers.Tools() {
+			if tool.Tool.Name == "tmc_login" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !hasLogin(&Config{LoginClientID: "client-id"}) {
+		t.Fatal("expected tmc_login with a login client ID")
+	}
+	if hasLogin(&Config{}) {
+		t.Fatal("expected tmc_login to require a login client ID")
+	}
+	if hasLogin(&Config{LoginClientID: "client-id", APIKey: "key", Region: terramate.RegionEU}) {
+		t.Fatal("expected no tmc_login with an API key")
+	}
+}
+
+func TestDeviceLogin_RequiresClientID(t *testing.T) {
+	if _, err := deviceLogin(&Config{}); err == nil {
+		t.Fatal("expected an error without --login-client-id")
+	}
+}
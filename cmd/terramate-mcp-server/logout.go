@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/urfave/cli/v2"
+)
+
+// errNotLoggedIn reports a logout without a JWT credential to end.
+var errNotLoggedIn = errors.New("not logged in with a credential file")
+
+// logoutCommand removes the stored login, e.g. when leaving a shared
+// workstation.
+var logoutCommand = &cli.Command{
+	Name:   "logout",
+	Usage:  "Log out of Terramate Cloud: revoke the refresh token (with --token-revocation-endpoint) and delete the credential file",
+	Flags:  appFlags,
+	Action: runLogout,
+}
+
+// runLogout ends the login stored in the credential store of config.
+// Without a stored credential there is nothing to do. Running servers keep
+// the tokens they loaded until restarted; use tmc_logout to end their login.
+func runLogout(c *cli.Context) error {
+	config, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+	jwtCred, location, err := storedCredential(config)
+	if errors.Is(err, errNoCredential) {
+		_, _ = fmt.Fprintln(c.App.Writer, "Not logged in:", err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load credentials: %w", err)
+	}
+	httpClient, err := proxyHTTPClient(config.Proxy)
+	if err != nil {
+		return err
+	}
+	if err = jwtCred.Logout(c.Context, tokenRevoker(config, httpClient)); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(c.App.Writer, "Logged out; removed the %s\n", location)
+	return nil
+}
+
+// tokenRevoker returns the revoker of the token revocation endpoint of
+// config, or nil when none is configured. Requests are sent with httpClient
+// unless it is nil.
+func tokenRevoker(config *Config, httpClient *http.Client) terramate.TokenRevoker {
+	if config.TokenRevocationEndpoint == "" {
+		return nil
+	}
+	return &terramate.OAuthTokenRevoker{Endpoint: config.TokenRevocationEndpoint, HTTPClient: httpClient}
+}
+
+// authHooks returns the hooks of the login tools of s.
+func (s *Server) authHooks() authHooks {
+	return authHooks{connect: s.connect, login: s.login, logout: s.logout}
+}
+
+// logout ends the JWT login for tmc_logout and switches to the offline
+// backend: only the local tools are served until a credential shows up
+// again, e.g. from tmc_login or 'terramate cloud login'.
+func (s *Server) logout(ctx context.Context) error {
+	s.connectMu.Lock()
+	defer s.connectMu.Unlock()
+
+	s.mu.RLock()
+	jwtCred, config, serveCtx := s.jwtCred, *s.config, s.serveCtx
+	s.mu.RUnlock()
+	if jwtCred == nil {
+		return errNotLoggedIn
+	}
+	b, err := newOfflineBackend(&config, s.index, s.metrics, s.callLog, s.authHooks())
+	if err != nil {
+		return err
+	}
+	httpClient, err := proxyHTTPClient(config.Proxy)
+	if err != nil {
+		return err
+	}
+	logoutErr := jwtCred.Logout(ctx, tokenRevoker(&config, httpClient))
+
+	// The tokens are cleared even when the revocation failed, so the
+	// Terramate Cloud tools go either way
+	s.useBackend(ctx, &config, b)
+	slog.Info("Logged out of Terramate Cloud")
+	if serveCtx != nil {
+		go s.awaitCredential(serveCtx)
+	}
+	return logoutErr
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/urfave/cli/v2"
+)
+
+// writeTestCredentialFile writes a credential file with a refresh token.
+func writeTestCredentialFile(t *testing.T, path string) {
+	t.Helper()
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "https://accounts.google.com",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+	data, _ := json.Marshal(map[string]string{"provider": "Google", "id_token": token, "refresh_token": "refresh-token"})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write credential file: %v", err)
+	}
+}
+
+func TestServerLogout(t *testing.T) {
+	var revoked string
+	revocation := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		revoked = r.PostForm.Get("token")
+	}))
+	defer revocation.Close()
+	credFile := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	writeTestCredentialFile(t, credFile)
+
+	s, err := newServer(&Config{CredentialFile: credFile, Region: "eu", TokenRevocationEndpoint: revocation.URL})
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	c, err := client.NewInProcessClient(s.mcp)
+	if err != nil {
+		t.Fatalf("client error: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("start error: %v", err)
+	}
+	if _, err := c.Initialize(ctx, mcp.InitializeRequest{}); err != nil {
+		t.Fatalf("initialize error: %v", err)
+	}
+
+	result, err := c.CallTool(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "tmc_logout"}})
+	if err != nil || result.IsError {
+		t.Fatalf("tmc_logout error: %v %+v", err, result)
+	}
+	if revoked != "refresh-token" {
+		t.Fatalf("expected the refresh token to be revoked, got %q", revoked)
+	}
+	if _, statErr := os.Stat(credFile); !errors.Is(statErr, os.ErrNotExist) {
+		t.Fatalf("expected the credential file to be removed, got %v", statErr)
+	}
+	list, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		t.Fatalf("list tools error: %v", err)
+	}
+	var names []string
+	for _, tool := range list.Tools {
+		names = append(names, tool.Name)
+	}
+	if strings.Join(names, ",") != "tmc_authenticate,tmc_credential_status,tmc_server_info" {
+		t.Fatalf("expected only the offline tools after the logout, got %v", names)
+	}
+	if err := s.logout(ctx); !errors.Is(err, errNotLoggedIn) {
+		t.Fatalf("expected errNotLoggedIn on a second logout, got %v", err)
+	}
+}
+
+func TestNewBackend_LogoutToolRequiresJWT(t *testing.T) {
+	logout := func(context.Context) error { return nil }
+	b, err := newBackend(&Config{APIKey: "key", Region: "eu"}, nil, newFreshnessMetrics(), nil, authHooks{logout: logout})
+	if err != nil {
+		t.Fatalf("newBackend error: %v", err)
+	}
+	for _, tool := range b.toolHandlers.Tools() {
+		if tool.Tool.Name == "tmc_logout" {
+			t.Fatal("expected no tmc_logout with an API key")
+		}
+	}
+}
+
+func TestLogoutCommand(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	credFile := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	writeTestCredentialFile(t, credFile)
+	run := func() string {
+		t.Helper()
+		var out bytes.Buffer
+		app := &cli.App{Commands: []*cli.Command{logoutCommand}, Writer: &out}
+		if err := app.Run([]string{"terramate-mcp-server", "logout", "--credential-file", credFile}); err != nil {
+			t.Fatalf("logout error: %v", err)
+		}
+		return out.String()
+	}
+
+	if out := run(); !strings.Contains(out, "Logged out") {
+		t.Fatalf("expected the logout to be reported, got %q", out)
+	}
+	if _, err := os.Stat(credFile); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the credential file to be removed, got %v", err)
+	}
+	if out := run(); !strings.Contains(out, "Not logged in") {
+		t.Fatalf("expected nothing to do on a second logout, got %q", out)
+	}
+}
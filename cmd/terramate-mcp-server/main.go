@@ -3,31 +3,90 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/terramate-io/terramate-mcp-server/internal/demo"
+	"github.com/terramate-io/terramate-mcp-server/internal/version"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools"
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
 	"github.com/urfave/cli/v2"
 )
 
 var (
+	configFlag = &cli.StringFlag{
+		Name:    "config",
+		Usage:   "Path to the YAML config file; flags and environment variables take precedence",
+		EnvVars: []string{"TERRAMATE_MCP_CONFIG"},
+		Value:   defaultConfigPath,
+	}
+
+	profileFlag = &cli.StringFlag{
+		Name:    "profile",
+		Usage:   "Profile of the config file providing the credential, region and default organization",
+		EnvVars: []string{"TERRAMATE_MCP_PROFILE"},
+	}
+
 	apiKeyFlag = &cli.StringFlag{
 		Name:    "api-key",
 		Usage:   "Terramate Cloud API key",
 		EnvVars: []string{"TERRAMATE_API_KEY"},
 	}
 
+	jwtFlag = &cli.StringFlag{
+		Name:    "jwt",
+		Usage:   "Terramate Cloud JWT (raw ID token), e.g. injected into a container; used instead of the credential store",
+		EnvVars: []string{"TERRAMATE_JWT"},
+	}
+
+	refreshTokenFlag = &cli.StringFlag{
+		Name:    "refresh-token",
+		Usage:   "Refresh token renewing the --jwt ID token; renewed tokens are kept in memory only",
+		EnvVars: []string{"TERRAMATE_REFRESH_TOKEN"},
+	}
+
+	oidcAudienceFlag = &cli.StringFlag{
+		Name:    "oidc-audience",
+		Usage:   "Audience of the OIDC tokens requested in GitHub Actions jobs, which authenticate without a credential when the job has the id-token: write permission",
+		EnvVars: []string{"TERRAMATE_OIDC_AUDIENCE"},
+		Value:   terramate.DefaultOIDCAudience,
+	}
+
+	workloadIdentityFlag = &cli.StringFlag{
+		Name:    "workload-identity",
+		Usage:   "Authenticate with the OIDC tokens of the cloud workload: 'aws' (STS GetWebIdentityToken with the instance role) or 'gcp' (the service account of the metadata server)",
+		EnvVars: []string{"TERRAMATE_WORKLOAD_IDENTITY"},
+	}
+
 	credentialFileFlag = &cli.StringFlag{
 		Name:    "credential-file",
-		Usage:   "Path to JWT credentials file (default: ~/.terramate.d/credentials.tmrc.json)",
+		Usage:   "Path to JWT credentials file (default: ~/.terramate.d/credentials.tmrc.json); refreshes lock <file>.lock against other MCP servers, not against the Terramate CLI",
 		EnvVars: []string{"TERRAMATE_CREDENTIAL_FILE"},
 	}
 
+	credentialStoreFlag = &cli.StringFlag{
+		Name:    "credential-store",
+		Usage:   "Where the JWT credential is stored: 'file' (the credential file) or 'keychain' (macOS Keychain, Windows Credential Manager or Secret Service; a credential file is moved into it)",
+		EnvVars: []string{"TERRAMATE_CREDENTIAL_STORE"},
+		Value:   credentialStoreFile,
+	}
+
+	readOnlyCredentialFileFlag = &cli.BoolFlag{
+		Name:    "read-only-credential-file",
+		Usage:   "Keep refreshed JWTs in memory instead of writing them to the credential file, e.g. on a read-only filesystem or when the Terramate CLI owns the file",
+		EnvVars: []string{"TERRAMATE_READ_ONLY_CREDENTIAL_FILE"},
+	}
+
 	regionFlag = &cli.StringFlag{
 		Name:     "region",
-		Usage:    "Terramate Cloud region (eu or us)",
+		Usage:    "Terramate Cloud region (eu or us; detected from the credential when neither --region nor --base-url is set)",
 		EnvVars:  []string{"TERRAMATE_REGION"},
 		Required: false,
 	}
@@ -38,69 +97,648 @@ var (
 		EnvVars: []string{"TERRAMATE_BASE_URL"},
 		Value:   "https://api.terramate.io",
 	}
+
+	tokenRefreshEndpointFlag = &cli.StringFlag{
+		Name:    "token-refresh-endpoint",
+		Usage:   "Token endpoint used to refresh JWT credentials, including the API key (default: Firebase Auth)",
+		EnvVars: []string{"TERRAMATE_TOKEN_REFRESH_ENDPOINT"},
+	}
+
+	tokenRefreshClientIDFlag = &cli.StringFlag{
+		Name:    "token-refresh-client-id",
+		Usage:   "OAuth 2.0 client ID refreshing JWT credentials with a standard token endpoint at --token-refresh-endpoint, e.g. of a self-hosted Terramate Cloud (default: Firebase Auth)",
+		EnvVars: []string{"TERRAMATE_TOKEN_REFRESH_CLIENT_ID"},
+	}
+
+	tokenRevocationEndpointFlag = &cli.StringFlag{
+		Name:    "token-revocation-endpoint",
+		Usage:   "OAuth 2.0 token revocation endpoint (RFC 7009) revoking the refresh token on logout (default: none, the credential file is only removed)",
+		EnvVars: []string{"TERRAMATE_TOKEN_REVOCATION_ENDPOINT"},
+	}
+
+	loginClientIDFlag = &cli.StringFlag{
+		Name:    "login-client-id",
+		Usage:   "Client ID of the GitHub OAuth app accepted by Terramate Cloud, enabling the login command and the tmc_login tool",
+		EnvVars: []string{"TERRAMATE_LOGIN_CLIENT_ID"},
+	}
+
+	proxyFlag = &cli.StringFlag{
+		Name:    "proxy",
+		Usage:   "Proxy URL for outbound requests to the Terramate Cloud API, token refresh and GitHub (default: HTTPS_PROXY; NO_PROXY is honored)",
+		EnvVars: []string{"TERRAMATE_MCP_PROXY"},
+	}
+
+	defaultOrganizationFlag = &cli.StringFlag{
+		Name:    "default-organization",
+		Usage:   "Organization UUID tools use when a call omits organization_uuid",
+		EnvVars: []string{"TERRAMATE_DEFAULT_ORGANIZATION"},
+	}
+
+	actAsFlag = &cli.StringFlag{
+		Name:    "act-as",
+		Usage:   "Member or service account every Terramate Cloud API request acts as, to audit what it can see (requires a credential allowed to impersonate)",
+		EnvVars: []string{"TERRAMATE_ACT_AS"},
+	}
+
+	toolsetsFlag = &cli.StringSliceFlag{
+		Name:    "toolsets",
+		Usage:   "Toolsets to enable, comma-separated (default: all): " + strings.Join(tools.Toolsets(), ", ") + "; reviews enables review_requests and previews",
+		EnvVars: []string{"TERRAMATE_TOOLSETS"},
+	}
+
+	maxConcurrentAPICallsFlag = &cli.IntFlag{
+		Name:    "max-concurrent-api-calls",
+		Usage:   "Maximum number of in-flight Terramate Cloud API requests across all tools (0 = unlimited)",
+		EnvVars: []string{"TERRAMATE_MAX_CONCURRENT_API_CALLS"},
+	}
+
+	maxConcurrentToolsFlag = &cli.IntFlag{
+		Name:    "max-concurrent-tools",
+		Usage:   "Maximum number of tool calls executed at the same time; excess calls queue (0 = unlimited)",
+		EnvVars: []string{"TERRAMATE_MAX_CONCURRENT_TOOLS"},
+	}
+
+	maxResponseSizeFlag = &cli.IntFlag{
+		Name:    "max-response-size",
+		Usage:   "Maximum size in MiB of a Terramate Cloud API response; larger responses fail with a hint to page through the results",
+		EnvVars: []string{"TERRAMATE_MAX_RESPONSE_SIZE"},
+		Value:   int(terramate.DefaultMaxResponseBytes >> 20),
+	}
+
+	defaultToolConcurrencyFlag = &cli.IntFlag{
+		Name:    "default-tool-concurrency",
+		Usage:   "Cap on parallel API calls made by a single invocation of tools without a --tool-concurrency entry",
+		EnvVars: []string{"TERRAMATE_DEFAULT_TOOL_CONCURRENCY"},
+		Value:   tmc.DefaultConcurrencyLimit,
+	}
+
+	toolConcurrencyFlag = &cli.StringSliceFlag{
+		Name:    "tool-concurrency",
+		Usage:   "Per-tool cap on parallel API calls made by a single invocation, as tool=n (repeatable)",
+		EnvVars: []string{"TERRAMATE_TOOL_CONCURRENCY"},
+	}
+
+	toolTimeoutFlag = &cli.StringSliceFlag{
+		Name:    "tool-timeout",
+		Usage:   "Per-tool timeout of a single invocation, as tool=duration, e.g. tmc_get_deployment_logs=2m (repeatable)",
+		EnvVars: []string{"TERRAMATE_TOOL_TIMEOUT"},
+	}
+
+	shutdownTimeoutFlag = &cli.DurationFlag{
+		Name:    "shutdown-timeout",
+		Usage:   "How long in-flight tool calls may run on shutdown before they are canceled",
+		EnvVars: []string{"TERRAMATE_MCP_SHUTDOWN_TIMEOUT"},
+		Value:   defaultShutdownTimeout,
+	}
+
+	githubTokenFlag = &cli.StringFlag{
+		Name:    "github-token",
+		Usage:   "GitHub token used to post drift issues (optional)",
+		EnvVars: []string{"TERRAMATE_GITHUB_TOKEN"},
+	}
+
+	transportFlag = &cli.StringFlag{
+		Name:    "transport",
+		Usage:   "MCP transport: stdio (single client) or http (multiple concurrent clients)",
+		EnvVars: []string{"TERRAMATE_MCP_TRANSPORT"},
+		Value:   transportStdio,
+	}
+
+	httpAddrFlag = &cli.StringFlag{
+		Name:    "http-addr",
+		Usage:   "Listen address for the http transport",
+		EnvVars: []string{"TERRAMATE_MCP_HTTP_ADDR"},
+		Value:   "127.0.0.1:8080",
+	}
+
+	tlsCertFlag = &cli.StringFlag{
+		Name:    "tls-cert",
+		Usage:   "TLS certificate file (PEM) to serve the http transport over HTTPS; requires --tls-key",
+		EnvVars: []string{"TERRAMATE_MCP_TLS_CERT"},
+	}
+
+	tlsKeyFlag = &cli.StringFlag{
+		Name:    "tls-key",
+		Usage:   "TLS private key file (PEM) of --tls-cert",
+		EnvVars: []string{"TERRAMATE_MCP_TLS_KEY"},
+	}
+
+	tlsWatchFlag = &cli.BoolFlag{
+		Name:    "tls-watch",
+		Usage:   "Reload the TLS certificate when its files change, e.g. on rotation",
+		EnvVars: []string{"TERRAMATE_MCP_TLS_WATCH"},
+	}
+
+	httpAuthTokenFlag = &cli.StringFlag{
+		Name:    "http-auth-token",
+		Usage:   "Bearer token MCP clients must send to the http transport (prefer --http-auth-token-file or the environment variable over the command line)",
+		EnvVars: []string{"TERRAMATE_MCP_HTTP_AUTH_TOKEN"},
+	}
+
+	httpAuthTokenFileFlag = &cli.StringFlag{
+		Name:    "http-auth-token-file",
+		Usage:   "File of bearer tokens accepted by the http transport, one per line; re-read on SIGHUP",
+		EnvVars: []string{"TERRAMATE_MCP_HTTP_AUTH_TOKEN_FILE"},
+	}
+
+	indexPathFlag = &cli.StringFlag{
+		Name:    "index-path",
+		Usage:   "Path to a local SQLite index of stacks, drifts and deployments, synced in the background (optional)",
+		EnvVars: []string{"TERRAMATE_MCP_INDEX_PATH"},
+	}
+
+	indexOrganizationFlag = &cli.StringFlag{
+		Name:    "index-organization",
+		Usage:   "Organization UUID synced into the local index (default: --default-organization)",
+		EnvVars: []string{"TERRAMATE_MCP_INDEX_ORGANIZATION"},
+	}
+
+	indexSyncIntervalFlag = &cli.DurationFlag{
+		Name:    "index-sync-interval",
+		Usage:   "How often the local index is synced",
+		EnvVars: []string{"TERRAMATE_MCP_INDEX_SYNC_INTERVAL"},
+		Value:   defaultIndexSyncInterval,
+	}
+
+	logLevelFlag = &cli.StringFlag{
+		Name:    "log-level",
+		Usage:   "Minimum level of server logs: debug, info, warn or error",
+		EnvVars: []string{"TERRAMATE_MCP_LOG_LEVEL"},
+		Value:   "info",
+	}
+
+	logFormatFlag = &cli.StringFlag{
+		Name:    "log-format",
+		Usage:   "Format of server logs: text or json",
+		EnvVars: []string{"TERRAMATE_MCP_LOG_FORMAT"},
+		Value:   logFormatText,
+	}
+
+	logFileFlag = &cli.StringFlag{
+		Name:    "log-file",
+		Usage:   "Append server logs to this file instead of stderr",
+		EnvVars: []string{"TERRAMATE_MCP_LOG_FILE"},
+	}
+
+	allowCredentialOverrideFlag = &cli.BoolFlag{
+		Name:    "allow-credential-override",
+		Usage:   "Accept the api_key and credential_profile tool arguments, directing single calls at another credential, e.g. of another organization",
+		EnvVars: []string{"TERRAMATE_MCP_ALLOW_CREDENTIAL_OVERRIDE"},
+	}
+
+	debugToolsFlag = &cli.BoolFlag{
+		Name:    "debug-tools",
+		Usage:   "Record recent tool calls (redacted) and serve tmc_replay_last and tmc_inspect_call to debug them",
+		EnvVars: []string{"TERRAMATE_MCP_DEBUG_TOOLS"},
+	}
+
+	demoFlag = &cli.BoolFlag{
+		Name:    "demo",
+		Usage:   "Serve a built-in demo organization instead of Terramate Cloud, to try the tools without an account; no credential is used",
+		EnvVars: []string{"TERRAMATE_MCP_DEMO"},
+	}
+
+	localOnlyFlag = &cli.BoolFlag{
+		Name:    "local-only",
+		Usage:   "Index-only mode without Terramate Cloud: serve only the local index tools, tmc_server_info and the debug tools; no credential is loaded and no login is offered",
+		EnvVars: []string{"TERRAMATE_MCP_LOCAL_ONLY"},
+	}
 )
 
+// appFlags are the flags accepted by the server.
+var appFlags = []cli.Flag{
+	configFlag, profileFlag, apiKeyFlag, jwtFlag, refreshTokenFlag, oidcAudienceFlag, workloadIdentityFlag, credentialFileFlag, credentialStoreFlag, readOnlyCredentialFileFlag, allowCredentialOverrideFlag, regionFlag, baseURLFlag, tokenRefreshEndpointFlag, tokenRefreshClientIDFlag, tokenRevocationEndpointFlag,
+	loginClientIDFlag, proxyFlag, defaultOrganizationFlag, actAsFlag, toolsetsFlag, maxConcurrentAPICallsFlag, maxConcurrentToolsFlag, defaultToolConcurrencyFlag, toolConcurrencyFlag,
+	toolTimeoutFlag, maxResponseSizeFlag, shutdownTimeoutFlag, githubTokenFlag, transportFlag, httpAddrFlag, tlsCertFlag, tlsKeyFlag,
+	tlsWatchFlag, httpAuthTokenFlag, httpAuthTokenFileFlag, indexPathFlag, indexOrganizationFlag,
+	indexSyncIntervalFlag, logLevelFlag, logFormatFlag, logFileFlag, debugToolsFlag, demoFlag, localOnlyFlag,
+	daemonizeFlag, pidFileFlag,
+}
+
 func main() {
 	app := &cli.App{
 		Name:        "terramate-mcp-server",
 		Usage:       "Terramate MCP Server",
 		Description: "Terramate MCP server to manage Terramate Cloud and CLI with natural language",
-		Flags:       []cli.Flag{apiKeyFlag, credentialFileFlag, regionFlag, baseURLFlag},
+		Version:     version.Version,
+		Flags:       appFlags,
+		Action:      run,
+		Commands:    append(append(daemonCommands, loginCommand, logoutCommand, checkCommand, configCommand, versionCommand), serviceCommands...),
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		slog.Error("Failed to run application", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run starts the server and serves until SIGINT/SIGTERM, or until the service
+// control manager stops it when run as a Windows service. SIGHUP reloads the
+// configuration without dropping connected clients.
+func run(c *cli.Context) error {
+	config, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+	if c.Bool(daemonizeFlag.Name) && !daemonized() {
+		return startDaemon(c, config)
+	}
+	closeLog, err := setupLogging(config)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
+	server, err := newServer(config)
+	if err != nil {
+		return fmt.Errorf("failed to create MCP server: %w", err)
+	}
+	if err := server.validateAPIKey(c.Context); err != nil {
+		return err
+	}
+	if daemonized() || c.IsSet(pidFileFlag.Name) {
+		removePIDFile, err := writePIDFile(c.String(pidFileFlag.Name))
+		if err != nil {
+			return err
+		}
+		defer removePIDFile()
+	}
+	if runningAsService() {
+		return serveService(func(ctx context.Context, notify func(serviceState)) error {
+			return serve(ctx, c, server, notify)
+		})
+	}
+	return serve(context.Background(), c, server, notifySystemd)
+}
+
+// serve runs server until SIGINT or SIGTERM or until ctx is canceled,
+// reloading the configuration on SIGHUP and reopening the log file on the log
+// rotation signals, and then shuts it down. notify receives the states to
+// report to the service manager.
+//
+// Shutting down drains the server rather than dropping clients: new tool
+// calls are rejected with a retry hint, calls in flight may finish within the
+// shutdown timeout and are then canceled, getting shutdownAbortGrace to
+// return their results, and only then are the transports closed. Signals
+// received meanwhile are ignored, so a service manager's stop timeout (e.g.
+// TimeoutStopSec= of systemd) should exceed the shutdown timeout plus
+// shutdownAbortGrace. serve returns nil after a shutdown on request, so the
+// process exits with status 0, and the server's error if it failed.
+func serve(ctx context.Context, c *cli.Context, server *Server, notify func(serviceState)) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go pingWatchdog(ctx)
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	rotate := make(chan os.Signal, 1)
+	if len(logRotationSignals) > 0 {
+		signal.Notify(rotate, logRotationSignals...)
+		defer signal.Stop(rotate)
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		if err := server.start(ctx, func() { notify(stateReady) }); err != nil {
+			errChan <- err
+		}
+	}()
+
+	var serverErr error
+wait:
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Received signal, shutting down")
+			break wait
+		case serverErr = <-errChan:
+			slog.Error("Server error, shutting down", "error", serverErr)
+			stop()
+			break wait
+		case <-hangup:
+			slog.Info("Received SIGHUP, reloading configuration")
+			notify(stateReloading)
+			reloadServer(ctx, server, c.App.Flags, os.Args)
+			notify(stateReady)
+		case <-rotate:
+			reopenLogFile()
+		}
+	}
+
+	notify(stateStopping)
+	// Use context.Background() for shutdown timeout to ensure it's not already canceled
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), server.shutdownTimeout())
+	defer shutdownCancel()
+
+	server.stop(shutdownCtx)
+
+	slog.Info("Terramate MCP server shut down")
+
+	return serverErr
+}
+
+// loadConfig reads the config file, applies it to the flags not given on the
+// command line or environment, and assembles the server config.
+func loadConfig(c *cli.Context) (*Config, error) {
+	fileCfg, err := loadFileConfig(c.String(configFlag.Name), c.IsSet(configFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	profile, err := fileCfg.selectProfile(c.String(profileFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file: %w", err)
+	}
+	if err := applyFileConfig(c, fileCfg); err != nil {
+		return nil, fmt.Errorf("invalid config file: %w", err)
+	}
+	config, err := buildConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	config.Profile = profile
+	if config.OrganizationProfiles, err = fileCfg.organizationProfiles(); err != nil {
+		return nil, fmt.Errorf("invalid config file: %w", err)
+	}
+	if config.AllowCredentialOverride || config.OrganizationProfiles != nil {
+		if config.CredentialProfiles, err = fileCfg.credentialProfiles(); err != nil {
+			return nil, fmt.Errorf("invalid config file: %w", err)
+		}
+	}
+	if !fileCfg.Guardrails.Empty() {
+		if err := fileCfg.Guardrails.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid config file: guardrails: %w", err)
+		}
+		config.Guardrails = &fileCfg.Guardrails
+	}
+	return config, nil
+}
+
+// reloadConfig re-reads the configuration the same way it was read at
+// startup: args and environment variables first, then the config file.
+func reloadConfig(flags []cli.Flag, args []string) (*Config, error) {
+	var config *Config
+	app := &cli.App{
+		Flags:     flags,
+		Writer:    io.Discard, // stdout carries the MCP protocol in stdio mode
+		ErrWriter: io.Discard,
 		Action: func(c *cli.Context) error {
-			apiKey := c.String(apiKeyFlag.Name)
-			credentialFile := c.String(credentialFileFlag.Name)
-			region := c.String(regionFlag.Name)
-			baseURL := c.String(baseURLFlag.Name)
-
-			// Only validate region if provided and using default base URL
-			if baseURL == "https://api.terramate.io" && region != "" && region != "eu" && region != "us" {
-				return fmt.Errorf("invalid region: %s (must be 'eu' or 'us')", region)
-			}
-
-			config := &Config{
-				APIKey:         apiKey,
-				CredentialFile: credentialFile,
-				Region:         region,
-				BaseURL:        baseURL,
-			}
-
-			server, err := newServer(config)
-			if err != nil {
-				return fmt.Errorf("failed to create MCP server: %w", err)
-			}
-
-			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
-			defer stop()
-
-			errChan := make(chan error, 1)
-			go func() {
-				if err := server.start(ctx); err != nil {
-					errChan <- err
-				}
-			}()
-
-			var serverErr error
-			select {
-			case <-ctx.Done():
-				log.Println("Received signal, shutting down...")
-			case serverErr = <-errChan:
-				log.Println("Server error, shutting down...")
-				stop()
-			}
-
-			// Use context.Background() for shutdown timeout to ensure it's not already canceled
-			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer shutdownCancel()
-
-			server.stop(shutdownCtx)
-
-			log.Println("Terramate MCP server shut down")
-
-			return serverErr
+			var err error
+			config, err = loadConfig(c)
+			return err
 		},
 	}
+	if err := app.Run(args); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// reloadServer applies a reloaded configuration, keeping the current one if
+// it is invalid.
+func reloadServer(ctx context.Context, server *Server, flags []cli.Flag, args []string) {
+	config, err := reloadConfig(flags, args)
+	if err == nil {
+		err = server.reload(ctx, config)
+	}
+	if err != nil {
+		slog.Error("Failed to reload configuration, keeping the current one", "error", err)
+		return
+	}
+	// The reloaded credential may have a different role
+	go server.probeServices(ctx)
+}
 
-	if err := app.Run(os.Args); err != nil {
-		log.Fatalf("Failed to run application: %v", err)
+// buildConfig validates the flag values and assembles the server config.
+func buildConfig(c *cli.Context) (*Config, error) {
+	transport := c.String(transportFlag.Name)
+	if transport != transportStdio && transport != transportHTTP {
+		return nil, fmt.Errorf("invalid transport: %s (must be '%s' or '%s')", transport, transportStdio, transportHTTP)
+	}
+
+	toolsets := c.StringSlice(toolsetsFlag.Name)
+	if err := tools.ValidateToolsets(toolsets); err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", toolsetsFlag.Name, err)
+	}
+
+	config := &Config{
+		APIKey:                  c.String(apiKeyFlag.Name),
+		JWT:                     c.String(jwtFlag.Name),
+		RefreshToken:            c.String(refreshTokenFlag.Name),
+		GitHubOIDC:              terramate.GitHubOIDCAvailable(),
+		OIDCAudience:            c.String(oidcAudienceFlag.Name),
+		WorkloadIdentity:        c.String(workloadIdentityFlag.Name),
+		CredentialFile:          c.String(credentialFileFlag.Name),
+		CredentialStore:         c.String(credentialStoreFlag.Name),
+		ReadOnlyCredentialFile:  c.Bool(readOnlyCredentialFileFlag.Name),
+		AllowCredentialOverride: c.Bool(allowCredentialOverrideFlag.Name),
+		TokenRefreshEndpoint:    c.String(tokenRefreshEndpointFlag.Name),
+		TokenRefreshClientID:    c.String(tokenRefreshClientIDFlag.Name),
+		TokenRevocationEndpoint: c.String(tokenRevocationEndpointFlag.Name),
+		LoginClientID:           c.String(loginClientIDFlag.Name),
+		Proxy:                   c.String(proxyFlag.Name),
+		GitHubToken:             c.String(githubTokenFlag.Name),
+		DefaultOrganization:     c.String(defaultOrganizationFlag.Name),
+		ActAs:                   c.String(actAsFlag.Name),
+		Toolsets:                toolsets,
+		Transport:               transport,
+		HTTPAddr:                c.String(httpAddrFlag.Name),
+		LogFile:                 c.String(logFileFlag.Name),
+		DebugTools:              c.Bool(debugToolsFlag.Name),
+		Demo:                    c.Bool(demoFlag.Name),
+		LocalOnly:               c.Bool(localOnlyFlag.Name),
+	}
+	if config.Demo && config.DefaultOrganization == "" {
+		config.DefaultOrganization = demo.OrganizationUUID
+	}
+	if err := validateCredential(config); err != nil {
+		return nil, err
+	}
+	if err := buildRegion(c, config); err != nil {
+		return nil, err
+	}
+	if _, err := terramate.ProxyFunc(config.Proxy); err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", proxyFlag.Name, err)
+	}
+	if err := buildIndex(c, config); err != nil {
+		return nil, err
+	}
+	if err := buildLimits(c, config); err != nil {
+		return nil, err
+	}
+	if err := buildTLS(c, config); err != nil {
+		return nil, err
+	}
+	if err := buildHTTPAuth(c, config); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if config.LogLevel, err = parseLogLevel(c.String(logLevelFlag.Name)); err != nil {
+		return nil, err
+	}
+	if config.LogFormat, err = parseLogFormat(c.String(logFormatFlag.Name)); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// validateCredential validates the --credential-store and
+// --workload-identity values and that a --refresh-token comes with the --jwt
+// it renews.
+func validateCredential(config *Config) error {
+	if store := config.CredentialStore; store != credentialStoreFile && store != credentialStoreKeychain {
+		return fmt.Errorf("invalid --%s: %s (must be '%s' or '%s')", credentialStoreFlag.Name, store, credentialStoreFile, credentialStoreKeychain)
+	}
+	if id := config.WorkloadIdentity; id != "" && id != workloadIdentityAWS && id != workloadIdentityGCP {
+		return fmt.Errorf("invalid --%s: %s (must be '%s' or '%s')", workloadIdentityFlag.Name, id, workloadIdentityAWS, workloadIdentityGCP)
+	}
+	if config.RefreshToken != "" && config.JWT == "" {
+		return fmt.Errorf("--%s requires --%s", refreshTokenFlag.Name, jwtFlag.Name)
+	}
+	if config.TokenRefreshClientID != "" && config.TokenRefreshEndpoint == "" {
+		return fmt.Errorf("--%s requires --%s", tokenRefreshClientIDFlag.Name, tokenRefreshEndpointFlag.Name)
+	}
+	if config.LocalOnly && (config.APIKey != "" || config.JWT != "" || config.WorkloadIdentity != "" || config.Demo) {
+		return fmt.Errorf("--%s loads no credential: remove --%s, --%s, --%s and --%s", localOnlyFlag.Name,
+			apiKeyFlag.Name, jwtFlag.Name, workloadIdentityFlag.Name, demoFlag.Name)
+	}
+	return nil
+}
+
+// buildRegion validates the region flags into config. The region is detected
+// from the credential when neither --region nor --base-url is set.
+func buildRegion(c *cli.Context, config *Config) error {
+	config.Region, config.BaseURL = c.String(regionFlag.Name), c.String(baseURLFlag.Name)
+	// Only validate region if provided and using default base URL
+	if config.BaseURL == "https://api.terramate.io" && config.Region != "" && config.Region != "eu" && config.Region != "us" {
+		return fmt.Errorf("invalid region: %s (must be 'eu' or 'us')", config.Region)
+	}
+	config.DetectRegion = config.Region == "" && !c.IsSet(baseURLFlag.Name)
+	return nil
+}
+
+// buildIndex validates the stack index flags into config.
+func buildIndex(c *cli.Context, config *Config) error {
+	config.IndexPath = c.String(indexPathFlag.Name)
+	config.IndexOrganization = c.String(indexOrganizationFlag.Name)
+	if config.IndexOrganization == "" {
+		config.IndexOrganization = config.DefaultOrganization
+	}
+	if config.IndexPath != "" && config.IndexOrganization == "" {
+		return fmt.Errorf("--%s requires --%s or --%s", indexPathFlag.Name, indexOrganizationFlag.Name, defaultOrganizationFlag.Name)
+	}
+	if config.IndexSyncInterval = c.Duration(indexSyncIntervalFlag.Name); config.IndexSyncInterval <= 0 {
+		return fmt.Errorf("invalid --%s: must be positive", indexSyncIntervalFlag.Name)
+	}
+	return nil
+}
+
+// buildLimits validates the concurrency and timeout flags into config.
+func buildLimits(c *cli.Context, config *Config) error {
+	if config.ShutdownTimeout = c.Duration(shutdownTimeoutFlag.Name); config.ShutdownTimeout <= 0 {
+		return fmt.Errorf("invalid --%s: must be positive", shutdownTimeoutFlag.Name)
+	}
+
+	var err error
+	if config.MaxConcurrentAPICalls, err = nonNegativeInt(c, maxConcurrentAPICallsFlag); err != nil {
+		return err
+	}
+	if config.MaxConcurrentTools, err = nonNegativeInt(c, maxConcurrentToolsFlag); err != nil {
+		return err
+	}
+	if config.MaxResponseSize, err = nonNegativeInt(c, maxResponseSizeFlag); err != nil {
+		return err
+	}
+	if config.DefaultToolConcurrency = c.Int(defaultToolConcurrencyFlag.Name); config.DefaultToolConcurrency <= 0 {
+		return fmt.Errorf("invalid --%s: must be positive", defaultToolConcurrencyFlag.Name)
+	}
+	if config.ToolConcurrency, err = parseToolLimits(c.StringSlice(toolConcurrencyFlag.Name)); err != nil {
+		return fmt.Errorf("invalid --%s: %w", toolConcurrencyFlag.Name, err)
+	}
+	if config.ToolTimeouts, err = parseToolTimeouts(c.StringSlice(toolTimeoutFlag.Name)); err != nil {
+		return fmt.Errorf("invalid --%s: %w", toolTimeoutFlag.Name, err)
+	}
+	return nil
+}
+
+// buildTLS validates the TLS settings of the http transport.
+func buildTLS(c *cli.Context, config *Config) error {
+	config.TLSCertFile, config.TLSKeyFile = c.String(tlsCertFlag.Name), c.String(tlsKeyFlag.Name)
+	config.TLSWatch = c.Bool(tlsWatchFlag.Name)
+	if config.TLSCertFile == "" && config.TLSKeyFile == "" {
+		return nil
+	}
+	if config.TLSCertFile == "" || config.TLSKeyFile == "" {
+		return fmt.Errorf("--%s and --%s must be set together", tlsCertFlag.Name, tlsKeyFlag.Name)
+	}
+	if config.Transport != transportHTTP {
+		return fmt.Errorf("--%s requires --%s %s", tlsCertFlag.Name, transportFlag.Name, transportHTTP)
+	}
+	return nil
+}
+
+// buildHTTPAuth collects the bearer tokens accepted by the http transport.
+func buildHTTPAuth(c *cli.Context, config *Config) error {
+	if token := strings.TrimSpace(c.String(httpAuthTokenFlag.Name)); token != "" {
+		config.HTTPAuthTokens = append(config.HTTPAuthTokens, token)
+	}
+	if path := c.String(httpAuthTokenFileFlag.Name); path != "" {
+		tokens, err := readTokenFile(path)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", httpAuthTokenFileFlag.Name, err)
+		}
+		config.HTTPAuthTokens = append(config.HTTPAuthTokens, tokens...)
+	}
+	if len(config.HTTPAuthTokens) > 0 && config.Transport != transportHTTP {
+		return fmt.Errorf("--%s and --%s require --%s %s", httpAuthTokenFlag.Name, httpAuthTokenFileFlag.Name, transportFlag.Name, transportHTTP)
+	}
+	return nil
+}
+
+// nonNegativeInt returns the value of flag, which must not be negative.
+func nonNegativeInt(c *cli.Context, flag *cli.IntFlag) (int, error) {
+	n := c.Int(flag.Name)
+	if n < 0 {
+		return 0, fmt.Errorf("invalid --%s: must not be negative", flag.Name)
+	}
+	return n, nil
+}
+
+// parseToolLimits parses "tool=n" entries into a map of positive limits.
+func parseToolLimits(entries []string) (map[string]int, error) {
+	limits := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%q must be in the form tool=n", entry)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("%q: limit must be a positive integer", entry)
+		}
+		limits[name] = n
+	}
+	return limits, nil
+}
+
+// parseToolTimeouts parses "tool=duration" entries into a map of positive
+// timeouts.
+func parseToolTimeouts(entries []string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%q must be in the form tool=duration", entry)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%q: timeout must be a positive duration, e.g. 30s or 2m", entry)
+		}
+		timeouts[name] = d
 	}
+	return timeouts, nil
 }
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseToolLimits(t *testing.T) {
+	limits, err := parseToolLimits([]string{"tmc_list_repositories=2", " tmc_list_stacks = 5 "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limits["tmc_list_repositories"] != 2 || limits["tmc_list_stacks"] != 5 {
+		t.Fatalf("unexpected limits: %v", limits)
+	}
+}
+
+func TestParseToolLimits_Invalid(t *testing.T) {
+	for _, entry := range []string{"tmc_list_stacks", "=3", "tmc_list_stacks=0", "tmc_list_stacks=abc"} {
+		if _, err := parseToolLimits([]string{entry}); err == nil {
+			t.Fatalf("expected error for %q", entry)
+		}
+	}
+}
+
+func TestParseToolTimeouts(t *testing.T) {
+	timeouts, err := parseToolTimeouts([]string{"tmc_get_deployment_logs=2m", " tmc_list_stacks = 10s "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeouts["tmc_get_deployment_logs"] != 2*time.Minute || timeouts["tmc_list_stacks"] != 10*time.Second {
+		t.Fatalf("unexpected timeouts: %v", timeouts)
+	}
+
+	for _, entry := range []string{"tmc_list_stacks", "=10s", "tmc_list_stacks=0s", "tmc_list_stacks=10"} {
+		if _, err := parseToolTimeouts([]string{entry}); err == nil {
+			t.Fatalf("expected error for %q", entry)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+)
+
+// metricsEndpointPath is the path serving metrics on the http transport.
+const metricsEndpointPath = "/metrics"
+
+// freshnessMetrics keeps the newest data observed per organization by
+// tmc_data_freshness and exports its age in the Prometheus text format. The
+// age is computed at scrape time, so it keeps growing while no newer data is
+// observed and alerts fire even if the tool is not called again.
+type freshnessMetrics struct {
+	mu    sync.Mutex
+	byOrg map[string]tmc.Freshness
+	now   func() time.Time
+}
+
+func newFreshnessMetrics() *freshnessMetrics {
+	return &freshnessMetrics{byOrg: map[string]tmc.Freshness{}, now: time.Now}
+}
+
+// RecordFreshness implements tmc.FreshnessRecorder.
+func (m *freshnessMetrics) RecordFreshness(f tmc.Freshness) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.byOrg[f.OrganizationUUID] = f
+}
+
+// ServeHTTP writes the metrics.
+func (m *freshnessMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	m.mu.Lock()
+	orgs := make([]string, 0, len(m.byOrg))
+	for org := range m.byOrg {
+		orgs = append(orgs, org)
+	}
+	sort.Strings(orgs)
+
+	var b strings.Builder
+	b.WriteString("# HELP terramate_mcp_data_age_seconds Age of the newest data seen in Terramate Cloud, by organization and kind.\n")
+	b.WriteString("# TYPE terramate_mcp_data_age_seconds gauge\n")
+	now := m.now()
+	for _, org := range orgs {
+		f := m.byOrg[org]
+		for _, kind := range []struct {
+			name   string
+			newest *time.Time
+		}{
+			{"deployment", f.NewestDeploymentAt},
+			{"drift", f.NewestDriftAt},
+		} {
+			if kind.newest == nil {
+				continue
+			}
+			age := strconv.FormatFloat(now.Sub(*kind.newest).Seconds(), 'f', 0, 64)
+			fmt.Fprintf(&b, "terramate_mcp_data_age_seconds{organization=%q,kind=%q} %s\n", org, kind.name, age)
+		}
+	}
+	m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
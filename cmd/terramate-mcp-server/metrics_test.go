@@ -0,0 +1,41 @@
+package main
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+)
+
+func TestFreshnessMetrics(t *testing.T) {
+	now := time.Date(2026, 1, 8, 12, 0, 0, 0, time.UTC)
+	deployedAt := now.Add(-time.Hour)
+
+	m := newFreshnessMetrics()
+	m.now = func() time.Time { return now }
+	m.RecordFreshness(tmc.Freshness{OrganizationUUID: "org-b", NewestDeploymentAt: &deployedAt})
+	m.RecordFreshness(tmc.Freshness{OrganizationUUID: "org-a", NewestDeploymentAt: &deployedAt, NewestDriftAt: &deployedAt})
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", metricsEndpointPath, nil))
+
+	want := []string{
+		`terramate_mcp_data_age_seconds{organization="org-a",kind="deployment"} 3600`,
+		`terramate_mcp_data_age_seconds{organization="org-a",kind="drift"} 3600`,
+		`terramate_mcp_data_age_seconds{organization="org-b",kind="deployment"} 3600`,
+	}
+	body := rec.Body.String()
+	if got := strings.Split(strings.TrimSpace(body), "\n")[2:]; strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected metrics:\n%s", body)
+	}
+
+	// The age grows while no newer data is recorded
+	m.now = func() time.Time { return now.Add(time.Hour) }
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", metricsEndpointPath, nil))
+	if !strings.Contains(rec.Body.String(), `{organization="org-b",kind="deployment"} 7200`) {
+		t.Errorf("expected the age to grow, got:\n%s", rec.Body.String())
+	}
+}
@@ -2,30 +2,211 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/terramate-io/terramate-mcp-server/internal/version"
 	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
 	"github.com/terramate-io/terramate-mcp-server/tools"
+	"github.com/terramate-io/terramate-mcp-server/tools/guardrail"
+	"github.com/terramate-io/terramate-mcp-server/tools/index"
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+	"github.com/terramate-io/terramate-mcp-server/tools/vcs"
 )
 
+// Supported transports.
+const (
+	transportStdio = "stdio"
+	transportHTTP  = "http"
+)
+
+// httpEndpointPath is the path serving the streamable HTTP transport.
+const httpEndpointPath = "/mcp"
+
+// defaultShutdownTimeout is used when the config leaves the shutdown timeout unset.
+const defaultShutdownTimeout = 30 * time.Second
+
+// defaultIndexSyncInterval is used when the config leaves the sync interval unset.
+const defaultIndexSyncInterval = 5 * time.Minute
+
+// regionDetectionTimeout bounds how long probing the regions may delay startup.
+const regionDetectionTimeout = 15 * time.Second
+
+// apiKeyValidationTimeout bounds how long validating an API key may delay
+// startup.
+const apiKeyValidationTimeout = 15 * time.Second
+
 // Server implements the MCP server to extend its functionality
 type Server struct {
-	mcp          *server.MCPServer
+	mcp      *server.MCPServer
+	sessions *tmc.SessionStore // Per-client state (default org, caches)
+	index    *index.Index      // Local index (nil when disabled)
+	calls    *callTracker      // In-flight tool calls, drained on shutdown
+	metrics  *freshnessMetrics // Data freshness observed by tmc_data_freshness
+	tls      *certReloader     // TLS certificate of the HTTP transport (nil without TLS)
+	callLog  *tmc.CallLog      // Recent tool calls for the debug tools (nil when disabled)
+
+	// mu guards the fields replaced when the configuration is reloaded
+	mu           sync.RWMutex
 	toolHandlers *tools.ToolHandlers
+	client       *terramate.Client
 	config       *Config
 	jwtCred      *terramate.JWTCredential // Store JWT credential for cleanup
+	// credentialSource is the kind of source of the credential (see
+	// credentialChain), "" without one
+	credentialSource string
+	// credentialOverrides resolves the credentials of single calls, nil
+	// without --allow-credential-override
+	credentialOverrides *credentialOverrides
+	callSlots           server.ToolHandlerMiddleware // Queues calls beyond MaxConcurrentTools
+
+	// Set by start, closed by stop once in-flight calls are drained
+	httpServer    *http.Server
+	stopListening context.CancelFunc
+	// serveCtx is the context passed to start; work started later, e.g. when
+	// connecting to Terramate Cloud, runs until it is canceled
+	serveCtx context.Context
+
+	connectMu sync.Mutex // serializes connect
 }
 
 // Config holds server configuration values required to initialize dependencies.
 type Config struct {
-	APIKey         string
+	// Profile is the config file profile in use (optional).
+	Profile string
+
+	APIKey string
+	// JWT is a raw ID token injected through the environment, used instead of
+	// the credential store; RefreshToken optionally renews it in memory.
+	JWT            string
+	RefreshToken   string
 	CredentialFile string
-	Region         string
-	BaseURL        string
+	// GitHubOIDC selects the OIDC tokens of the GitHub Actions job running
+	// the server, requested for OIDCAudience.
+	GitHubOIDC   bool
+	OIDCAudience string
+	// WorkloadIdentity is workloadIdentityAWS or workloadIdentityGCP to
+	// authenticate with the OIDC tokens of the cloud workload, also requested
+	// for OIDCAudience.
+	WorkloadIdentity string
+	// CredentialStore is credentialStoreFile or credentialStoreKeychain.
+	CredentialStore string
+	// ReadOnlyCredentialFile keeps refreshed JWTs in memory instead of
+	// writing them to the credential file, which is then never moved into
+	// the keychain either.
+	ReadOnlyCredentialFile bool
+	Region                 string
+	BaseURL                string
+	// DetectRegion selects the region accepting the credential, from the
+	// region hint of the credential file or by probing each region.
+	DetectRegion bool
+
+	// AllowCredentialOverride accepts the api_key and credential_profile tool
+	// arguments; credential_profile selects one of CredentialProfiles.
+	AllowCredentialOverride bool
+	CredentialProfiles      map[string]credentialProfile
+	// OrganizationProfiles maps organization UUIDs to the profile of
+	// CredentialProfiles authenticating their tool calls.
+	OrganizationProfiles map[string]string
+
+	// TokenRefreshEndpoint overrides the Firebase Auth token endpoint used to
+	// refresh JWTs (e.g. for sovereign cloud deployments).
+	TokenRefreshEndpoint string
+	// TokenRefreshClientID makes TokenRefreshEndpoint a standard OAuth 2.0
+	// token endpoint, refreshing JWTs as this client (e.g. for self-hosted
+	// Terramate Cloud instances).
+	TokenRefreshClientID string
+	// TokenRevocationEndpoint revokes the refresh token on logout (optional;
+	// empty only removes the credential).
+	TokenRevocationEndpoint string
+	// LoginClientID is the client ID of the GitHub OAuth app used by the
+	// login command and tmc_login (optional; empty disables tmc_login).
+	LoginClientID string
+
+	// Proxy routes outbound requests through this proxy URL instead of the
+	// one from HTTPS_PROXY (optional).
+	Proxy string
+
+	// MaxConcurrentAPICalls bounds in-flight API requests across all tools (0 = unlimited).
+	MaxConcurrentAPICalls int
+	// MaxConcurrentTools bounds the tool calls executed at the same time; excess calls queue (0 = unlimited).
+	MaxConcurrentTools int
+	// MaxResponseSize bounds the size of an API response in MiB (0 = the SDK default).
+	MaxResponseSize int
+	// DefaultToolConcurrency caps the parallel API calls of a single invocation
+	// of tools without a ToolConcurrency entry.
+	DefaultToolConcurrency int
+	// ToolConcurrency caps the parallel API calls of a single invocation, keyed by tool name.
+	ToolConcurrency map[string]int
+	// ToolTimeouts bounds the duration of a single invocation, keyed by tool name.
+	ToolTimeouts map[string]time.Duration
+
+	// GitHubToken enables posting drift issues to GitHub (optional).
+	GitHubToken string
+
+	// DefaultOrganization is the organization new client sessions start with (optional).
+	DefaultOrganization string
+	// ActAs is the member or service account API requests act as (optional).
+	ActAs string
+	// Toolsets restricts the registered tools (empty = all toolsets).
+	Toolsets []string
+
+	// Transport is "stdio" (default) or "http".
+	Transport string
+	// HTTPAddr is the listen address of the HTTP transport.
+	HTTPAddr string
+	// TLSCertFile and TLSKeyFile serve the HTTP transport over HTTPS (optional).
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSWatch reloads the TLS certificate when its files change.
+	TLSWatch bool
+	// HTTPAuthTokens are the bearer tokens accepted by the HTTP transport
+	// (empty = no authentication).
+	HTTPAuthTokens []string
+
+	// IndexPath enables the local index stored at this path (optional).
+	IndexPath string
+	// IndexOrganization is the organization synced into the local index.
+	IndexOrganization string
+	// IndexSyncInterval is how often the local index is synced.
+	IndexSyncInterval time.Duration
+
+	// ShutdownTimeout is how long in-flight tool calls may run on shutdown
+	// before they are canceled.
+	ShutdownTimeout time.Duration
+
+	// LogLevel is the minimum level of server logs.
+	LogLevel slog.Level
+	// LogFormat is "text" (default) or "json".
+	LogFormat string
+	// LogFile receives server logs instead of stderr (optional).
+	LogFile string
+
+	// Guardrails enables tmc_advise_apply with this apply policy (optional).
+	Guardrails *guardrail.Policy
+
+	// DebugTools records recent tool calls and serves tmc_replay_last and
+	// tmc_inspect_call.
+	DebugTools bool
+
+	// Demo serves the built-in demo organization instead of Terramate Cloud;
+	// the credential settings are ignored.
+	Demo bool
+
+	// LocalOnly serves only the index, debug and server info tools, without
+	// loading a credential.
+	LocalOnly bool
 }
 
 // newServer creates a new server instance
@@ -34,121 +215,763 @@ func newServer(config *Config) (*Server, error) {
 		return nil, fmt.Errorf("config is required")
 	}
 
-	// Load credential (precedence: API Key > JWT from file)
-	var credential terramate.Credential
-	var err error
-
-	// Check API key first (backward compatibility)
-	if config.APIKey != "" {
-		credential = terramate.NewAPIKeyCredential(config.APIKey)
-	} else {
-		// Load JWT from credential file
-		credPath := config.CredentialFile
-		if credPath == "" {
-			// Use default path
-			credPath, err = terramate.GetDefaultCredentialPath()
-			if err != nil {
-				return nil, fmt.Errorf("failed to determine default credential path: %w", err)
-			}
+	var certs *certReloader
+	if config.TLSCertFile != "" {
+		var err error
+		if certs, err = newCertReloader(config.TLSCertFile, config.TLSKeyFile); err != nil {
+			return nil, err
 		}
+	}
 
-		credential, err = terramate.LoadJWTFromFile(credPath)
+	var idx *index.Index
+	if config.IndexPath != "" {
+		path, err := expandHome(config.IndexPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load credentials: %w", err)
+			return nil, err
 		}
-		log.Printf("Using JWT authentication (provider: %s)", credential.Name())
-	}
-
-	// Create Terramate Cloud API client with credential
-	var opts []terramate.ClientOption
-	if config.BaseURL == "" || config.BaseURL == "https://api.terramate.io" {
-		opts = append(opts, terramate.WithRegion(config.Region))
-	} else {
-		opts = append(opts, terramate.WithBaseURL(config.BaseURL))
+		if idx, err = index.Open(path); err != nil {
+			return nil, err
+		}
+		slog.Info("Serving index tools", "path", path)
+	} else if config.LocalOnly {
+		slog.Warn("Local-only mode without --index-path serves no Terramate data; sync an index with a credential first")
 	}
 
-	tmcClient, err := terramate.NewClient(credential, opts...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Terramate client: %w", err)
+	var callLog *tmc.CallLog
+	if config.DebugTools {
+		callLog = tmc.NewCallLog(tmc.DefaultCallLogSize)
 	}
 
-	// Create tool handlers
-	toolHandlers := tools.New(tmcClient)
-
 	// Create server
 	s := &Server{
-		toolHandlers: toolHandlers,
-		config:       config,
+		config:    config,
+		callSlots: tools.MaxConcurrentCalls(config.MaxConcurrentTools),
+		sessions:  tmc.NewSessionStore(tmc.DefaultSessionIdleTimeout),
+		index:     idx,
+		calls:     newCallTracker(),
+		metrics:   newFreshnessMetrics(),
+		tls:       certs,
+		callLog:   callLog,
 	}
-
-	// Store JWT credential if we're using it
-	if jwtCred, ok := credential.(*terramate.JWTCredential); ok {
-		s.jwtCred = jwtCred
+	b, err := newBackend(config, idx, s.metrics, callLog, s.authHooks())
+	if err != nil {
+		if idx != nil {
+			_ = idx.Close()
+		}
+		return nil, err
 	}
+	s.toolHandlers, s.client, s.jwtCred = b.toolHandlers, b.client, b.jwtCred
+	s.credentialSource, s.credentialOverrides = b.credentialSource, b.credentialOverrides
+	s.sessions.SetDefaultOrganization(config.DefaultOrganization)
 
 	// Create MCP server
-	s.mcp = server.NewMCPServer(
-		"terramate-mcp-server",
-		version.Version,
-		server.WithToolCapabilities(false),
+	serverOpts := []server.ServerOption{
+		server.WithToolCapabilities(true),
 		server.WithLogging(),
+		server.WithToolHandlerMiddleware(s.calls.track),
+		server.WithToolHandlerMiddleware(tools.RedactErrors()),
+		server.WithToolHandlerMiddleware(tools.Reauthentication(s.sessionExpired, s.relogin)),
+		server.WithToolHandlerMiddleware(s.limitCalls),
+		server.WithToolHandlerMiddleware(s.limitConcurrency),
+		server.WithToolHandlerMiddleware(s.limitDuration),
+		server.WithToolHandlerMiddleware(tools.Sessions(s.sessions)),
+		server.WithToolHandlerMiddleware(tools.CorrelationIDs()),
+		server.WithToolHandlerMiddleware(s.overrideCredentials),
+		server.WithToolHandlerMiddleware(s.selectOrganizationCredentials),
+		server.WithToolFilter(s.filterTools),
 		// server.WithInstructions(instructions.Get()),
-	)
+	}
+	if callLog != nil {
+		// Innermost, so the recorded arguments include the session defaults
+		serverOpts = append(serverOpts, server.WithToolHandlerMiddleware(tools.RecordCalls(callLog)))
+		slog.Info("Serving debug tools", "recorded_calls", callLog.Size())
+	}
+	s.mcp = server.NewMCPServer("terramate-mcp-server", version.Version, serverOpts...)
 
 	// Register MCP tools using AddTools
-	s.mcp.AddTools(toolHandlers.Tools()...)
-	for _, tool := range toolHandlers.Tools() {
-		log.Printf("Registered MCP tool: %s", tool.Tool.Name)
+	s.mcp.AddTools(b.toolHandlers.Tools()...)
+	for _, tool := range b.toolHandlers.Tools() {
+		slog.Debug("Registered MCP tool", "tool", tool.Tool.Name)
 	}
 
 	return s, nil
 }
 
-// start starts the server with the given configuration
-func (s *Server) start(ctx context.Context) error {
-	log.Printf("Starting Terramate MCP server in stdio mode")
+// backend bundles the dependencies derived from the configuration. It is
+// rebuilt from scratch when the configuration is reloaded.
+type backend struct {
+	client       *terramate.Client        // nil until a credential is configured
+	jwtCred      *terramate.JWTCredential // nil when using an API key
+	toolHandlers *tools.ToolHandlers
+	// credentialSource is the kind of source of the credential
+	credentialSource    string
+	credentialOverrides *credentialOverrides // nil without --allow-credential-override
+}
+
+// authHooks are the Server callbacks of the tools changing the login. Nil
+// hooks disable their tool, e.g. in the check command.
+type authHooks struct {
+	connect func(context.Context) error // tmc_authenticate without a credential
+	login   tmc.LoginFunc               // tmc_login
+	logout  func(context.Context) error // tmc_logout
+}
+
+// newBackend loads the credential and creates the API client and tool
+// handlers. idx enables the index tools when non-nil; metrics receives the
+// data freshness observed by the tools; callLog, when non-nil, enables the
+// debug tools and receives the API requests of recorded calls.
+//
+// With --local-only, no credential is loaded and the backend serves only the
+// index tools, the debug tools and tmc_server_info; there are no tools
+// wrapping the local Terramate CLI or repository. Without a credential, the backend is the
+// offline backend of newOfflineBackend. The login hook serves tmc_login if a login client ID is
+// configured and no API key is used; the logout hook serves tmc_logout with a
+// JWT credential.
+func newBackend(config *Config, idx *index.Index, metrics *freshnessMetrics, callLog *tmc.CallLog, hooks authHooks) (*backend, error) {
+	httpClient, toolOpts, err := backendToolOptions(config, idx, metrics, callLog, hooks)
+	if err != nil {
+		return nil, err
+	}
+	if config.LocalOnly {
+		slog.Info("Local-only mode: serving only the index, debug and server info tools, without Terramate Cloud")
+		toolOpts = append(toolOpts, tools.WithServerInfo(serverInfo(config, nil, nil)))
+		return &backend{toolHandlers: tools.New(nil, toolOpts...)}, nil
+	}
+	credential, source, err := loadCredential(config, httpClient)
+	if errors.Is(err, errNoCredential) {
+		slog.Warn("Serving only the local tools until a Terramate Cloud credential is configured", "reason", err)
+		return offlineBackend(config, toolOpts, hooks, err), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Only a stored credential can be logged out; a JWT from the environment
+	// would be back on the next reload
+	if stored := source.kind == sourceCredentialFile || source.kind == sourceKeychain; stored && hooks.logout != nil {
+		toolOpts = append(toolOpts, tools.WithLogout(hooks.logout))
+	}
+
+	// Create Terramate Cloud API client with credential
+	tmcClient, err := terramate.NewClient(credential, clientOptions(config, credential, callLog)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Terramate client: %w", err)
+	}
+
+	b := &backend{client: tmcClient, credentialSource: source.kind}
+	if (config.AllowCredentialOverride || config.OrganizationProfiles != nil) && !config.Demo {
+		b.credentialOverrides = newCredentialOverrides(config, httpClient)
+	}
+	if config.AllowCredentialOverride && !config.Demo {
+		toolOpts = append(toolOpts, tools.WithCredentialOverrides())
+	}
+	toolOpts = append(toolOpts,
+		tools.WithServerInfo(serverInfo(config, credential, tmcClient)),
+		tools.WithCredentialStatus(credentialStatus(config, credential, source, nil)))
+	b.toolHandlers = tools.New(tmcClient, toolOpts...)
+	// Store JWT credential if we're using it
+	if jwtCred, ok := credential.(*terramate.JWTCredential); ok {
+		b.jwtCred = jwtCred
+	}
+	return b, nil
+}
+
+// clientOptions returns the options of the Terramate Cloud API client of
+// config authenticating with credential. callLog, when non-nil, receives the
+// API requests of recorded calls.
+func clientOptions(config *Config, credential terramate.Credential, callLog *tmc.CallLog) []terramate.ClientOption {
+	var instrumentation terramate.Instrumentation = logInstrumentation{}
+	if callLog != nil {
+		instrumentation = instrumentations{instrumentation, tmc.CallLogInstrumentation{}}
+	}
+	return []terramate.ClientOption{
+		terramate.WithProxy(config.Proxy),
+		terramate.WithMaxConcurrentRequests(config.MaxConcurrentAPICalls),
+		terramate.WithMaxResponseBytes(int64(config.MaxResponseSize) << 20),
+		terramate.WithInstrumentation(instrumentation),
+		// Traces requests with --log-level debug
+		terramate.WithDebugLogging(slog.Default()),
+		terramate.WithActAs(config.ActAs),
+		// Last, as the demo endpoint replaces the HTTP client
+		apiEndpoint(config, credential),
+	}
+}
+
+// newOfflineBackend returns the backend of config without a credential, e.g.
+// after a logout: it has no API client and serves the local tools and a
+// tmc_authenticate calling the connect hook to load the credential.
+func newOfflineBackend(config *Config, idx *index.Index, metrics *freshnessMetrics, callLog *tmc.CallLog, hooks authHooks) (*backend, error) {
+	_, toolOpts, err := backendToolOptions(config, idx, metrics, callLog, hooks)
+	if err != nil {
+		return nil, err
+	}
+	return offlineBackend(config, toolOpts, hooks, terramate.ErrLoggedOut), nil
+}
+
+// offlineBackend returns the backend without a credential serving toolOpts;
+// reason explains why there is no credential.
+func offlineBackend(config *Config, toolOpts []tools.Option, hooks authHooks, reason error) *backend {
+	toolOpts = append(toolOpts,
+		tools.WithConnector(hooks.connect),
+		tools.WithServerInfo(serverInfo(config, nil, nil)),
+		tools.WithCredentialStatus(credentialStatus(config, nil, chainSource{}, reason)))
+	return &backend{toolHandlers: tools.New(nil, toolOpts...)}
+}
+
+// backendToolOptions returns the proxy HTTP client of config and the tool
+// handler options shared by the backends with and without a credential.
+func backendToolOptions(config *Config, idx *index.Index, metrics *freshnessMetrics, callLog *tmc.CallLog, hooks authHooks) (*http.Client, []tools.Option, error) {
+	httpClient, err := proxyHTTPClient(config.Proxy)
+	if err != nil {
+		return nil, nil, err
+	}
+	toolOpts, err := toolOptions(config, idx, metrics, callLog, httpClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	if hooks.login != nil && config.LoginClientID != "" && !injectedCredential(config) && !config.Demo && !config.LocalOnly {
+		toolOpts = append(toolOpts, tools.WithLogin(hooks.login))
+	}
+	return httpClient, toolOpts, nil
+}
+
+// toolOptions returns the tool handler options of config. GitHub requests are
+// sent with httpClient unless it is nil.
+func toolOptions(config *Config, idx *index.Index, metrics *freshnessMetrics, callLog *tmc.CallLog, httpClient *http.Client) ([]tools.Option, error) {
+	toolOpts := []tools.Option{tools.WithToolsets(config.Toolsets), tools.WithFreshnessRecorder(metrics)}
+	if idx != nil {
+		toolOpts = append(toolOpts, tools.WithIndex(idx))
+	}
+	if config.Guardrails != nil {
+		toolOpts = append(toolOpts, tools.WithGuardrails(config.Guardrails))
+	}
+	if callLog != nil {
+		toolOpts = append(toolOpts, tools.WithCallLog(callLog))
+	}
+	if config.GitHubToken != "" {
+		var githubOpts []vcs.GitHubOption
+		if httpClient != nil {
+			githubOpts = append(githubOpts, vcs.WithGitHubHTTPClient(httpClient))
+		}
+		tracker, err := vcs.NewGitHub(config.GitHubToken, githubOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub integration: %w", err)
+		}
+		toolOpts = append(toolOpts, tools.WithIssueTracker(tracker))
+		slog.Info("GitHub integration enabled for posting issues")
+	}
+	return toolOpts, nil
+}
+
+// apiEndpoint returns the client option selecting the API of config: the
+// demo API, its custom base URL, or else the configured or detected region.
+func apiEndpoint(config *Config, credential terramate.Credential) terramate.ClientOption {
+	if config.Demo {
+		return demoEndpoint
+	}
+	if config.BaseURL != "" && config.BaseURL != "https://api.terramate.io" {
+		return terramate.WithBaseURL(config.BaseURL)
+	}
+	if config.DetectRegion {
+		return terramate.WithRegion(detectRegion(config, credential))
+	}
+	return terramate.WithRegion(config.Region)
+}
+
+// detectRegion returns the region of credential: the region hint of the
+// credential file, or else the first region accepting it. It falls back to the
+// EU region when no region accepts the credential or probing fails, e.g.
+// while offline, so the tools report the actual error.
+func detectRegion(config *Config, credential terramate.Credential) string {
+	if jwtCred, ok := credential.(*terramate.JWTCredential); ok && jwtCred.Region() != "" {
+		slog.Info("Using the region of the credential file", "region", jwtCred.Region())
+		return jwtCred.Region()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), regionDetectionTimeout)
+	defer cancel()
+	region, err := terramate.DetectRegion(ctx, credential, terramate.WithProxy(config.Proxy))
+	if err != nil {
+		slog.Warn("Failed to detect the Terramate Cloud region, using eu (set --region to skip detection)", "error", err)
+		return terramate.RegionEU
+	}
+	slog.Info("Detected Terramate Cloud region", "region", region)
+	return region
+}
+
+// proxyHTTPClient returns the HTTP client of the requests sent outside the API
+// client (token refresh, GitHub) when a proxy is configured, or nil to keep
+// their default client, which honors the proxy environment variables.
+func proxyHTTPClient(proxy string) (*http.Client, error) {
+	if proxy == "" {
+		return nil, nil
+	}
+	transport, err := terramate.NewProxyTransport(proxy)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Timeout: 30 * time.Second, Transport: transport}, nil
+}
+
+// reload swaps in a backend built from config without dropping connected
+// clients: the tool list is replaced (clients are notified that it changed),
+// cached per-session lookups are discarded and the credential file watcher is
+// restarted. On error the current configuration stays in effect.
+//
+// The transport, listen address, TLS files, index path, log format, log file
+// and debug tools cannot change while serving; differing values are ignored
+// until the next restart. The log level takes effect immediately.
+func (s *Server) reload(ctx context.Context, config *Config) error {
+	s.mu.RLock()
+	current := s.config
+	s.mu.RUnlock()
+	keepRestartSettings(config, current)
+	if s.tls != nil {
+		s.tls.reloadAndLog()
+	}
+
+	b, err := newBackend(config, s.index, s.metrics, s.callLog, s.authHooks())
+	if err != nil {
+		return err
+	}
+	s.useBackend(ctx, config, b)
+	slog.Info("Reloaded configuration", "tools", len(b.toolHandlers.Tools()))
+	return nil
+}
+
+// useBackend serves b with config, notifying connected clients that the tool
+// list changed.
+func (s *Server) useBackend(ctx context.Context, config *Config, b *backend) {
+	s.mu.Lock()
+	current := s.config
+	oldCred := s.jwtCred
+	if config.MaxConcurrentTools != current.MaxConcurrentTools {
+		// Calls in flight release their slots in the previous limiter
+		s.callSlots = tools.MaxConcurrentCalls(config.MaxConcurrentTools)
+	}
+	s.config = config
+	s.client = b.client
+	s.jwtCred = b.jwtCred
+	s.credentialSource = b.credentialSource
+	s.credentialOverrides = b.credentialOverrides
+	s.toolHandlers = b.toolHandlers
+	s.mu.Unlock()
+
+	if oldCred != nil {
+		oldCred.StopWatching()
+		oldCred.StopRefreshScheduler()
+	}
+	s.watchCredentials(ctx)
+
+	s.sessions.SetDefaultOrganization(config.DefaultOrganization)
+	s.sessions.ClearCaches()
+	s.mcp.SetTools(b.toolHandlers.Tools()...)
+	logLevel.Set(config.LogLevel)
+}
+
+// keepRestartSettings keeps the settings of current in config that only take
+// effect after a restart, warning if they were changed.
+func keepRestartSettings(config, current *Config) {
+	if config.Transport != current.Transport || config.HTTPAddr != current.HTTPAddr || config.IndexPath != current.IndexPath ||
+		config.LogFormat != current.LogFormat || config.LogFile != current.LogFile ||
+		config.TLSCertFile != current.TLSCertFile || config.TLSKeyFile != current.TLSKeyFile || config.TLSWatch != current.TLSWatch ||
+		config.DebugTools != current.DebugTools {
+		slog.Warn("Transport, HTTP address, TLS, index path, log format, log file and debug tools changes take effect after a restart")
+	}
+	config.Transport, config.HTTPAddr, config.IndexPath = current.Transport, current.HTTPAddr, current.IndexPath
+	config.LogFormat, config.LogFile = current.LogFormat, current.LogFile
+	config.TLSCertFile, config.TLSKeyFile, config.TLSWatch = current.TLSCertFile, current.TLSKeyFile, current.TLSWatch
+	config.DebugTools = current.DebugTools
+}
+
+// limitCalls queues tool calls beyond the configured MaxConcurrentTools.
+func (s *Server) limitCalls(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	s.mu.RLock()
+	callSlots := s.callSlots
+	s.mu.RUnlock()
+	return callSlots(next)
+}
+
+// limitConcurrency applies the per-tool concurrency limits of the current
+// configuration.
+func (s *Server) limitConcurrency(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	s.mu.RLock()
+	defaultLimit, limits := s.config.DefaultToolConcurrency, s.config.ToolConcurrency
+	s.mu.RUnlock()
+	return tools.ConcurrencyLimits(defaultLimit, limits)(next)
+}
+
+// limitDuration applies the per-tool timeouts of the current configuration.
+func (s *Server) limitDuration(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	s.mu.RLock()
+	timeouts := s.config.ToolTimeouts
+	s.mu.RUnlock()
+	return tools.Timeouts(timeouts)(next)
+}
+
+// overrideCredentials authenticates single calls with the credential of their
+// api_key or credential_profile argument (see tools.CredentialOverrides).
+func (s *Server) overrideCredentials(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	s.mu.RLock()
+	overrides, allowed := s.credentialOverrides, s.config.AllowCredentialOverride
+	s.mu.RUnlock()
+	var resolve tools.CredentialResolver
+	if overrides != nil && allowed {
+		resolve = overrides.resolve
+	}
+	return tools.CredentialOverrides(resolve)(next)
+}
+
+// selectOrganizationCredentials authenticates the calls targeting an
+// organization listed by a profile with the credential of that profile (see
+// tools.OrganizationCredentials).
+func (s *Server) selectOrganizationCredentials(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	s.mu.RLock()
+	overrides := s.credentialOverrides
+	s.mu.RUnlock()
+	var resolve tools.OrganizationCredentialResolver
+	if overrides != nil {
+		resolve = overrides.forOrganization
+	}
+	return tools.OrganizationCredentials(resolve)(next)
+}
+
+// filterTools tailors the listed tools to the session organization's features
+// using the current API client.
+func (s *Server) filterTools(ctx context.Context, list []mcp.Tool) []mcp.Tool {
+	s.mu.RLock()
+	client := s.client
+	s.mu.RUnlock()
+	if client == nil {
+		return list
+	}
+	return tools.FeatureFilter(s.sessions, client)(ctx, list)
+}
+
+// watchCredentials starts refreshing the JWT credential, if any, before it
+// expires and watching its credential file.
+//
+// Note: We use graceful degradation - if file watching fails, the server continues
+// to work normally. Token refresh will still work via the automatic refresh mechanism
+// when API calls return 401. We don't retry starting the watcher because:
+// 1. File watching is a convenience feature, not critical for functionality
+// 2. Retry logic would add complexity without significant benefit
+// 3. Users can restart the server if file watching is needed
+func (s *Server) watchCredentials(ctx context.Context) {
+	s.mu.RLock()
+	jwtCred, source := s.jwtCred, s.credentialSource
+	s.mu.RUnlock()
+	if jwtCred == nil {
+		return
+	}
+	if err := jwtCred.StartRefreshScheduler(ctx, 0); err != nil {
+		slog.Info("Tokens are only refreshed when the API rejects them", "reason", err)
+	}
+	if source != sourceCredentialFile {
+		return // neither the keychain nor the environment has change notifications
+	}
+	if err := jwtCred.StartWatching(ctx); err != nil {
+		slog.Warn("Failed to start credential file watching; automatic token reload from CLI updates will not be available", "error", err)
+	} else {
+		slog.Info("Started watching credential file for automatic token reload")
+	}
+}
+
+// start starts the server with the given configuration and serves until ctx
+// is canceled. ready is called once the transport accepts clients.
+func (s *Server) start(ctx context.Context, ready func()) error {
+	s.mu.RLock()
+	transport, httpAddr, profile, tlsWatch := s.config.Transport, s.config.HTTPAddr, s.config.Profile, s.config.TLSWatch
+	s.mu.RUnlock()
+	if transport == "" {
+		transport = transportStdio
+	}
+	if profile != "" {
+		slog.Info("Starting Terramate MCP server", "transport", transport, "profile", profile)
+	} else {
+		slog.Info("Starting Terramate MCP server", "transport", transport)
+	}
+
+	s.mu.Lock()
+	s.serveCtx = ctx
+	connected := s.client != nil
+	s.mu.Unlock()
 
 	// Start file watching if using JWT credentials
-	// Note: We use graceful degradation - if file watching fails, the server continues
-	// to work normally. Token refresh will still work via the automatic refresh mechanism
-	// when API calls return 401. We don't retry starting the watcher because:
-	// 1. File watching is a convenience feature, not critical for functionality
-	// 2. Retry logic would add complexity without significant benefit
-	// 3. Users can restart the server if file watching is needed
-	if s.jwtCred != nil {
-		if err := s.jwtCred.StartWatching(ctx); err != nil {
-			log.Printf("Warning: failed to start credential file watching: %v", err)
-			log.Printf("Automatic token reload from CLI updates will not be available")
-		} else {
-			log.Printf("Started watching credential file for automatic token reload")
-		}
-	}
-
-	// Start server in a goroutine so we can handle context cancellation
+	s.watchCredentials(ctx)
+	if !connected {
+		go s.awaitCredential(ctx)
+	}
+
+	if s.index != nil {
+		go s.syncIndex(ctx)
+	}
+	go s.probeServices(ctx)
+
+	if transport == transportHTTP {
+		if s.tls != nil && tlsWatch {
+			if err := s.tls.watch(ctx); err != nil {
+				slog.Warn("Failed to watch the TLS certificate; send SIGHUP to reload it after a rotation", "error", err)
+			} else {
+				slog.Info("Watching TLS certificate files for rotation")
+			}
+		}
+		return s.serveHTTP(ctx, httpAddr, ready)
+	}
+
+	// Serve stdio until stop has drained the in-flight tool calls, so their
+	// results still reach the client after ctx is canceled
+	listenCtx, stopListening := context.WithCancel(context.WithoutCancel(ctx))
+	s.mu.Lock()
+	s.stopListening = stopListening
+	s.mu.Unlock()
+
 	errChan := make(chan error, 1)
 	go func() {
-		errChan <- server.ServeStdio(s.mcp)
+		errChan <- server.NewStdioServer(s.mcp).Listen(listenCtx, os.Stdin, os.Stdout)
 	}()
+	ready()
 
 	// Wait for context cancellation or server error
 	select {
 	case <-ctx.Done():
-		log.Println("Context canceled, shutting down stdio server")
+		slog.Info("Context canceled, shutting down stdio server")
 		return ctx.Err()
 	case err := <-errChan:
 		return err
 	}
 }
 
-// stop gracefully shuts down the server
-func (s *Server) stop(_ context.Context) {
+// syncIndex syncs the local index immediately and then every
+// IndexSyncInterval until ctx is canceled. Failed syncs are logged and
+// retried at the next interval; queries keep serving the data synced so far.
+func (s *Server) syncIndex(ctx context.Context) {
+	s.mu.RLock()
+	interval := s.config.IndexSyncInterval
+	s.mu.RUnlock()
+	if interval <= 0 {
+		interval = defaultIndexSyncInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	synced := false
+	for {
+		s.mu.RLock()
+		client, orgUUID, limit := s.client, s.config.IndexOrganization, s.config.DefaultToolConcurrency
+		s.mu.RUnlock()
+
+		started := time.Now()
+		if client == nil {
+			slog.Debug("Skipping local index sync until a Terramate Cloud credential is configured")
+		} else if err := s.index.Sync(tmc.WithConcurrencyLimit(ctx, limit), client, orgUUID); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("Failed to sync local index", "organization", orgUUID, "error", err)
+		} else if !synced {
+			synced = true
+			slog.Info("Synced local index", "organization", orgUUID, "duration", time.Since(started).Round(time.Millisecond))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// validateAPIKey checks an API key credential against the API on startup and
+// logs the organization it is bound to. A rejected key returns an error, so
+// the server fails fast instead of every tool call failing with 401; an
+// unreachable API is only logged, so the server still starts offline.
+func (s *Server) validateAPIKey(ctx context.Context) error {
+	s.mu.RLock()
+	client, source, demo := s.client, s.credentialSource, s.config.Demo
+	s.mu.RUnlock()
+	if client == nil || source != sourceAPIKey || demo {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiKeyValidationTimeout)
+	defer cancel()
+	memberships, _, err := client.Memberships.List(ctx)
+	var apiErr *terramate.APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.IsUnauthorized():
+		return fmt.Errorf("%s rejected the API key: %s (check --%s or %s, and the region)",
+			client.BaseURL(), terramate.ErrAuthenticationFailed, apiKeyFlag.Name, apiKeyFlag.EnvVars[0])
+	case err != nil:
+		slog.Warn("Failed to validate the API key; tool calls will report the error", "error", err)
+	case len(memberships) == 0:
+		slog.Warn("The API key is not bound to any organization")
+	}
+	for _, m := range memberships {
+		slog.Info("API key is bound to organization", "organization", m.OrgName, "organization_uuid", m.OrgUUID, "role", m.Role)
+	}
+	return nil
+}
+
+// probeServices checks which APIs the credential can access in each of its
+// organizations. Tools of the services found unavailable then fail fast with
+// a capability message instead of sending requests bound to be rejected.
+// Failures are logged: services that could not be probed stay usable.
+func (s *Server) probeServices(ctx context.Context) {
+	s.mu.RLock()
+	client := s.client
+	s.mu.RUnlock()
+	if client == nil {
+		return
+	}
+
+	memberships, _, err := client.Memberships.List(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Warn("Failed to list organizations to probe available APIs", "error", err)
+		}
+		return
+	}
+	for _, m := range memberships {
+		available, err := client.ProbeServices(ctx, m.OrgUUID)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			slog.Debug("Failed to probe some APIs; they stay enabled", "organization", m.OrgName, "error", err)
+		}
+		var unavailable []string
+		for service, ok := range available {
+			if !ok {
+				unavailable = append(unavailable, string(service))
+			}
+		}
+		if len(unavailable) > 0 {
+			sort.Strings(unavailable)
+			slog.Info("Credential cannot access some APIs; their tools report the missing capability",
+				"organization", m.OrgName, "services", strings.Join(unavailable, ", "))
+		}
+	}
+}
+
+// serveHTTP serves the streamable HTTP transport until ctx is canceled,
+// calling ready once it listens on addr. Each MCP client gets its own
+// session, identified by the Mcp-Session-Id header.
+func (s *Server) serveHTTP(ctx context.Context, addr string, ready func()) error {
+	mux := http.NewServeMux()
+	mux.Handle(httpEndpointPath, s.releaseTerminatedSessions(server.NewStreamableHTTPServer(s.mcp)))
+	mux.Handle(metricsEndpointPath, s.metrics)
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.authenticate(mux),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	s.mu.Lock()
+	s.httpServer = httpServer
+	s.mu.Unlock()
+
+	// Listen before reporting readiness, so clients started after the server
+	// by a service manager find it accepting connections
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	scheme := "http"
+	serveListener := httpServer.Serve
+	if s.tls != nil {
+		httpServer.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: s.tls.GetCertificate}
+		scheme = "https"
+		serveListener = func(l net.Listener) error { return httpServer.ServeTLS(l, "", "") }
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- serveListener(listener)
+	}()
+	slog.Info("Listening for MCP clients", "url", scheme+"://"+addr+httpEndpointPath)
+	ready()
+	s.mu.RLock()
+	tokens := len(s.config.HTTPAuthTokens)
+	s.mu.RUnlock()
+	if tokens > 0 {
+		slog.Info("Requiring a bearer token from MCP clients", "tokens", tokens)
+	} else if !loopbackAddr(addr) {
+		slog.Warn("The HTTP transport accepts unauthenticated clients on a non-loopback address; set --http-auth-token-file to require a bearer token", "addr", addr)
+	}
+
+	select {
+	case <-ctx.Done():
+		slog.Info("Context canceled, shutting down HTTP server")
+		return ctx.Err()
+	case err := <-errChan:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+}
+
+// releaseTerminatedSessions drops a client's session state when the client
+// terminates its session with DELETE. Sessions of clients that disappear
+// without terminating are evicted once idle.
+func (s *Server) releaseTerminatedSessions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			if id := r.Header.Get(server.HeaderKeySessionID); id != "" {
+				s.sessions.Delete(id)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// shutdownTimeout returns how long stop may wait for in-flight tool calls.
+func (s *Server) shutdownTimeout() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.config.ShutdownTimeout > 0 {
+		return s.config.ShutdownTimeout
+	}
+	return defaultShutdownTimeout
+}
+
+// stop gracefully shuts down the server: new tool calls are rejected, calls
+// in flight may finish until ctx is done and are then canceled with a
+// shutting down error, and only then are the transports closed.
+func (s *Server) stop(ctx context.Context) {
+	if s.calls.drain(ctx) {
+		slog.Debug("Drained in-flight tool calls")
+	}
+
+	s.mu.RLock()
+	jwtCred, httpServer, stopListening := s.jwtCred, s.httpServer, s.stopListening
+	s.mu.RUnlock()
+
+	if httpServer != nil {
+		// Calls are done; give the responses a moment to be written
+		closeCtx, cancel := context.WithTimeout(context.Background(), shutdownAbortGrace)
+		if err := httpServer.Shutdown(closeCtx); err != nil {
+			slog.Warn("HTTP server shutdown failed", "error", err)
+		}
+		cancel()
+	}
+	if stopListening != nil {
+		stopListening()
+	}
+
 	// Stop file watching if active
-	if s.jwtCred != nil {
-		s.jwtCred.StopWatching()
-		log.Println("Stopped credential file watching")
+	if jwtCred != nil {
+		jwtCred.StopWatching()
+		jwtCred.StopRefreshScheduler()
+		slog.Info("Stopped credential file watching")
+	}
+
+	if s.index != nil {
+		if err := s.index.Close(); err != nil {
+			slog.Warn("Failed to close local index", "error", err)
+		}
 	}
 
-	log.Println("Terramate MCP server stopped")
+	slog.Info("Terramate MCP server stopped")
 }
 
 // AddTool registers an MCP tool handler
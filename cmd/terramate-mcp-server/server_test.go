@@ -1,13 +1,22 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools"
 )
 
 func TestNewServer_RequiresConfig(t *testing.T) {
@@ -91,6 +100,208 @@ func TestNewServer_Success(t *testing.T) {
 	}
 }
 
+func TestNewServer_LocalOnly(t *testing.T) {
+	toolNames := func(s *Server) string {
+		names := make([]string, 0, len(s.mcp.ListTools()))
+		for name := range s.mcp.ListTools() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return strings.Join(names, " ")
+	}
+
+	s, err := newServer(&Config{LocalOnly: true, DebugTools: true})
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	if got := toolNames(s); got != "tmc_inspect_call tmc_replay_last tmc_server_info" {
+		t.Fatalf("expected only the debug and server info tools without an index, got %s", got)
+	}
+
+	s, err = newServer(&Config{
+		LocalOnly:         true,
+		IndexPath:         filepath.Join(t.TempDir(), "index.db"),
+		IndexOrganization: "org-uuid",
+	})
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	defer func() { _ = s.index.Close() }()
+	want := "tmc_index_list_deployments tmc_index_list_drifts tmc_index_list_stacks tmc_index_status tmc_search tmc_server_info"
+	if got := toolNames(s); got != want {
+		t.Fatalf("expected only the index and server info tools, got %s", got)
+	}
+	if s.client != nil {
+		t.Fatal("expected no Terramate Cloud client in local-only mode")
+	}
+}
+
+func TestNewServer_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.Host
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer proxy.Close()
+
+	s, err := newServer(&Config{APIKey: "key", BaseURL: "http://api.example.test", Proxy: proxy.URL})
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	if _, _, err := s.client.Memberships.List(context.Background()); err != nil {
+		t.Fatalf("Memberships.List error: %v", err)
+	}
+	if proxied != "api.example.test" {
+		t.Fatalf("expected API requests to go through the proxy, got host %q", proxied)
+	}
+}
+
+func TestServer_ValidateAPIKey(t *testing.T) {
+	status := http.StatusUnauthorized
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			_, _ = w.Write([]byte(`[{"org_uuid":"org-uuid","org_name":"acme","role":"admin"}]`))
+		}
+	}))
+	defer api.Close()
+
+	s, err := newServer(&Config{APIKey: "key", BaseURL: api.URL})
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	if err := s.validateAPIKey(context.Background()); err == nil || !strings.Contains(err.Error(), "rejected the API key") {
+		t.Fatalf("expected a rejected API key to fail, got %v", err)
+	}
+
+	var logs strings.Builder
+	restoreDefaultLogger(t)
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	status = http.StatusOK
+	if err := s.validateAPIKey(context.Background()); err != nil {
+		t.Fatalf("validateAPIKey error: %v", err)
+	}
+	if !strings.Contains(logs.String(), "organization=acme") {
+		t.Fatalf("expected the organization of the key to be logged, got %q", logs.String())
+	}
+
+	api.Close()
+	if err := s.validateAPIKey(context.Background()); err != nil {
+		t.Fatalf("expected an unreachable API not to fail, got %v", err)
+	}
+}
+
+// hostRecorder answers every request with an empty list, recording its host.
+type hostRecorder struct{ host string }
+
+func (h *hostRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	h.host = req.URL.Host
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`[]`)),
+		Request:    req,
+	}, nil
+}
+
+func TestAPIEndpoint_RegionOfCredentialFile(t *testing.T) {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "https://accounts.google.com",
+		"exp": time.Now().Add(1 * time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+	credFile := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	data, _ := json.Marshal(map[string]string{"provider": "Google", "id_token": token, "region": "us"})
+	if err := os.WriteFile(credFile, data, 0o600); err != nil {
+		t.Fatalf("failed to write credential file: %v", err)
+	}
+
+	config := &Config{CredentialFile: credFile, DetectRegion: true}
+	credential, _, err := loadCredential(config, nil)
+	if err != nil {
+		t.Fatalf("loadCredential error: %v", err)
+	}
+	recorder := &hostRecorder{}
+	client, err := terramate.NewClient(credential, apiEndpoint(config, credential), terramate.WithHTTPClient(&http.Client{Transport: recorder}))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	if _, _, err := client.Memberships.List(context.Background()); err != nil {
+		t.Fatalf("Memberships.List error: %v", err)
+	}
+	if recorder.host != "us.api.terramate.io" {
+		t.Fatalf("expected the region hint of the credential file to be used, got host %q", recorder.host)
+	}
+}
+
+func TestLoadCredential_JWTFromEnvironment(t *testing.T) {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "https://accounts.google.com",
+		"exp": time.Now().Add(1 * time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+
+	config := &Config{JWT: token, RefreshToken: "refresh-token", CredentialFile: "/nonexistent/path/credentials.json"}
+	credential, _, err := loadCredential(config, nil)
+	if err != nil {
+		t.Fatalf("loadCredential error: %v", err)
+	}
+	if jwtCred, ok := credential.(*terramate.JWTCredential); !ok || jwtCred.Name() != "Google" {
+		t.Fatalf("expected the JWT instead of the credential file, got %#v", credential)
+	}
+
+	if _, _, err := loadCredential(&Config{JWT: "not-a-jwt"}, nil); err == nil || !strings.Contains(err.Error(), "--jwt") {
+		t.Fatalf("expected an invalid JWT to fail, got %v", err)
+	}
+}
+
+func TestLoadCredential_GitHubOIDC(t *testing.T) {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "https://token.actions.githubusercontent.com",
+		"exp": time.Now().Add(1 * time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+	var audience string
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		audience = r.URL.Query().Get("audience")
+		_ = json.NewEncoder(w).Encode(map[string]string{"value": token})
+	}))
+	defer tokenServer.Close()
+	t.Setenv(terramate.GitHubOIDCRequestURLEnv, tokenServer.URL)
+	t.Setenv(terramate.GitHubOIDCRequestTokenEnv, "request-token")
+
+	config := &Config{GitHubOIDC: true, OIDCAudience: "acme@api.terramate.io", CredentialFile: "/nonexistent/path/credentials.json"}
+	credential, _, err := loadCredential(config, nil)
+	if err != nil {
+		t.Fatalf("loadCredential error: %v", err)
+	}
+	if _, ok := credential.(*terramate.OIDCCredential); !ok || audience != config.OIDCAudience {
+		t.Fatalf("expected the OIDC token for %s, got %#v for %q", config.OIDCAudience, credential, audience)
+	}
+}
+
+func TestLoadCredential_WorkloadIdentity(t *testing.T) {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "https://accounts.google.com",
+		"exp": time.Now().Add(1 * time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+	metadata := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(token))
+	}))
+	defer metadata.Close()
+	t.Setenv(terramate.GCEMetadataHostEnv, metadata.Listener.Addr().String())
+	// The explicit workload identity wins over GitHub Actions OIDC
+	t.Setenv(terramate.GitHubOIDCRequestURLEnv, "http://127.0.0.1:1")
+
+	config := &Config{WorkloadIdentity: workloadIdentityGCP, GitHubOIDC: true, OIDCAudience: terramate.DefaultOIDCAudience}
+	credential, _, err := loadCredential(config, nil)
+	if err != nil {
+		t.Fatalf("loadCredential error: %v", err)
+	}
+	if credential.Name() != "GCP workload identity" {
+		t.Fatalf("expected the GCP workload identity, got %s", credential.Name())
+	}
+}
+
 func TestConfig_Struct(t *testing.T) {
 	cfg := &Config{
 		APIKey:  "key",
@@ -101,3 +312,66 @@ func TestConfig_Struct(t *testing.T) {
 		t.Fatalf("config fields not set correctly")
 	}
 }
+
+func TestServer_Reload(t *testing.T) {
+	s, err := newServer(&Config{APIKey: "key", Region: "eu", Transport: transportStdio})
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	all := len(s.mcp.ListTools())
+	s.sessions.Get("a").SetMemberships([]terramate.Membership{{OrgUUID: "org-a"}})
+	oldClient := s.client
+	restoreDefaultLogger(t)
+
+	err = s.reload(context.Background(), &Config{
+		APIKey:              "rotated",
+		Region:              "us",
+		DefaultOrganization: "org-b",
+		Toolsets:            []string{tools.ToolsetStacks},
+		Transport:           transportHTTP,
+		HTTPAddr:            "127.0.0.1:0",
+		LogLevel:            slog.LevelDebug,
+		LogFormat:           logFormatJSON,
+	})
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	if s.client == oldClient {
+		t.Fatal("expected a new API client after reload")
+	}
+	if got := len(s.mcp.ListTools()); got == 0 || got >= all {
+		t.Fatalf("expected the stacks toolset only (fewer than %d tools), got %d", all, got)
+	}
+	if s.mcp.GetTool("tmc_authenticate") == nil || s.mcp.GetTool("tmc_list_drifts") != nil {
+		t.Fatal("unexpected tools after reload")
+	}
+	if _, ok := s.sessions.Get("a").Memberships(); ok {
+		t.Fatal("expected cached memberships to be cleared")
+	}
+	if got := s.sessions.Get("new").DefaultOrganization(); got != "org-b" {
+		t.Fatalf("expected new sessions to default to org-b, got %q", got)
+	}
+	if s.config.Transport != transportStdio || s.config.LogFormat != "" {
+		t.Fatalf("expected transport and log format to be kept until restart, got %+v", s.config)
+	}
+	if logLevel.Level() != slog.LevelDebug {
+		t.Fatalf("expected the log level to change immediately, got %v", logLevel.Level())
+	}
+}
+
+func TestServer_ReloadKeepsConfigOnError(t *testing.T) {
+	config := &Config{APIKey: "key", Region: "eu"}
+	s, err := newServer(config)
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	registered := len(s.mcp.ListTools())
+
+	err = s.reload(context.Background(), &Config{CredentialFile: "/nonexistent/path/credentials.json"})
+	if err == nil {
+		t.Fatal("expected reload error for missing credentials")
+	}
+	if s.config != config || len(s.mcp.ListTools()) != registered {
+		t.Fatal("expected the current configuration to stay in effect")
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// serviceState is a state of the server reported to the service manager
+// running it: systemd on Linux, the service control manager on Windows.
+type serviceState int
+
+const (
+	// stateReady reports that the transport accepts MCP clients.
+	stateReady serviceState = iota
+	// stateReloading reports that the configuration is being reloaded.
+	stateReloading
+	// stateStopping reports that in-flight tool calls are being drained
+	// before the server exits.
+	stateStopping
+)
+
+// notifySystemd reports state to systemd, which waits for stateReady before
+// starting the units ordered after a Type=notify service. Without systemd it
+// does nothing; failures are logged and the server keeps serving.
+func notifySystemd(state serviceState) {
+	var message string
+	switch state {
+	case stateReady:
+		message = "READY=1\nSTATUS=Serving MCP clients"
+	case stateReloading:
+		message = sdReloading()
+	case stateStopping:
+		message = "STOPPING=1\nSTATUS=Draining in-flight tool calls"
+	}
+	if err := sdNotify(message); err != nil {
+		slog.Warn("Failed to notify systemd", "error", err)
+	}
+}
+
+// pingWatchdog keeps the systemd watchdog of the unit (WatchdogSec=) from
+// restarting the server until ctx is canceled. It returns at once if the
+// watchdog is not enabled.
+func pingWatchdog(ctx context.Context) {
+	interval := sdWatchdogInterval()
+	if interval <= 0 {
+		return
+	}
+	// Ping at twice the rate systemd expects, as sd_watchdog_enabled(3) recommends
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := sdNotify("WATCHDOG=1"); err != nil {
+				slog.Warn("Failed to ping the systemd watchdog", "error", err)
+			}
+		}
+	}
+}
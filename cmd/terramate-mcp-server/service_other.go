@@ -0,0 +1,24 @@
+//go:build !windows
+
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/urfave/cli/v2"
+)
+
+// serviceCommands is empty: outside Windows the server is installed as a
+// service with the tooling of the service manager, e.g. a systemd unit.
+var serviceCommands []*cli.Command
+
+// runningAsService reports whether the Windows service control manager
+// started the process, which it never does here.
+func runningAsService() bool {
+	return false
+}
+
+func serveService(func(context.Context, func(serviceState)) error) error {
+	return errors.New("running as a Windows service is only supported on Windows")
+}
@@ -0,0 +1,21 @@
+//go:build !windows
+
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestServeService_OutsideWindows(t *testing.T) {
+	if runningAsService() || len(serviceCommands) != 0 {
+		t.Fatal("expected no Windows service outside Windows")
+	}
+	err := serveService(func(context.Context, func(serviceState)) error {
+		t.Fatal("expected the server not to run")
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected running as a Windows service to fail")
+	}
+}
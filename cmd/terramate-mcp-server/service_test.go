@@ -0,0 +1,95 @@
+//go:build linux
+
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// listenNotifySocket points NOTIFY_SOCKET at a socket standing in for systemd
+// and returns a function receiving the next notification.
+func listenNotifySocket(t *testing.T) func() string {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("listen error: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	t.Setenv("NOTIFY_SOCKET", socket)
+
+	return func() string {
+		t.Helper()
+		buf := make([]byte, 256)
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatalf("read error: %v", err)
+		}
+		return string(buf[:n])
+	}
+}
+
+func TestNotifySystemd(t *testing.T) {
+	receive := listenNotifySocket(t)
+
+	notifySystemd(stateReady)
+	if got := receive(); !strings.HasPrefix(got, "READY=1\n") {
+		t.Fatalf("expected READY=1, got %q", got)
+	}
+	notifySystemd(stateReloading)
+	if got := receive(); !strings.HasPrefix(got, "RELOADING=1\nMONOTONIC_USEC=") {
+		t.Fatalf("expected RELOADING=1 with the reload time, got %q", got)
+	}
+	notifySystemd(stateStopping)
+	if got := receive(); !strings.HasPrefix(got, "STOPPING=1\n") {
+		t.Fatalf("expected STOPPING=1, got %q", got)
+	}
+}
+
+func TestPingWatchdog(t *testing.T) {
+	receive := listenNotifySocket(t)
+	t.Setenv("WATCHDOG_USEC", "100000")
+	t.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		pingWatchdog(ctx)
+		close(done)
+	}()
+	for range 2 {
+		if got := receive(); got != "WATCHDOG=1" {
+			t.Fatalf("expected a watchdog ping, got %q", got)
+		}
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected pingWatchdog to return once canceled")
+	}
+}
+
+func TestPingWatchdog_Disabled(t *testing.T) {
+	t.Setenv("WATCHDOG_USEC", "")
+	t.Setenv("WATCHDOG_PID", "")
+
+	done := make(chan struct{})
+	go func() {
+		pingWatchdog(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected pingWatchdog to return at once without a watchdog")
+	}
+}
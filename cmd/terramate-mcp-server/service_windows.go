@@ -0,0 +1,147 @@
+//go:build windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// serviceName is the name of the Windows service installed by
+// "service install", as used with sc.exe or Start-Service.
+const serviceName = "terramate-mcp-server"
+
+// serviceCommands install the server as a Windows service. Starting and
+// stopping it is left to the standard tooling (sc.exe, Start-Service,
+// Stop-Service or the Services console).
+var serviceCommands = []*cli.Command{
+	{
+		Name:  "service",
+		Usage: "Manage the Windows service running the server",
+		Subcommands: []*cli.Command{
+			{
+				Name:            "install",
+				Usage:           "Install the Windows service, starting automatically with the given server flags",
+				ArgsUsage:       "[server flags]",
+				SkipFlagParsing: true,
+				Action:          installService,
+			},
+			{
+				Name:   "uninstall",
+				Usage:  "Remove the Windows service; stop it first",
+				Action: uninstallService,
+			},
+		},
+	},
+}
+
+// installService registers the running executable as an automatically
+// started service, passing the command line arguments to the server.
+func installService(c *cli.Context) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the server executable: %w", err)
+	}
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service control manager: %w", err)
+	}
+	defer func() { _ = m.Disconnect() }()
+
+	s, err := m.CreateService(serviceName, exe, mgr.Config{
+		DisplayName: "Terramate MCP Server",
+		Description: "Serves Terramate Cloud to MCP clients",
+		StartType:   mgr.StartAutomatic,
+	}, c.Args().Slice()...)
+	if err != nil {
+		return fmt.Errorf("failed to install the %s service: %w", serviceName, err)
+	}
+	defer func() { _ = s.Close() }()
+	_, _ = fmt.Fprintf(c.App.Writer, "Installed the %s service; start it with: sc.exe start %s\n", serviceName, serviceName)
+	return nil
+}
+
+// uninstallService removes the service installed by installService.
+func uninstallService(c *cli.Context) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the service control manager: %w", err)
+	}
+	defer func() { _ = m.Disconnect() }()
+
+	s, err := m.OpenService(serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to open the %s service: %w", serviceName, err)
+	}
+	defer func() { _ = s.Close() }()
+	if err := s.Delete(); err != nil {
+		return fmt.Errorf("failed to uninstall the %s service: %w", serviceName, err)
+	}
+	_, _ = fmt.Fprintf(c.App.Writer, "Uninstalled the %s service\n", serviceName)
+	return nil
+}
+
+// runningAsService reports whether the Windows service control manager
+// started the process.
+func runningAsService() bool {
+	isService, err := svc.IsWindowsService()
+	return err == nil && isService
+}
+
+// serveService runs serve under the service control manager: the server is
+// reported running once it accepts clients, and stop and shutdown requests
+// shut it down as SIGTERM does.
+func serveService(serve func(context.Context, func(serviceState)) error) error {
+	handler := &serviceHandler{serve: serve}
+	if err := svc.Run(serviceName, handler); err != nil {
+		return fmt.Errorf("failed to run as a Windows service: %w", err)
+	}
+	return handler.err
+}
+
+// serviceHandler translates between the service control manager and serve.
+type serviceHandler struct {
+	serve func(context.Context, func(serviceState)) error
+	err   error
+}
+
+// Execute implements svc.Handler.
+func (h *serviceHandler) Execute(_ []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	changes <- svc.Status{State: svc.StartPending}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.serve(ctx, func(state serviceState) {
+			switch state {
+			case stateReady:
+				changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+			case stateStopping:
+				changes <- svc.Status{State: svc.StopPending}
+			}
+		})
+	}()
+
+	for {
+		select {
+		case h.err = <-done:
+			if h.err != nil {
+				return true, 1
+			}
+			return false, 0
+		case request := <-requests:
+			switch request.Cmd {
+			case svc.Interrogate:
+				changes <- request.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				cancel()
+			}
+		}
+	}
+}
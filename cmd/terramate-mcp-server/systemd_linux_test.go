@@ -0,0 +1,34 @@
+//go:build linux
+
+package main
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNotifySystemd_WithoutSystemd(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	if err := sdNotify("READY=1"); err != nil {
+		t.Fatalf("expected notifications to be skipped, got %v", err)
+	}
+}
+
+func TestSDWatchdogInterval(t *testing.T) {
+	t.Setenv("WATCHDOG_USEC", "30000000")
+	t.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getpid()))
+	if got := sdWatchdogInterval(); got != 30*time.Second {
+		t.Fatalf("expected 30s, got %s", got)
+	}
+	t.Setenv("WATCHDOG_PID", strconv.Itoa(os.Getppid()))
+	if got := sdWatchdogInterval(); got != 0 {
+		t.Fatalf("expected the watchdog of another process to be ignored, got %s", got)
+	}
+	t.Setenv("WATCHDOG_USEC", "")
+	t.Setenv("WATCHDOG_PID", "")
+	if got := sdWatchdogInterval(); got != 0 {
+		t.Fatalf("expected no watchdog, got %s", got)
+	}
+}
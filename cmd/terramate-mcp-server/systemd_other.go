@@ -0,0 +1,18 @@
+//go:build !linux
+
+package main
+
+import "time"
+
+// sdNotify does nothing: systemd only runs on Linux.
+func sdNotify(string) error {
+	return nil
+}
+
+func sdReloading() string {
+	return "RELOADING=1"
+}
+
+func sdWatchdogInterval() time.Duration {
+	return 0
+}
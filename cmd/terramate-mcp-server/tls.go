@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// certReloadDelay debounces the file events of a certificate rotation, which
+// usually replaces the certificate and the key in separate steps.
+const certReloadDelay = 500 * time.Millisecond
+
+// certReloader serves the TLS certificate of the HTTP transport. It reloads
+// the certificate from disk on SIGHUP and, when watching, whenever the files
+// change, so rotated certificates are used without a restart.
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// newCertReloader loads the certificate and key pair.
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	var err error
+	r := &certReloader{}
+	if r.certFile, err = expandHome(certFile); err != nil {
+		return nil, err
+	}
+	if r.keyFile, err = expandHome(keyFile); err != nil {
+		return nil, err
+	}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// reload loads the certificate from disk, keeping the current one if that
+// fails. It reports whether the certificate changed.
+func (r *certReloader) reload() (bool, error) {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	changed := r.cert == nil || !bytes.Equal(r.cert.Certificate[0], cert.Certificate[0])
+	r.cert = &cert
+	return changed, nil
+}
+
+// reloadAndLog reloads the certificate and logs the outcome.
+func (r *certReloader) reloadAndLog() {
+	changed, err := r.reload()
+	switch {
+	case err != nil:
+		slog.Warn("Failed to reload TLS certificate, serving the previous one", "error", err)
+	case changed:
+		slog.Info("Reloaded TLS certificate", "cert_file", r.certFile)
+	}
+}
+
+// watch reloads the certificate whenever its files change, until ctx is
+// canceled. The directories are watched rather than the files so rotations
+// that replace the files, e.g. by swapping the symlinks of a Kubernetes
+// secret volume, are noticed as well.
+func (r *certReloader) watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	for _, dir := range []string{filepath.Dir(r.certFile), filepath.Dir(r.keyFile)} {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+
+	go func() {
+		defer func() { _ = watcher.Close() }()
+		var pending <-chan time.Time
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op != fsnotify.Chmod {
+					pending = time.After(certReloadDelay)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				slog.Warn("TLS certificate watcher error", "error", err)
+			case <-pending:
+				pending = nil
+				r.reloadAndLog()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
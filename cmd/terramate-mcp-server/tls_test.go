@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCert writes a self-signed certificate and key for commonName to dir.
+func writeCert(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{commonName},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+
+	certFile, keyFile = filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// servedName returns the common name of the certificate r serves.
+func servedName(t *testing.T, r *certReloader) string {
+	t.Helper()
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		t.Fatalf("GetCertificate error: %v", err)
+	}
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	return parsed.Subject.CommonName
+}
+
+func TestCertReloader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "old.example.com")
+
+	r, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("newCertReloader error: %v", err)
+	}
+	if got := servedName(t, r); got != "old.example.com" {
+		t.Fatalf("expected the initial certificate, got %s", got)
+	}
+
+	if changed, err := r.reload(); err != nil || changed {
+		t.Fatalf("expected an unchanged certificate, got changed=%v (err %v)", changed, err)
+	}
+
+	writeCert(t, dir, "new.example.com")
+	if changed, err := r.reload(); err != nil || !changed {
+		t.Fatalf("expected the rotated certificate to be loaded, got changed=%v (err %v)", changed, err)
+	}
+	if got := servedName(t, r); got != "new.example.com" {
+		t.Fatalf("expected the rotated certificate, got %s", got)
+	}
+
+	if err := os.WriteFile(keyFile, []byte("garbage"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.reload(); err == nil {
+		t.Fatal("expected an invalid key to fail the reload")
+	}
+	if got := servedName(t, r); got != "new.example.com" {
+		t.Fatalf("expected the previous certificate to be kept, got %s", got)
+	}
+}
+
+func TestCertReloader_Watch(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "old.example.com")
+	r, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("newCertReloader error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := r.watch(ctx); err != nil {
+		t.Fatalf("watch error: %v", err)
+	}
+
+	writeCert(t, dir, "new.example.com")
+	deadline := time.Now().Add(5 * time.Second)
+	for servedName(t, r) != "new.example.com" {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the rotated certificate to be picked up")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestNewCertReloader_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := newCertReloader(filepath.Join(dir, "missing.crt"), filepath.Join(dir, "missing.key")); err == nil {
+		t.Fatal("expected missing files to fail")
+	}
+}
+
+func TestLoadConfig_TLS(t *testing.T) {
+	args := func(extra ...string) []string {
+		return append([]string{"terramate-mcp-server", "--config", writeConfigFile(t, "")}, extra...)
+	}
+
+	config, err := reloadConfig(appFlags, args("--transport", "http", "--tls-cert", "tls.crt", "--tls-key", "tls.key", "--tls-watch"))
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if config.TLSCertFile != "tls.crt" || config.TLSKeyFile != "tls.key" || !config.TLSWatch {
+		t.Fatalf("unexpected TLS config: %+v", config)
+	}
+
+	path := writeConfigFile(t, "transport: http\ntls:\n  cert: file.crt\n  key: file.key\n  watch: true\n")
+	config, err = reloadConfig(appFlags, []string{"terramate-mcp-server", "--config", path})
+	if err != nil {
+		t.Fatalf("reloadConfig error: %v", err)
+	}
+	if config.TLSCertFile != "file.crt" || config.TLSKeyFile != "file.key" || !config.TLSWatch {
+		t.Fatalf("expected the config file TLS settings, got %+v", config)
+	}
+
+	if _, err := reloadConfig(appFlags, args("--transport", "http", "--tls-cert", "tls.crt")); err == nil {
+		t.Fatal("expected a certificate without a key to fail")
+	}
+	if _, err := reloadConfig(appFlags, args("--tls-cert", "tls.crt", "--tls-key", "tls.key")); err == nil {
+		t.Fatal("expected TLS on the stdio transport to fail")
+	}
+}
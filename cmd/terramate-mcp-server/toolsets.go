@@ -0,0 +1,8 @@
+package main
+
+// External toolsets are compiled into the server by importing the packages
+// that register them with tools.RegisterToolset, e.g.
+//
+//	import _ "example.com/acme/terramate-mcp-tools"
+//
+// Their names are then accepted by --toolsets like the built-in toolsets.
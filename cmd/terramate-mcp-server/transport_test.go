@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/internal/demo"
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+)
+
+func TestHTTPTransport_IsolatesClientSessions(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/memberships":
+			_, _ = w.Write([]byte(`[{"org_uuid":"org-uuid","org_name":"acme"}]`))
+		case "/v1/stacks/org-uuid":
+			_, _ = w.Write([]byte(`{"stacks":[],"paginated_result":{"total":0,"page":1,"per_page":10}}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	s, err := newServer(&Config{APIKey: "test-key", BaseURL: api.URL})
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	ts := httptest.NewServer(s.releaseTerminatedSessions(server.NewStreamableHTTPServer(s.mcp)))
+	defer ts.Close()
+
+	ctx := context.Background()
+	connect := func() *client.Client {
+		c, err := client.NewStreamableHttpClient(ts.URL)
+		if err != nil {
+			t.Fatalf("client error: %v", err)
+		}
+		if _, err := c.Initialize(ctx, mcp.InitializeRequest{}); err != nil {
+			t.Fatalf("initialize error: %v", err)
+		}
+		return c
+	}
+	call := func(c *client.Client, name string) *mcp.CallToolResult {
+		result, err := c.CallTool(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name}})
+		if err != nil {
+			t.Fatalf("%s error: %v", name, err)
+		}
+		return result
+	}
+
+	alice, bob := connect(), connect()
+	defer func() { _ = bob.Close() }()
+
+	if result := call(alice, "tmc_authenticate"); result.IsError {
+		t.Fatalf("authenticate failed: %+v", result.Content)
+	}
+	if result := call(alice, "tmc_list_stacks"); result.IsError {
+		t.Fatalf("expected alice's default organization to be used: %+v", result.Content)
+	}
+	if result := call(bob, "tmc_list_stacks"); !result.IsError {
+		t.Fatal("expected bob to have no default organization")
+	}
+
+	sessions := s.sessions.Len()
+	if err := alice.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+	if got := s.sessions.Len(); got != sessions-1 {
+		t.Fatalf("expected terminated session to be released, have %d of %d", got, sessions)
+	}
+}
+
+func TestDebugTools_ReplayLastShowsAPIRequests(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"stacks":[],"paginated_result":{"total":0,"page":1,"per_page":10}}`))
+	}))
+	defer api.Close()
+
+	s, err := newServer(&Config{APIKey: "test-key", BaseURL: api.URL, DefaultOrganization: "org-uuid", DebugTools: true})
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	c, err := client.NewInProcessClient(s.mcp)
+	if err != nil {
+		t.Fatalf("client error: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("start error: %v", err)
+	}
+	if _, err := c.Initialize(ctx, mcp.InitializeRequest{}); err != nil {
+		t.Fatalf("initialize error: %v", err)
+	}
+
+	args := map[string]any{"drift_status": []any{"drifted"}}
+	if _, err := c.CallTool(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "tmc_list_stacks", Arguments: args}}); err != nil {
+		t.Fatalf("tmc_list_stacks error: %v", err)
+	}
+	result, err := c.CallTool(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "tmc_replay_last"}})
+	if err != nil || result.IsError {
+		t.Fatalf("tmc_replay_last failed: %v %+v", err, result)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{`"tool": "tmc_list_stacks"`, `"organization_uuid": "org-uuid"`, `"path": "/v1/stacks/org-uuid"`, "drift_status=drifted"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %s in the replay:\n%s", want, text)
+		}
+	}
+}
+
+func TestProbeServices_ToolsReportMissingCapability(t *testing.T) {
+	var deploymentRequests atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/v1/memberships":
+			_, _ = w.Write([]byte(`[{"org_uuid":"org-uuid","org_name":"acme","status":"active"}]`))
+		case strings.HasPrefix(r.URL.Path, "/v1/stack_deployments/"):
+			deploymentRequests.Add(1)
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer api.Close()
+
+	s, err := newServer(&Config{APIKey: "test-key", BaseURL: api.URL, DefaultOrganization: "org-uuid"})
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	ctx := context.Background()
+	s.probeServices(ctx)
+	if n := deploymentRequests.Load(); n != 1 {
+		t.Fatalf("expected one probe of the deployments API, got %d requests", n)
+	}
+
+	c, err := client.NewInProcessClient(s.mcp)
+	if err != nil {
+		t.Fatalf("client error: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("start error: %v", err)
+	}
+	if _, err := c.Initialize(ctx, mcp.InitializeRequest{}); err != nil {
+		t.Fatalf("initialize error: %v", err)
+	}
+
+	result, err := c.CallTool(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "tmc_list_deployments"}})
+	if err != nil {
+		t.Fatalf("tmc_list_deployments error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if !result.IsError || !strings.Contains(text, "Capability unavailable") || !strings.Contains(text, "Deployments API") {
+		t.Fatalf("expected a capability message, got %+v", result)
+	}
+	if n := deploymentRequests.Load(); n != 1 {
+		t.Fatalf("expected the tool to fail without a request, got %d requests", n)
+	}
+}
+
+func TestNewServer_WithoutCredentialConnectsOnLogin(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/memberships" {
+			_, _ = w.Write([]byte(`[{"org_uuid":"org-uuid","org_name":"acme","status":"active"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer api.Close()
+
+	s, err := newServer(&Config{BaseURL: api.URL})
+	if err != nil {
+		t.Fatalf("expected the server to start without a credential, got %v", err)
+	}
+	serveCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.serveCtx = serveCtx // as set by start
+	c, err := client.NewInProcessClient(s.mcp)
+	if err != nil {
+		t.Fatalf("client error: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("start error: %v", err)
+	}
+	if _, err := c.Initialize(ctx, mcp.InitializeRequest{}); err != nil {
+		t.Fatalf("initialize error: %v", err)
+	}
+	toolNames := func() []string {
+		list, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+		if err != nil {
+			t.Fatalf("list tools error: %v", err)
+		}
+		var names []string
+		for _, tool := range list.Tools {
+			names = append(names, tool.Name)
+		}
+		return names
+	}
+	authenticate := func() *mcp.CallToolResult {
+		result, err := c.CallTool(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "tmc_authenticate"}})
+		if err != nil {
+			t.Fatalf("tmc_authenticate error: %v", err)
+		}
+		return result
+	}
+
+	if names := toolNames(); strings.Join(names, ",") != "tmc_authenticate,tmc_credential_status,tmc_server_info" {
+		t.Fatalf("expected only the offline tools without a credential, got %v", names)
+	}
+	if result := authenticate(); !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "terramate cloud login") {
+		t.Fatalf("expected login instructions, got %+v", result)
+	}
+
+	// terramate cloud login writes the credential file
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "https://accounts.google.com",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+	data, _ := json.Marshal(map[string]string{"provider": "Google", "id_token": token})
+	if err := os.MkdirAll(filepath.Join(home, ".terramate.d"), 0o700); err != nil {
+		t.Fatalf("mkdir error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".terramate.d", "credentials.tmrc.json"), data, 0o600); err != nil {
+		t.Fatalf("failed to write credential file: %v", err)
+	}
+	defer func() { s.jwtCred.StopWatching() }()
+
+	result := authenticate()
+	if result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, `"organization_uuid": "org-uuid"`) {
+		t.Fatalf("expected tmc_authenticate to connect and return the membership, got %+v", result)
+	}
+	if names := toolNames(); !strings.Contains(strings.Join(names, ","), "tmc_list_stacks") {
+		t.Fatalf("expected the cloud tools once connected, got %v", names)
+	}
+}
+
+func TestNewServer_Demo(t *testing.T) {
+	// Neither a credential nor network access is needed
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	config := runWithFileConfig(t, &fileConfig{}, "--demo")
+	if config.DefaultOrganization != demo.OrganizationUUID {
+		t.Fatalf("expected the demo organization as default, got %q", config.DefaultOrganization)
+	}
+
+	s, err := newServer(config)
+	if err != nil {
+		t.Fatalf("newServer error: %v", err)
+	}
+	c, err := client.NewInProcessClient(s.mcp)
+	if err != nil {
+		t.Fatalf("client error: %v", err)
+	}
+	defer func() { _ = c.Close() }()
+	ctx := context.Background()
+	if err := c.Start(ctx); err != nil {
+		t.Fatalf("start error: %v", err)
+	}
+	if _, err := c.Initialize(ctx, mcp.InitializeRequest{}); err != nil {
+		t.Fatalf("initialize error: %v", err)
+	}
+
+	args := map[string]any{"drift_status": []any{"drifted"}}
+	result, err := c.CallTool(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "tmc_list_stacks", Arguments: args}})
+	if err != nil || result.IsError {
+		t.Fatalf("tmc_list_stacks failed: %v %+v", err, result)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "/stacks/aws/prod/network") {
+		t.Fatalf("expected the drifted demo stacks, got %s", text)
+	}
+
+	result, err = c.CallTool(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "tmc_server_info"}})
+	if err != nil || result.IsError {
+		t.Fatalf("tmc_server_info failed: %v %+v", err, result)
+	}
+	var info struct {
+		tmc.ServerInfo
+		OrganizationUUID string `json:"organization_uuid"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &info); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if !info.Demo || info.Credential != "demo" || info.BaseURL != demo.BaseURL || info.OrganizationUUID != demo.OrganizationUUID {
+		t.Fatalf("expected the demo setup, got %+v", info)
+	}
+}
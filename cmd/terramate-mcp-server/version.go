@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/terramate-io/terramate-mcp-server/internal/version"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+	"github.com/urfave/cli/v2"
+)
+
+// versionCommand prints the build of the server, e.g. for bug reports.
+var versionCommand = &cli.Command{
+	Name:  "version",
+	Usage: "Print the server, SDK and Go versions",
+	Action: func(c *cli.Context) error {
+		info := buildInfo()
+		_, _ = fmt.Fprintf(c.App.Writer, "terramate-mcp-server %s\n", info.Version)
+		if info.Commit != "" {
+			_, _ = fmt.Fprintf(c.App.Writer, "Commit:     %s\n", info.Commit)
+		}
+		if info.BuildTime != "" {
+			_, _ = fmt.Fprintf(c.App.Writer, "Built:      %s\n", info.BuildTime)
+		}
+		_, _ = fmt.Fprintf(c.App.Writer, "SDK:        %s\n", info.SDKVersion)
+		_, _ = fmt.Fprintf(c.App.Writer, "Go:         %s %s\n", info.GoVersion, info.Platform)
+		return nil
+	},
+}
+
+// buildInfo returns the build part of the server info.
+func buildInfo() tmc.ServerInfo {
+	return tmc.ServerInfo{
+		Version:    version.Version,
+		Commit:     version.Commit,
+		BuildTime:  version.BuildTime,
+		SDKVersion: terramate.Version,
+		GoVersion:  runtime.Version(),
+		Platform:   runtime.GOOS + "/" + runtime.GOARCH,
+	}
+}
+
+// serverInfo returns the info reported by tmc_server_info for config. client
+// is nil while no credential is configured.
+func serverInfo(config *Config, credential terramate.Credential, client *terramate.Client) tmc.ServerInfo {
+	info := buildInfo()
+	info.Transport = config.Transport
+	info.Demo = config.Demo
+	info.LocalOnly = config.LocalOnly
+	info.Credential = "none"
+	if client != nil {
+		info.Region = client.Region()
+		info.BaseURL = client.BaseURL()
+		info.Credential = credential.Name()
+		info.ActAs = client.ActAs()
+		if config.Demo {
+			info.Credential = "demo"
+		}
+	}
+	return info
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/terramate-io/terramate-mcp-server/internal/version"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/urfave/cli/v2"
+)
+
+func TestVersionCommand(t *testing.T) {
+	var out bytes.Buffer
+	app := &cli.App{Commands: []*cli.Command{versionCommand}, Writer: &out}
+	if err := app.Run([]string{"terramate-mcp-server", "version"}); err != nil {
+		t.Fatalf("version error: %v", err)
+	}
+	got := out.String()
+	if !strings.HasPrefix(got, "terramate-mcp-server "+version.Version+"\n") || !strings.Contains(got, runtime.Version()) {
+		t.Fatalf("expected the server and Go versions, got %q", got)
+	}
+}
+
+func TestServerInfo_WithoutCredential(t *testing.T) {
+	info := serverInfo(&Config{Transport: "http"}, nil, nil)
+	if info.Credential != "none" || info.Transport != "http" || info.BaseURL != "" || info.Version != version.Version {
+		t.Fatalf("unexpected server info: %+v", info)
+	}
+}
+
+func TestServerInfo_ActAs(t *testing.T) {
+	config := runWithFileConfig(t, &fileConfig{ActAs: "auditor@example.com"}, "--api-key", "key", "--region", "eu")
+	b, err := newBackend(config, nil, newFreshnessMetrics(), nil, authHooks{})
+	if err != nil {
+		t.Fatalf("newBackend error: %v", err)
+	}
+	if info := serverInfo(config, terramate.NewAPIKeyCredential("key"), b.client); info.ActAs != "auditor@example.com" {
+		t.Fatalf("expected the impersonated subject in the server info, got %+v", info)
+	}
+}
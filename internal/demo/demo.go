@@ -0,0 +1,307 @@
+// Package demo serves an in-process mock of the Terramate Cloud API with a
+// fixed demo organization: stacks across two repositories, their drift
+// checks with plans, and workflow deployments with logs. Backing the SDK
+// client with Transport lets users evaluate the MCP tools without a
+// Terramate Cloud account; no request leaves the process.
+//
+// The mock implements the read endpoints used by the SDK, including
+// pagination and the common list filters. Review requests and resources are
+// served as empty lists.
+package demo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// BaseURL is the API base URL to configure on clients using Transport. The
+// host is never resolved.
+const BaseURL = "https://demo.terramate.invalid"
+
+const (
+	defaultPerPage = 10
+	maxPerPage     = 100
+)
+
+// Handler returns the mock API, with timestamps relative to now.
+func Handler(now time.Time) http.Handler {
+	d := newDataset(now)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/memberships", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, []terramate.Membership{d.membership})
+	})
+	mux.HandleFunc("GET /v1/organizations/{org}/features", d.org(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, terramate.OrganizationFeatures{Previews: true})
+	}))
+	mux.HandleFunc("GET /v1/stacks/{org}", d.org(d.listStacks))
+	mux.HandleFunc("GET /v1/stacks/{org}/{stack}", d.org(d.getStack))
+	mux.HandleFunc("GET /v1/stacks/{org}/{stack}/drifts", d.org(d.listDrifts))
+	mux.HandleFunc("GET /v1/drifts/{org}/{stack}/{drift}", d.org(d.getDrift))
+	mux.HandleFunc("GET /v1/organizations/{org}/deployments", d.org(d.listDeployments))
+	mux.HandleFunc("GET /v1/workflow_deployment_groups/{org}/{group}", d.org(d.getDeployment))
+	mux.HandleFunc("GET /v1/workflow_deployment_groups/{org}/{group}/stacks", d.org(d.listGroupStacks))
+	mux.HandleFunc("GET /v1/stack_deployments/{org}", d.org(d.listStackDeployments))
+	mux.HandleFunc("GET /v1/stack_deployments/{org}/{id}", d.org(d.getStackDeployment))
+	mux.HandleFunc("GET /v1/stacks/{org}/{stack}/deployments/{uuid}/logs", d.org(d.getLogs))
+	mux.HandleFunc("GET /v1/review_requests/{org}", d.org(func(w http.ResponseWriter, r *http.Request) {
+		items, result := paginate(r, []terramate.ReviewRequest{})
+		writeJSON(w, http.StatusOK, terramate.ReviewRequestsListResponse{ReviewRequests: items, PaginatedResult: result})
+	}))
+	mux.HandleFunc("GET /v1/resources/{org}", d.org(func(w http.ResponseWriter, r *http.Request) {
+		items, result := paginate(r, []terramate.Resource{})
+		writeJSON(w, http.StatusOK, terramate.ResourcesListResponse{Resources: items, PaginatedResult: result})
+	}))
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		writeError(w, http.StatusNotFound, "not available in demo mode")
+	})
+	return mux
+}
+
+// Transport returns a RoundTripper serving every request with Handler, as
+// of the time it was created.
+func Transport() http.RoundTripper {
+	return handlerTransport{Handler(time.Now())}
+}
+
+type handlerTransport struct {
+	handler http.Handler
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, req)
+	resp := recorder.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+// org rejects requests for organizations other than the demo organization,
+// as the API does for organizations the credential is no member of.
+func (d *dataset) org(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("org") != OrganizationUUID {
+			writeError(w, http.StatusForbidden, "not a member of the organization")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (d *dataset) listStacks(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	archived := []string{"false"}
+	if q.Has("is_archived") {
+		archived = list(q.Get("is_archived"))
+	}
+	search := strings.ToLower(q.Get("search"))
+	var stacks []terramate.Stack
+	for _, stack := range d.stacks {
+		if !matches(q, "repository", stack.Repository) || !matches(q, "status", stack.Status) ||
+			!matches(q, "drift_status", stack.DriftStatus) || !matches(q, "deployment_status", stack.DeploymentStatus) ||
+			!slices.Contains(archived, strconv.FormatBool(stack.IsArchived)) ||
+			(q.Has("draft") && q.Get("draft") != strconv.FormatBool(stack.Draft)) ||
+			(q.Has("meta_id") && q.Get("meta_id") != stack.MetaID) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(stack.Path+" "+stack.MetaName+" "+stack.MetaDescription), search) {
+			continue
+		}
+		if tags := q["meta_tag"]; len(tags) > 0 && !containsAll(stack.MetaTags, tags) {
+			continue
+		}
+		stacks = append(stacks, stack)
+	}
+	items, result := paginate(r, stacks)
+	writeJSON(w, http.StatusOK, terramate.StacksListResponse{Stacks: items, PaginatedResult: result})
+}
+
+func (d *dataset) getStack(w http.ResponseWriter, r *http.Request) {
+	stack := d.stack(intValue(r, "stack"))
+	if stack == nil {
+		writeError(w, http.StatusNotFound, "stack not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, stack)
+}
+
+func (d *dataset) listDrifts(w http.ResponseWriter, r *http.Request) {
+	stackID := intValue(r, "stack")
+	if d.stack(stackID) == nil {
+		writeError(w, http.StatusNotFound, "stack not found")
+		return
+	}
+	q := r.URL.Query()
+	drifts := []terramate.Drift{}
+	for _, drift := range d.drifts[stackID] {
+		if !matches(q, "drift_status", drift.Status) || (q.Has("grouping_key") && q.Get("grouping_key") != drift.GroupingKey) {
+			continue
+		}
+		drift.DriftDetails = nil // only returned by getDrift
+		drifts = append(drifts, drift)
+	}
+	items, result := paginate(r, drifts)
+	writeJSON(w, http.StatusOK, terramate.DriftsListResponse{Drifts: items, PaginatedResult: result})
+}
+
+func (d *dataset) getDrift(w http.ResponseWriter, r *http.Request) {
+	stackID, driftID := intValue(r, "stack"), intValue(r, "drift")
+	for _, drift := range d.drifts[stackID] {
+		if drift.ID == driftID {
+			drift.Stack = d.stack(stackID)
+			writeJSON(w, http.StatusOK, drift)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "drift not found")
+}
+
+func (d *dataset) listDeployments(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	search := strings.ToLower(q.Get("search"))
+	deployments := []terramate.WorkflowDeploymentGroup{}
+	for _, group := range d.deployments {
+		if !matches(q, "repository", group.Repository) || !matches(q, "status", group.Status) ||
+			!matches(q, "auth_type", group.AuthType) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(group.CommitTitle+" "+group.CommitSHA), search) {
+			continue
+		}
+		deployments = append(deployments, group)
+	}
+	items, result := paginate(r, deployments)
+	writeJSON(w, http.StatusOK, terramate.DeploymentsListResponse{Deployments: items, PaginatedResult: result})
+}
+
+func (d *dataset) getDeployment(w http.ResponseWriter, r *http.Request) {
+	groupID := intValue(r, "group")
+	for _, group := range d.deployments {
+		if group.ID == groupID {
+			writeJSON(w, http.StatusOK, group)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "deployment not found")
+}
+
+func (d *dataset) listGroupStacks(w http.ResponseWriter, r *http.Request) {
+	ids, ok := d.groupStacks[intValue(r, "group")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "deployment not found")
+		return
+	}
+	q := r.URL.Query()
+	deployments := []terramate.StackDeployment{}
+	for _, sd := range d.stackDeployments {
+		if slices.Contains(ids, sd.ID) && matches(q, "status", sd.Status) {
+			deployments = append(deployments, sd)
+		}
+	}
+	items, result := paginate(r, deployments)
+	writeJSON(w, http.StatusOK, terramate.StackDeploymentsListResponse{StackDeployments: items, PaginatedResult: result})
+}
+
+func (d *dataset) listStackDeployments(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	deployments := []terramate.StackDeployment{}
+	for _, sd := range d.stackDeployments {
+		if matches(q, "status", sd.Status) {
+			deployments = append(deployments, sd)
+		}
+	}
+	items, result := paginate(r, deployments)
+	writeJSON(w, http.StatusOK, terramate.StackDeploymentsListResponse{StackDeployments: items, PaginatedResult: result})
+}
+
+func (d *dataset) getStackDeployment(w http.ResponseWriter, r *http.Request) {
+	id := intValue(r, "id")
+	for _, sd := range d.stackDeployments {
+		if sd.ID == id {
+			writeJSON(w, http.StatusOK, sd)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "stack deployment not found")
+}
+
+func (d *dataset) getLogs(w http.ResponseWriter, r *http.Request) {
+	logs, ok := d.logs[r.PathValue("uuid")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "deployment not found")
+		return
+	}
+	q := r.URL.Query()
+	lines := []terramate.CommandLogLine{}
+	for _, line := range logs {
+		if !q.Has("channel") || q.Get("channel") == line.Channel {
+			lines = append(lines, line)
+		}
+	}
+	items, result := paginate(r, lines)
+	writeJSON(w, http.StatusOK, terramate.DeploymentLogsResponse{DeploymentLogLines: items, PaginatedResult: result})
+}
+
+// paginate returns the page of items requested by the page and per_page
+// query parameters.
+func paginate[T any](r *http.Request, items []T) ([]T, terramate.PaginatedResult) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	page = max(page, 1)
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	perPage = min(perPage, maxPerPage)
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	return append([]T{}, items[start:end]...), terramate.PaginatedResult{Total: len(items), Page: page, PerPage: perPage}
+}
+
+// matches reports whether value is one of the comma-separated values of the
+// query parameter key, or the parameter is not set.
+func matches(q map[string][]string, key, value string) bool {
+	values, ok := q[key]
+	if !ok || len(values) == 0 {
+		return true
+	}
+	return slices.Contains(list(values[0]), value)
+}
+
+func list(value string) []string {
+	return strings.Split(value, ",")
+}
+
+func containsAll(have, want []string) bool {
+	for _, w := range want {
+		if !slices.Contains(have, w) {
+			return false
+		}
+	}
+	return true
+}
+
+func intValue(r *http.Request, name string) int {
+	n, _ := strconv.Atoi(r.PathValue(name))
+	return n
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, terramate.ErrorResponse{Error: message})
+}
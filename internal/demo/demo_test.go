@@ -0,0 +1,89 @@
+package demo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func newClient(t *testing.T) *terramate.Client {
+	t.Helper()
+	client, err := terramate.NewClientWithAPIKey("demo",
+		terramate.WithBaseURL(BaseURL), terramate.WithHTTPClient(&http.Client{Transport: Transport()}))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	return client
+}
+
+func TestDemo_StacksAndDrifts(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	memberships, _, err := client.Memberships.List(ctx)
+	if err != nil || len(memberships) != 1 || memberships[0].OrgUUID != OrganizationUUID {
+		t.Fatalf("expected the demo membership, got %+v (err %v)", memberships, err)
+	}
+
+	all, _, err := client.Stacks.List(ctx, OrganizationUUID, &terramate.StacksListOptions{ListOptions: terramate.ListOptions{PerPage: 5}})
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(all.Stacks) != 5 || all.PaginatedResult.Total != len(stackFixtures)-1 {
+		t.Fatalf("expected the first page of the unarchived stacks, got %d of %d", len(all.Stacks), all.PaginatedResult.Total)
+	}
+
+	drifted, _, err := client.Stacks.List(ctx, OrganizationUUID, &terramate.StacksListOptions{
+		DriftStatus: []string{"drifted"}, MetaTag: []string{"prod"},
+	})
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	var paths []string
+	for _, stack := range drifted.Stacks {
+		paths = append(paths, stack.Path)
+	}
+	if strings.Join(paths, ",") != "/stacks/aws/prod/network,/services/api/dns" {
+		t.Fatalf("unexpected drifted production stacks: %v", paths)
+	}
+
+	drifts, _, err := client.Drifts.ListForStack(ctx, OrganizationUUID, drifted.Stacks[0].StackID, nil)
+	if err != nil || len(drifts.Drifts) == 0 {
+		t.Fatalf("expected drift runs, got %+v (err %v)", drifts, err)
+	}
+	drift, _, err := client.Drifts.Get(ctx, OrganizationUUID, drifted.Stacks[0].StackID, drifts.Drifts[0].ID)
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if drift.Status != "drifted" || drift.DriftDetails == nil || !strings.Contains(drift.DriftDetails.ChangesetASCII, "Plan: 1 to add") {
+		t.Fatalf("expected the drift with its plan, got %+v", drift)
+	}
+}
+
+func TestDemo_Deployments(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	failed, _, err := client.Deployments.ListStackDeployments(ctx, OrganizationUUID, &terramate.StackDeploymentsListOptions{Status: []string{"failed"}})
+	if err != nil || len(failed.StackDeployments) != 1 {
+		t.Fatalf("expected one failed stack deployment, got %+v (err %v)", failed, err)
+	}
+	sd := failed.StackDeployments[0]
+	logs, _, err := client.Deployments.GetDeploymentLogs(ctx, OrganizationUUID, sd.Stack.StackID, sd.DeploymentUUID,
+		&terramate.DeploymentLogsOptions{Channel: "stderr"})
+	if err != nil || len(logs.DeploymentLogLines) == 0 || !strings.Contains(logs.DeploymentLogLines[0].Message, "Error:") {
+		t.Fatalf("expected the error output of the failed deployment, got %+v (err %v)", logs, err)
+	}
+}
+
+func TestDemo_OtherOrganization(t *testing.T) {
+	_, _, err := newClient(t).Stacks.List(context.Background(), "00000000-0000-0000-0000-000000000000", nil)
+	var apiErr *terramate.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for another organization, got %v", err)
+	}
+}
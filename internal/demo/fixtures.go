@@ -0,0 +1,343 @@
+package demo
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// OrganizationUUID is the UUID of the demo organization.
+const OrganizationUUID = "0de70de7-0000-4000-8000-000000000001"
+
+const (
+	infraRepo    = "github.com/acme/infrastructure"
+	platformRepo = "github.com/acme/platform"
+)
+
+// dataset is the content of the demo organization. Timestamps are relative
+// to the time the dataset was built, so the data always looks recent.
+type dataset struct {
+	membership       terramate.Membership
+	stacks           []terramate.Stack
+	drifts           map[int][]terramate.Drift // by stack ID, newest first
+	deployments      []terramate.WorkflowDeploymentGroup
+	stackDeployments []terramate.StackDeployment // newest first
+	groupStacks      map[int][]int               // stack deployment IDs by workflow deployment group ID
+	logs             map[string][]terramate.CommandLogLine
+}
+
+// stackFixture describes a demo stack.
+type stackFixture struct {
+	repo, path, name, description string
+	tags                          []string
+	drift, deployment             string
+	archived, draft               bool
+	resources                     int
+}
+
+var stackFixtures = []stackFixture{
+	{infraRepo, "/stacks/aws/prod/network", "prod-network", "VPC, subnets and NAT gateways of production", []string{"aws", "prod", "network"}, "drifted", "ok", false, false, 42},
+	{infraRepo, "/stacks/aws/prod/eks", "prod-eks", "EKS cluster and node groups of production", []string{"aws", "prod", "kubernetes"}, "ok", "ok", false, false, 67},
+	{infraRepo, "/stacks/aws/prod/rds", "prod-rds", "PostgreSQL primary and read replicas of production", []string{"aws", "prod", "database"}, "ok", "failed", false, false, 18},
+	{infraRepo, "/stacks/aws/staging/network", "staging-network", "VPC, subnets and NAT gateways of staging", []string{"aws", "staging", "network"}, "ok", "ok", false, false, 38},
+	{infraRepo, "/stacks/aws/staging/eks", "staging-eks", "EKS cluster and node groups of staging", []string{"aws", "staging", "kubernetes"}, "drifted", "ok", false, false, 55},
+	{infraRepo, "/stacks/aws/staging/rds", "staging-rds", "PostgreSQL instance of staging", []string{"aws", "staging", "database"}, "ok", "ok", false, false, 12},
+	{infraRepo, "/stacks/gcp/prod/gke", "prod-gke", "GKE cluster of the analytics workloads", []string{"gcp", "prod", "kubernetes"}, "unknown", "ok", false, false, 29},
+	{infraRepo, "/stacks/aws/dev/sandbox", "dev-sandbox", "Scratch account for experiments", []string{"aws", "dev"}, "ok", "ok", false, true, 4},
+	{platformRepo, "/services/api/iam", "api-iam", "IAM roles and policies of the API services", []string{"aws", "prod", "iam"}, "ok", "ok", false, false, 23},
+	{platformRepo, "/services/api/dns", "api-dns", "Route 53 zones and records of the API", []string{"aws", "prod", "dns"}, "drifted", "ok", false, false, 16},
+	{platformRepo, "/services/monitoring", "monitoring", "Alarms, dashboards and log retention", []string{"aws", "prod", "observability"}, "failed", "ok", false, false, 31},
+	{platformRepo, "/services/legacy-queue", "legacy-queue", "SQS queues of the retired order pipeline", []string{"aws", "prod"}, "ok", "ok", true, false, 6},
+}
+
+// driftPlans are the plans of the drifted stacks, by stack path.
+var driftPlans = map[string]string{
+	"/stacks/aws/prod/network": `Terraform will perform the following actions:
+
+  # aws_security_group_rule.bastion_ssh will be updated in-place
+  ~ resource "aws_security_group_rule" "bastion_ssh" {
+      ~ cidr_blocks = [
+          - "0.0.0.0/0",
+          + "10.0.0.0/8",
+        ]
+        id          = "sgrule-2618034981"
+    }
+
+  # aws_route.private_nat[1] will be created
+  + resource "aws_route" "private_nat" {
+      + destination_cidr_block = "0.0.0.0/0"
+      + nat_gateway_id         = "nat-0b7c2f6a1d3e4f5a6"
+      + route_table_id         = "rtb-04e1c7d2b9a8f6e53"
+    }
+
+Plan: 1 to add, 1 to change, 0 to destroy.
+`,
+	"/stacks/aws/staging/eks": `Terraform will perform the following actions:
+
+  # aws_eks_node_group.general will be updated in-place
+  ~ resource "aws_eks_node_group" "general" {
+        id = "staging:general"
+      ~ scaling_config {
+          ~ desired_size = 6 -> 3
+            max_size     = 10
+            min_size     = 2
+        }
+    }
+
+Plan: 0 to add, 1 to change, 0 to destroy.
+`,
+	"/services/api/dns": `Terraform will perform the following actions:
+
+  # aws_route53_record.api_legacy will be destroyed
+  # (because aws_route53_record.api_legacy is not in configuration)
+  - resource "aws_route53_record" "api_legacy" {
+      - name    = "legacy.api.acme.example" -> null
+      - records = ["203.0.113.10"] -> null
+      - ttl     = 300 -> null
+      - type    = "A" -> null
+      - zone_id = "Z0123456789ABCDEFGHIJ" -> null
+    }
+
+Plan: 0 to add, 0 to change, 1 to destroy.
+`,
+}
+
+// deploymentFixture describes a demo workflow deployment.
+type deploymentFixture struct {
+	repo, commit, branch, author string
+	age                          time.Duration
+	stacks                       map[int]string // stack ID to stack deployment status
+}
+
+var deploymentFixtures = []deploymentFixture{
+	{infraRepo, "Add read replica to the production database", "feat/rds-replica", "Maria Garcia", 3 * time.Hour, map[int]string{3: "failed"}},
+	{platformRepo, "Tighten retention of the monitoring log groups", "chore/log-retention", "Sam Lee", 26 * time.Hour, map[int]string{11: "ok"}},
+	{infraRepo, "Upgrade EKS to 1.31", "feat/eks-1.31", "Jonas Weber", 50 * time.Hour, map[int]string{2: "ok", 5: "ok"}},
+	{platformRepo, "Add records for the v2 API endpoints", "feat/api-v2-dns", "Sam Lee", 4 * 24 * time.Hour, map[int]string{10: "ok", 9: "ok"}},
+	{infraRepo, "Split staging subnets across three zones", "feat/staging-az", "Maria Garcia", 9 * 24 * time.Hour, map[int]string{4: "ok", 6: "ok"}},
+}
+
+// newDataset builds the demo organization as of now.
+func newDataset(now time.Time) *dataset {
+	d := &dataset{
+		membership: terramate.Membership{
+			MemberID: 1, OrgUUID: OrganizationUUID, OrgName: "acme-demo", OrgDisplayName: "Acme (demo)",
+			OrgDomain: "acme.example", Role: "admin", Status: "active",
+		},
+		drifts:      map[int][]terramate.Drift{},
+		groupStacks: map[int][]int{},
+		logs:        map[string][]terramate.CommandLogLine{},
+	}
+	d.addStacks(now)
+	d.addDrifts(now)
+	d.addDeployments(now)
+	return d
+}
+
+func (d *dataset) addStacks(now time.Time) {
+	for i, f := range stackFixtures {
+		id := i + 1
+		seen := now.Add(-time.Duration(id) * 17 * time.Minute)
+		stack := terramate.Stack{
+			StackID:          id,
+			Repository:       f.repo,
+			Path:             f.path,
+			DefaultBranch:    "main",
+			MetaID:           fmt.Sprintf("5ac00000-0000-4000-8000-%012d", id),
+			MetaName:         f.name,
+			MetaDescription:  f.description,
+			MetaTags:         f.tags,
+			DriftStatus:      f.drift,
+			DeploymentStatus: f.deployment,
+			Draft:            f.draft,
+			IsArchived:       f.archived,
+			CreatedAt:        now.AddDate(0, -8, -id),
+			UpdatedAt:        seen,
+			SeenAt:           &seen,
+			Resources:        &terramate.StackResources{Count: f.resources},
+		}
+		stack.Status = stackStatus(f.drift, f.deployment)
+		if f.archived {
+			archived := now.AddDate(0, 0, -20)
+			stack.ArchivedAt = &archived
+		}
+		d.stacks = append(d.stacks, stack)
+	}
+}
+
+// stackStatus derives the status of a stack as the API does: a failed
+// deployment or drift check outweighs drift.
+func stackStatus(drift, deployment string) string {
+	switch {
+	case deployment == "failed" || drift == "failed":
+		return "failed"
+	case drift == "drifted":
+		return "drifted"
+	case drift == "unknown":
+		return "unknown"
+	}
+	return "ok"
+}
+
+func (d *dataset) addDrifts(now time.Time) {
+	id := 1000
+	for i := range d.stacks {
+		stack := &d.stacks[i]
+		if stack.DriftStatus == "unknown" {
+			continue
+		}
+		// A daily drift check at 06:00, newest first: the current status and
+		// two clean runs before it
+		for day := 0; day < 3; day++ {
+			id++
+			started := now.Truncate(24*time.Hour).AddDate(0, 0, -day).Add(6 * time.Hour)
+			if started.After(now) {
+				started = started.AddDate(0, 0, -1)
+			}
+			finished := started.Add(time.Duration(40+stack.StackID*7) * time.Second)
+			status := "ok"
+			if day == 0 {
+				status = stack.DriftStatus
+			}
+			drift := terramate.Drift{
+				ID: id, OrgUUID: OrganizationUUID, StackID: stack.StackID, Status: status,
+				Metadata:    map[string]interface{}{"github_actions_run_id": 9000000 + id},
+				StartedAt:   &started,
+				FinishedAt:  &finished,
+				AuthType:    "gha",
+				AuthTrust:   &terramate.TrustInfo{AuthID: "repo:acme/" + repoName(stack.Repository) + ":ref:refs/heads/main"},
+				GroupingKey: "drift-check-" + started.Format("2006-01-02"),
+				Cmd:         []string{"terraform", "plan", "-detailed-exitcode", "-lock=false"},
+			}
+			switch status {
+			case "drifted":
+				drift.DriftDetails = &terramate.ChangesetDetails{
+					Provisioner: "terraform", Serial: int64(100 + id), ChangesetASCII: driftPlans[stack.Path],
+				}
+			case "failed":
+				drift.DriftDetails = &terramate.ChangesetDetails{
+					Provisioner: "terraform",
+					ChangesetASCII: "Error: reading CloudWatch Log Group (/aws/lambda/order-sync): AccessDeniedException: " +
+						"User is not authorized to perform: logs:DescribeLogGroups\n",
+				}
+			}
+			d.drifts[stack.StackID] = append(d.drifts[stack.StackID], drift)
+		}
+	}
+}
+
+func (d *dataset) addDeployments(now time.Time) {
+	stackDeploymentID := 4000
+	for i, f := range deploymentFixtures {
+		groupID := 300 + len(deploymentFixtures) - i
+		created := now.Add(-f.age)
+		started := created.Add(20 * time.Second)
+		group := terramate.WorkflowDeploymentGroup{
+			ID:           groupID,
+			CommitTitle:  f.commit,
+			CommitSHA:    fmt.Sprintf("%07x%033x", 0x5eed000+groupID*0x9c4, groupID),
+			Repository:   f.repo,
+			AuthType:     "gha",
+			AuthUser:     &terramate.UserInfo{DisplayName: f.author},
+			CreatedAt:    created,
+			StartedAt:    &started,
+			Branch:       "main",
+			WorkflowName: "Terramate Deploy",
+			Metadata:     map[string]interface{}{"pull_request_branch": f.branch},
+		}
+		var finished time.Time
+		for n, stackID := range slices.Sorted(maps.Keys(f.stacks)) {
+			status := f.stacks[stackID]
+			stackDeploymentID++
+			stack := d.stack(stackID)
+			stackStarted := started.Add(time.Duration(n*5) * time.Second)
+			stackFinished := stackStarted.Add(time.Duration(90+stackID*11) * time.Second)
+			if stackFinished.After(finished) {
+				finished = stackFinished
+			}
+			sd := terramate.StackDeployment{
+				ID:             stackDeploymentID,
+				DeploymentUUID: fmt.Sprintf("de910000-0000-4000-8000-%012d", stackDeploymentID),
+				Path:           stack.Path,
+				Cmd:            []string{"terraform", "apply", "-auto-approve", "-input=false", "out.tfplan"},
+				Status:         status,
+				CreatedAt:      created,
+				StartedAt:      &stackStarted,
+				FinishedAt:     &stackFinished,
+				Stack:          stack,
+			}
+			d.stackDeployments = append(d.stackDeployments, sd)
+			d.groupStacks[groupID] = append(d.groupStacks[groupID], stackDeploymentID)
+			d.logs[sd.DeploymentUUID] = deploymentLogs(stack, status, stackStarted)
+
+			group.StackDeploymentTotalCount++
+			if status == "failed" {
+				group.FailedCount++
+			} else {
+				group.OkCount++
+			}
+		}
+		group.FinishedAt = &finished
+		group.Status = "ok"
+		if group.FailedCount > 0 {
+			group.Status = "failed"
+		}
+		d.deployments = append(d.deployments, group)
+	}
+}
+
+// deploymentLogs returns the terraform apply output of a stack deployment.
+func deploymentLogs(stack *terramate.Stack, status string, started time.Time) []terramate.CommandLogLine {
+	lines := []struct{ channel, message string }{
+		{"stdout", "Acquiring state lock. This may take a few moments..."},
+		{"stdout", fmt.Sprintf("Refreshing state... [%d resources]", resourceCount(stack))},
+	}
+	if status == "failed" {
+		lines = append(lines,
+			struct{ channel, message string }{"stdout", "aws_db_instance.replica: Creating..."},
+			struct{ channel, message string }{"stdout", "aws_db_instance.replica: Still creating... [10m0s elapsed]"},
+			struct{ channel, message string }{"stderr", "Error: creating RDS DB Instance (read replica) (prod-rds-replica-1): InvalidParameterCombination: " +
+				"Cannot create a read replica of a DB instance with automated backups disabled"},
+			struct{ channel, message string }{"stderr", "  with aws_db_instance.replica, on replica.tf line 1"},
+			struct{ channel, message string }{"stdout", "Releasing state lock. This may take a few moments..."},
+		)
+	} else {
+		lines = append(lines,
+			struct{ channel, message string }{"stdout", "Apply complete! Resources: 1 added, 2 changed, 0 destroyed."},
+			struct{ channel, message string }{"stdout", "Releasing state lock. This may take a few moments..."},
+		)
+	}
+	logs := make([]terramate.CommandLogLine, len(lines))
+	for i, line := range lines {
+		logs[i] = terramate.CommandLogLine{
+			LogLine: i + 1, Timestamp: started.Add(time.Duration(i) * 3 * time.Second),
+			Channel: line.channel, Message: line.message,
+		}
+	}
+	return logs
+}
+
+func resourceCount(stack *terramate.Stack) int {
+	if stack.Resources == nil {
+		return 0
+	}
+	return stack.Resources.Count
+}
+
+// stack returns a copy of the stack with id, or nil.
+func (d *dataset) stack(id int) *terramate.Stack {
+	for _, stack := range d.stacks {
+		if stack.StackID == id {
+			return &stack
+		}
+	}
+	return nil
+}
+
+func repoName(repo string) string {
+	return repo[strings.LastIndex(repo, "/")+1:]
+}
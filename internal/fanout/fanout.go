@@ -0,0 +1,51 @@
+// Package fanout runs API calls in parallel under the per-invocation limit
+// carried by the context, so one limit governs the fan-out of the tools and
+// of the local index sync.
+package fanout
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultLimit is the number of calls Run may run in parallel when no
+// explicit limit has been configured.
+const DefaultLimit = 4
+
+// limitKey is the context key for the fan-out limit.
+type limitKey struct{}
+
+// WithLimit returns a context that caps how many calls Run may run in
+// parallel. Non-positive values leave the default in place.
+func WithLimit(ctx context.Context, n int) context.Context {
+	if n <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, limitKey{}, n)
+}
+
+// Limit returns the fan-out limit configured on ctx.
+func Limit(ctx context.Context) int {
+	if n, ok := ctx.Value(limitKey{}).(int); ok && n > 0 {
+		return n
+	}
+	return DefaultLimit
+}
+
+// Run calls fn for every index in [0, n) with at most Limit(ctx) calls in
+// flight. The first error cancels the context passed to the remaining calls
+// and is returned once all started calls have finished.
+func Run(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(Limit(ctx))
+	for i := 0; i < n; i++ {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			return fn(gctx, i)
+		})
+	}
+	return g.Wait()
+}
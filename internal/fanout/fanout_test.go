@@ -0,0 +1,61 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun_HonorsContextLimit(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	ctx := WithLimit(context.Background(), 2)
+
+	err := Run(ctx, 10, func(ctx context.Context, i int) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := peak.Load(); got > 2 {
+		t.Fatalf("expected at most 2 concurrent calls, got %d", got)
+	}
+}
+
+func TestRun_ReturnsFirstError(t *testing.T) {
+	boom := errors.New("boom")
+	var calls atomic.Int32
+
+	err := Run(WithLimit(context.Background(), 1), 5, func(ctx context.Context, i int) error {
+		calls.Add(1)
+		if i == 1 {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got: %v", err)
+	}
+	if calls.Load() == 5 {
+		t.Fatal("expected remaining calls to be skipped after the first error")
+	}
+}
+
+func TestLimit_Default(t *testing.T) {
+	if got := Limit(context.Background()); got != DefaultLimit {
+		t.Fatalf("expected default %d, got %d", DefaultLimit, got)
+	}
+	if got := Limit(WithLimit(context.Background(), 0)); got != DefaultLimit {
+		t.Fatalf("expected default for zero limit, got %d", got)
+	}
+}
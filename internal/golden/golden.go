@@ -0,0 +1,49 @@
+// Package golden compares rendered output with golden files checked into a
+// package's testdata directory, so formatting changes show up in review as
+// diffs of those files.
+//
+// Run the tests of a package with -update to rewrite its golden files from
+// the current output:
+//
+//	go test ./tools/tmc/ -run Snapshot -update
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// Path returns the golden file of name, testdata/name.golden.
+func Path(name string) string {
+	return filepath.Join("testdata", name+".golden")
+}
+
+// Assert fails t unless got equals the golden file of name. With -update the
+// golden file is written instead.
+func Assert(t testing.TB, name string, got []byte) {
+	t.Helper()
+	path := Path(name)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create testdata directory: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run the test with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run the test with -update to accept it)\n--- got\n%s\n--- want\n%s", path, got, want)
+	}
+}
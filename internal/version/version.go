@@ -3,6 +3,13 @@ package version
 // Version is the semantic version embedded in binaries and used in the user agent.
 const Version = "0.0.2"
 
+// Commit and BuildTime describe the build; the Makefile sets them with
+// -ldflags "-X". They are empty in builds without the Makefile, e.g. go install.
+var (
+	Commit    string
+	BuildTime string
+)
+
 // UserAgent returns the default HTTP User-Agent string for outbound requests.
 func UserAgent() string {
 	return "terramate-mcp-server/" + Version
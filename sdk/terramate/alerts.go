@@ -0,0 +1,153 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// AlertsService handles communication with the alerts related methods of the
+// Terramate Cloud API.
+type AlertsService struct {
+	client *Client
+}
+
+// buildQuery constructs URL query parameters from AlertsListOptions.
+func (opts *AlertsListOptions) buildQuery() url.Values {
+	query := url.Values{}
+	if opts == nil {
+		return query
+	}
+
+	addPagination(query, opts.Page, opts.PerPage)
+	addStringSlice(query, "severity", opts.Severity)
+	addStringSlice(query, "status", opts.Status)
+	addStringSlice(query, "type", opts.Type)
+	addIntSlice(query, "stack_id", opts.StackID)
+
+	return query
+}
+
+// List retrieves the alerts of an organization with optional filters.
+//
+// GET /v1/alerts/{org_uuid}
+//
+// Alerts are raised for stacks that drifted or whose deployment failed. Filter
+// by status "active" to get the alerts still awaiting triage.
+func (s *AlertsService) List(ctx context.Context, orgUUID string, opts *AlertsListOptions) (*AlertsListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+
+	path := fmt.Sprintf("/v1/alerts/%s", orgUUID)
+
+	if opts != nil {
+		query := opts.buildQuery()
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result AlertsListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// All returns an iterator over the alerts matching opts, walking the pages of
+// List from opts.Page as the iteration advances.
+func (s *AlertsService) All(ctx context.Context, orgUUID string, opts *AlertsListOptions) iter.Seq2[Alert, error] {
+	return s.listPages(ctx, orgUUID, opts).all()
+}
+
+// ListAll accumulates the alerts matching opts in a slice, up to maxItems
+// (10000 when not positive), walking the pages of List from opts.Page. The
+// bool reports whether the result was truncated at maxItems.
+func (s *AlertsService) ListAll(ctx context.Context, orgUUID string, opts *AlertsListOptions, maxItems int) ([]Alert, bool, error) {
+	return s.listPages(ctx, orgUUID, opts).collect(maxItems)
+}
+
+// listPages pages through List from the page of opts.
+func (s *AlertsService) listPages(ctx context.Context, orgUUID string, opts *AlertsListOptions) pages[Alert] {
+	var o AlertsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[Alert]{opts: o.ListOptions, list: func(page ListOptions) ([]Alert, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.List(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Alerts, result.PaginatedResult, nil
+	}}
+}
+
+// Get retrieves a specific alert by UUID.
+//
+// GET /v1/alerts/{org_uuid}/{alert_uuid}
+func (s *AlertsService) Get(ctx context.Context, orgUUID, alertUUID string) (*Alert, *Response, error) {
+	path, err := alertPath(orgUUID, alertUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var alert Alert
+	resp, err := s.client.do(req, &alert)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &alert, resp, nil
+}
+
+// Acknowledge acknowledges an active alert, marking it as being handled, and
+// returns the updated alert. The alert is resolved by the API once its cause,
+// e.g. the drift, is gone.
+//
+// POST /v1/alerts/{org_uuid}/{alert_uuid}/acknowledge
+func (s *AlertsService) Acknowledge(ctx context.Context, orgUUID, alertUUID string) (*Alert, *Response, error) {
+	path, err := alertPath(orgUUID, alertUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, path+"/acknowledge", nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var alert Alert
+	resp, err := s.client.do(req, &alert)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &alert, resp, nil
+}
+
+// alertPath returns the path of an alert, validating its identifiers.
+func alertPath(orgUUID, alertUUID string) (string, error) {
+	if orgUUID == "" {
+		return "", fmt.Errorf("organization UUID is required")
+	}
+	if alertUUID == "" {
+		return "", fmt.Errorf("alert UUID is required")
+	}
+	return fmt.Sprintf("/v1/alerts/%s/%s", orgUUID, alertUUID), nil
+}
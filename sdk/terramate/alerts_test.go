@@ -0,0 +1,98 @@
+package terramate
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestAlertsList_WithOptions(t *testing.T) {
+	payload := `{
+		"alerts": [
+			{
+				"alert_uuid": "alert-1",
+				"type": "drift",
+				"severity": "high",
+				"status": "active",
+				"title": "Stack vpc drifted",
+				"stack": {"stack_id": 7, "path": "/stacks/vpc", "meta_id": "vpc", "status": "drifted"},
+				"drift_id": 42,
+				"created_at": "2024-04-12T07:06:00Z"
+			}
+		],
+		"paginated_result": {"total": 1, "page": 1, "per_page": 20}
+	}`
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/alerts/org-uuid-123" {
+			t.Errorf("unexpected path: got %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("severity") != "high,critical" || query.Get("status") != "active" || query.Get("stack_id") != "7" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, werr := w.Write([]byte(payload)); werr != nil {
+			panic(werr)
+		}
+	})
+	defer cleanup()
+
+	result, _, err := client.Alerts.List(context.Background(), "org-uuid-123", &AlertsListOptions{
+		Severity: []string{"high", "critical"},
+		Status:   []string{"active"},
+		StackID:  []int{7},
+	})
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(result.Alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(result.Alerts))
+	}
+	alert := result.Alerts[0]
+	if alert.AlertUUID != "alert-1" || alert.Severity != "high" || alert.DriftID != 42 {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+	if alert.Stack == nil || alert.Stack.StackID != 7 {
+		t.Errorf("unexpected stack: %+v", alert.Stack)
+	}
+}
+
+func TestAlertsGetAndAcknowledge(t *testing.T) {
+	var requests []string
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		status := "active"
+		if r.Method == http.MethodPost {
+			status = "acknowledged"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, werr := w.Write([]byte(`{"alert_uuid": "alert-1", "type": "deployment_failed", "severity": "critical", "status": "` + status + `", "created_at": "2024-04-12T07:06:00Z"}`)); werr != nil {
+			panic(werr)
+		}
+	})
+	defer cleanup()
+
+	alert, _, err := client.Alerts.Get(context.Background(), "org-uuid-123", "alert-1")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if alert.Status != "active" || alert.Type != "deployment_failed" {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+	alert, _, err = client.Alerts.Acknowledge(context.Background(), "org-uuid-123", "alert-1")
+	if err != nil {
+		t.Fatalf("Acknowledge error: %v", err)
+	}
+	if alert.Status != "acknowledged" {
+		t.Errorf("status = %q, want acknowledged", alert.Status)
+	}
+	want := []string{"GET /v1/alerts/org-uuid-123/alert-1", "POST /v1/alerts/org-uuid-123/alert-1/acknowledge"}
+	if len(requests) != 2 || requests[0] != want[0] || requests[1] != want[1] {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+
+	if _, _, err := client.Alerts.Acknowledge(context.Background(), "org-uuid-123", ""); err == nil {
+		t.Fatal("expected an error without alert UUID")
+	}
+}
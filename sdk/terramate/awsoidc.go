@@ -0,0 +1,248 @@
+package terramate
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Environment variables of the AWS SDKs honored by AWSSTSTokenSource.
+const (
+	AWSSTSEndpointEnv  = "AWS_ENDPOINT_URL_STS"
+	AWSIMDSEndpointEnv = "AWS_EC2_METADATA_SERVICE_ENDPOINT"
+)
+
+const (
+	defaultIMDSEndpoint = "http://169.254.169.254"
+	// imdsTokenTTL is the lifetime of the IMDSv2 session tokens requested.
+	imdsTokenTTL = "300"
+	// stsAPIVersion is the version of the STS query API.
+	stsAPIVersion = "2011-06-15"
+)
+
+// AWSSTSTokenSource issues OIDC tokens for the IAM identity of the workload
+// with the STS GetWebIdentityToken API (outbound identity federation, which
+// must be enabled for the AWS account). Requests are signed with the
+// credentials of AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN or, without them, with the instance role credentials of
+// the EC2 instance metadata service (IMDSv2).
+type AWSSTSTokenSource struct {
+	// Region of the STS endpoint. Defaults to AWS_REGION, AWS_DEFAULT_REGION,
+	// or the region of the EC2 instance.
+	Region string
+
+	// STSEndpoint defaults to AWS_ENDPOINT_URL_STS, or
+	// https://sts.<region>.amazonaws.com.
+	STSEndpoint string
+
+	// IMDSEndpoint defaults to AWS_EC2_METADATA_SERVICE_ENDPOINT, or
+	// http://169.254.169.254.
+	IMDSEndpoint string
+
+	// HTTPClient sends the STS requests and defaults to a client with a 30
+	// second timeout. The instance metadata service is always reached
+	// directly.
+	HTTPClient *http.Client
+}
+
+// awsCredentials are the AWS credentials signing STS requests.
+type awsCredentials struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	SessionToken    string `json:"Token"`
+}
+
+// NewAWSWorkloadIdentityCredential creates a credential from the OIDC tokens
+// STS issues for the IAM role of the workload running the process.
+func NewAWSWorkloadIdentityCredential(ctx context.Context, opts ...OIDCOption) (*OIDCCredential, error) {
+	o := newOIDCOptions(opts)
+	return NewOIDCCredential(ctx, "AWS workload identity", &AWSSTSTokenSource{HTTPClient: o.httpClient}, o.audience)
+}
+
+// Token implements OIDCTokenSource.
+func (a *AWSSTSTokenSource) Token(ctx context.Context, audience string) (string, error) {
+	creds, err := a.credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+	region, err := a.region(ctx)
+	if err != nil {
+		return "", err
+	}
+	endpoint := a.STSEndpoint
+	if endpoint == "" {
+		endpoint = os.Getenv(AWSSTSEndpointEnv)
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://sts.%s.amazonaws.com", region)
+	}
+
+	form := url.Values{
+		"Action":            {"GetWebIdentityToken"},
+		"Version":           {stsAPIVersion},
+		"Audience.member.1": {audience},
+		"SigningAlgorithm":  {"RS256"},
+	}
+	body := form.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	signAWSRequest(req, []byte(body), creds, region, "sts", time.Now())
+	respBody, err := doTokenRequest(a.HTTPClient, req, "AWS web identity token")
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Token string `xml:"GetWebIdentityTokenResult>WebIdentityToken"`
+	}
+	if parseErr := xml.Unmarshal(respBody, &result); parseErr != nil || result.Token == "" {
+		return "", errors.New("AWS web identity token response has no token")
+	}
+	return result.Token, nil
+}
+
+// credentials returns the AWS credentials of the environment or, without
+// them, of the instance role.
+func (a *AWSSTSTokenSource) credentials(ctx context.Context) (awsCredentials, error) {
+	if id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"); id != "" && secret != "" {
+		return awsCredentials{AccessKeyID: id, SecretAccessKey: secret, SessionToken: os.Getenv("AWS_SESSION_TOKEN")}, nil
+	}
+	roles, err := a.imdsGet(ctx, "/latest/meta-data/iam/security-credentials/")
+	if err != nil {
+		return awsCredentials{}, fmt.Errorf("no AWS credentials in the environment and no instance role: %w", err)
+	}
+	role, _, _ := strings.Cut(strings.TrimSpace(roles), "\n")
+	data, err := a.imdsGet(ctx, "/latest/meta-data/iam/security-credentials/"+role)
+	if err != nil {
+		return awsCredentials{}, err
+	}
+	var creds awsCredentials
+	if parseErr := json.Unmarshal([]byte(data), &creds); parseErr != nil || creds.AccessKeyID == "" {
+		return awsCredentials{}, fmt.Errorf("invalid credentials of the instance role %s", role)
+	}
+	return creds, nil
+}
+
+// region returns the configured region or the region of the EC2 instance.
+func (a *AWSSTSTokenSource) region(ctx context.Context) (string, error) {
+	for _, region := range []string{a.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")} {
+		if region != "" {
+			return region, nil
+		}
+	}
+	region, err := a.imdsGet(ctx, "/latest/meta-data/placement/region")
+	if err != nil {
+		return "", fmt.Errorf("set AWS_REGION; the region of the instance is unknown: %w", err)
+	}
+	return strings.TrimSpace(region), nil
+}
+
+// imdsGet reads path from the instance metadata service with an IMDSv2
+// session token.
+func (a *AWSSTSTokenSource) imdsGet(ctx context.Context, path string) (string, error) {
+	base := a.IMDSEndpoint
+	if base == "" {
+		base = os.Getenv(AWSIMDSEndpointEnv)
+	}
+	if base == "" {
+		base = defaultIMDSEndpoint
+	}
+	base = strings.TrimSuffix(base, "/")
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, base+"/latest/api/token", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", imdsTokenTTL)
+	token, err := doTokenRequest(client, req, "instance metadata session token")
+	if err != nil {
+		return "", err
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, base+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token", string(token))
+	data, err := doTokenRequest(client, req, "instance metadata "+path)
+	return string(data), err
+}
+
+// signAWSRequest signs req, whose body is body, with AWS Signature Version 4.
+// The signed headers are Host, X-Amz-Date, X-Amz-Security-Token (with a
+// session token) and Content-Type, if set.
+func signAWSRequest(req *http.Request, body []byte, creds awsCredentials, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256(body),
+	}, "\n")
+
+	scope := strings.Join([]string{amzDate[:8], region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hexSHA256([]byte(canonicalRequest))}, "\n")
+	signature := hex.EncodeToString(hmacSHA256(awsSigningKey(creds.SecretAccessKey, amzDate[:8], region, service), stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// awsSigningKey derives the Signature Version 4 signing key.
+func awsSigningKey(secret, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
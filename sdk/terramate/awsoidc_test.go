@@ -0,0 +1,86 @@
+package terramate
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAWSSigningKey(t *testing.T) {
+	// Example of the AWS Signature Version 4 documentation
+	key := awsSigningKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	if got := hex.EncodeToString(key); got != "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d" {
+		t.Fatalf("unexpected signing key %s", got)
+	}
+}
+
+func TestSignAWSRequest(t *testing.T) {
+	// get-vanilla of the AWS Signature Version 4 test suite
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	creds := awsCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signAWSRequest(req, nil, creds, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Fatalf("unexpected Authorization header:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestAWSSTSTokenSource_InstanceRole(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	token := generateMockJWT()
+
+	imds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/latest/api/token" {
+			_, _ = w.Write([]byte("session"))
+			return
+		}
+		if r.Header.Get("X-aws-ec2-metadata-token") != "session" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/latest/meta-data/iam/security-credentials/":
+			_, _ = w.Write([]byte("mcp-role"))
+		case "/latest/meta-data/iam/security-credentials/mcp-role":
+			_, _ = w.Write([]byte(`{"Code":"Success","AccessKeyId":"ASIAEXAMPLE","SecretAccessKey":"secret","Token":"session-token"}`))
+		case "/latest/meta-data/placement/region":
+			_, _ = w.Write([]byte("eu-west-1"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer imds.Close()
+
+	var authorization, audience string
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		authorization, audience = r.Header.Get("Authorization"), r.PostForm.Get("Audience.member.1")
+		if r.PostForm.Get("Action") != "GetWebIdentityToken" || r.Header.Get("X-Amz-Security-Token") != "session-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `<GetWebIdentityTokenResponse><GetWebIdentityTokenResult><WebIdentityToken>%s</WebIdentityToken></GetWebIdentityTokenResult></GetWebIdentityTokenResponse>`, token)
+	}))
+	defer sts.Close()
+
+	source := &AWSSTSTokenSource{STSEndpoint: sts.URL, IMDSEndpoint: imds.URL}
+	cred, err := NewOIDCCredential(context.Background(), "AWS workload identity", source, "")
+	if err != nil {
+		t.Fatalf("NewOIDCCredential() error = %v", err)
+	}
+	if cred.token != token || audience != DefaultOIDCAudience {
+		t.Fatalf("expected the STS token for %s, got audience %q", DefaultOIDCAudience, audience)
+	}
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=ASIAEXAMPLE/") || !strings.Contains(authorization, "/eu-west-1/sts/aws4_request") {
+		t.Fatalf("expected a request signed for the instance region, got %q", authorization)
+	}
+}
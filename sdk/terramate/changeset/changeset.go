@@ -0,0 +1,110 @@
+// Package changeset parses the JSON plans of stack previews and deployments
+// (ChangesetDetails.ChangesetJSON, the output of `terraform show -json`) into
+// typed structs. The types follow github.com/hashicorp/terraform-json, so
+// code written against its Plan reads the same against this one.
+package changeset
+
+import (
+	"errors"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate/internal/plan"
+)
+
+// ErrNoJSONPlan is returned by FromDetails when the changeset carries no JSON
+// plan, e.g. when only its ASCII format was requested.
+var ErrNoJSONPlan = errors.New("changeset has no JSON plan")
+
+// Action is an action planned on a resource.
+type Action = plan.Action
+
+// Actions planned on a resource.
+const (
+	ActionNoop   = plan.ActionNoop
+	ActionCreate = plan.ActionCreate
+	ActionRead   = plan.ActionRead
+	ActionUpdate = plan.ActionUpdate
+	ActionDelete = plan.ActionDelete
+	ActionForget = plan.ActionForget
+)
+
+// Actions are the actions planned on a resource: a single action, or a
+// delete and a create for a replacement.
+type Actions = plan.Actions
+
+// Plan is a Terraform or OpenTofu plan in its JSON format.
+type Plan plan.Plan
+
+// ResourceChange is the change planned on a resource.
+type ResourceChange = plan.ResourceChange
+
+// Change is a planned change: its actions and the values before and after.
+// Values are the decoded JSON of the attributes (maps, slices, strings,
+// float64s, bools and nils).
+type Change = plan.Change
+
+// Importing describes the import of a resource into the state.
+type Importing = plan.Importing
+
+// Parse parses a JSON plan.
+func Parse(data []byte) (*Plan, error) {
+	p, err := plan.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return (*Plan)(p), nil
+}
+
+// FromDetails parses the JSON plan of a changeset, as returned by
+// Previews.GetChangeset with terramate.ChangesetFormatJSON. It returns
+// ErrNoJSONPlan when details carries none.
+func FromDetails(details *terramate.ChangesetDetails) (*Plan, error) {
+	if details == nil || details.ChangesetJSON == "" {
+		return nil, ErrNoJSONPlan
+	}
+	return Parse([]byte(details.ChangesetJSON))
+}
+
+// Find returns the change to the resource at address, or to one of its
+// instances, in the resource changes then the resource drift of the plan.
+// No-op changes and reads are skipped. It returns nil when the resource does
+// not change.
+func (p *Plan) Find(address string) *ResourceChange {
+	return (*plan.Plan)(p).Find(address)
+}
+
+// Summary counts the resource changes of the plan by action, as the API
+// reports them in ResourceChangesActionsSummary. Imports and moves are
+// counted in addition to the action of the resource.
+func (p *Plan) Summary() terramate.ResourceChangesActionsSummary {
+	var summary terramate.ResourceChangesActionsSummary
+	for _, rc := range p.ResourceChanges {
+		if rc.Change == nil {
+			continue
+		}
+		actions := rc.Change.Actions
+		switch {
+		case actions.NoOp():
+			summary.NoopCount++
+		case actions.Create():
+			summary.CreateCount++
+		case actions.Read():
+			summary.ReadCount++
+		case actions.Update():
+			summary.UpdateCount++
+		case actions.Delete():
+			summary.DeleteCount++
+		case actions.Replace():
+			summary.ReplaceCount++
+		case actions.Forget():
+			summary.ForgetCount++
+		}
+		if rc.Change.Importing != nil {
+			summary.ImportCount++
+		}
+		if rc.Moved() {
+			summary.MoveCount++
+		}
+	}
+	return summary
+}
@@ -0,0 +1,60 @@
+package changeset
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+const testPlan = `{
+	"format_version": "1.2",
+	"terraform_version": "1.9.5",
+	"resource_changes": [
+		{"address": "aws_instance.web", "mode": "managed", "type": "aws_instance", "name": "web",
+		 "change": {"actions": ["update"], "before": {"instance_type": "t3.micro"}, "after": {"instance_type": "t3.small"}}},
+		{"address": "aws_db_instance.main", "mode": "managed", "type": "aws_db_instance", "name": "main",
+		 "change": {"actions": ["delete", "create"], "before": {"engine_version": "15"}, "after": {"engine_version": "16"}},
+		 "action_reason": "replace_because_cannot_update"},
+		{"address": "aws_s3_bucket.logs[\"eu\"]", "previous_address": "aws_s3_bucket.logs", "index": "eu",
+		 "change": {"actions": ["no-op"]}},
+		{"address": "aws_iam_role.ci", "change": {"actions": ["no-op"], "importing": {"id": "ci"}}},
+		{"address": "data.aws_ami.ubuntu", "mode": "data", "change": {"actions": ["read"]}}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	plan, err := FromDetails(&terramate.ChangesetDetails{ChangesetJSON: testPlan})
+	if err != nil {
+		t.Fatalf("FromDetails error: %v", err)
+	}
+	if plan.TerraformVersion != "1.9.5" || len(plan.ResourceChanges) != 5 {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+
+	db := plan.ResourceChanges[1]
+	if !db.Change.Actions.Replace() || !db.Change.Actions.DestroyBeforeCreate() || db.Change.Actions.CreateBeforeDestroy() {
+		t.Errorf("expected a destroy-before-create replacement, got %v", db.Change.Actions)
+	}
+	if after, ok := db.Change.After.(map[string]interface{}); !ok || after["engine_version"] != "16" {
+		t.Errorf("unexpected after value: %#v", db.Change.After)
+	}
+	if !plan.ResourceChanges[2].Moved() || plan.ResourceChanges[0].Moved() {
+		t.Error("expected only the bucket to have moved")
+	}
+
+	summary := plan.Summary()
+	want := terramate.ResourceChangesActionsSummary{UpdateCount: 1, ReplaceCount: 1, NoopCount: 2, ReadCount: 1, ImportCount: 1, MoveCount: 1}
+	if summary != want {
+		t.Errorf("expected summary %+v, got %+v", want, summary)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	if _, err := FromDetails(&terramate.ChangesetDetails{ChangesetASCII: "Plan: 1 to add"}); !errors.Is(err, ErrNoJSONPlan) {
+		t.Errorf("expected ErrNoJSONPlan, got %v", err)
+	}
+	if _, err := Parse([]byte(`{"resource_changes": [`)); err == nil {
+		t.Error("expected an error for truncated JSON")
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/terramate-io/terramate-mcp-server/internal/version"
@@ -19,6 +21,9 @@ const (
 	defaultTimeout = 30 * time.Second
 )
 
+// Version is the version of the SDK, sent in the User-Agent header.
+const Version = version.Version
+
 // contextKey is a type for context keys to avoid collisions
 type contextKey string
 
@@ -42,14 +47,46 @@ type Client struct {
 	// User agent for requests
 	userAgent string
 
+	// requestSem bounds the number of in-flight HTTP requests when set
+	// (see WithMaxConcurrentRequests). A nil channel means unlimited.
+	requestSem chan struct{}
+
+	// Hooks observing requests, retries and refreshes (see WithInstrumentation)
+	instrumentation Instrumentation
+
+	// debugLogger receives the debug logs of requests (see WithDebugLogging)
+	debugLogger *slog.Logger
+
+	// Services found unavailable by ProbeServices
+	services probedServices
+
+	// maxResponseBytes bounds the size of a response body (see WithMaxResponseBytes)
+	maxResponseBytes int64
+
+	// actAs is the subject requests act as (see WithActAs)
+	actAs string
+
+	// rate is the rate limit of the latest response reporting one
+	rate atomic.Pointer[RateLimit]
+
 	// Services
 	Memberships    *MembershipsService
+	Members        *MembersService
+	Teams          *TeamsService
 	Stacks         *StacksService
 	Drifts         *DriftsService
 	ReviewRequests *ReviewRequestsService
 	Deployments    *DeploymentsService
 	Previews       *PreviewsService
 	Resources      *ResourcesService
+	Organizations  *OrganizationsService
+	Alerts         *AlertsService
+	Notifications  *NotificationsService
+	Webhooks       *WebhooksService
+	Summaries      *SummariesService
+	Usage          *UsageService
+	Events         *EventsService
+	Metrics        *MetricsService
 }
 
 // ClientOption is a functional option for configuring the Client
@@ -71,9 +108,11 @@ func NewClient(credential Credential, opts ...ClientOption) (*Client, error) {
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
-		baseURL:    baseURL,
-		credential: credential,
-		userAgent:  version.UserAgent(),
+		baseURL:          baseURL,
+		credential:       credential,
+		userAgent:        version.UserAgent(),
+		instrumentation:  NopInstrumentation{},
+		maxResponseBytes: DefaultMaxResponseBytes,
 	}
 
 	// Apply options
@@ -82,15 +121,28 @@ func NewClient(credential Credential, opts ...ClientOption) (*Client, error) {
 			return nil, fmt.Errorf("failed to apply client option: %w", err)
 		}
 	}
+	if client.debugLogger != nil {
+		client.instrumentation = instrumentations{client.instrumentation, debugInstrumentation{logger: client.debugLogger}}
+	}
 
 	// Initialize services
 	client.Memberships = &MembershipsService{client: client}
+	client.Members = &MembersService{client: client}
+	client.Teams = &TeamsService{client: client}
 	client.Stacks = &StacksService{client: client}
 	client.Drifts = &DriftsService{client: client}
 	client.ReviewRequests = &ReviewRequestsService{client: client}
 	client.Deployments = &DeploymentsService{client: client}
 	client.Previews = &PreviewsService{client: client}
 	client.Resources = &ResourcesService{client: client}
+	client.Organizations = &OrganizationsService{client: client}
+	client.Alerts = &AlertsService{client: client}
+	client.Notifications = &NotificationsService{client: client}
+	client.Webhooks = &WebhooksService{client: client}
+	client.Summaries = &SummariesService{client: client}
+	client.Usage = &UsageService{client: client}
+	client.Events = &EventsService{client: client}
+	client.Metrics = &MetricsService{client: client}
 
 	return client, nil
 }
@@ -138,13 +190,8 @@ func WithRegion(region string) ClientOption {
 		if region == "" {
 			return nil
 		}
-		var base string
-		switch region {
-		case "us":
-			base = "https://us.api.terramate.io"
-		case "eu":
-			base = "https://api.terramate.io"
-		default:
+		base, ok := regionBaseURLs[region]
+		if !ok {
 			return fmt.Errorf("invalid region: %q", region)
 		}
 		u, err := url.Parse(base)
@@ -175,6 +222,52 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithMaxConcurrentRequests limits how many HTTP requests the client may have
+// in flight at the same time. Additional requests wait for a free slot (or for
+// their context to be canceled). Zero means unlimited.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 0 {
+			return fmt.Errorf("max concurrent requests cannot be negative")
+		}
+		if n == 0 {
+			c.requestSem = nil
+			return nil
+		}
+		c.requestSem = make(chan struct{}, n)
+		return nil
+	}
+}
+
+// WithMaxResponseBytes bounds the size of a response body. Larger responses
+// fail with a *ResponseTooLargeError. A non-positive n keeps
+// DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return func(c *Client) error {
+		if n > 0 {
+			c.maxResponseBytes = n
+		}
+		return nil
+	}
+}
+
+// BaseURL returns the API base URL requests are sent to, e.g. the one of the
+// region selected with WithRegion.
+func (c *Client) BaseURL() string {
+	return c.baseURL.String()
+}
+
+// Region returns the region whose API the client uses, or "" when the base
+// URL is not the one of a region, e.g. one set with WithBaseURL.
+func (c *Client) Region() string {
+	for region, base := range regionBaseURLs {
+		if strings.TrimSuffix(c.BaseURL(), "/") == base {
+			return region
+		}
+	}
+	return ""
+}
+
 //nolint:unparam // method parameter will be used with different HTTP methods as SDK grows
 func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
 	// Build full URL
@@ -223,9 +316,15 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body io.Re
 	req.Header.Set("User-Agent", c.userAgent)
 	req.Header.Set("Content-Type", contentTypeJSON)
 	req.Header.Set("Accept", contentTypeJSON)
+	if id := CorrelationID(ctx); id != "" {
+		req.Header.Set(CorrelationIDHeader, id)
+	}
+	if c.actAs != "" {
+		req.Header.Set(ActAsHeader, c.actAs)
+	}
 
 	// Apply credentials (JWT Bearer token or API Key Basic Auth)
-	if err := c.credential.ApplyCredentials(req); err != nil {
+	if err := c.credentialFor(ctx).ApplyCredentials(req); err != nil {
 		return nil, fmt.Errorf("failed to apply credentials: %w", err)
 	}
 
@@ -236,23 +335,20 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body io.Re
 // If the request fails with 401 Unauthorized and the client uses JWT authentication,
 // it attempts to refresh the token and retry the request once.
 func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
-	const maxBodyBytes = 10 << 20 // 10 MiB
-	resp, err := c.executeRequestWithRetries(req, 3)
+	resp, body, err := c.roundTrip(req)
 	if err != nil {
 		return nil, err
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	response := &Response{HTTPResponse: resp, Body: body, Rate: parseRateLimit(resp.Header, time.Now())}
+	if response.Rate != nil {
+		c.rate.Store(response.Rate)
 	}
 
-	response := &Response{HTTPResponse: resp, Body: body}
-
 	// Handle 401 Unauthorized - attempt token refresh if using JWT
 	if resp.StatusCode == http.StatusUnauthorized {
-		if refreshableCred, ok := c.credential.(RefreshableCredential); ok {
+		credential := c.credentialFor(req.Context())
+		if refreshableCred, ok := credential.(RefreshableCredential); ok {
 			// Check retry count to prevent unbounded recursion
 			retryCount := 0
 			if count, ok := req.Context().Value(retryCountKey).(int); ok {
@@ -264,14 +360,20 @@ func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
 			}
 
 			// Try to refresh the token
+			refreshStart := time.Now()
 			refreshErr := refreshableCred.Refresh(req.Context())
+			c.instrumentation.OnRefresh(req.Context(), RefreshInfo{
+				Credential: credential.Name(),
+				Duration:   time.Since(refreshStart),
+				Err:        refreshErr,
+			})
 			if refreshErr == nil {
 				// Token refreshed successfully - retry the request
 				// Clone the request to avoid reusing the body
 				retryReq, cloneErr := cloneRequest(req)
 				if cloneErr == nil {
 					// Apply the new credentials
-					if applyErr := c.credential.ApplyCredentials(retryReq); applyErr == nil {
+					if applyErr := credential.ApplyCredentials(retryReq); applyErr == nil {
 						// Increment retry count in context to prevent infinite recursion
 						retryCtx := context.WithValue(retryReq.Context(), retryCountKey, retryCount+1)
 						retryReq = retryReq.WithContext(retryCtx)
@@ -288,6 +390,8 @@ func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
 						"Authentication failed and automatic token refresh was unsuccessful: %v",
 						refreshErr,
 					),
+					RequestID:     requestID(resp.Header),
+					CorrelationID: req.Header.Get(CorrelationIDHeader),
 				}
 			}
 		}
@@ -310,6 +414,68 @@ func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
 	return response, nil
 }
 
+// roundTrip sends the request (with transient-error retries) and reads the
+// response body. The concurrency slot is held only for the duration of the
+// network exchange so that the 401 refresh-and-retry path in do() never
+// waits on a slot it already owns.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, []byte, error) {
+	if err := c.acquireRequestSlot(req.Context()); err != nil {
+		return nil, nil, err
+	}
+	defer c.releaseRequestSlot()
+
+	start := time.Now()
+	c.instrumentation.OnRequestStart(req.Context(), req)
+	resp, body, err := c.readResponse(req, c.maxResponseBytes)
+	info := RequestInfo{Method: req.Method, Path: req.URL.Path, Query: req.URL.RawQuery, Duration: time.Since(start), Err: err}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+	}
+	c.instrumentation.OnRequestEnd(req.Context(), info)
+
+	return resp, body, err
+}
+
+// readResponse executes the request and reads the body, failing with a
+// *ResponseTooLargeError if it is larger than maxBodyBytes.
+func (c *Client) readResponse(req *http.Request, maxBodyBytes int64) (*http.Response, []byte, error) {
+	resp, err := c.executeRequestWithRetries(req, 3)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes+1))
+	if err != nil {
+		return resp, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(body)) > maxBodyBytes {
+		return resp, nil, &ResponseTooLargeError{Method: req.Method, Path: req.URL.Path, Limit: maxBodyBytes}
+	}
+
+	return resp, body, nil
+}
+
+// acquireRequestSlot blocks until a concurrency slot is available or ctx is done.
+func (c *Client) acquireRequestSlot(ctx context.Context) error {
+	if c.requestSem == nil {
+		return nil
+	}
+	select {
+	case c.requestSem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for request slot: %w", ctx.Err())
+	}
+}
+
+// releaseRequestSlot frees a slot taken by acquireRequestSlot.
+func (c *Client) releaseRequestSlot() {
+	if c.requestSem != nil {
+		<-c.requestSem
+	}
+}
+
 // cloneRequest creates a clone of an HTTP request for retry purposes.
 // This is necessary because http.Request.Body can only be read once.
 func cloneRequest(req *http.Request) (*http.Request, error) {
@@ -337,38 +503,66 @@ func cloneRequest(req *http.Request) (*http.Request, error) {
 
 func (c *Client) executeRequestWithRetries(req *http.Request, maxRetries int) (*http.Response, error) {
 	isIdempotent := req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions
-	for attempt := 0; attempt <= maxRetries; attempt++ {
+	for attempt := 0; ; attempt++ {
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			if isIdempotent && attempt < maxRetries && req.Context().Err() == nil {
-				if wait := backoffForAttempt(attempt); !sleepOrCtxDone(req.Context(), wait) {
+				wait := backoffForAttempt(attempt)
+				c.notifyRetry(req, RetryInfo{Attempt: attempt, Wait: wait, Err: err})
+				if !sleepOrCtxDone(req.Context(), wait) {
 					continue
 				}
 			}
 			return nil, fmt.Errorf("request failed: %w", err)
 		}
-		if isIdempotent && shouldRetryStatus(resp.StatusCode) {
-			if attempt < maxRetries {
+		if isIdempotent && shouldRetryStatus(resp.StatusCode) && attempt < maxRetries {
+			wait, advertised := retryWait(resp, attempt)
+			if wait <= maxRetryAfter {
 				_ = resp.Body.Close()
-				if wait := backoffForAttempt(attempt); sleepOrCtxDone(req.Context(), wait) {
+				c.notifyRetry(req, RetryInfo{Attempt: attempt, Wait: wait, StatusCode: resp.StatusCode, RetryAfter: advertised})
+				if sleepOrCtxDone(req.Context(), wait) {
 					// Context was canceled during backoff
 					return nil, req.Context().Err()
 				}
 				continue
 			}
-			// On final attempt with retryable status, return error
-			_ = resp.Body.Close()
-			return nil, fmt.Errorf("request failed with status %d after %d retries", resp.StatusCode, maxRetries)
 		}
+		// A retryable status on the final attempt, or asking to wait longer
+		// than maxRetryAfter, is returned as is, so that its APIError carries
+		// the status, request ID and Retry-After
 		return resp, nil
 	}
-	return nil, fmt.Errorf("exceeded retry attempts")
+}
+
+// notifyRetry reports a retry decision about req to the configured
+// instrumentation.
+func (c *Client) notifyRetry(req *http.Request, info RetryInfo) {
+	info.Method = req.Method
+	info.Path = req.URL.Path
+	c.instrumentation.OnRetry(req.Context(), info)
 }
 
 func shouldRetryStatus(code int) bool {
 	return code == http.StatusTooManyRequests || (code >= 500 && code < 600)
 }
 
+// maxRetryAfter is the longest Retry-After the client waits before retrying.
+// Longer waits fail the request with an APIError carrying RetryAfter rather
+// than holding a concurrency slot, possibly without a deadline.
+const maxRetryAfter = 30 * time.Second
+
+// retryWait returns how long to wait before retrying after resp: the
+// Retry-After of a 429 response when it advertises one, the exponential
+// backoff otherwise. The second result reports whether Retry-After was used.
+func retryWait(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if wait := retryAfter(resp.Header, time.Now()); wait > 0 {
+			return wait, true
+		}
+	}
+	return backoffForAttempt(attempt), false
+}
+
 func backoffForAttempt(attempt int) time.Duration {
 	return time.Duration(100*(1<<attempt)) * time.Millisecond
 }
@@ -387,6 +581,11 @@ func parseAPIError(resp *http.Response, body []byte) error {
 	apiErr := &APIError{
 		StatusCode: resp.StatusCode,
 		Message:    fmt.Sprintf("API request failed with status %d", resp.StatusCode),
+		RequestID:  requestID(resp.Header),
+		RetryAfter: retryAfter(resp.Header, time.Now()),
+	}
+	if resp.Request != nil {
+		apiErr.CorrelationID = resp.Request.Header.Get(CorrelationIDHeader)
 	}
 
 	// Try to parse JSON error response safely
@@ -439,6 +638,8 @@ func isJSONContentType(ct string) bool {
 type Response struct {
 	HTTPResponse *http.Response
 	Body         []byte
+	// Rate is the rate limit reported by the response headers, nil if none.
+	Rate *RateLimit
 }
 
 // Query builder helper functions
@@ -67,6 +67,7 @@ func TestClient_401RetryWithRefresh(t *testing.T) {
 
 	t.Run("handles refresh failure", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request-Id", "req-401")
 			w.WriteHeader(http.StatusUnauthorized)
 			_ = json.NewEncoder(w).Encode(map[string]string{"error": "unauthorized"})
 		}))
@@ -85,7 +86,7 @@ func TestClient_401RetryWithRefresh(t *testing.T) {
 		}
 
 		// Make request
-		req, _ := client.newRequest(context.Background(), "GET", "/test", nil)
+		req, _ := client.newRequest(WithCorrelationID(context.Background(), "CHG-1234"), "GET", "/test", nil)
 		_, err = client.do(req, nil)
 
 		if err == nil {
@@ -101,6 +102,9 @@ func TestClient_401RetryWithRefresh(t *testing.T) {
 		if apiErr.StatusCode != http.StatusUnauthorized {
 			t.Errorf("expected 401, got %d", apiErr.StatusCode)
 		}
+		if apiErr.RequestID != "req-401" || apiErr.CorrelationID != "CHG-1234" {
+			t.Errorf("expected the request and correlation IDs of the 401, got %q and %q", apiErr.RequestID, apiErr.CorrelationID)
+		}
 
 		t.Log("✓ Returns 401 error when refresh fails")
 	})
@@ -2,6 +2,7 @@ package terramate
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"sync/atomic"
@@ -39,6 +40,122 @@ func TestClient_RetriesOn429(t *testing.T) {
 	}
 }
 
+func TestClient_RateLimitedAfterRetries(t *testing.T) {
+	attempts := atomic.Int32{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 4 {
+			// advertised on the last attempt only, which is not retried
+			w.Header().Set("Retry-After", "30")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":"rate limit exceeded"}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL), WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	_, _, err = c.Memberships.List(context.Background())
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got: %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != 30*time.Second || apiErr.RequestID != "req-123" || apiErr.Message != "rate limit exceeded" {
+		t.Fatalf("unexpected API error: %#v", err)
+	}
+	if attempts.Load() != 4 {
+		t.Fatalf("expected 4 attempts, got: %d", attempts.Load())
+	}
+}
+
+func TestClient_HonorsRetryAfter(t *testing.T) {
+	attempts := atomic.Int32{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	rec := &recordingInstrumentation{}
+	c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL), WithInstrumentation(rec))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	start := time.Now()
+	if _, _, err = c.Memberships.List(context.Background()); err != nil {
+		t.Fatalf("expected success after retry, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected to wait the advertised second, waited %s", elapsed)
+	}
+	if len(rec.retries) != 1 || rec.retries[0].Wait != time.Second || !rec.retries[0].RetryAfter {
+		t.Fatalf("unexpected retries: %+v", rec.retries)
+	}
+}
+
+func TestClient_RetryAfterBoundedByContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err = c.Memberships.List(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context deadline, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the wait to end with the context, waited %s", elapsed)
+	}
+}
+
+func TestClient_RetryAfterAboveCap(t *testing.T) {
+	attempts := atomic.Int32{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	rec := &recordingInstrumentation{}
+	c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL), WithInstrumentation(rec))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	start := time.Now()
+	_, _, err = c.Memberships.List(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrRateLimited) || apiErr.RetryAfter != time.Hour {
+		t.Fatalf("expected a rate limit error asking to wait an hour, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected no wait above the cap, waited %s", elapsed)
+	}
+	if attempts.Load() != 1 || len(rec.retries) != 0 {
+		t.Fatalf("expected a single attempt without retries, got %d attempts and %+v", attempts.Load(), rec.retries)
+	}
+}
+
 func TestClient_RetriesOn500(t *testing.T) {
 	attempts := atomic.Int32{}
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -3,9 +3,12 @@ package terramate
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -58,6 +61,17 @@ func TestWithRegion_SetsExpectedBaseURL(t *testing.T) {
 	if got := cUS.baseURL.String(); got != "https://us.api.terramate.io" {
 		t.Fatalf("us baseURL: %s", got)
 	}
+	if cEU.Region() != RegionEU || cUS.Region() != RegionUS {
+		t.Fatalf("expected regions eu and us, got %q and %q", cEU.Region(), cUS.Region())
+	}
+
+	custom, err := NewClientWithAPIKey("k", WithBaseURL("https://terramate.example.com"))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	if got := custom.Region(); got != "" {
+		t.Fatalf("expected no region for a custom base URL, got %q", got)
+	}
 }
 
 func TestDo_ParsesAPIErrorJSON(t *testing.T) {
@@ -106,6 +120,38 @@ func TestDo_Handles204NoContent(t *testing.T) {
 	}
 }
 
+func TestWithMaxResponseBytes_RejectsLargerResponses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"` + strings.Repeat("x", 100) + `"}`))
+	}))
+	defer ts.Close()
+	get := func(limit int64) error {
+		c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL), WithMaxResponseBytes(limit))
+		if err != nil {
+			t.Fatalf("NewClient error: %v", err)
+		}
+		req, err := c.newRequest(context.Background(), http.MethodGet, "/x", nil)
+		if err != nil {
+			t.Fatalf("newRequest: %v", err)
+		}
+		var v map[string]string
+		_, err = c.do(req, &v)
+		return err
+	}
+
+	err := get(64)
+	var tooLarge *ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("expected a ResponseTooLargeError, got %v", err)
+	}
+	if tooLarge.Limit != 64 || tooLarge.Path != "/x" {
+		t.Fatalf("unexpected error details: %+v", tooLarge)
+	}
+	if err := get(0); err != nil {
+		t.Fatalf("expected the default limit to fit the response, got %v", err)
+	}
+}
+
 func TestNewClientWithJWT_SetsBearerAuth(t *testing.T) {
 	jwtToken := generateTestJWT(time.Now().Add(1 * time.Hour))
 
@@ -172,3 +218,87 @@ func TestNewClientWithJWT_ExpiredTokenSentToAPI(t *testing.T) {
 		t.Errorf("expected error to mention 'terramate cloud login', got: %v", errMsg)
 	}
 }
+
+func TestWithMaxConcurrentRequests_BoundsInFlight(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL), WithMaxConcurrentRequests(2))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := c.Memberships.List(context.Background()); err != nil {
+				t.Errorf("List error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", got)
+	}
+}
+
+func TestWithMaxConcurrentRequests_RespectsContext(t *testing.T) {
+	c, err := NewClientWithAPIKey("key", WithMaxConcurrentRequests(1))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	// Occupy the only slot.
+	c.requestSem <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, _, err = c.Memberships.List(ctx)
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded while waiting for slot, got: %v", err)
+	}
+}
+
+func TestWithMaxConcurrentRequests_RejectsNegative(t *testing.T) {
+	if _, err := NewClientWithAPIKey("key", WithMaxConcurrentRequests(-1)); err == nil {
+		t.Fatal("expected error for negative limit")
+	}
+}
+
+func TestNewRequest_SetsActAsHeader(t *testing.T) {
+	var got []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get(ActAsHeader))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	for _, subject := range []string{"dev@example.com", ""} {
+		c, err := NewClientWithAPIKey("test-key", WithBaseURL(ts.URL), WithActAs(subject))
+		if err != nil {
+			t.Fatalf("NewClient error: %v", err)
+		}
+		if _, _, err := c.Memberships.List(context.Background()); err != nil {
+			t.Fatalf("List memberships error: %v", err)
+		}
+	}
+	if len(got) != 2 || got[0] != "dev@example.com" || got[1] != "" {
+		t.Fatalf("unexpected act-as headers: %q", got)
+	}
+}
@@ -0,0 +1,25 @@
+package terramate
+
+import "context"
+
+// CorrelationIDHeader carries the caller's correlation ID on API requests.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// correlationIDKey is the context key of the correlation ID.
+const correlationIDKey contextKey = "correlation_id"
+
+// WithCorrelationID returns a context whose API requests carry id in the
+// X-Correlation-ID header, so they can be tied to the caller's own ticket or
+// workflow. An empty id leaves ctx unchanged.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, correlationIDKey, id)
+}
+
+// CorrelationID returns the correlation ID attached to ctx, or "".
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey).(string)
+	return id
+}
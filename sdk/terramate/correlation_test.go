@@ -0,0 +1,49 @@
+package terramate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewRequest_SetsCorrelationIDHeader(t *testing.T) {
+	var got []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get(CorrelationIDHeader))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithAPIKey("test-key", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	if _, _, err := c.Memberships.List(WithCorrelationID(context.Background(), "CHG-1234")); err != nil {
+		t.Fatalf("List memberships error: %v", err)
+	}
+	if _, _, err := c.Memberships.List(context.Background()); err != nil {
+		t.Fatalf("List memberships error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "CHG-1234" || got[1] != "" {
+		t.Fatalf("unexpected correlation ID headers: %q", got)
+	}
+}
+
+func TestWithCorrelationID(t *testing.T) {
+	ctx := context.Background()
+	if got := WithCorrelationID(ctx, ""); got != ctx {
+		t.Fatal("expected an empty correlation ID to leave the context unchanged")
+	}
+	outer := WithCorrelationID(ctx, "CHG-1")
+	if got := CorrelationID(WithCorrelationID(outer, "CHG-2")); got != "CHG-2" {
+		t.Fatalf("expected the innermost correlation ID, got %q", got)
+	}
+	if got := CorrelationID(WithCorrelationID(outer, "")); got != "CHG-1" {
+		t.Fatalf("expected an empty correlation ID to keep the outer one, got %q", got)
+	}
+	if got := CorrelationID(ctx); got != "" {
+		t.Fatalf("expected no correlation ID, got %q", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -43,6 +44,9 @@ func TestJWTCredential_Refresh(t *testing.T) {
 	t.Run("concurrent refresh attempts", testJWTCredentialRefreshConcurrent)
 	t.Run("waitForRefresh waits for slow refresh", testJWTCredentialRefreshWaitForRefresh)
 	t.Run("waitForRefresh respects context cancellation", testJWTCredentialRefreshContextCancellation)
+	t.Run("stuck refresh is abandoned", testJWTCredentialRefreshStuck)
+	t.Run("abandoned refresh finishing after its successor started", testJWTCredentialRefreshSuperseded)
+	t.Run("panicking refresh releases the lock", testJWTCredentialRefreshPanic)
 }
 
 func TestIDPKey_Default(t *testing.T) {
@@ -67,13 +71,13 @@ func TestMakeRefreshRequest_UsesConfiguredIDPKeyInEndpoint(t *testing.T) {
 	t.Setenv("TMC_API_IDP_KEY", expectedIDPKey)
 
 	rt := &captureRoundTripper{}
-	cred := &JWTCredential{
-		httpClient: &http.Client{
+	transport := &FirebaseRefreshTransport{
+		HTTPClient: &http.Client{
 			Transport: rt,
 		},
 	}
 
-	resp, _, err := cred.makeRefreshRequest(context.Background(), "refresh-token")
+	resp, _, err := transport.makeRefreshRequest(context.Background(), "refresh-token")
 	if err != nil {
 		t.Fatalf("expected refresh request to succeed, got error: %v", err)
 	}
@@ -113,14 +117,13 @@ func testJWTCredentialRefreshSuccessful(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Create credential with injected HTTP client and endpoint for testing
+	// Create credential with a refresh transport pointing at the mock server
 	oldToken := generateMockJWT()
 	cred := &JWTCredential{
-		idToken:         oldToken,
-		refreshToken:    "old-refresh-token-123",
-		provider:        "Google",
-		httpClient:      server.Client(),
-		refreshEndpoint: server.URL + "/v1/token",
+		idToken:          oldToken,
+		refreshToken:     "old-refresh-token-123",
+		provider:         "Google",
+		refreshTransport: &FirebaseRefreshTransport{Endpoint: server.URL + "/v1/token", HTTPClient: server.Client()},
 	}
 
 	ctx := context.Background()
@@ -201,13 +204,12 @@ func testJWTCredentialRefreshWaitForRefresh(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Create credential with refresh token and injected HTTP client/endpoint for testing
+	// Create credential with refresh token and a refresh transport pointing at the mock server
 	cred := &JWTCredential{
-		idToken:         generateMockJWT(),
-		refreshToken:    "test-refresh-token",
-		provider:        "Google",
-		httpClient:      server.Client(),
-		refreshEndpoint: server.URL + "/v1/token",
+		idToken:          generateMockJWT(),
+		refreshToken:     "test-refresh-token",
+		provider:         "Google",
+		refreshTransport: &FirebaseRefreshTransport{Endpoint: server.URL + "/v1/token", HTTPClient: server.Client()},
 	}
 
 	// Start a refresh that will take time (200ms delay from mock server)
@@ -283,13 +285,12 @@ func testJWTCredentialRefreshContextCancellation(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Create a credential with refresh token and injected HTTP client/endpoint for testing
+	// Create a credential with refresh token and a refresh transport pointing at the mock server
 	cred := &JWTCredential{
-		idToken:         "test-token",
-		refreshToken:    "test-refresh-token",
-		provider:        "Google",
-		httpClient:      server.Client(),
-		refreshEndpoint: server.URL + "/v1/token",
+		idToken:          "test-token",
+		refreshToken:     "test-refresh-token",
+		provider:         "Google",
+		refreshTransport: &FirebaseRefreshTransport{Endpoint: server.URL + "/v1/token", HTTPClient: server.Client()},
 	}
 
 	// Start a slow refresh that will take a while
@@ -353,12 +354,154 @@ func testJWTCredentialRefreshContextCancellation(t *testing.T) {
 	}
 }
 
+// refreshTransportFunc adapts a function to RefreshTransport.
+type refreshTransportFunc func(ctx context.Context, refreshToken string) (*RefreshedTokens, error)
+
+func (f refreshTransportFunc) RefreshTokens(ctx context.Context, refreshToken string) (*RefreshedTokens, error) {
+	return f(ctx, refreshToken)
+}
+
+// testJWTCredentialRefreshStuck verifies that a refresh that never returns
+// does not block later refreshes: the watchdog releases the waiting requests
+// and resets the refresh state.
+func testJWTCredentialRefreshStuck(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+	var calls int
+	var mu sync.Mutex
+	cred := &JWTCredential{
+		idToken:            generateMockJWT(),
+		refreshToken:       "test-refresh-token",
+		provider:           "Google",
+		maxRefreshDuration: 50 * time.Millisecond,
+		refreshTransport: refreshTransportFunc(func(ctx context.Context, _ string) (*RefreshedTokens, error) {
+			mu.Lock()
+			calls++
+			first := calls == 1
+			mu.Unlock()
+			if first {
+				<-unblock // wedged: ignores its context
+			}
+			return &RefreshedTokens{IDToken: "new-token"}, nil
+		}),
+	}
+
+	go func() { _ = cred.Refresh(context.Background()) }()
+	for i := 0; i < 100; i++ {
+		cred.mu.RLock()
+		refreshing := cred.refreshing
+		cred.mu.RUnlock()
+		if refreshing {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	err := cred.Refresh(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "did not complete within 50ms") {
+		t.Fatalf("expected waiting refresh to fail with the watchdog error, got %v", err)
+	}
+
+	if err := cred.Refresh(context.Background()); err != nil {
+		t.Fatalf("expected a new refresh to succeed after the stuck one was abandoned, got %v", err)
+	}
+	cred.mu.RLock()
+	defer cred.mu.RUnlock()
+	if cred.idToken != "new-token" || cred.refreshing {
+		t.Fatalf("unexpected state after refresh: token %q, refreshing %v", cred.idToken, cred.refreshing)
+	}
+}
+
+// testJWTCredentialRefreshSuperseded verifies that an abandoned refresh whose
+// transport returns after a later refresh started changes neither the tokens
+// nor the last refresh error.
+func testJWTCredentialRefreshSuperseded(t *testing.T) {
+	started := make(chan int, 2)
+	release := []chan struct{}{make(chan struct{}), make(chan struct{})}
+	var calls int
+	var mu sync.Mutex
+	cred := &JWTCredential{
+		idToken:            generateMockJWT(),
+		refreshToken:       "test-refresh-token",
+		provider:           "Google",
+		maxRefreshDuration: 200 * time.Millisecond,
+		refreshTransport: refreshTransportFunc(func(context.Context, string) (*RefreshedTokens, error) {
+			mu.Lock()
+			call := calls
+			calls++
+			mu.Unlock()
+			started <- call
+			<-release[call] // ignores its context, like a wedged transport
+			return &RefreshedTokens{IDToken: []string{"stale-token", "new-token"}[call], RefreshToken: "rotated"}, nil
+		}),
+	}
+	state := func() (string, error) {
+		cred.mu.RLock()
+		defer cred.mu.RUnlock()
+		return cred.idToken, cred.lastRefreshErr
+	}
+	original, _ := state()
+
+	abandoned := make(chan error, 1)
+	go func() { abandoned <- cred.Refresh(context.Background()) }()
+	<-started
+	if err := cred.Refresh(context.Background()); err == nil || !strings.Contains(err.Error(), "did not complete within") {
+		t.Fatalf("expected waiting refresh to fail with the watchdog error, got %v", err)
+	}
+
+	successor := make(chan error, 1)
+	go func() { successor <- cred.Refresh(context.Background()) }()
+	<-started
+	close(release[0])
+	if err := <-abandoned; !errors.Is(err, errRefreshSuperseded) {
+		t.Fatalf("expected the abandoned refresh to be superseded, got %v", err)
+	}
+	token, lastErr := state()
+	if token != original {
+		t.Fatalf("abandoned refresh replaced the token with %q", token)
+	}
+	if lastErr == nil || !strings.Contains(lastErr.Error(), "did not complete within") {
+		t.Fatalf("abandoned refresh overwrote the last refresh error: %v", lastErr)
+	}
+
+	close(release[1])
+	if err := <-successor; err != nil {
+		t.Fatalf("successor refresh error: %v", err)
+	}
+	if token, lastErr = state(); token != "new-token" || lastErr != nil {
+		t.Fatalf("expected the successor's token, got %q, %v", token, lastErr)
+	}
+}
+
+// testJWTCredentialRefreshPanic verifies that a panicking refresh transport
+// surfaces as an error and leaves the credential able to refresh again.
+func testJWTCredentialRefreshPanic(t *testing.T) {
+	cred := &JWTCredential{
+		idToken:      generateMockJWT(),
+		refreshToken: "test-refresh-token",
+		provider:     "Google",
+		refreshTransport: refreshTransportFunc(func(context.Context, string) (*RefreshedTokens, error) {
+			panic("boom")
+		}),
+	}
+
+	err := cred.Refresh(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected panic to surface as an error, got %v", err)
+	}
+	if _, ok := cred.acquireRefreshLock(); !ok {
+		t.Fatal("expected refresh lock to be released after the panic")
+	}
+}
+
 func TestJWTCredential_StartWatching(t *testing.T) {
 	t.Run("watches file for changes", testStartWatchingFileChanges)
 	t.Run("handles atomic file replacement via rename", testStartWatchingAtomicRename)
 	t.Run("handles missing credential path", testStartWatchingMissingPath)
 	t.Run("can restart watching after stop", testStartWatchingRestart)
 	t.Run("watcher works after stop and restart", testStartWatchingWorksAfterRestart)
+	t.Run("handles deletion and recreation of the file", testStartWatchingFileRecreated)
+	t.Run("handles deletion and recreation of the directory", testStartWatchingDirectoryRecreated)
 }
 
 // testStartWatchingFileChanges tests that the watcher detects file changes and reloads credentials.
@@ -719,6 +862,85 @@ func testStartWatchingWorksAfterRestart(t *testing.T) {
 	cred.StopWatching()
 }
 
+// testStartWatchingFileRecreated tests that the watcher survives the credential
+// file being deleted and recreated, e.g. by 'terramate cloud logout' and 'login',
+// and that a refresh in between does not recreate the file.
+func testStartWatchingFileRecreated(t *testing.T) {
+	cred, path := loadTestCredential(t)
+	if err := cred.StartWatching(context.Background()); err != nil {
+		t.Fatalf("failed to start watching: %v", err)
+	}
+	defer cred.StopWatching()
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	waitForCredential(t, cred, "the removal to be noticed", func() bool { return cred.fileRemoved })
+
+	cred.refreshTransport = &fakeRefreshTransport{tokens: &RefreshedTokens{IDToken: generateMockJWT()}}
+	if err := cred.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the refresh not to recreate the removed file, got %v", err)
+	}
+
+	newToken := generateMockJWT()
+	if err := os.WriteFile(path, []byte(createTestCredentialFile("Google", newToken, "refresh-token-2")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitForCredential(t, cred, "the recreated file to be reloaded", func() bool { return cred.idToken == newToken })
+}
+
+// testStartWatchingDirectoryRecreated tests that the watcher watches the
+// directory of the credential file again after it was removed and recreated.
+func testStartWatchingDirectoryRecreated(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ".terramate.d")
+	path := filepath.Join(dir, "credentials.tmrc.json")
+	write := func(token string) {
+		t.Helper()
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(createTestCredentialFile("Google", token, "refresh-token")), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(generateMockJWT())
+	cred, err := LoadJWTFromFile(path)
+	if err != nil {
+		t.Fatalf("failed to load credential: %v", err)
+	}
+	if err := cred.StartWatching(context.Background()); err != nil {
+		t.Fatalf("failed to start watching: %v", err)
+	}
+	defer cred.StopWatching()
+
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	newToken := generateMockJWT()
+	write(newToken)
+	waitForCredential(t, cred, "the credential of the recreated directory to be reloaded", func() bool { return cred.idToken == newToken })
+}
+
+// waitForCredential waits up to 5 seconds for cond, called with cred locked.
+func waitForCredential(t *testing.T, cred *JWTCredential, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		cred.mu.RLock()
+		done := cond()
+		cred.mu.RUnlock()
+		if done {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
 // getStopWatcher safely retrieves the stopWatcher channel from the credential.
 func getStopWatcher(cred *JWTCredential) chan struct{} {
 	cred.mu.RLock()
@@ -1036,3 +1258,35 @@ func generateMockJWT() string {
 
 	return h + "." + c + "." + signature
 }
+
+func TestJWTCredential_Refresh_AdoptsTokensOfAnotherProcess(t *testing.T) {
+	cred, path := loadTestCredential(t)
+	transport := &fakeRefreshTransport{tokens: &RefreshedTokens{IDToken: generateMockJWT()}}
+	cred.refreshTransport = transport
+
+	// Another process holds the lock while it refreshes and rotates the tokens
+	unlock, err := lockCredentialFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("lockCredentialFile error: %v", err)
+	}
+	refreshed := make(chan error)
+	go func() { refreshed <- cred.Refresh(context.Background()) }()
+
+	rotated := generateTestJWT(time.Now().Add(time.Hour))
+	if err := os.WriteFile(path, []byte(createTestCredentialFile("Google", rotated, "rotated-refresh-token")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+
+	if err := <-refreshed; err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	if transport.calls != 0 {
+		t.Fatalf("expected the tokens of the other process to be adopted without a refresh, got %d refreshes", transport.calls)
+	}
+	cred.mu.RLock()
+	defer cred.mu.RUnlock()
+	if cred.idToken != rotated || cred.refreshToken != "rotated-refresh-token" {
+		t.Fatal("expected the rotated tokens of the credential file")
+	}
+}
@@ -2,6 +2,7 @@ package terramate
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -84,6 +85,35 @@ func TestJWTCredential_Name(t *testing.T) {
 	}
 }
 
+func TestJWTCredential_Status(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	path := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	if err := os.WriteFile(path, []byte(createTestCredentialFile("Google", generateTestJWT(exp), "refresh-token")), 0o600); err != nil {
+		t.Fatalf("failed to write credential file: %v", err)
+	}
+	cred, err := LoadJWTFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadJWTFromFile() error = %v", err)
+	}
+	status := cred.Status()
+	if !status.ExpiresAt.Equal(exp) || !status.Refreshable || status.CredentialFile != path || status.LastRefreshError != nil {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	_ = cred.setRefreshError(cred.refreshGen, errors.New("refresh failed"))
+	if status = cred.Status(); status.LastRefreshError == nil {
+		t.Fatal("expected the last refresh error")
+	}
+
+	cred, err = NewJWTCredential(generateTestJWT(exp), "")
+	if err != nil {
+		t.Fatalf("NewJWTCredential() error = %v", err)
+	}
+	if status = cred.Status(); status.Refreshable || status.CredentialFile != "" {
+		t.Fatalf("expected a JWT without refresh token or file, got %+v", status)
+	}
+}
+
 func TestAPIKeyCredential_ApplyCredentials(t *testing.T) {
 	apiKey := "test-api-key-123"
 	cred := NewAPIKeyCredential(apiKey)
@@ -184,6 +214,23 @@ func TestLoadJWTFromFile(t *testing.T) {
 			fileContent: `{"provider": "Google", "id_token": "not-a-valid-jwt", "refresh_token": "refresh"}`,
 			expectError: true,
 		},
+		{
+			name:        "region hint",
+			fileName:    "region.json",
+			fileContent: `{"provider": "Google", "id_token": "` + generateTestJWT(time.Now().Add(1*time.Hour)) + `", "region": "us"}`,
+			expectError: false,
+			checkFunc: func(t *testing.T, cred *JWTCredential) {
+				if cred.Region() != "us" {
+					t.Errorf("Region() = %v, want us", cred.Region())
+				}
+			},
+		},
+		{
+			name:        "invalid region hint",
+			fileName:    "invalid-region.json",
+			fileContent: `{"provider": "Google", "id_token": "` + generateTestJWT(time.Now().Add(1*time.Hour)) + `", "region": "ap"}`,
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
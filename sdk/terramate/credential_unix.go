@@ -22,3 +22,9 @@ func checkCredentialFilePermissions(path string, fileInfo os.FileInfo) error {
 	}
 	return nil
 }
+
+// restrictCredentialFile restricts the credential file at path to its owner.
+// On Unix systems, os.WriteFile already created it with mode 0600.
+func restrictCredentialFile(string) error {
+	return nil
+}
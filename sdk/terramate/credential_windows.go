@@ -3,15 +3,95 @@
 package terramate
 
 import (
+	"fmt"
 	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
 )
 
+// broadGroups are the well-known groups that must not be able to read a
+// credential file, as they include other users of the machine.
+var broadGroups = map[windows.WELL_KNOWN_SID_TYPE]string{
+	windows.WinWorldSid:             "Everyone",
+	windows.WinAuthenticatedUserSid: "Authenticated Users",
+	windows.WinBuiltinUsersSid:      "Users",
+}
+
+// fileReadAccess are the access rights that allow reading a file's content.
+const fileReadAccess = windows.FILE_READ_DATA | windows.GENERIC_READ | windows.GENERIC_ALL
+
 // checkCredentialFilePermissions validates that the credential file has secure permissions
-// On Windows, we skip Unix-style permission checks as Windows uses ACLs instead
-// The Terramate CLI handles setting appropriate Windows ACLs when creating the credential file
-func checkCredentialFilePermissions(path string, fileInfo os.FileInfo) error {
-	// Windows uses ACLs, not Unix permissions
-	// The Terramate CLI sets appropriate permissions when creating the file
-	// For now, we trust the Windows file system security model
+// On Windows, the DACL of the file must not allow Everyone, Authenticated Users or Users
+// to read it. A missing (NULL) DACL grants everyone access and is rejected as well.
+func checkCredentialFilePermissions(path string, _ os.FileInfo) error {
+	sd, err := windows.GetNamedSecurityInfo(path, windows.SE_FILE_OBJECT, windows.DACL_SECURITY_INFORMATION)
+	if err != nil {
+		return fmt.Errorf("failed to read the ACL of %s: %w", path, err)
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return fmt.Errorf("failed to read the ACL of %s: %w", path, err)
+	}
+	if dacl == nil {
+		return insecureACLError(path, "Everyone")
+	}
+
+	for i := uint32(0); i < uint32(dacl.AceCount); i++ {
+		var ace *windows.ACCESS_ALLOWED_ACE
+		if err := windows.GetAce(dacl, i, &ace); err != nil {
+			return fmt.Errorf("failed to read the ACL of %s: %w", path, err)
+		}
+		if ace.Header.AceType != windows.ACCESS_ALLOWED_ACE_TYPE || uint32(ace.Mask)&fileReadAccess == 0 {
+			continue
+		}
+		sid := (*windows.SID)(unsafe.Pointer(&ace.SidStart))
+		for sidType, group := range broadGroups {
+			if sid.IsWellKnown(sidType) {
+				return insecureACLError(path, group)
+			}
+		}
+	}
+	return nil
+}
+
+// insecureACLError reports that group may read the credential file at path.
+func insecureACLError(path, group string) error {
+	return fmt.Errorf(
+		"credential file has insecure permissions: %s (readable by %s)\n\n"+
+			"The file contains sensitive tokens and should only be readable by the owner.\n"+
+			"To fix:\n"+
+			"  icacls \"%s\" /inheritance:r /grant:r \"%%USERNAME%%:F\"",
+		path, group, path,
+	)
+}
+
+// restrictCredentialFile replaces the DACL of the credential file at path
+// with one granting only the current user access, as the file mode of
+// os.WriteFile does not apply to Windows and inherited ACEs may let other
+// users read it.
+func restrictCredentialFile(path string) error {
+	user, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return fmt.Errorf("failed to get the current user: %w", err)
+	}
+	acl, err := windows.ACLFromEntries([]windows.EXPLICIT_ACCESS{{
+		AccessPermissions: windows.GENERIC_ALL,
+		AccessMode:        windows.GRANT_ACCESS,
+		Inheritance:       windows.NO_INHERITANCE,
+		Trustee: windows.TRUSTEE{
+			TrusteeForm:  windows.TRUSTEE_IS_SID,
+			TrusteeType:  windows.TRUSTEE_IS_USER,
+			TrusteeValue: windows.TrusteeValueFromSID(user.User.Sid),
+		},
+	}}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build the ACL: %w", err)
+	}
+	err = windows.SetNamedSecurityInfo(path, windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION, nil, nil, acl, nil)
+	if err != nil {
+		return fmt.Errorf("failed to restrict the ACL of %s: %w", path, err)
+	}
 	return nil
 }
@@ -0,0 +1,65 @@
+//go:build windows
+
+package terramate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestCheckCredentialFilePermissions_ACL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	if err := writeCredentialFile(path, cachedCredential{Provider: "Google", IDToken: generateMockJWT()}); err != nil {
+		t.Fatalf("writeCredentialFile error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkCredentialFilePermissions(path, info); err != nil {
+		t.Fatalf("expected a file written by the SDK to be accepted, got %v", err)
+	}
+
+	// Grant Everyone read access on top of the owner-only DACL
+	everyone, err := windows.CreateWellKnownSid(windows.WinWorldSid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd, err := windows.GetNamedSecurityInfo(path, windows.SE_FILE_OBJECT, windows.DACL_SECURITY_INFORMATION)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	acl, err := windows.ACLFromEntries([]windows.EXPLICIT_ACCESS{{
+		AccessPermissions: windows.GENERIC_READ,
+		AccessMode:        windows.GRANT_ACCESS,
+		Inheritance:       windows.NO_INHERITANCE,
+		Trustee: windows.TRUSTEE{
+			TrusteeForm:  windows.TRUSTEE_IS_SID,
+			TrusteeType:  windows.TRUSTEE_IS_WELL_KNOWN_GROUP,
+			TrusteeValue: windows.TrusteeValueFromSID(everyone),
+		},
+	}}, dacl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = windows.SetNamedSecurityInfo(path, windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION, nil, nil, acl, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := checkCredentialFilePermissions(path, info); err == nil || !strings.Contains(err.Error(), "readable by Everyone") {
+		t.Fatalf("expected an Everyone-readable file to be rejected, got %v", err)
+	}
+	if _, err := LoadJWTFromFile(path); err == nil {
+		t.Fatal("expected LoadJWTFromFile to refuse an Everyone-readable file")
+	}
+}
@@ -0,0 +1,32 @@
+package terramate
+
+import "context"
+
+// credentialKey is the context key of the credential override.
+const credentialKey contextKey = "credential"
+
+// WithCredential returns a context whose API requests are authenticated with
+// credential instead of the credential of the client, e.g. to direct a single
+// call at another organization. A 401 response refreshes credential, if it is
+// refreshable, not the client's. A nil credential leaves ctx unchanged.
+func WithCredential(ctx context.Context, credential Credential) context.Context {
+	if credential == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, credentialKey, credential)
+}
+
+// CredentialFromContext returns the credential attached to ctx with
+// WithCredential, or nil.
+func CredentialFromContext(ctx context.Context) Credential {
+	credential, _ := ctx.Value(credentialKey).(Credential)
+	return credential
+}
+
+// credentialFor returns the credential authenticating the requests of ctx.
+func (c *Client) credentialFor(ctx context.Context) Credential {
+	if credential := CredentialFromContext(ctx); credential != nil {
+		return credential
+	}
+	return c.credential
+}
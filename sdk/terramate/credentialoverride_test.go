@@ -0,0 +1,90 @@
+package terramate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewRequest_UsesCredentialOfContext(t *testing.T) {
+	var got []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, _, _ := r.BasicAuth()
+		got = append(got, user)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithAPIKey("client-key", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	ctx := WithCredential(context.Background(), NewAPIKeyCredential("override-key"))
+	if _, _, err := c.Memberships.List(ctx); err != nil {
+		t.Fatalf("List memberships error: %v", err)
+	}
+	if _, _, err := c.Memberships.List(context.Background()); err != nil {
+		t.Fatalf("List memberships error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "override-key" || got[1] != "client-key" {
+		t.Fatalf("unexpected API keys: %q", got)
+	}
+}
+
+func TestWithCredential_Nil(t *testing.T) {
+	ctx := context.Background()
+	if got := WithCredential(ctx, nil); got != ctx {
+		t.Fatal("expected a nil credential to leave the context unchanged")
+	}
+	override := NewAPIKeyCredential("override-key")
+	if got := CredentialFromContext(WithCredential(WithCredential(ctx, override), nil)); got != override {
+		t.Fatalf("expected a nil credential to keep the override, got %v", got)
+	}
+}
+
+func TestClient_RefreshesCredentialOfContext(t *testing.T) {
+	var got []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		got = append(got, auth)
+		if auth == "Bearer override-old" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	var clientRefreshes, overrideRefreshes int
+	clientCred := &testJWTCredential{
+		JWTCredential: JWTCredential{idToken: "client-token", refreshToken: "r", provider: "Google"},
+		onRefresh:     func() { clientRefreshes++ },
+		newToken:      "client-token-2",
+	}
+	override := &testJWTCredential{
+		JWTCredential: JWTCredential{idToken: "override-old", refreshToken: "r", provider: "Google"},
+		onRefresh:     func() { overrideRefreshes++ },
+		newToken:      "override-new",
+	}
+	c, err := NewClient(clientCred, WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	if _, _, err := c.Memberships.List(WithCredential(context.Background(), override)); err != nil {
+		t.Fatalf("List memberships error: %v", err)
+	}
+	if _, _, err := c.Memberships.List(context.Background()); err != nil {
+		t.Fatalf("List memberships error: %v", err)
+	}
+	if overrideRefreshes != 1 || clientRefreshes != 0 {
+		t.Fatalf("expected only the override to be refreshed, got %d override and %d client refreshes", overrideRefreshes, clientRefreshes)
+	}
+	want := []string{"Bearer override-old", "Bearer override-new", "Bearer client-token"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Fatalf("unexpected Authorization headers: %q", got)
+	}
+}
@@ -0,0 +1,104 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// redactedHeaders are the request headers WithDebugLogging logs as Redacted.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+// WithDebugLogging logs the client's requests to logger at debug level: the
+// method, URL and headers of each request, the status and duration of its
+// response, retry decisions and credential refreshes. Credentials are
+// redacted: the Authorization header (bearer tokens and basic auth), the
+// Proxy-Authorization and Cookie headers, the user info of URLs and the
+// secrets known to Redact. It complements WithInstrumentation, whatever their
+// order.
+func WithDebugLogging(logger *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		if logger == nil {
+			return fmt.Errorf("logger cannot be nil")
+		}
+		c.debugLogger = logger
+		return nil
+	}
+}
+
+// debugInstrumentation logs the events of a client at debug level (see
+// WithDebugLogging).
+type debugInstrumentation struct {
+	logger *slog.Logger
+}
+
+// OnRequestStart implements Instrumentation.
+func (d debugInstrumentation) OnRequestStart(ctx context.Context, req *http.Request) {
+	if !d.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	d.logger.DebugContext(ctx, "Terramate Cloud request",
+		"method", req.Method, "url", Redact(req.URL.Redacted()), "headers", redactHeaders(req.Header))
+}
+
+// OnRequestEnd implements Instrumentation.
+func (d debugInstrumentation) OnRequestEnd(ctx context.Context, info RequestInfo) {
+	if !d.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	url := info.Path
+	if info.Query != "" {
+		url += "?" + info.Query
+	}
+	attrs := []any{"method", info.Method, "url", Redact(url), "status", info.StatusCode, "duration", info.Duration}
+	if info.Err != nil {
+		attrs = append(attrs, "error", Redact(info.Err.Error()))
+	}
+	d.logger.DebugContext(ctx, "Terramate Cloud response", attrs...)
+}
+
+// OnRetry implements Instrumentation.
+func (d debugInstrumentation) OnRetry(ctx context.Context, info RetryInfo) {
+	if !d.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attrs := []any{"method", info.Method, "path", info.Path, "attempt", info.Attempt + 1, "wait", info.Wait,
+		"status", info.StatusCode, "retry_after", info.RetryAfter}
+	if info.Err != nil {
+		attrs = append(attrs, "error", Redact(info.Err.Error()))
+	}
+	d.logger.DebugContext(ctx, "Retrying Terramate Cloud request", attrs...)
+}
+
+// OnRefresh implements Instrumentation.
+func (d debugInstrumentation) OnRefresh(ctx context.Context, info RefreshInfo) {
+	if !d.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	attrs := []any{"credential", info.Credential, "duration", info.Duration}
+	if info.Err != nil {
+		attrs = append(attrs, "error", Redact(info.Err.Error()))
+	}
+	d.logger.DebugContext(ctx, "Credential refresh", attrs...)
+}
+
+// redactHeaders returns a copy of h with the values of redactedHeaders
+// replaced by Redacted and the secrets known to Redact removed from the
+// others.
+func redactHeaders(h http.Header) http.Header {
+	redacted := make(http.Header, len(h))
+	for name, values := range h {
+		clean := make([]string, len(values))
+		for i, v := range values {
+			clean[i] = Redact(v)
+		}
+		redacted[name] = clean
+	}
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, Redacted)
+		}
+	}
+	return redacted
+}
@@ -1,12 +1,30 @@
 package terramate
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
+// defaultDownloadLogsPerPage is the page size DownloadLogs walks the logs
+// with when the options leave it unset.
+const defaultDownloadLogsPerPage = 100
+
+// defaultStreamLogsPollInterval is how often StreamLogs polls for new log
+// lines when the options leave it unset.
+const defaultStreamLogsPollInterval = 5 * time.Second
+
+// ErrStopStreaming can be returned by the callback of StreamLogs to stop
+// streaming without an error.
+var ErrStopStreaming = errors.New("stop streaming")
+
 // DeploymentsService handles communication with the deployments related
 // methods of the Terramate Cloud API
 type DeploymentsService struct {
@@ -68,6 +86,9 @@ func (s *DeploymentsService) List(ctx context.Context, orgUUID string, opts *Dep
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceDeployments, orgUUID); err != nil {
+		return nil, nil, err
+	}
 
 	path := fmt.Sprintf("/v1/organizations/%s/deployments", orgUUID)
 
@@ -92,6 +113,214 @@ func (s *DeploymentsService) List(ctx context.Context, orgUUID string, opts *Dep
 	return &result, resp, nil
 }
 
+// All returns an iterator over the workflow deployments matching opts, walking
+// the pages of List from opts.Page as the iteration advances.
+func (s *DeploymentsService) All(ctx context.Context, orgUUID string, opts *DeploymentsListOptions) iter.Seq2[WorkflowDeploymentGroup, error] {
+	return s.listPages(ctx, orgUUID, opts).all()
+}
+
+// ListAll accumulates the workflow deployments matching opts in a slice, up to
+// maxItems (10000 when not positive), walking the pages of List from
+// opts.Page. The bool reports whether the result was truncated at maxItems.
+func (s *DeploymentsService) ListAll(ctx context.Context, orgUUID string, opts *DeploymentsListOptions, maxItems int) ([]WorkflowDeploymentGroup, bool, error) {
+	return s.listPages(ctx, orgUUID, opts).collect(maxItems)
+}
+
+// listPages pages through List from the page of opts.
+func (s *DeploymentsService) listPages(ctx context.Context, orgUUID string, opts *DeploymentsListOptions) pages[WorkflowDeploymentGroup] {
+	var o DeploymentsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[WorkflowDeploymentGroup]{opts: o.ListOptions, list: func(page ListOptions) ([]WorkflowDeploymentGroup, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.List(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Deployments, result.PaginatedResult, nil
+	}}
+}
+
+// SummarizeResourceChanges sums the resource changes planned by the workflow
+// deployments matching opts, walking all pages. A deployment is counted by
+// the current preview of the review request it ran for, once per review
+// request; deployments without one (e.g. started outside of a pull request)
+// are skipped. Bound the scan with opts.CreatedAtFrom; Page and PerPage are
+// ignored.
+//
+// GET /v1/organizations/{org_uuid}/deployments
+//
+// Access: Members of the organization with any role are allowed to query.
+func (s *DeploymentsService) SummarizeResourceChanges(ctx context.Context, orgUUID string, opts *DeploymentsListOptions) (*ResourceChangesAggregate, error) {
+	var o DeploymentsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	o.Page = 1
+	o.PerPage = defaultDownloadLogsPerPage
+
+	aggregate := &ResourceChangesAggregate{ReviewRequestIDs: []int{}}
+	for {
+		result, _, err := s.List(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, err
+		}
+		for _, deployment := range result.Deployments {
+			aggregate.ScannedCount++
+			if !aggregate.addPreview(deployment.ReviewRequest) {
+				aggregate.SkippedCount++
+			}
+		}
+		if len(result.Deployments) == 0 || !result.PaginatedResult.HasNextPage() {
+			return aggregate, nil
+		}
+		o.Page++
+	}
+}
+
+// ListForReviewRequest retrieves the workflow deployment groups produced by a
+// review request, e.g. the deployments that ran after a pull request was
+// merged.
+//
+// GET /v1/review_requests/{org_uuid}/{review_request_id}/deployments
+//
+// Access: Members of the organization with any role are allowed to query.
+func (s *DeploymentsService) ListForReviewRequest(ctx context.Context, orgUUID string, reviewRequestID int, opts *DeploymentsListOptions) (*DeploymentsListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceDeployments, orgUUID); err != nil {
+		return nil, nil, err
+	}
+	if reviewRequestID <= 0 {
+		return nil, nil, fmt.Errorf("review request ID must be positive")
+	}
+
+	path := fmt.Sprintf("/v1/review_requests/%s/%d/deployments", orgUUID, reviewRequestID)
+
+	if opts != nil {
+		query := opts.buildQuery()
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result DeploymentsListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// AllForReviewRequest returns an iterator over the workflow deployments
+// produced by a review request, walking the pages of ListForReviewRequest from
+// opts.Page as the iteration advances.
+func (s *DeploymentsService) AllForReviewRequest(ctx context.Context, orgUUID string, reviewRequestID int, opts *DeploymentsListOptions) iter.Seq2[WorkflowDeploymentGroup, error] {
+	return s.forReviewRequestPages(ctx, orgUUID, reviewRequestID, opts).all()
+}
+
+// ListAllForReviewRequest accumulates the workflow deployments produced by a
+// review request in a slice, up to maxItems (10000 when not positive), walking
+// the pages of ListForReviewRequest from opts.Page. The bool reports whether
+// the result was truncated at maxItems.
+func (s *DeploymentsService) ListAllForReviewRequest(ctx context.Context, orgUUID string, reviewRequestID int, opts *DeploymentsListOptions, maxItems int) ([]WorkflowDeploymentGroup, bool, error) {
+	return s.forReviewRequestPages(ctx, orgUUID, reviewRequestID, opts).collect(maxItems)
+}
+
+// forReviewRequestPages pages through ListForReviewRequest from the page of opts.
+func (s *DeploymentsService) forReviewRequestPages(ctx context.Context, orgUUID string, reviewRequestID int, opts *DeploymentsListOptions) pages[WorkflowDeploymentGroup] {
+	var o DeploymentsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[WorkflowDeploymentGroup]{opts: o.ListOptions, list: func(page ListOptions) ([]WorkflowDeploymentGroup, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.ListForReviewRequest(ctx, orgUUID, reviewRequestID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Deployments, result.PaginatedResult, nil
+	}}
+}
+
+// ListForStack retrieves the deployment history of a single stack, newest
+// first, optionally restricted to a time range with CreatedAtFrom and
+// CreatedAtTo.
+//
+// GET /v1/stacks/{org_uuid}/{stack_id}/deployments
+//
+// Access: Members of the organization with any role are allowed to query.
+func (s *DeploymentsService) ListForStack(ctx context.Context, orgUUID string, stackID int, opts *StackDeploymentsListOptions) (*StackDeploymentsListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceDeployments, orgUUID); err != nil {
+		return nil, nil, err
+	}
+	if stackID <= 0 {
+		return nil, nil, fmt.Errorf("stack ID must be positive")
+	}
+
+	path := fmt.Sprintf("/v1/stacks/%s/%d/deployments", orgUUID, stackID)
+
+	if opts != nil {
+		query := opts.buildQuery()
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result StackDeploymentsListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// AllForStack returns an iterator over the deployments of a stack, walking the
+// pages of ListForStack from opts.Page as the iteration advances.
+func (s *DeploymentsService) AllForStack(ctx context.Context, orgUUID string, stackID int, opts *StackDeploymentsListOptions) iter.Seq2[StackDeployment, error] {
+	return s.forStackPages(ctx, orgUUID, stackID, opts).all()
+}
+
+// ListAllForStack accumulates the deployments of a stack in a slice, up to
+// maxItems (10000 when not positive), walking the pages of ListForStack from
+// opts.Page. The bool reports whether the result was truncated at maxItems.
+func (s *DeploymentsService) ListAllForStack(ctx context.Context, orgUUID string, stackID int, opts *StackDeploymentsListOptions, maxItems int) ([]StackDeployment, bool, error) {
+	return s.forStackPages(ctx, orgUUID, stackID, opts).collect(maxItems)
+}
+
+// forStackPages pages through ListForStack from the page of opts.
+func (s *DeploymentsService) forStackPages(ctx context.Context, orgUUID string, stackID int, opts *StackDeploymentsListOptions) pages[StackDeployment] {
+	var o StackDeploymentsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[StackDeployment]{opts: o.ListOptions, list: func(page ListOptions) ([]StackDeployment, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.ListForStack(ctx, orgUUID, stackID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.StackDeployments, result.PaginatedResult, nil
+	}}
+}
+
 // GetWorkflow retrieves a specific workflow deployment group by ID.
 //
 // GET /v1/workflow_deployment_groups/{org_uuid}/{workflow_deployment_group_id}
@@ -103,6 +332,9 @@ func (s *DeploymentsService) GetWorkflow(ctx context.Context, orgUUID string, wo
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceDeployments, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if workflowDeploymentGroupID <= 0 {
 		return nil, nil, fmt.Errorf("workflow deployment group ID must be positive")
 	}
@@ -134,6 +366,9 @@ func (s *DeploymentsService) ListForWorkflow(ctx context.Context, orgUUID string
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceDeployments, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if workflowDeploymentGroupID <= 0 {
 		return nil, nil, fmt.Errorf("workflow deployment group ID must be positive")
 	}
@@ -162,6 +397,37 @@ func (s *DeploymentsService) ListForWorkflow(ctx context.Context, orgUUID string
 	return &result, resp, nil
 }
 
+// AllForWorkflow returns an iterator over the stack deployments of a workflow
+// deployment group, walking the pages of ListForWorkflow from opts.Page as the
+// iteration advances.
+func (s *DeploymentsService) AllForWorkflow(ctx context.Context, orgUUID string, workflowDeploymentGroupID int, opts *ListOptions) iter.Seq2[StackDeployment, error] {
+	return s.forWorkflowPages(ctx, orgUUID, workflowDeploymentGroupID, opts).all()
+}
+
+// ListAllForWorkflow accumulates the stack deployments of a workflow
+// deployment group in a slice, up to maxItems (10000 when not positive),
+// walking the pages of ListForWorkflow from opts.Page. The bool reports
+// whether the result was truncated at maxItems.
+func (s *DeploymentsService) ListAllForWorkflow(ctx context.Context, orgUUID string, workflowDeploymentGroupID int, opts *ListOptions, maxItems int) ([]StackDeployment, bool, error) {
+	return s.forWorkflowPages(ctx, orgUUID, workflowDeploymentGroupID, opts).collect(maxItems)
+}
+
+// forWorkflowPages pages through ListForWorkflow from the page of opts.
+func (s *DeploymentsService) forWorkflowPages(ctx context.Context, orgUUID string, workflowDeploymentGroupID int, opts *ListOptions) pages[StackDeployment] {
+	var o ListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[StackDeployment]{opts: o, list: func(page ListOptions) ([]StackDeployment, PaginatedResult, error) {
+		o = page
+		result, _, err := s.ListForWorkflow(ctx, orgUUID, workflowDeploymentGroupID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.StackDeployments, result.PaginatedResult, nil
+	}}
+}
+
 // ListStackDeployments retrieves all stack deployments for an organization.
 //
 // GET /v1/stack_deployments/{org_uuid}
@@ -173,6 +439,9 @@ func (s *DeploymentsService) ListStackDeployments(ctx context.Context, orgUUID s
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceDeployments, orgUUID); err != nil {
+		return nil, nil, err
+	}
 
 	path := fmt.Sprintf("/v1/stack_deployments/%s", orgUUID)
 
@@ -197,6 +466,37 @@ func (s *DeploymentsService) ListStackDeployments(ctx context.Context, orgUUID s
 	return &result, resp, nil
 }
 
+// AllStackDeployments returns an iterator over the stack deployments of an
+// organization, walking the pages of ListStackDeployments from opts.Page as
+// the iteration advances.
+func (s *DeploymentsService) AllStackDeployments(ctx context.Context, orgUUID string, opts *StackDeploymentsListOptions) iter.Seq2[StackDeployment, error] {
+	return s.stackDeploymentsPages(ctx, orgUUID, opts).all()
+}
+
+// ListAllStackDeployments accumulates the stack deployments of an organization
+// in a slice, up to maxItems (10000 when not positive), walking the pages of
+// ListStackDeployments from opts.Page. The bool reports whether the result was
+// truncated at maxItems.
+func (s *DeploymentsService) ListAllStackDeployments(ctx context.Context, orgUUID string, opts *StackDeploymentsListOptions, maxItems int) ([]StackDeployment, bool, error) {
+	return s.stackDeploymentsPages(ctx, orgUUID, opts).collect(maxItems)
+}
+
+// stackDeploymentsPages pages through ListStackDeployments from the page of opts.
+func (s *DeploymentsService) stackDeploymentsPages(ctx context.Context, orgUUID string, opts *StackDeploymentsListOptions) pages[StackDeployment] {
+	var o StackDeploymentsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[StackDeployment]{opts: o.ListOptions, list: func(page ListOptions) ([]StackDeployment, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.ListStackDeployments(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.StackDeployments, result.PaginatedResult, nil
+	}}
+}
+
 // GetStackDeployment retrieves a specific stack deployment by ID.
 //
 // GET /v1/stack_deployments/{org_uuid}/{stack_deployment_id}
@@ -209,6 +509,9 @@ func (s *DeploymentsService) GetStackDeployment(ctx context.Context, orgUUID str
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceDeployments, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if stackDeploymentID <= 0 {
 		return nil, nil, fmt.Errorf("stack deployment ID must be positive")
 	}
@@ -241,6 +544,9 @@ func (s *DeploymentsService) GetDeploymentLogs(ctx context.Context, orgUUID stri
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceDeployments, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if stackID <= 0 {
 		return nil, nil, fmt.Errorf("stack ID must be positive")
 	}
@@ -272,3 +578,165 @@ func (s *DeploymentsService) GetDeploymentLogs(ctx context.Context, orgUUID stri
 
 	return &result, resp, nil
 }
+
+// DownloadLogs streams the complete terraform command logs of a stack
+// deployment into w, one message per line, fetching one page at a time so
+// logs of any size can be saved without holding them in memory.
+//
+// The returned progress is valid even when an error is returned: pass its
+// Page and NextLine as StartPage and StartLine to resume the download. It
+// only advances once a page has been written completely, so a resumed
+// download may repeat lines of a page whose write failed, but never skips
+// any.
+func (s *DeploymentsService) DownloadLogs(ctx context.Context, orgUUID string, stackID int, deploymentUUID string, w io.Writer, opts *DownloadLogsOptions) (*DownloadLogsProgress, error) {
+	if w == nil {
+		return nil, fmt.Errorf("writer is required")
+	}
+	var o DownloadLogsOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.PerPage <= 0 {
+		o.PerPage = defaultDownloadLogsPerPage
+	}
+
+	progress := &DownloadLogsProgress{Page: max(o.StartPage, 1), NextLine: o.StartLine}
+	bw := bufio.NewWriter(w)
+	for {
+		logs, _, err := s.GetDeploymentLogs(ctx, orgUUID, stackID, deploymentUUID, &DeploymentLogsOptions{
+			ListOptions: ListOptions{Page: progress.Page, PerPage: o.PerPage},
+			Channel:     o.Channel,
+		})
+		if err != nil {
+			return progress, err
+		}
+
+		page := *progress
+		for _, line := range logs.DeploymentLogLines {
+			if line.LogLine < page.NextLine {
+				continue
+			}
+			if _, err := bw.WriteString(strings.TrimRight(line.Message, "\n") + "\n"); err != nil {
+				return progress, fmt.Errorf("failed to write logs: %w", err)
+			}
+			page.Lines++
+			page.NextLine = line.LogLine + 1
+		}
+		if err := bw.Flush(); err != nil {
+			return progress, fmt.Errorf("failed to write logs: %w", err)
+		}
+		*progress = page
+
+		if len(logs.DeploymentLogLines) == 0 || !logs.PaginatedResult.HasNextPage() {
+			return progress, nil
+		}
+		progress.Page++
+	}
+}
+
+// StreamLogs follows the terraform command logs of a stack deployment,
+// calling fn with each log line in order, including the lines logged before
+// the call. It polls for new lines until the deployment is no longer pending
+// or running and its logs are read completely, fn returns an error or ctx is
+// done.
+//
+// When fn returns ErrStopStreaming, StreamLogs returns a nil error; any other
+// error is returned as is and the line is not counted, so a resumed stream
+// delivers it again. The returned progress is valid even when an error is
+// returned: pass its Page and NextLine as StartPage and StartLine to resume.
+func (s *DeploymentsService) StreamLogs(ctx context.Context, orgUUID string, stackID int, deploymentUUID string, fn func(CommandLogLine) error, opts *StreamLogsOptions) (*DownloadLogsProgress, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("callback is required")
+	}
+	var o StreamLogsOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.PerPage <= 0 {
+		o.PerPage = defaultDownloadLogsPerPage
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = defaultStreamLogsPollInterval
+	}
+
+	progress := &DownloadLogsProgress{Page: max(o.StartPage, 1), NextLine: o.StartLine}
+	for {
+		// The status is checked before reading the logs, so the lines logged
+		// until the deployment finished are read before streaming stops.
+		running, err := s.deploymentRunning(ctx, orgUUID, stackID, deploymentUUID)
+		if err != nil {
+			return progress, err
+		}
+		err = s.readNewLogs(ctx, orgUUID, stackID, deploymentUUID, fn, &o, progress)
+		if errors.Is(err, ErrStopStreaming) {
+			return progress, nil
+		}
+		if err != nil || !running {
+			return progress, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return progress, ctx.Err()
+		case <-time.After(o.PollInterval):
+		}
+	}
+}
+
+// readNewLogs calls fn with the log lines from progress on, advancing
+// progress, until it reaches the last page of the logs.
+func (s *DeploymentsService) readNewLogs(ctx context.Context, orgUUID string, stackID int, deploymentUUID string, fn func(CommandLogLine) error, o *StreamLogsOptions, progress *DownloadLogsProgress) error {
+	for {
+		logs, _, err := s.GetDeploymentLogs(ctx, orgUUID, stackID, deploymentUUID, &DeploymentLogsOptions{
+			ListOptions: ListOptions{Page: progress.Page, PerPage: o.PerPage},
+			Channel:     o.Channel,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, line := range logs.DeploymentLogLines {
+			if line.LogLine < progress.NextLine {
+				continue
+			}
+			err = fn(line)
+			if err != nil && !errors.Is(err, ErrStopStreaming) {
+				return err
+			}
+			progress.Lines++
+			progress.NextLine = line.LogLine + 1
+			if err != nil {
+				return err
+			}
+		}
+
+		// A partial page is the last one; lines logged later are appended to it.
+		if len(logs.DeploymentLogLines) < o.PerPage && !logs.PaginatedResult.HasNextPage() {
+			return nil
+		}
+		progress.Page++
+	}
+}
+
+// deploymentRunning reports whether a stack deployment is pending or running.
+func (s *DeploymentsService) deploymentRunning(ctx context.Context, orgUUID string, stackID int, deploymentUUID string) (bool, error) {
+	opts := &StackDeploymentsListOptions{
+		ListOptions: ListOptions{Page: 1, PerPage: defaultDownloadLogsPerPage},
+		Status:      []string{"pending", "running"},
+	}
+	for {
+		result, _, err := s.ListForStack(ctx, orgUUID, stackID, opts)
+		if err != nil {
+			return false, err
+		}
+		for _, deployment := range result.StackDeployments {
+			if deployment.DeploymentUUID == deploymentUUID {
+				return true, nil
+			}
+		}
+		if len(result.StackDeployments) == 0 || !result.PaginatedResult.HasNextPage() {
+			return false, nil
+		}
+		opts.Page++
+	}
+}
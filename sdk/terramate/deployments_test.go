@@ -2,7 +2,14 @@ package terramate
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -138,6 +145,69 @@ func TestDeploymentsList_Validation(t *testing.T) {
 	}
 }
 
+func TestDeploymentsListForReviewRequest(t *testing.T) {
+	payload := `{
+		"deployments": [
+			{"id": 7, "status": "ok", "commit_title": "Merge pull request #245", "repository": "github.com/acme/infra", "review_request": {"review_request_id": 245, "number": 245}}
+		],
+		"paginated_result": {"total": 1, "page": 1, "per_page": 20}
+	}`
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/review_requests/org-uuid/245/deployments" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("status"); got != "ok" {
+			t.Errorf("status = %q, want ok", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	})
+	defer cleanup()
+
+	result, _, err := client.Deployments.ListForReviewRequest(context.Background(), "org-uuid", 245,
+		&DeploymentsListOptions{Status: []string{"ok"}})
+	if err != nil {
+		t.Fatalf("ListForReviewRequest error: %v", err)
+	}
+	if len(result.Deployments) != 1 || result.Deployments[0].ID != 7 {
+		t.Fatalf("unexpected deployments: %+v", result.Deployments)
+	}
+
+	if _, _, err := client.Deployments.ListForReviewRequest(context.Background(), "org-uuid", 0, nil); err == nil {
+		t.Error("expected an error for a non-positive review request ID")
+	}
+}
+
+func TestDeploymentsListForStack(t *testing.T) {
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/stacks/org-uuid/42/deployments" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("created_at_from") != "2024-04-01T00:00:00Z" || query.Get("status") != "failed" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"stack_deployments": [{"id": 3, "status": "failed"}], "paginated_result": {"total": 1, "page": 1, "per_page": 20}}`))
+	})
+	defer cleanup()
+
+	result, _, err := client.Deployments.ListForStack(context.Background(), "org-uuid", 42,
+		&StackDeploymentsListOptions{Status: []string{"failed"}, CreatedAtFrom: &from})
+	if err != nil {
+		t.Fatalf("ListForStack error: %v", err)
+	}
+	if len(result.StackDeployments) != 1 || result.StackDeployments[0].ID != 3 {
+		t.Fatalf("unexpected stack deployments: %+v", result.StackDeployments)
+	}
+
+	if _, _, err := client.Deployments.ListForStack(context.Background(), "org-uuid", 0, nil); err == nil {
+		t.Error("expected an error for a non-positive stack ID")
+	}
+}
+
 func TestDeploymentsGetWorkflow_ParsesResponse(t *testing.T) {
 	payload := `{
 		"id": 100,
@@ -371,3 +441,234 @@ func TestDeploymentsGetWorkflow_RespectsContextTimeout(t *testing.T) {
 		t.Fatal("expected timeout error")
 	}
 }
+
+// logsServer serves total log lines numbered from 1 in pages, failing the
+// request for page failPage with a server error.
+func logsServer(t *testing.T, total, failPage int) (*Client, *[]int, func()) {
+	t.Helper()
+	var pages []int
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/stacks/org-uuid/42/deployments/deploy-uuid/logs" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		pages = append(pages, page)
+		if page == failPage {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		result := DeploymentLogsResponse{PaginatedResult: PaginatedResult{Total: total, Page: page, PerPage: perPage}}
+		for n := (page-1)*perPage + 1; n <= page*perPage && n <= total; n++ {
+			result.DeploymentLogLines = append(result.DeploymentLogLines, CommandLogLine{
+				LogLine: n, Channel: "stdout", Message: fmt.Sprintf("line %d\n", n),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	})
+	return client, &pages, cleanup
+}
+
+func TestDeploymentsDownloadLogs(t *testing.T) {
+	client, pages, cleanup := logsServer(t, 5, 0)
+	defer cleanup()
+
+	var out strings.Builder
+	progress, err := client.Deployments.DownloadLogs(context.Background(), "org-uuid", 42, "deploy-uuid", &out, &DownloadLogsOptions{PerPage: 2})
+	if err != nil {
+		t.Fatalf("DownloadLogs error: %v", err)
+	}
+	if want := "line 1\nline 2\nline 3\nline 4\nline 5\n"; out.String() != want {
+		t.Errorf("unexpected logs:\n%q\nwant\n%q", out.String(), want)
+	}
+	if *progress != (DownloadLogsProgress{Lines: 5, Page: 3, NextLine: 6}) {
+		t.Errorf("unexpected progress: %+v", progress)
+	}
+	if len(*pages) != 3 {
+		t.Errorf("expected 3 page requests, got %v", *pages)
+	}
+}
+
+func TestDeploymentsDownloadLogs_Resume(t *testing.T) {
+	client, _, cleanup := logsServer(t, 5, 3)
+	defer cleanup()
+
+	var out strings.Builder
+	progress, err := client.Deployments.DownloadLogs(context.Background(), "org-uuid", 42, "deploy-uuid", &out, &DownloadLogsOptions{PerPage: 2})
+	if err == nil {
+		t.Fatal("expected error for the failing page")
+	}
+	if *progress != (DownloadLogsProgress{Lines: 4, Page: 3, NextLine: 5}) {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+
+	client, pages, cleanup := logsServer(t, 5, 0)
+	defer cleanup()
+	_, err = client.Deployments.DownloadLogs(context.Background(), "org-uuid", 42, "deploy-uuid", &out, &DownloadLogsOptions{
+		PerPage: 2, StartPage: progress.Page, StartLine: progress.NextLine,
+	})
+	if err != nil {
+		t.Fatalf("DownloadLogs error: %v", err)
+	}
+	if want := "line 1\nline 2\nline 3\nline 4\nline 5\n"; out.String() != want {
+		t.Errorf("unexpected logs after resume:\n%q", out.String())
+	}
+	if len(*pages) != 1 || (*pages)[0] != 3 {
+		t.Errorf("expected the resumed download to start at page 3, got %v", *pages)
+	}
+
+	// A start line within the start page skips the lines before it
+	out.Reset()
+	if _, err := client.Deployments.DownloadLogs(context.Background(), "org-uuid", 42, "deploy-uuid", &out, &DownloadLogsOptions{
+		PerPage: 2, StartPage: 2, StartLine: 4,
+	}); err != nil {
+		t.Fatalf("DownloadLogs error: %v", err)
+	}
+	if out.String() != "line 4\nline 5\n" {
+		t.Errorf("unexpected logs from line 4: %q", out.String())
+	}
+}
+
+func TestDeploymentsDownloadLogs_Validation(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("unexpected request")
+	})
+	defer cleanup()
+
+	if _, err := client.Deployments.DownloadLogs(context.Background(), "org-uuid", 42, "deploy-uuid", nil, nil); err == nil {
+		t.Error("expected error for nil writer")
+	}
+	if _, err := client.Deployments.DownloadLogs(context.Background(), "", 42, "deploy-uuid", io.Discard, nil); err == nil {
+		t.Error("expected error for missing organization")
+	}
+}
+
+// streamServer serves a deployment that stays running for runningPolls status
+// checks and logs two more lines, numbered from 1, on every status check.
+func streamServer(t *testing.T, runningPolls int) (*Client, func()) {
+	t.Helper()
+	var mu sync.Mutex
+	var polls, total int
+	return setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/stacks/org-uuid/42/deployments":
+			if got := r.URL.Query()["status"]; strings.Join(got, ",") != "pending,running" {
+				t.Errorf("unexpected status filter: %v", got)
+			}
+			polls++
+			total += 2
+			result := StackDeploymentsListResponse{PaginatedResult: PaginatedResult{Page: 1, PerPage: 100}}
+			if polls <= runningPolls {
+				result.StackDeployments = []StackDeployment{{ID: 1, DeploymentUUID: "deploy-uuid", Status: "running"}}
+				result.PaginatedResult.Total = 1
+			}
+			_ = json.NewEncoder(w).Encode(result)
+		case "/v1/stacks/org-uuid/42/deployments/deploy-uuid/logs":
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+			result := DeploymentLogsResponse{PaginatedResult: PaginatedResult{Total: total, Page: page, PerPage: perPage}}
+			for n := (page-1)*perPage + 1; n <= page*perPage && n <= total; n++ {
+				result.DeploymentLogLines = append(result.DeploymentLogLines, CommandLogLine{LogLine: n, Message: fmt.Sprintf("line %d", n)})
+			}
+			_ = json.NewEncoder(w).Encode(result)
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	})
+}
+
+func TestDeploymentsStreamLogs(t *testing.T) {
+	client, cleanup := streamServer(t, 2)
+	defer cleanup()
+
+	var got []int
+	progress, err := client.Deployments.StreamLogs(context.Background(), "org-uuid", 42, "deploy-uuid", func(line CommandLogLine) error {
+		got = append(got, line.LogLine)
+		return nil
+	}, &StreamLogsOptions{PerPage: 4, PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("StreamLogs error: %v", err)
+	}
+	// Two running polls and the final one after the deployment finished
+	if fmt.Sprint(got) != "[1 2 3 4 5 6]" {
+		t.Errorf("unexpected lines: %v", got)
+	}
+	if *progress != (DownloadLogsProgress{Lines: 6, Page: 2, NextLine: 7}) {
+		t.Errorf("unexpected progress: %+v", progress)
+	}
+}
+
+func TestDeploymentsStreamLogs_Stop(t *testing.T) {
+	client, cleanup := streamServer(t, 100)
+	defer cleanup()
+
+	var got []int
+	progress, err := client.Deployments.StreamLogs(context.Background(), "org-uuid", 42, "deploy-uuid", func(line CommandLogLine) error {
+		got = append(got, line.LogLine)
+		if line.LogLine == 3 {
+			return ErrStopStreaming
+		}
+		return nil
+	}, &StreamLogsOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("StreamLogs error: %v", err)
+	}
+	if fmt.Sprint(got) != "[1 2 3]" || progress.NextLine != 4 {
+		t.Errorf("unexpected lines %v and progress %+v", got, progress)
+	}
+
+	failure := fmt.Errorf("callback failed")
+	progress, err = client.Deployments.StreamLogs(context.Background(), "org-uuid", 42, "deploy-uuid", func(line CommandLogLine) error {
+		return failure
+	}, &StreamLogsOptions{PollInterval: time.Millisecond})
+	if err != failure {
+		t.Fatalf("expected the callback error, got %v", err)
+	}
+	if progress.Lines != 0 || progress.NextLine != 0 {
+		t.Errorf("expected the failed line not to be counted, got %+v", progress)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err = client.Deployments.StreamLogs(ctx, "org-uuid", 42, "deploy-uuid", func(CommandLogLine) error { return nil },
+		&StreamLogsOptions{PollInterval: time.Millisecond}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the context error, got %v", err)
+	}
+}
+
+func TestDeploymentsSummarizeResourceChanges(t *testing.T) {
+	payload := `{
+		"deployments": [
+			{"id": 1, "review_request": {"review_request_id": 7, "preview": {"status": "current", "resource_changes": {"update_count": 2, "delete_count": 1}}}},
+			{"id": 2, "review_request": {"review_request_id": 7, "preview": {"status": "current", "resource_changes": {"update_count": 2, "delete_count": 1}}}},
+			{"id": 3}
+		],
+		"paginated_result": {"total": 3, "page": 1, "per_page": 100}
+	}`
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("created_at_from") != "2026-01-01T00:00:00Z" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	})
+	defer cleanup()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	aggregate, err := client.Deployments.SummarizeResourceChanges(context.Background(), "org-uuid", &DeploymentsListOptions{CreatedAtFrom: &from})
+	if err != nil {
+		t.Fatalf("SummarizeResourceChanges error: %v", err)
+	}
+	if aggregate.Actions.UpdateCount != 2 || aggregate.Actions.DeleteCount != 1 {
+		t.Errorf("expected review request 7 counted once, got %+v", aggregate.Actions)
+	}
+	if aggregate.ScannedCount != 3 || aggregate.SkippedCount != 1 {
+		t.Errorf("unexpected aggregate: %+v", aggregate)
+	}
+}
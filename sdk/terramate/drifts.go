@@ -1,10 +1,16 @@
 package terramate
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
+
+	"github.com/terramate-io/terramate-mcp-server/internal/fanout"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate/internal/plan"
 )
 
 // DriftsService handles communication with the drifts related
@@ -38,6 +44,9 @@ func (s *DriftsService) ListForStack(ctx context.Context, orgUUID string, stackI
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceStacks, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if stackID <= 0 {
 		return nil, nil, fmt.Errorf("stack ID must be positive")
 	}
@@ -66,6 +75,35 @@ func (s *DriftsService) ListForStack(ctx context.Context, orgUUID string, stackI
 	return &result, resp, nil
 }
 
+// AllForStack returns an iterator over the drift runs of a stack, walking the
+// pages of ListForStack from opts.Page as the iteration advances.
+func (s *DriftsService) AllForStack(ctx context.Context, orgUUID string, stackID int, opts *DriftsListOptions) iter.Seq2[Drift, error] {
+	return s.forStackPages(ctx, orgUUID, stackID, opts).all()
+}
+
+// ListAllForStack accumulates the drift runs of a stack in a slice, up to
+// maxItems (10000 when not positive), walking the pages of ListForStack from
+// opts.Page. The bool reports whether the result was truncated at maxItems.
+func (s *DriftsService) ListAllForStack(ctx context.Context, orgUUID string, stackID int, opts *DriftsListOptions, maxItems int) ([]Drift, bool, error) {
+	return s.forStackPages(ctx, orgUUID, stackID, opts).collect(maxItems)
+}
+
+// forStackPages pages through ListForStack from the page of opts.
+func (s *DriftsService) forStackPages(ctx context.Context, orgUUID string, stackID int, opts *DriftsListOptions) pages[Drift] {
+	var o DriftsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[Drift]{opts: o.ListOptions, list: func(page ListOptions) ([]Drift, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.ListForStack(ctx, orgUUID, stackID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Drifts, result.PaginatedResult, nil
+	}}
+}
+
 // Get retrieves detailed information for a specific drift.
 //
 // GET /v1/drifts/{org_uuid}/{stack_id}/{drift_id}
@@ -78,6 +116,9 @@ func (s *DriftsService) Get(ctx context.Context, orgUUID string, stackID, driftI
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceStacks, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if stackID <= 0 {
 		return nil, nil, fmt.Errorf("stack ID must be positive")
 	}
@@ -100,3 +141,320 @@ func (s *DriftsService) Get(ctx context.Context, orgUUID string, stackID, driftI
 
 	return &drift, resp, nil
 }
+
+// defaultResourceDriftRuns is the number of drifted runs ListForResource
+// inspects when ResourceDriftHistoryOptions.MaxRuns is not set.
+const defaultResourceDriftRuns = 20
+
+// defaultResourceDriftConcurrency is the number of drifted runs
+// ListForResource fetches in parallel when
+// ResourceDriftHistoryOptions.Concurrency is not set.
+const defaultResourceDriftConcurrency = 4
+
+// ListForResource retrieves the drift runs of a stack in which the resource
+// at address drifted, newest first, e.g. to answer "how often does
+// aws_security_group.web drift?". Instances of the resource match too:
+// aws_security_group.web matches aws_security_group.web[0].
+//
+// GET /v1/stacks/{org_uuid}/{stack_id}/drifts
+// GET /v1/drifts/{org_uuid}/{stack_id}/{drift_id}
+//
+// Each drifted run is fetched to inspect its plan, up to opts.MaxRuns runs
+// and opts.Concurrency at a time. The scan stops once opts.MaxMatches runs
+// matched.
+//
+// Access: All members of the organization with any role are allowed to query.
+func (s *DriftsService) ListForResource(ctx context.Context, orgUUID string, stackID int, address string, opts *ResourceDriftHistoryOptions) (*ResourceDriftHistory, error) {
+	if address == "" {
+		return nil, fmt.Errorf("resource address is required")
+	}
+	maxRuns, concurrency, maxMatches := opts.limits()
+
+	history := &ResourceDriftHistory{StackID: stackID, Address: address, Runs: []ResourceDriftRun{}}
+	listOpts := &DriftsListOptions{
+		ListOptions: ListOptions{Page: 1, PerPage: min(maxRuns, 100)},
+		DriftStatus: []string{"drifted"},
+	}
+	for {
+		list, _, err := s.ListForStack(ctx, orgUUID, stackID, listOpts)
+		if err != nil {
+			return nil, err
+		}
+		runs := list.Drifts[:min(len(list.Drifts), maxRuns-history.ScannedCount)]
+		more := len(runs) < len(list.Drifts) || list.PaginatedResult.HasNextPage()
+		for start := 0; start < len(runs); start += concurrency {
+			batch := runs[start:min(start+concurrency, len(runs))]
+			drifts, err := s.getAll(ctx, orgUUID, stackID, batch)
+			if err != nil {
+				return nil, err
+			}
+			for i, drift := range drifts {
+				history.ScannedCount++
+				history.add(drift, address)
+				if len(history.Runs) == maxMatches {
+					history.HasMore = more || start+i+1 < len(runs)
+					return history, nil
+				}
+			}
+		}
+		if len(list.Drifts) == 0 || !more || history.ScannedCount == maxRuns {
+			history.HasMore = more && history.ScannedCount == maxRuns
+			return history, nil
+		}
+		listOpts.Page++
+	}
+}
+
+// limits returns the runs to inspect, the runs to fetch in parallel and the
+// matches to stop at (-1 for no limit) set by o, or their defaults.
+func (o *ResourceDriftHistoryOptions) limits() (maxRuns, concurrency, maxMatches int) {
+	maxRuns, concurrency, maxMatches = defaultResourceDriftRuns, defaultResourceDriftConcurrency, -1
+	if o == nil {
+		return maxRuns, concurrency, maxMatches
+	}
+	if o.MaxRuns > 0 {
+		maxRuns = o.MaxRuns
+	}
+	if o.Concurrency > 0 {
+		concurrency = o.Concurrency
+	}
+	if o.MaxMatches > 0 {
+		maxMatches = o.MaxMatches
+	}
+	return maxRuns, concurrency, maxMatches
+}
+
+// getAll fetches the drifts of runs in parallel, in the order of runs.
+func (s *DriftsService) getAll(ctx context.Context, orgUUID string, stackID int, runs []Drift) ([]*Drift, error) {
+	drifts := make([]*Drift, len(runs))
+	err := fanout.Run(fanout.WithLimit(ctx, len(runs)), len(runs), func(ctx context.Context, i int) error {
+		drift, _, err := s.Get(ctx, orgUUID, stackID, runs[i].ID)
+		drifts[i] = drift
+		return err
+	})
+	return drifts, err
+}
+
+// add records drift in the history if the resource at address drifted in
+// it.
+func (h *ResourceDriftHistory) add(drift *Drift, address string) {
+	found, actions := driftedResource(drift.DriftDetails, address)
+	if found == "" {
+		return
+	}
+	h.Runs = append(h.Runs, ResourceDriftRun{
+		DriftID:    drift.ID,
+		Address:    found,
+		StartedAt:  drift.StartedAt,
+		FinishedAt: drift.FinishedAt,
+		Actions:    actions,
+	})
+}
+
+// driftedResource returns the address of the instance of the resource at
+// address changed in the drift plan of details, with its actions, preferring
+// the JSON plan and falling back to the ASCII one. It returns "" when the
+// resource did not drift.
+func driftedResource(details *ChangesetDetails, address string) (string, []string) {
+	if details == nil {
+		return "", nil
+	}
+	if details.ChangesetJSON != "" {
+		if p, err := plan.Parse([]byte(details.ChangesetJSON)); err == nil {
+			rc := p.Find(address)
+			if rc == nil {
+				return "", nil
+			}
+			actions := make([]string, len(rc.Change.Actions))
+			for i, action := range rc.Change.Actions {
+				actions[i] = string(action)
+			}
+			return rc.Address, actions
+		}
+	}
+	return plan.FindASCII(details.ChangesetASCII, address), nil
+}
+
+// ListGroupingKeys retrieves the distinct drift grouping keys of an
+// organization with their drift counts and latest status, e.g. to find the
+// failing drift check groups without paging through all drifts.
+//
+// GET /v1/drifts/{org_uuid}/grouping_keys
+//
+// Access: All members of the organization with any role are allowed to query.
+func (s *DriftsService) ListGroupingKeys(ctx context.Context, orgUUID string, opts *DriftGroupingKeysListOptions) (*DriftGroupingKeysListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceStacks, orgUUID); err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/v1/drifts/%s/grouping_keys", orgUUID)
+
+	if opts != nil {
+		query := url.Values{}
+		addPagination(query, opts.Page, opts.PerPage)
+		addStringSlice(query, "repository", opts.Repository)
+		addStringSlice(query, "latest_status", opts.LatestStatus)
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result DriftGroupingKeysListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// AllGroupingKeys returns an iterator over the drift grouping keys of an
+// organization, walking the pages of ListGroupingKeys from opts.Page as the
+// iteration advances.
+func (s *DriftsService) AllGroupingKeys(ctx context.Context, orgUUID string, opts *DriftGroupingKeysListOptions) iter.Seq2[DriftGroupingKey, error] {
+	return s.groupingKeysPages(ctx, orgUUID, opts).all()
+}
+
+// ListAllGroupingKeys accumulates the drift grouping keys of an organization
+// in a slice, up to maxItems (10000 when not positive), walking the pages of
+// ListGroupingKeys from opts.Page. The bool reports whether the result was
+// truncated at maxItems.
+func (s *DriftsService) ListAllGroupingKeys(ctx context.Context, orgUUID string, opts *DriftGroupingKeysListOptions, maxItems int) ([]DriftGroupingKey, bool, error) {
+	return s.groupingKeysPages(ctx, orgUUID, opts).collect(maxItems)
+}
+
+// groupingKeysPages pages through ListGroupingKeys from the page of opts.
+func (s *DriftsService) groupingKeysPages(ctx context.Context, orgUUID string, opts *DriftGroupingKeysListOptions) pages[DriftGroupingKey] {
+	var o DriftGroupingKeysListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[DriftGroupingKey]{opts: o.ListOptions, list: func(page ListOptions) ([]DriftGroupingKey, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.ListGroupingKeys(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.GroupingKeys, result.PaginatedResult, nil
+	}}
+}
+
+// Trigger requests a new drift check of a stack, e.g. to confirm that a
+// remediation removed the drift. The check runs asynchronously; its result
+// is listed by ListForStack once it finished.
+//
+// POST /v1/drifts/{org_uuid}/{stack_id}/trigger
+//
+// A 409 Conflict is returned while a drift check of the stack is already
+// queued or running.
+//
+// Access: Members of the organization with the admin role are allowed to trigger drift checks.
+func (s *DriftsService) Trigger(ctx context.Context, orgUUID string, stackID int) (*DriftCheckRequest, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceStacks, orgUUID); err != nil {
+		return nil, nil, err
+	}
+	if stackID <= 0 {
+		return nil, nil, fmt.Errorf("stack ID must be positive")
+	}
+
+	path := fmt.Sprintf("/v1/drifts/%s/%d/trigger", orgUUID, stackID)
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var request DriftCheckRequest
+	resp, err := s.client.do(req, &request)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &request, resp, nil
+}
+
+// Acknowledge marks a drift as known, silencing it, e.g. for stacks whose
+// drift is expected until a pending change lands, and returns the updated
+// drift.
+//
+// POST /v1/drifts/{org_uuid}/{stack_id}/{drift_id}/acknowledge
+//
+// Access: All members of the organization with any role are allowed to acknowledge drifts.
+func (s *DriftsService) Acknowledge(ctx context.Context, orgUUID string, stackID, driftID int, opts AcknowledgeDriftOptions) (*Drift, *Response, error) {
+	path, err := s.acknowledgementPath(orgUUID, stackID, driftID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := json.Marshal(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := s.client.newRequest(ctx, http.MethodPost, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var drift Drift
+	resp, err := s.client.do(req, &drift)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &drift, resp, nil
+}
+
+// Unacknowledge revokes the acknowledgement of a drift, so it is reported
+// again, and returns the updated drift.
+//
+// DELETE /v1/drifts/{org_uuid}/{stack_id}/{drift_id}/acknowledge
+//
+// Access: All members of the organization with any role are allowed to revoke acknowledgements.
+func (s *DriftsService) Unacknowledge(ctx context.Context, orgUUID string, stackID, driftID int) (*Drift, *Response, error) {
+	path, err := s.acknowledgementPath(orgUUID, stackID, driftID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var drift Drift
+	resp, err := s.client.do(req, &drift)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &drift, resp, nil
+}
+
+// acknowledgementPath returns the acknowledgement path of a drift,
+// validating its identifiers.
+func (s *DriftsService) acknowledgementPath(orgUUID string, stackID, driftID int) (string, error) {
+	if orgUUID == "" {
+		return "", fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceStacks, orgUUID); err != nil {
+		return "", err
+	}
+	if stackID <= 0 {
+		return "", fmt.Errorf("stack ID must be positive")
+	}
+	if driftID <= 0 {
+		return "", fmt.Errorf("drift ID must be positive")
+	}
+	return fmt.Sprintf("/v1/drifts/%s/%d/%d/acknowledge", orgUUID, stackID, driftID), nil
+}
@@ -2,7 +2,10 @@ package terramate
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -653,3 +656,203 @@ func TestDriftsGet_RespectsContextTimeout(t *testing.T) {
 		t.Fatal("expected timeout error")
 	}
 }
+
+func TestDriftsListForResource(t *testing.T) {
+	jsonPlan := func(address string, actions ...string) string {
+		plan, _ := json.Marshal(map[string]interface{}{
+			"resource_changes": []interface{}{
+				map[string]interface{}{"address": "aws_instance.web", "change": map[string]interface{}{"actions": []string{"no-op"}}},
+				map[string]interface{}{"address": address, "change": map[string]interface{}{"actions": actions}},
+			},
+		})
+		return string(plan)
+	}
+	details := map[int]ChangesetDetails{
+		5: {ChangesetJSON: jsonPlan(`aws_security_group.web[0]`, "update")},
+		4: {ChangesetJSON: jsonPlan("aws_security_group.web_v2", "update")},
+		3: {ChangesetASCII: "  # aws_security_group.web will be updated in-place\n  ~ resource \"aws_security_group\" \"web\" {"},
+		2: {ChangesetJSON: jsonPlan("aws_security_group.web", "delete", "create")},
+		1: {ChangesetJSON: jsonPlan("aws_security_group.web", "update")},
+	}
+
+	var gets atomic.Int32
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/stacks/org-uuid/42/drifts" {
+			if r.URL.Query().Get("drift_status") != "drifted" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"drifts": [{"id": 5}, {"id": 4}, {"id": 3}, {"id": 2}, {"id": 1}], "paginated_result": {"total": 5, "page": 1, "per_page": 4}}`))
+			return
+		}
+		gets.Add(1)
+		var id int
+		if _, err := fmt.Sscanf(r.URL.Path, "/v1/drifts/org-uuid/42/%d", &id); err != nil {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		d := details[id]
+		_ = json.NewEncoder(w).Encode(Drift{ID: id, StackID: 42, Status: "drifted", DriftDetails: &d})
+	})
+	defer cleanup()
+
+	history, err := client.Drifts.ListForResource(context.Background(), "org-uuid", 42, "aws_security_group.web", &ResourceDriftHistoryOptions{MaxRuns: 4})
+	if err != nil {
+		t.Fatalf("ListForResource error: %v", err)
+	}
+	if history.ScannedCount != 4 || !history.HasMore || gets.Load() != 4 {
+		t.Errorf("expected 4 runs inspected and more left, got %+v after %d gets", history, gets.Load())
+	}
+	if len(history.Runs) != 3 {
+		t.Fatalf("expected 3 runs, got %+v", history.Runs)
+	}
+	if history.Runs[0].DriftID != 5 || history.Runs[0].Address != "aws_security_group.web[0]" || history.Runs[0].Actions[0] != "update" {
+		t.Errorf("unexpected JSON run: %+v", history.Runs[0])
+	}
+	if history.Runs[1].DriftID != 3 || history.Runs[1].Actions != nil {
+		t.Errorf("unexpected ASCII run: %+v", history.Runs[1])
+	}
+	if history.Runs[2].DriftID != 2 || len(history.Runs[2].Actions) != 2 {
+		t.Errorf("unexpected replace run: %+v", history.Runs[2])
+	}
+
+	gets.Store(0)
+	history, err = client.Drifts.ListForResource(context.Background(), "org-uuid", 42, "aws_security_group.web",
+		&ResourceDriftHistoryOptions{MaxMatches: 1, Concurrency: 1})
+	if err != nil {
+		t.Fatalf("ListForResource error: %v", err)
+	}
+	if len(history.Runs) != 1 || history.Runs[0].DriftID != 5 || history.ScannedCount != 1 || !history.HasMore || gets.Load() != 1 {
+		t.Errorf("expected the scan to stop at the first match, got %+v after %d gets", history, gets.Load())
+	}
+
+	if _, err := client.Drifts.ListForResource(context.Background(), "org-uuid", 42, "", nil); err == nil {
+		t.Error("expected an error without an address")
+	}
+}
+
+func TestDriftsListGroupingKeys(t *testing.T) {
+	payload := `{
+		"grouping_keys": [
+			{"grouping_key": "nightly-drift", "repository": "github.com/acme/infra", "drift_count": 120, "stack_count": 40, "latest_status": "failed", "latest_started_at": "2024-04-12T02:00:00Z"}
+		],
+		"paginated_result": {"total": 1, "page": 1, "per_page": 20}
+	}`
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/drifts/org-uuid/grouping_keys" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("repository") != "github.com/acme/infra" || query.Get("latest_status") != "failed" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	})
+	defer cleanup()
+
+	result, _, err := client.Drifts.ListGroupingKeys(context.Background(), "org-uuid", &DriftGroupingKeysListOptions{
+		Repository:   []string{"github.com/acme/infra"},
+		LatestStatus: []string{"failed"},
+	})
+	if err != nil {
+		t.Fatalf("ListGroupingKeys error: %v", err)
+	}
+	if len(result.GroupingKeys) != 1 {
+		t.Fatalf("expected 1 grouping key, got %d", len(result.GroupingKeys))
+	}
+	key := result.GroupingKeys[0]
+	if key.GroupingKey != "nightly-drift" || key.DriftCount != 120 || key.LatestStatus != "failed" || key.LatestStartedAt == nil {
+		t.Errorf("unexpected grouping key: %+v", key)
+	}
+
+	if _, _, err := client.Drifts.ListGroupingKeys(context.Background(), "", nil); err == nil {
+		t.Error("expected an error without organization UUID")
+	}
+}
+
+func TestDriftsTrigger(t *testing.T) {
+	var requests int
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/drifts/org-uuid/42/trigger" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if requests > 1 {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error": "drift check already queued"}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"stack_id": 42, "status": "queued", "requested_at": "2026-03-02T09:30:00Z"}`))
+	})
+	defer cleanup()
+
+	request, _, err := client.Drifts.Trigger(context.Background(), "org-uuid", 42)
+	if err != nil {
+		t.Fatalf("Trigger error: %v", err)
+	}
+	if request.StackID != 42 || request.Status != "queued" || request.RequestedAt.IsZero() {
+		t.Errorf("unexpected drift check request: %+v", request)
+	}
+
+	_, _, err = client.Drifts.Trigger(context.Background(), "org-uuid", 42)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusConflict {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected the POST not to be retried, got %d requests", requests)
+	}
+
+	if _, _, err := client.Drifts.Trigger(context.Background(), "org-uuid", 0); err == nil {
+		t.Error("expected error for a non-positive stack ID")
+	}
+}
+
+func TestDriftsAcknowledge(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/drifts/org-uuid/42/7/acknowledge" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			var opts AcknowledgeDriftOptions
+			if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+				t.Errorf("decode: %v", err)
+			}
+			if opts.Comment != "waiting for PR 245" || opts.Until == nil {
+				t.Errorf("unexpected options: %+v", opts)
+			}
+			_, _ = w.Write([]byte(`{"id": 7, "stack_id": 42, "status": "drifted", "acknowledgement": {"acknowledged_at": "2026-03-02T09:30:00Z", "comment": "waiting for PR 245", "until": "2026-03-09T00:00:00Z"}}`))
+		case http.MethodDelete:
+			_, _ = w.Write([]byte(`{"id": 7, "stack_id": 42, "status": "drifted"}`))
+		default:
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+	})
+	defer cleanup()
+	ctx := context.Background()
+
+	until := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	drift, _, err := client.Drifts.Acknowledge(ctx, "org-uuid", 42, 7, AcknowledgeDriftOptions{Comment: "waiting for PR 245", Until: &until})
+	if err != nil {
+		t.Fatalf("Acknowledge error: %v", err)
+	}
+	if drift.Acknowledgement == nil || drift.Acknowledgement.Comment != "waiting for PR 245" || !drift.Acknowledgement.Until.Equal(until) {
+		t.Errorf("unexpected acknowledgement: %+v", drift.Acknowledgement)
+	}
+
+	drift, _, err = client.Drifts.Unacknowledge(ctx, "org-uuid", 42, 7)
+	if err != nil {
+		t.Fatalf("Unacknowledge error: %v", err)
+	}
+	if drift.Acknowledgement != nil {
+		t.Errorf("expected no acknowledgement, got %+v", drift.Acknowledgement)
+	}
+
+	if _, _, err := client.Drifts.Acknowledge(ctx, "org-uuid", 42, 0, AcknowledgeDriftOptions{}); err == nil {
+		t.Error("expected error for a non-positive drift ID")
+	}
+}
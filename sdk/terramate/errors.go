@@ -1,8 +1,11 @@
 package terramate
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 const (
@@ -10,19 +13,120 @@ const (
 	ErrAuthenticationFailed = "Authentication failed: credentials are invalid or expired"
 )
 
+// ErrFeatureUnavailable matches, with errors.Is, the errors returned without
+// sending a request by services the credential cannot access (see
+// Client.ProbeServices).
+var ErrFeatureUnavailable = errors.New("feature unavailable")
+
+// FeatureUnavailableError is returned by the methods of a service that
+// ProbeServices found the credential cannot access in an organization.
+type FeatureUnavailableError struct {
+	Service          Service
+	OrganizationUUID string
+	// StatusCode is the status the probe request was answered with
+	// (403 Forbidden or 404 Not Found).
+	StatusCode int
+}
+
+// Error implements the error interface
+func (e *FeatureUnavailableError) Error() string {
+	return fmt.Sprintf("%s API is not available to this credential in organization %s (probe returned %d %s)",
+		e.Service, e.OrganizationUUID, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Is reports whether target is ErrFeatureUnavailable.
+func (e *FeatureUnavailableError) Is(target error) bool {
+	return target == ErrFeatureUnavailable
+}
+
+// DefaultMaxResponseBytes is the default size limit of a response body.
+const DefaultMaxResponseBytes int64 = 10 << 20 // 10 MiB
+
+// ErrResponseTooLarge matches, with errors.Is, the errors returned when a
+// response body exceeds the client's size limit.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ResponseTooLargeError is returned when a response body exceeds the size
+// limit of the client (see WithMaxResponseBytes). The response is discarded
+// rather than decoded partially.
+type ResponseTooLargeError struct {
+	Method string
+	Path   string
+	// Limit is the size limit in bytes.
+	Limit int64
+}
+
+// Error implements the error interface
+func (e *ResponseTooLargeError) Error() string {
+	limit := fmt.Sprintf("%d bytes", e.Limit)
+	if e.Limit%(1<<20) == 0 {
+		limit = fmt.Sprintf("%d MiB", e.Limit>>20)
+	}
+	return fmt.Sprintf("response to %s %s exceeds the size limit of %s", e.Method, e.Path, limit)
+}
+
+// Is reports whether target is ErrResponseTooLarge.
+func (e *ResponseTooLargeError) Is(target error) bool {
+	return target == ErrResponseTooLarge
+}
+
+// Sentinel errors matched, with errors.Is, by the APIErrors of the
+// corresponding status codes.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
 // APIError represents an error returned by the Terramate Cloud API
 type APIError struct {
 	StatusCode int
 	Message    string
 	Details    map[string]interface{}
+	// RequestID is the ID the API assigned to the request (see
+	// RequestIDHeaders), to reference in support requests.
+	RequestID string
+	// CorrelationID is the correlation ID this client sent with the request
+	// (see WithCorrelationID), if any.
+	CorrelationID string
+	// RetryAfter is how long the API asked to wait before retrying, from the
+	// Retry-After header of 429 and 503 responses.
+	RetryAfter time.Duration
 }
 
-// Error implements the error interface
+// Error implements the error interface. Secrets echoed by the API are redacted.
 func (e *APIError) Error() string {
-	if len(e.Details) == 0 {
-		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+	msg := fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+	if len(e.Details) > 0 {
+		msg += fmt.Sprintf(" - %v", e.Details)
+	}
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(" (retry after %s)", e.RetryAfter)
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" [request ID: %s]", e.RequestID)
+	}
+	if e.CorrelationID != "" {
+		msg += fmt.Sprintf(" [correlation ID: %s]", e.CorrelationID)
+	}
+	return Redact(msg)
+}
+
+// Unwrap returns the sentinel error of the status code (ErrUnauthorized,
+// ErrForbidden, ErrNotFound or ErrRateLimited), or nil for other statuses.
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
 	}
-	return fmt.Sprintf("API error (status %d): %s - %v", e.StatusCode, e.Message, e.Details)
+	return nil
 }
 
 // IsNotFound returns true if the error is a 404 Not Found error
@@ -54,3 +158,40 @@ func (e *APIError) IsServerError() bool {
 func (e *APIError) IsClientError() bool {
 	return e.StatusCode >= 400 && e.StatusCode < 500
 }
+
+// RequestIDHeaders are the response headers parseAPIError reads the request
+// ID of an APIError from, in order. Only headers issued by the server are
+// listed: CorrelationIDHeader, which the client sends itself and proxies may
+// echo back, is reported as APIError.CorrelationID instead.
+var RequestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid"}
+
+// requestID returns the first request ID header set in h.
+func requestID(h http.Header) string {
+	for _, name := range RequestIDHeaders {
+		if id := h.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// retryAfter parses a Retry-After header, in seconds or as an HTTP date
+// relative to now. It returns 0 when the header is missing, invalid or in
+// the past.
+func retryAfter(h http.Header, now time.Time) time.Duration {
+	value := h.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	at, err := http.ParseTime(value)
+	if err != nil || !at.After(now) {
+		return 0
+	}
+	return at.Sub(now).Round(time.Second)
+}
@@ -1,8 +1,13 @@
 package terramate
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestAPIError_ErrorMessage(t *testing.T) {
@@ -25,6 +30,60 @@ func TestAPIError_ErrorMessage(t *testing.T) {
 	}
 }
 
+func TestAPIError_RequestIDAndRetryAfter(t *testing.T) {
+	err := &APIError{
+		StatusCode: http.StatusTooManyRequests,
+		Message:    "rate limit exceeded",
+		RequestID:  "req-123",
+		RetryAfter: 30 * time.Second,
+	}
+	expected := "API error (status 429): rate limit exceeded (retry after 30s) [request ID: req-123]"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestParseAPIError_CorrelationIDIsNotRequestID(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// a proxy echoing the client's correlation ID
+		w.Header().Set(CorrelationIDHeader, r.Header.Get(CorrelationIDHeader))
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer cleanup()
+
+	_, _, err := client.Stacks.Get(WithCorrelationID(context.Background(), "CHG-1234"), "org-uuid", 1)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an API error, got %v", err)
+	}
+	if apiErr.RequestID != "" || apiErr.CorrelationID != "CHG-1234" {
+		t.Fatalf("expected the correlation ID apart from the request ID, got %+v", apiErr)
+	}
+	if !strings.Contains(apiErr.Error(), "[correlation ID: CHG-1234]") {
+		t.Fatalf("expected the correlation ID in %q", apiErr.Error())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for value, want := range map[string]time.Duration{
+		"":                              0,
+		"120":                           2 * time.Minute,
+		"-1":                            0,
+		"soon":                          0,
+		"Sun, 01 Mar 2026 12:00:45 GMT": 45 * time.Second,
+		"Sun, 01 Mar 2026 11:59:00 GMT": 0,
+	} {
+		h := http.Header{}
+		if value != "" {
+			h.Set("Retry-After", value)
+		}
+		if got := retryAfter(h, now); got != want {
+			t.Errorf("Retry-After %q: expected %s, got %s", value, want, got)
+		}
+	}
+}
+
 func TestAPIError_IsNotFound(t *testing.T) {
 	err := &APIError{StatusCode: http.StatusNotFound}
 	if !err.IsNotFound() {
@@ -83,6 +142,30 @@ func TestAPIError_IsClientError(t *testing.T) {
 	}
 }
 
+func TestAPIError_Is(t *testing.T) {
+	for status, sentinel := range map[int]error{
+		http.StatusUnauthorized:    ErrUnauthorized,
+		http.StatusForbidden:       ErrForbidden,
+		http.StatusNotFound:        ErrNotFound,
+		http.StatusTooManyRequests: ErrRateLimited,
+	} {
+		err := fmt.Errorf("listing stacks: %w", &APIError{StatusCode: status})
+		if !errors.Is(err, sentinel) {
+			t.Errorf("expected status %d to match %v", status, sentinel)
+		}
+		if errors.Is(err, ErrFeatureUnavailable) {
+			t.Errorf("expected status %d not to match ErrFeatureUnavailable", status)
+		}
+	}
+
+	err := &APIError{StatusCode: http.StatusInternalServerError}
+	for _, sentinel := range []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited} {
+		if errors.Is(err, sentinel) {
+			t.Errorf("expected status 500 not to match %v", sentinel)
+		}
+	}
+}
+
 func TestErrorResponse_String(t *testing.T) {
 	er := &ErrorResponse{Error: "error"}
 	if er.String() != "error" {
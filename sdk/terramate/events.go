@@ -0,0 +1,99 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// EventsService handles communication with the audit log related methods of
+// the Terramate Cloud API.
+type EventsService struct {
+	client *Client
+}
+
+// buildQuery constructs URL query parameters from AuditEventsListOptions.
+func (opts *AuditEventsListOptions) buildQuery() url.Values {
+	query := url.Values{}
+	if opts == nil {
+		return query
+	}
+
+	addPagination(query, opts.Page, opts.PerPage)
+	addStringSlice(query, "action", opts.Action)
+	addStringSlice(query, "resource_type", opts.ResourceType)
+	addStringSlice(query, "user_uuid", opts.UserUUID)
+	addIntSlice(query, "stack_id", opts.StackID)
+	addTimePtr(query, "created_at_from", opts.CreatedAtFrom)
+	addTimePtr(query, "created_at_to", opts.CreatedAtTo)
+
+	return query
+}
+
+// List retrieves the audit log of an organization, newest first: who
+// archived a stack, who rotated an API key, when drift checks ran.
+//
+// GET /v1/organizations/{org_uuid}/events
+//
+// Access: Members of the organization with the admin role are allowed to query.
+func (s *EventsService) List(ctx context.Context, orgUUID string, opts *AuditEventsListOptions) (*AuditEventsListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if opts != nil && opts.CreatedAtFrom != nil && opts.CreatedAtTo != nil && opts.CreatedAtTo.Before(*opts.CreatedAtFrom) {
+		return nil, nil, fmt.Errorf("created_at_to must not be before created_at_from")
+	}
+
+	path := fmt.Sprintf("/v1/organizations/%s/events", orgUUID)
+
+	if opts != nil {
+		query := opts.buildQuery()
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result AuditEventsListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// All returns an iterator over the audit events matching opts, walking the
+// pages of List from opts.Page as the iteration advances.
+func (s *EventsService) All(ctx context.Context, orgUUID string, opts *AuditEventsListOptions) iter.Seq2[AuditEvent, error] {
+	return s.listPages(ctx, orgUUID, opts).all()
+}
+
+// ListAll accumulates the audit events matching opts in a slice, up to
+// maxItems (10000 when not positive), walking the pages of List from
+// opts.Page. The bool reports whether the result was truncated at maxItems.
+func (s *EventsService) ListAll(ctx context.Context, orgUUID string, opts *AuditEventsListOptions, maxItems int) ([]AuditEvent, bool, error) {
+	return s.listPages(ctx, orgUUID, opts).collect(maxItems)
+}
+
+// listPages pages through List from the page of opts.
+func (s *EventsService) listPages(ctx context.Context, orgUUID string, opts *AuditEventsListOptions) pages[AuditEvent] {
+	var o AuditEventsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[AuditEvent]{opts: o.ListOptions, list: func(page ListOptions) ([]AuditEvent, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.List(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Events, result.PaginatedResult, nil
+	}}
+}
@@ -0,0 +1,76 @@
+package terramate
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestEventsList_WithOptions(t *testing.T) {
+	payload := `{
+		"events": [
+			{
+				"event_uuid": "event-1",
+				"action": "stack.archived",
+				"actor_type": "user",
+				"actor": {"user_uuid": "user-1", "email": "jane@example.com", "display_name": "Jane"},
+				"resource_type": "stack",
+				"resource_id": "7",
+				"stack": {"stack_id": 7, "path": "/stacks/vpc", "meta_id": "vpc"},
+				"created_at": "2026-03-02T09:30:00Z"
+			}
+		],
+		"paginated_result": {"total": 1, "page": 1, "per_page": 20}
+	}`
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/organizations/org-uuid-123/events" {
+			t.Errorf("unexpected path: got %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("action") != "stack.archived,api_key.rotated" || query.Get("created_at_from") != "2026-03-01T00:00:00Z" || query.Get("created_at_to") != "" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, werr := w.Write([]byte(payload)); werr != nil {
+			panic(werr)
+		}
+	})
+	defer cleanup()
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	result, _, err := client.Events.List(context.Background(), "org-uuid-123", &AuditEventsListOptions{
+		Action:        []string{"stack.archived", "api_key.rotated"},
+		CreatedAtFrom: &from,
+	})
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(result.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(result.Events))
+	}
+	event := result.Events[0]
+	if event.Action != "stack.archived" || event.Actor == nil || event.Actor.Email != "jane@example.com" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if event.Stack == nil || event.Stack.StackID != 7 {
+		t.Errorf("unexpected stack: %+v", event.Stack)
+	}
+}
+
+func TestEventsList_Validation(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("unexpected request")
+	})
+	defer cleanup()
+
+	if _, _, err := client.Events.List(context.Background(), "", nil); err == nil {
+		t.Error("expected error for missing organization")
+	}
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+	if _, _, err := client.Events.List(context.Background(), "org-uuid", &AuditEventsListOptions{CreatedAtFrom: &from, CreatedAtTo: &to}); err == nil {
+		t.Error("expected error for an inverted time range")
+	}
+}
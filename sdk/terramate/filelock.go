@@ -0,0 +1,53 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// credentialLockPollInterval is how often lockCredentialFile retries a lock
+// held by another process.
+const credentialLockPollInterval = 50 * time.Millisecond
+
+// credentialLockPath returns the path of the advisory lock file of the
+// credential file at path.
+func credentialLockPath(path string) string {
+	return path + ".lock"
+}
+
+// lockCredentialFile takes the advisory lock of the credential file at path,
+// serializing token refreshes and credential file writes across the processes
+// using this package with the file, so one process cannot invalidate the
+// refresh token another one just rotated. The Terramate CLI does not take this
+// lock, so its refreshes are not serialized with ours. It waits until the lock
+// is free or ctx is done. The returned function releases the lock.
+func lockCredentialFile(ctx context.Context, path string) (func(), error) {
+	lockPath := credentialLockPath(path)
+	f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the credential lock file: %w", err)
+	}
+	ticker := time.NewTicker(credentialLockPollInterval)
+	defer ticker.Stop()
+	for {
+		locked, lockErr := tryLockFile(f)
+		if lockErr != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", lockPath, lockErr)
+		}
+		if locked {
+			return func() {
+				_ = unlockFile(f)
+				_ = f.Close()
+			}, nil
+		}
+		select {
+		case <-ctx.Done():
+			_ = f.Close()
+			return nil, fmt.Errorf("waiting for the lock %s held by another process: %w", lockPath, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLockCredentialFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	unlock, err := lockCredentialFile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("lockCredentialFile error: %v", err)
+	}
+
+	// The lock is per open file, so it excludes this process as well
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := lockCredentialFile(ctx, path); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the held lock to time out, got %v", err)
+	}
+
+	acquired := make(chan func())
+	go func() {
+		unlockSecond, lockErr := lockCredentialFile(context.Background(), path)
+		if lockErr != nil {
+			t.Errorf("lockCredentialFile error: %v", lockErr)
+		}
+		acquired <- unlockSecond
+	}()
+	time.Sleep(2 * credentialLockPollInterval)
+	unlock()
+	select {
+	case unlockSecond := <-acquired:
+		if unlockSecond != nil {
+			unlockSecond()
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the lock to be taken once released")
+	}
+}
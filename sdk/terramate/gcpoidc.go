@@ -0,0 +1,66 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// GCEMetadataHostEnv overrides the host of the GCP metadata server, as in the
+// Google Cloud client libraries.
+const GCEMetadataHostEnv = "GCE_METADATA_HOST"
+
+// defaultGCEMetadataHost is the metadata server of Compute Engine and of GKE
+// workload identity.
+const defaultGCEMetadataHost = "metadata.google.internal"
+
+// GCPMetadataTokenSource issues the ID tokens of the service account of a
+// Compute Engine VM, Cloud Run service or GKE pod (with workload identity),
+// from the GCP metadata server.
+type GCPMetadataTokenSource struct {
+	// MetadataURL defaults to http://$GCE_METADATA_HOST, or
+	// http://metadata.google.internal.
+	MetadataURL string
+
+	// HTTPClient defaults to a client with a 30 second timeout. It must reach
+	// the metadata server directly, without a proxy.
+	HTTPClient *http.Client
+}
+
+// NewGCPWorkloadIdentityCredential creates a credential from the ID tokens of
+// the GCP service account of the workload running the process.
+func NewGCPWorkloadIdentityCredential(ctx context.Context, opts ...OIDCOption) (*OIDCCredential, error) {
+	o := newOIDCOptions(opts)
+	return NewOIDCCredential(ctx, "GCP workload identity", &GCPMetadataTokenSource{HTTPClient: o.httpClient}, o.audience)
+}
+
+// Token implements OIDCTokenSource.
+func (g *GCPMetadataTokenSource) Token(ctx context.Context, audience string) (string, error) {
+	base := g.MetadataURL
+	if base == "" {
+		host := os.Getenv(GCEMetadataHostEnv)
+		if host == "" {
+			host = defaultGCEMetadataHost
+		}
+		base = "http://" + host
+	}
+	query := url.Values{"audience": {audience}, "format": {"full"}}
+	endpoint := strings.TrimSuffix(base, "/") + "/computeMetadata/v1/instance/service-accounts/default/identity?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	body, err := doTokenRequest(g.HTTPClient, req, "GCP identity token")
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(body))
+	if token == "" {
+		return "", errors.New("GCP identity token response has no token")
+	}
+	return token, nil
+}
@@ -0,0 +1,31 @@
+package terramate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGCPWorkloadIdentityCredential(t *testing.T) {
+	token := generateMockJWT()
+	var audience string
+	metadata := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" || r.URL.Path != "/computeMetadata/v1/instance/service-accounts/default/identity" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		audience = r.URL.Query().Get("audience")
+		_, _ = w.Write([]byte(token))
+	}))
+	defer metadata.Close()
+	t.Setenv(GCEMetadataHostEnv, metadata.Listener.Addr().String())
+
+	cred, err := NewGCPWorkloadIdentityCredential(context.Background(), WithOIDCAudience("acme@api.terramate.io"))
+	if err != nil {
+		t.Fatalf("NewGCPWorkloadIdentityCredential() error = %v", err)
+	}
+	if cred.token != token || audience != "acme@api.terramate.io" || cred.Name() != "GCP workload identity" {
+		t.Fatalf("expected the metadata server token, got audience %q", audience)
+	}
+}
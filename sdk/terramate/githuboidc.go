@@ -0,0 +1,82 @@
+package terramate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// Environment variables GitHub Actions sets in jobs granted the
+// id-token: write permission.
+const (
+	GitHubOIDCRequestURLEnv   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	GitHubOIDCRequestTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+)
+
+// GitHubActionsTokenSource issues the OIDC tokens of a GitHub Actions job,
+// like the Terramate CLI uses in workflows.
+type GitHubActionsTokenSource struct {
+	// RequestURL and RequestToken are the values of ACTIONS_ID_TOKEN_REQUEST_URL
+	// and ACTIONS_ID_TOKEN_REQUEST_TOKEN.
+	RequestURL   string
+	RequestToken string
+
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// GitHubOIDCAvailable reports whether the process runs in a GitHub Actions job
+// that can request OIDC tokens.
+func GitHubOIDCAvailable() bool {
+	return os.Getenv(GitHubOIDCRequestURLEnv) != ""
+}
+
+// NewGitHubOIDCCredential creates a credential from the OIDC tokens of the
+// GitHub Actions job running the process.
+func NewGitHubOIDCCredential(ctx context.Context, opts ...OIDCOption) (*OIDCCredential, error) {
+	o := newOIDCOptions(opts)
+	source := &GitHubActionsTokenSource{
+		RequestURL:   os.Getenv(GitHubOIDCRequestURLEnv),
+		RequestToken: os.Getenv(GitHubOIDCRequestTokenEnv),
+		HTTPClient:   o.httpClient,
+	}
+	if source.RequestURL == "" || source.RequestToken == "" {
+		return nil, fmt.Errorf("%s and %s are not set; grant the GitHub Actions job the id-token: write permission",
+			GitHubOIDCRequestURLEnv, GitHubOIDCRequestTokenEnv)
+	}
+	return NewOIDCCredential(ctx, "GitHub Actions OIDC", source, o.audience)
+}
+
+// Token implements OIDCTokenSource.
+func (g *GitHubActionsTokenSource) Token(ctx context.Context, audience string) (string, error) {
+	u, err := url.Parse(g.RequestURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s: %w", GitHubOIDCRequestURLEnv, err)
+	}
+	query := u.Query()
+	query.Set("audience", audience)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.RequestToken)
+	req.Header.Set("Accept", "application/json")
+	body, err := doTokenRequest(g.HTTPClient, req, "GitHub Actions OIDC token")
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Value string `json:"value"`
+	}
+	if parseErr := json.Unmarshal(body, &result); parseErr != nil || result.Value == "" {
+		return "", errors.New("GitHub Actions OIDC token response has no token")
+	}
+	return result.Value, nil
+}
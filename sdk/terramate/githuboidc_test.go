@@ -0,0 +1,72 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newGitHubOIDCServer serves OIDC tokens like the GitHub Actions token
+// endpoint, counting the requests.
+func newGitHubOIDCServer(t *testing.T, calls *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if r.Header.Get("Authorization") != "Bearer request-token" || r.URL.Query().Get("audience") != DefaultOIDCAudience {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"value":%q}`, generateMockJWT())
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv(GitHubOIDCRequestURLEnv, server.URL+"?api-version=2.0")
+	t.Setenv(GitHubOIDCRequestTokenEnv, "request-token")
+	return server
+}
+
+func TestGitHubOIDCCredential(t *testing.T) {
+	var calls int
+	newGitHubOIDCServer(t, &calls)
+	if !GitHubOIDCAvailable() {
+		t.Fatal("expected GitHub Actions OIDC to be detected")
+	}
+
+	cred, err := NewGitHubOIDCCredential(context.Background())
+	if err != nil {
+		t.Fatalf("NewGitHubOIDCCredential() error = %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.terramate.io/v1/memberships", nil)
+	if err := cred.ApplyCredentials(req); err != nil {
+		t.Fatalf("ApplyCredentials() error = %v", err)
+	}
+	if req.Header.Get("Authorization") != "Bearer "+cred.token || calls != 1 {
+		t.Fatalf("expected the fetched token to be applied once fetched, got %d fetches", calls)
+	}
+
+	if err := cred.Refresh(context.Background()); err != nil || calls != 2 {
+		t.Fatalf("expected Refresh to fetch a new token, got %d fetches (%v)", calls, err)
+	}
+
+	cred.source.(*GitHubActionsTokenSource).RequestToken = "revoked"
+	if err := cred.Refresh(context.Background()); err == nil {
+		t.Fatal("expected a rejected token request to fail")
+	}
+	if status := cred.Status(); !status.Refreshable || status.ExpiresAt.IsZero() || status.LastRefreshError == nil {
+		t.Fatalf("expected the failed fetch in the status, got %+v", status)
+	}
+}
+
+func TestGitHubOIDCCredential_Errors(t *testing.T) {
+	t.Setenv(GitHubOIDCRequestURLEnv, "")
+	if _, err := NewGitHubOIDCCredential(context.Background()); err == nil {
+		t.Fatal("expected an error outside of GitHub Actions")
+	}
+
+	var calls int
+	newGitHubOIDCServer(t, &calls)
+	if _, err := NewGitHubOIDCCredential(context.Background(), WithOIDCAudience("other")); err == nil {
+		t.Fatal("expected a rejected token request to fail")
+	}
+}
@@ -0,0 +1,21 @@
+package terramate
+
+// ActAsHeader carries the subject an API request acts as (see WithActAs).
+const ActAsHeader = "X-Terramate-Act-As"
+
+// WithActAs makes every API request of the client act as subject, e.g. the
+// email of a member or the name of a service account, sent in ActAsHeader. It
+// lets platform admins audit what an agent would see with the permissions of
+// that member. The API only honors the header for credentials allowed to
+// impersonate others; an empty subject sends no header.
+func WithActAs(subject string) ClientOption {
+	return func(c *Client) error {
+		c.actAs = subject
+		return nil
+	}
+}
+
+// ActAs returns the subject the requests of the client act as, or "".
+func (c *Client) ActAs() string {
+	return c.actAs
+}
@@ -0,0 +1,122 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Instrumentation receives lifecycle callbacks for the requests made by a Client.
+// It is the extension point for logging, metrics and tracing: the MCP server
+// builds its own observability on top of it, and SDK-only consumers can do the same.
+//
+// Implementations must be safe for concurrent use and should return quickly,
+// since hooks run inline on the request path. Embed NopInstrumentation to
+// implement only the hooks you need.
+type Instrumentation interface {
+	// OnRequestStart is called before a request is sent (once per logical
+	// request, not once per retry attempt).
+	OnRequestStart(ctx context.Context, req *http.Request)
+
+	// OnRequestEnd is called when a logical request has completed, including
+	// any transient-error retries.
+	OnRequestEnd(ctx context.Context, info RequestInfo)
+
+	// OnRetry is called before the client waits to retry a request after a
+	// transient failure (network error, 429 or 5xx).
+	OnRetry(ctx context.Context, info RetryInfo)
+
+	// OnRefresh is called after the client attempted to refresh its credential
+	// in response to a 401 Unauthorized.
+	OnRefresh(ctx context.Context, info RefreshInfo)
+}
+
+// RequestInfo describes a completed request.
+type RequestInfo struct {
+	Method     string
+	Path       string
+	Query      string // raw query string, without the leading "?"
+	StatusCode int    // 0 if no response was received
+	Duration   time.Duration
+	Err        error
+}
+
+// RetryInfo describes a retry decision.
+type RetryInfo struct {
+	Method     string
+	Path       string
+	Attempt    int // zero-based attempt that failed
+	Wait       time.Duration
+	StatusCode int // 0 for network errors
+	Err        error
+	// RetryAfter reports whether Wait is the Retry-After advertised by a 429
+	// response rather than the exponential backoff.
+	RetryAfter bool
+}
+
+// RefreshInfo describes a credential refresh attempt.
+type RefreshInfo struct {
+	Credential string // credential name, e.g. the JWT provider
+	Duration   time.Duration
+	Err        error
+}
+
+// NopInstrumentation implements Instrumentation with no-op hooks.
+type NopInstrumentation struct{}
+
+// OnRequestStart implements Instrumentation.
+func (NopInstrumentation) OnRequestStart(context.Context, *http.Request) {}
+
+// OnRequestEnd implements Instrumentation.
+func (NopInstrumentation) OnRequestEnd(context.Context, RequestInfo) {}
+
+// OnRetry implements Instrumentation.
+func (NopInstrumentation) OnRetry(context.Context, RetryInfo) {}
+
+// OnRefresh implements Instrumentation.
+func (NopInstrumentation) OnRefresh(context.Context, RefreshInfo) {}
+
+// instrumentations passes the events of a client to each of its hooks in
+// order.
+type instrumentations []Instrumentation
+
+// OnRequestStart implements Instrumentation.
+func (is instrumentations) OnRequestStart(ctx context.Context, req *http.Request) {
+	for _, i := range is {
+		i.OnRequestStart(ctx, req)
+	}
+}
+
+// OnRequestEnd implements Instrumentation.
+func (is instrumentations) OnRequestEnd(ctx context.Context, info RequestInfo) {
+	for _, i := range is {
+		i.OnRequestEnd(ctx, info)
+	}
+}
+
+// OnRetry implements Instrumentation.
+func (is instrumentations) OnRetry(ctx context.Context, info RetryInfo) {
+	for _, i := range is {
+		i.OnRetry(ctx, info)
+	}
+}
+
+// OnRefresh implements Instrumentation.
+func (is instrumentations) OnRefresh(ctx context.Context, info RefreshInfo) {
+	for _, i := range is {
+		i.OnRefresh(ctx, info)
+	}
+}
+
+// WithInstrumentation registers hooks that observe the client's requests,
+// retries and credential refreshes.
+func WithInstrumentation(instrumentation Instrumentation) ClientOption {
+	return func(c *Client) error {
+		if instrumentation == nil {
+			return fmt.Errorf("instrumentation cannot be nil")
+		}
+		c.instrumentation = instrumentation
+		return nil
+	}
+}
@@ -0,0 +1,173 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// recordingInstrumentation captures hook invocations for assertions.
+type recordingInstrumentation struct {
+	mu        sync.Mutex
+	starts    int
+	ends      []RequestInfo
+	retries   []RetryInfo
+	refreshes []RefreshInfo
+}
+
+func (r *recordingInstrumentation) OnRequestStart(context.Context, *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.starts++
+}
+
+func (r *recordingInstrumentation) OnRequestEnd(_ context.Context, info RequestInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ends = append(r.ends, info)
+}
+
+func (r *recordingInstrumentation) OnRetry(_ context.Context, info RetryInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retries = append(r.retries, info)
+}
+
+func (r *recordingInstrumentation) OnRefresh(_ context.Context, info RefreshInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refreshes = append(r.refreshes, info)
+}
+
+// stubRefreshableCredential is a RefreshableCredential with a scripted Refresh result.
+type stubRefreshableCredential struct {
+	token      string
+	refreshErr error
+}
+
+func (s *stubRefreshableCredential) ApplyCredentials(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	return nil
+}
+
+func (s *stubRefreshableCredential) Name() string { return "stub" }
+
+func (s *stubRefreshableCredential) Refresh(context.Context) error {
+	if s.refreshErr != nil {
+		return s.refreshErr
+	}
+	s.token = "fresh"
+	return nil
+}
+
+func TestInstrumentation_RequestAndRetryHooks(t *testing.T) {
+	attempts := atomic.Int32{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	rec := &recordingInstrumentation{}
+	c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL), WithInstrumentation(rec))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	if _, _, err := c.Memberships.List(context.Background()); err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+
+	if rec.starts != 1 || len(rec.ends) != 1 {
+		t.Fatalf("expected one start/end pair, got %d/%d", rec.starts, len(rec.ends))
+	}
+	end := rec.ends[0]
+	if end.Method != http.MethodGet || end.Path != "/v1/memberships" || end.StatusCode != http.StatusOK || end.Err != nil {
+		t.Fatalf("unexpected end info: %+v", end)
+	}
+	if len(rec.retries) != 1 || rec.retries[0].StatusCode != http.StatusServiceUnavailable || rec.retries[0].Attempt != 0 {
+		t.Fatalf("unexpected retries: %+v", rec.retries)
+	}
+}
+
+func TestInstrumentation_RequestQuery(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"stacks":[],"paginated_result":{"total":0,"page":1,"per_page":10}}`))
+	}))
+	defer ts.Close()
+
+	rec := &recordingInstrumentation{}
+	c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL), WithInstrumentation(rec))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	opts := &StacksListOptions{DriftStatus: []string{"drifted"}}
+	if _, _, err := c.Stacks.List(context.Background(), "org-uuid", opts); err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+
+	if len(rec.ends) != 1 || rec.ends[0].Path != "/v1/stacks/org-uuid" || rec.ends[0].Query != "drift_status=drifted" {
+		t.Fatalf("expected the request query to be reported, got %+v", rec.ends)
+	}
+}
+
+func TestInstrumentation_RefreshHook(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	rec := &recordingInstrumentation{}
+	c, err := NewClient(&stubRefreshableCredential{token: "stale"}, WithBaseURL(ts.URL), WithInstrumentation(rec))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	if _, _, err := c.Memberships.List(context.Background()); err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(rec.refreshes) != 1 || rec.refreshes[0].Err != nil || rec.refreshes[0].Credential != "stub" {
+		t.Fatalf("unexpected refreshes: %+v", rec.refreshes)
+	}
+	if len(rec.ends) != 2 {
+		t.Fatalf("expected original request and retry to be reported, got %d", len(rec.ends))
+	}
+}
+
+func TestInstrumentation_RefreshFailureReported(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	rec := &recordingInstrumentation{}
+	refreshErr := errors.New("refresh token revoked")
+	c, err := NewClient(&stubRefreshableCredential{token: "stale", refreshErr: refreshErr}, WithBaseURL(ts.URL), WithInstrumentation(rec))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	if _, _, err := c.Memberships.List(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(rec.refreshes) != 1 || !errors.Is(rec.refreshes[0].Err, refreshErr) {
+		t.Fatalf("unexpected refreshes: %+v", rec.refreshes)
+	}
+}
+
+func TestWithInstrumentation_RejectsNil(t *testing.T) {
+	if _, err := NewClientWithAPIKey("key", WithInstrumentation(nil)); err == nil {
+		t.Fatal("expected error for nil instrumentation")
+	}
+}
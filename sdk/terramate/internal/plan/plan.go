@@ -0,0 +1,170 @@
+// Package plan parses the JSON plans of Terraform and OpenTofu (the output of
+// `terraform show -json`) and finds the changes to a resource in them. It
+// backs the changeset package and Drifts.ListForResource.
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Action is an action planned on a resource.
+type Action string
+
+// Actions planned on a resource.
+const (
+	ActionNoop   Action = "no-op"
+	ActionCreate Action = "create"
+	ActionRead   Action = "read"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	ActionForget Action = "forget"
+)
+
+// Actions are the actions planned on a resource: a single action, or a
+// delete and a create for a replacement.
+type Actions []Action
+
+// is reports whether the actions are exactly want.
+func (a Actions) is(want ...Action) bool {
+	if len(a) != len(want) {
+		return false
+	}
+	for i := range a {
+		if a[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// NoOp reports whether the resource is left unchanged.
+func (a Actions) NoOp() bool { return a.is(ActionNoop) }
+
+// Create reports whether the resource is created.
+func (a Actions) Create() bool { return a.is(ActionCreate) }
+
+// Read reports whether the data source is read.
+func (a Actions) Read() bool { return a.is(ActionRead) }
+
+// Update reports whether the resource is updated in place.
+func (a Actions) Update() bool { return a.is(ActionUpdate) }
+
+// Delete reports whether the resource is destroyed.
+func (a Actions) Delete() bool { return a.is(ActionDelete) }
+
+// Forget reports whether the resource is removed from the state without
+// being destroyed.
+func (a Actions) Forget() bool { return a.is(ActionForget) }
+
+// DestroyBeforeCreate reports whether the resource is replaced, destroying
+// it first.
+func (a Actions) DestroyBeforeCreate() bool { return a.is(ActionDelete, ActionCreate) }
+
+// CreateBeforeDestroy reports whether the resource is replaced, creating its
+// replacement first.
+func (a Actions) CreateBeforeDestroy() bool { return a.is(ActionCreate, ActionDelete) }
+
+// Replace reports whether the resource is replaced, in either order.
+func (a Actions) Replace() bool { return a.DestroyBeforeCreate() || a.CreateBeforeDestroy() }
+
+// Plan is a Terraform or OpenTofu plan in its JSON format.
+type Plan struct {
+	FormatVersion    string             `json:"format_version,omitempty"`
+	TerraformVersion string             `json:"terraform_version,omitempty"`
+	ResourceChanges  []*ResourceChange  `json:"resource_changes,omitempty"`
+	ResourceDrift    []*ResourceChange  `json:"resource_drift,omitempty"`
+	OutputChanges    map[string]*Change `json:"output_changes,omitempty"`
+	Errored          bool               `json:"errored,omitempty"`
+}
+
+// ResourceChange is the change planned on a resource.
+type ResourceChange struct {
+	Address         string      `json:"address,omitempty"`
+	PreviousAddress string      `json:"previous_address,omitempty"`
+	ModuleAddress   string      `json:"module_address,omitempty"`
+	Mode            string      `json:"mode,omitempty"` // managed, data
+	Type            string      `json:"type,omitempty"`
+	Name            string      `json:"name,omitempty"`
+	Index           interface{} `json:"index,omitempty"` // count (number) or for_each (string) key
+	ProviderName    string      `json:"provider_name,omitempty"`
+	DeposedKey      string      `json:"deposed,omitempty"`
+	Change          *Change     `json:"change,omitempty"`
+	ActionReason    string      `json:"action_reason,omitempty"`
+}
+
+// Moved reports whether the resource moved from another address.
+func (rc *ResourceChange) Moved() bool {
+	return rc.PreviousAddress != "" && rc.PreviousAddress != rc.Address
+}
+
+// Change is a planned change: its actions and the values before and after.
+// Values are the decoded JSON of the attributes (maps, slices, strings,
+// float64s, bools and nils).
+type Change struct {
+	Actions         Actions     `json:"actions,omitempty"`
+	Before          interface{} `json:"before,omitempty"`
+	After           interface{} `json:"after,omitempty"`
+	AfterUnknown    interface{} `json:"after_unknown,omitempty"`
+	BeforeSensitive interface{} `json:"before_sensitive,omitempty"`
+	AfterSensitive  interface{} `json:"after_sensitive,omitempty"`
+	Importing       *Importing  `json:"importing,omitempty"`
+	GeneratedConfig string      `json:"generated_config,omitempty"`
+}
+
+// Importing describes the import of a resource into the state.
+type Importing struct {
+	ID string `json:"id,omitempty"`
+}
+
+// Parse parses a JSON plan.
+func Parse(data []byte) (*Plan, error) {
+	var plan Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	return &plan, nil
+}
+
+// Find returns the change to the resource at address, or to one of its
+// instances (aws_security_group.web matches aws_security_group.web[0]), in
+// the resource changes then the resource drift of the plan. No-op changes and
+// reads are skipped. It returns nil when the resource does not change.
+func (p *Plan) Find(address string) *ResourceChange {
+	for _, changes := range [][]*ResourceChange{p.ResourceChanges, p.ResourceDrift} {
+		for _, rc := range changes {
+			if rc.Change == nil || len(rc.Change.Actions) == 0 || rc.Change.Actions.NoOp() || rc.Change.Actions.Read() {
+				continue
+			}
+			if matches(rc.Address, address) {
+				return rc
+			}
+		}
+	}
+	return nil
+}
+
+// FindASCII returns the address of the resource at address, or of one of
+// its instances, heading a change in a plan in its ASCII format, which heads
+// each change with "# <address> has changed", "will be updated in-place",
+// ... It returns "" when the resource does not change.
+func FindASCII(text, address string) string {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "# ") {
+			continue
+		}
+		found, _, _ := strings.Cut(strings.TrimPrefix(line, "# "), " ")
+		if matches(found, address) {
+			return found
+		}
+	}
+	return ""
+}
+
+// matches reports whether found is the resource at address or one of its
+// instances.
+func matches(found, address string) bool {
+	return found == address || strings.HasPrefix(found, address+"[")
+}
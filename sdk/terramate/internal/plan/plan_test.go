@@ -0,0 +1,45 @@
+package plan
+
+import "testing"
+
+const testPlan = `{
+	"resource_changes": [
+		{"address": "aws_instance.web", "change": {"actions": ["no-op"]}},
+		{"address": "data.aws_ami.ubuntu", "change": {"actions": ["read"]}},
+		{"address": "aws_security_group.web_v2", "change": {"actions": ["update"]}},
+		{"address": "aws_security_group.web[0]", "change": {"actions": ["delete", "create"]}}
+	],
+	"resource_drift": [
+		{"address": "aws_instance.web", "change": {"actions": ["update"]}}
+	]
+}`
+
+func TestFind(t *testing.T) {
+	p, err := Parse([]byte(testPlan))
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if rc := p.Find("aws_security_group.web"); rc == nil || rc.Address != "aws_security_group.web[0]" || !rc.Change.Actions.Replace() {
+		t.Errorf("expected the replaced instance, got %+v", rc)
+	}
+	if rc := p.Find("aws_instance.web"); rc == nil || !rc.Change.Actions.Update() {
+		t.Errorf("expected the drifted instance, got %+v", rc)
+	}
+	for _, address := range []string{"data.aws_ami.ubuntu", "aws_s3_bucket.logs"} {
+		if rc := p.Find(address); rc != nil {
+			t.Errorf("expected no change to %s, got %+v", address, rc)
+		}
+	}
+}
+
+func TestFindASCII(t *testing.T) {
+	text := "  # aws_security_group.web_v2 will be updated in-place\n" +
+		"  # aws_security_group.web[\"eu\"] has changed\n" +
+		"  ~ resource \"aws_security_group\" \"web\" {"
+	if got := FindASCII(text, "aws_security_group.web"); got != `aws_security_group.web["eu"]` {
+		t.Errorf("expected the changed instance, got %q", got)
+	}
+	if got := FindASCII(text, "aws_instance.web"); got != "" {
+		t.Errorf("expected no change, got %q", got)
+	}
+}
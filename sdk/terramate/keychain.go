@@ -0,0 +1,159 @@
+package terramate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+const (
+	// DefaultKeychainService is the service name of Keychain.
+	DefaultKeychainService = "terramate-cloud"
+	// DefaultKeychainAccount is the account name of Keychain.
+	DefaultKeychainAccount = "default"
+)
+
+// ErrKeychainItemNotFound is returned when the OS keychain holds no credential.
+var ErrKeychainItemNotFound = errors.New("no Terramate Cloud credential in the OS keychain")
+
+// keychainName restricts service and account names, which are passed to the
+// security and secret-tool commands.
+var keychainName = regexp.MustCompile(`^[A-Za-z0-9._@-]+$`)
+
+// keychainBackend reads and writes secrets of the OS keychain.
+type keychainBackend interface {
+	get(service, account string) ([]byte, error) // ErrKeychainItemNotFound if missing
+	set(service, account string, secret []byte) error
+	delete(service, account string) error // nil if missing
+}
+
+// osKeychain is the keychain of the running OS (see nativeKeychain); tests
+// replace it.
+var osKeychain keychainBackend = nativeKeychain{}
+
+// Keychain stores a JWT credential in the OS keychain instead of the
+// plaintext credentials.tmrc.json: the macOS Keychain (through the security
+// command), the Windows Credential Manager, or a Secret Service provider such
+// as GNOME Keyring or KWallet on Linux (through secret-tool from libsecret).
+// The item holds the fields of the credential file as JSON.
+type Keychain struct {
+	// Service defaults to DefaultKeychainService.
+	Service string
+	// Account defaults to DefaultKeychainAccount, e.g. one per profile.
+	Account string
+}
+
+// String describes the keychain item, e.g. in log messages.
+func (k Keychain) String() string {
+	service, account := k.names()
+	return fmt.Sprintf("the OS keychain (service %s, account %s)", service, account)
+}
+
+// names returns the service and account names with their defaults applied.
+func (k Keychain) names() (string, string) {
+	return defaultString(k.Service, DefaultKeychainService), defaultString(k.Account, DefaultKeychainAccount)
+}
+
+// load reads the credential from the keychain.
+func (k Keychain) load() (cachedCredential, error) {
+	var cached cachedCredential
+	service, account := k.names()
+	if !keychainName.MatchString(service) || !keychainName.MatchString(account) {
+		return cached, fmt.Errorf("invalid keychain service %q or account %q", service, account)
+	}
+	data, err := osKeychain.get(service, account)
+	if err != nil {
+		return cached, err
+	}
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return cached, fmt.Errorf("failed to parse the credential in the OS keychain: %w", err)
+	}
+	return cached, nil
+}
+
+// store writes cached to the keychain, replacing the previous credential.
+func (k Keychain) store(cached cachedCredential) error {
+	service, account := k.names()
+	if !keychainName.MatchString(service) || !keychainName.MatchString(account) {
+		return fmt.Errorf("invalid keychain service %q or account %q", service, account)
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+	if err := osKeychain.set(service, account, data); err != nil {
+		return fmt.Errorf("failed to store the credential in the OS keychain: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the credential from the keychain. A missing credential is
+// not an error.
+func (k Keychain) Delete() error {
+	service, account := k.names()
+	if err := osKeychain.delete(service, account); err != nil {
+		return fmt.Errorf("failed to remove the credential from the OS keychain: %w", err)
+	}
+	return nil
+}
+
+// Store stores the tokens of result in the keychain. region is the optional
+// region hint of the credential.
+func (k Keychain) Store(result *LoginResult, region string) error {
+	if _, ok := regionBaseURLs[region]; region != "" && !ok {
+		return fmt.Errorf("invalid region: %q", region)
+	}
+	return k.store(cachedCredential{
+		Provider:     providerGitHub,
+		IDToken:      result.IDToken,
+		RefreshToken: result.RefreshToken,
+		Region:       region,
+	})
+}
+
+// ImportCredentialFile moves the credential file at path into the keychain:
+// the file is validated as by LoadJWTFromFile, stored in the keychain and
+// removed, so the tokens no longer sit on disk in plaintext.
+func (k Keychain) ImportCredentialFile(path string) error {
+	cred, err := LoadJWTFromFile(path)
+	if err != nil {
+		return err
+	}
+	if err := k.store(cachedCredential{
+		Provider:     cred.provider,
+		IDToken:      cred.idToken,
+		RefreshToken: cred.refreshToken,
+		Region:       cred.region,
+	}); err != nil {
+		return err
+	}
+	if err := os.Remove(cred.credentialPath); err != nil {
+		return fmt.Errorf("stored the credential in the OS keychain but failed to remove %s: %w", cred.credentialPath, err)
+	}
+	return nil
+}
+
+// LoadJWTFromKeychain loads a JWT credential from the keychain. It returns an
+// error wrapping ErrKeychainItemNotFound when the keychain has no credential.
+// Refreshed tokens are written back to the keychain. Unlike the credential
+// file, the keychain is not watched for external updates.
+func LoadJWTFromKeychain(k Keychain, opts ...JWTOption) (*JWTCredential, error) {
+	cached, err := k.load()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := regionBaseURLs[cached.Region]; cached.Region != "" && !ok {
+		return nil, fmt.Errorf("the credential in the OS keychain has an invalid region %q (must be 'eu' or 'us')", cached.Region)
+	}
+	cred, err := NewJWTCredential(cached.IDToken, cached.Provider, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential in the OS keychain (log in again): %w", err)
+	}
+	RegisterSecret(cached.RefreshToken)
+	cred.refreshToken = cached.RefreshToken
+	cred.region = cached.Region
+	cred.keychain = &k
+	return cred, nil
+}
@@ -0,0 +1,64 @@
+//go:build darwin
+
+package terramate
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// securityCommand manages the macOS Keychain.
+const securityCommand = "/usr/bin/security"
+
+// errSecItemNotFound is the exit status of security for a missing item.
+const errSecItemNotFound = 44
+
+// nativeKeychain stores secrets as generic passwords in the login keychain.
+type nativeKeychain struct{}
+
+func (nativeKeychain) get(service, account string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command(securityCommand, "find-generic-password", "-s", service, "-a", account, "-w")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == errSecItemNotFound {
+		return nil, ErrKeychainItemNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("security find-generic-password: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return bytes.TrimSuffix(out, []byte("\n")), nil
+}
+
+func (nativeKeychain) set(service, account string, secret []byte) error {
+	// The secret goes through stdin in interactive mode, so it does not show
+	// up in the process list
+	var stderr bytes.Buffer
+	cmd := exec.Command(securityCommand, "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n", service, account, hex.EncodeToString(secret)))
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("security add-generic-password: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (nativeKeychain) delete(service, account string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(securityCommand, "delete-generic-password", "-s", service, "-a", account)
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == errSecItemNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("security delete-generic-password: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+//go:build linux
+
+package terramate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secretToolCommand talks to the Secret Service (GNOME Keyring, KWallet,
+// KeePassXC) over D-Bus; it is part of libsecret-tools.
+const secretToolCommand = "secret-tool"
+
+// nativeKeychain stores secrets in the default Secret Service collection.
+type nativeKeychain struct{}
+
+func (nativeKeychain) get(service, account string) ([]byte, error) {
+	out, stderr, err := runSecretTool(nil, "lookup", "service", service, "account", account)
+	// secret-tool exits with 1 and prints nothing when no item matches
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(out) == 0 && stderr == "" {
+		return nil, ErrKeychainItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(out, []byte("\n")), nil
+}
+
+func (nativeKeychain) set(service, account string, secret []byte) error {
+	// secret-tool reads the secret from stdin, so it does not show up in the
+	// process list
+	_, _, err := runSecretTool(secret, "store", "--label", "Terramate Cloud credential", "service", service, "account", account)
+	return err
+}
+
+func (nativeKeychain) delete(service, account string) error {
+	_, stderr, err := runSecretTool(nil, "clear", "service", service, "account", account)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && stderr == "" {
+		return nil // nothing to clear
+	}
+	return err
+}
+
+// runSecretTool runs secret-tool with args, writing stdin to it.
+func runSecretTool(stdin []byte, args ...string) ([]byte, string, error) {
+	path, err := exec.LookPath(secretToolCommand)
+	if err != nil {
+		return nil, "", fmt.Errorf("the OS keychain requires %s (package libsecret-tools or libsecret): %w", secretToolCommand, err)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		return stdout.Bytes(), message, fmt.Errorf("secret-tool %s: %w: %s", args[0], err, message)
+	}
+	return stdout.Bytes(), "", nil
+}
@@ -0,0 +1,18 @@
+//go:build !darwin && !linux && !windows
+
+package terramate
+
+import "errors"
+
+// errKeychainUnsupported is returned by the OS keychain on platforms without
+// a supported keychain.
+var errKeychainUnsupported = errors.New("the OS keychain is only supported on macOS, Windows and Linux")
+
+// nativeKeychain is unavailable on this platform.
+type nativeKeychain struct{}
+
+func (nativeKeychain) get(_, _ string) ([]byte, error) { return nil, errKeychainUnsupported }
+
+func (nativeKeychain) set(_, _ string, _ []byte) error { return errKeychainUnsupported }
+
+func (nativeKeychain) delete(_, _ string) error { return errKeychainUnsupported }
@@ -0,0 +1,120 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeKeychain is an in-memory keychainBackend.
+type fakeKeychain map[string][]byte
+
+func (f fakeKeychain) get(service, account string) ([]byte, error) {
+	secret, ok := f[service+":"+account]
+	if !ok {
+		return nil, ErrKeychainItemNotFound
+	}
+	return secret, nil
+}
+
+func (f fakeKeychain) set(service, account string, secret []byte) error {
+	f[service+":"+account] = secret
+	return nil
+}
+
+func (f fakeKeychain) delete(service, account string) error {
+	delete(f, service+":"+account)
+	return nil
+}
+
+// useFakeKeychain replaces the OS keychain for the test.
+func useFakeKeychain(t *testing.T) fakeKeychain {
+	t.Helper()
+	fake := fakeKeychain{}
+	previous := osKeychain
+	osKeychain = fake
+	t.Cleanup(func() { osKeychain = previous })
+	return fake
+}
+
+func TestKeychain_StoreAndLoad(t *testing.T) {
+	fake := useFakeKeychain(t)
+	kc := Keychain{Account: "prod"}
+	if _, err := LoadJWTFromKeychain(kc); !errors.Is(err, ErrKeychainItemNotFound) {
+		t.Fatalf("expected ErrKeychainItemNotFound, got %v", err)
+	}
+
+	if err := kc.Store(&LoginResult{IDToken: generateMockJWT(), RefreshToken: "refresh"}, RegionUS); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if _, ok := fake[DefaultKeychainService+":prod"]; !ok {
+		t.Fatalf("expected the item %s:prod, got %v", DefaultKeychainService, fake)
+	}
+	cred, err := LoadJWTFromKeychain(kc)
+	if err != nil {
+		t.Fatalf("LoadJWTFromKeychain() error = %v", err)
+	}
+	if cred.Name() != "GitHub" || cred.Region() != RegionUS || cred.refreshToken != "refresh" {
+		t.Fatalf("unexpected credential: provider %q region %q", cred.Name(), cred.Region())
+	}
+	if err := cred.StartWatching(context.Background()); err == nil {
+		t.Error("expected the keychain not to be watched")
+	}
+}
+
+func TestKeychain_RefreshAndLogout(t *testing.T) {
+	useFakeKeychain(t)
+	kc := Keychain{}
+	if err := kc.Store(&LoginResult{IDToken: generateMockJWT(), RefreshToken: "refresh"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	newToken := generateMockJWT()
+	cred, err := LoadJWTFromKeychain(kc, WithRefreshTransport(&fakeRefreshTransport{tokens: &RefreshedTokens{IDToken: newToken, RefreshToken: "rotated"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cred.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	stored, err := kc.load()
+	if err != nil || stored.IDToken != newToken || stored.RefreshToken != "rotated" {
+		t.Fatalf("expected the refreshed tokens in the keychain, got %+v (%v)", stored, err)
+	}
+
+	if err := cred.Logout(context.Background(), nil); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if _, err := kc.load(); !errors.Is(err, ErrKeychainItemNotFound) {
+		t.Fatalf("expected the keychain item to be deleted, got %v", err)
+	}
+}
+
+func TestKeychain_ImportCredentialFile(t *testing.T) {
+	useFakeKeychain(t)
+	path := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	if err := os.WriteFile(path, []byte(createTestCredentialFile("Google", generateMockJWT(), "refresh")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	kc := Keychain{}
+	if err := kc.ImportCredentialFile(path); err != nil {
+		t.Fatalf("ImportCredentialFile() error = %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the credential file to be removed, got %v", err)
+	}
+	cred, err := LoadJWTFromKeychain(kc)
+	if err != nil || cred.Name() != "Google" {
+		t.Fatalf("expected the imported credential, got %v", err)
+	}
+}
+
+func TestKeychain_InvalidAccount(t *testing.T) {
+	useFakeKeychain(t)
+	if err := (Keychain{Account: "prod -w"}).Store(&LoginResult{IDToken: generateMockJWT()}, ""); err == nil {
+		t.Fatal("expected an error for an account name the keychain commands cannot take")
+	}
+}
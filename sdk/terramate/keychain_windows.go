@@ -0,0 +1,103 @@
+//go:build windows
+
+package terramate
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	// credMaxBlobSize is CRED_MAX_CREDENTIAL_BLOB_SIZE.
+	credMaxBlobSize = 5 * 512
+)
+
+var (
+	advapi32        = windows.NewLazySystemDLL("advapi32.dll")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+// credential is the CREDENTIALW structure of the Credential Manager API.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// nativeKeychain stores secrets as generic credentials of the Windows
+// Credential Manager, named service:account.
+type nativeKeychain struct{}
+
+func (nativeKeychain) get(service, account string) ([]byte, error) {
+	target, err := windows.UTF16PtrFromString(service + ":" + account)
+	if err != nil {
+		return nil, err
+	}
+	var cred *credential
+	ret, _, callErr := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		if errors.Is(callErr, windows.ERROR_NOT_FOUND) {
+			return nil, ErrKeychainItemNotFound
+		}
+		return nil, fmt.Errorf("CredRead: %w", callErr)
+	}
+	defer func() { _, _, _ = procCredFree.Call(uintptr(unsafe.Pointer(cred))) }()
+	if cred.CredentialBlobSize == 0 {
+		return nil, nil
+	}
+	return append([]byte(nil), unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)...), nil
+}
+
+func (nativeKeychain) set(service, account string, secret []byte) error {
+	if len(secret) == 0 || len(secret) > credMaxBlobSize {
+		return fmt.Errorf("the credential has %d bytes; the Windows Credential Manager stores 1 to %d", len(secret), credMaxBlobSize)
+	}
+	target, err := windows.UTF16PtrFromString(service + ":" + account)
+	if err != nil {
+		return err
+	}
+	userName, err := windows.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(secret)),
+		CredentialBlob:     &secret[0],
+		Persist:            credPersistLocalMachine,
+		UserName:           userName,
+	}
+	if ret, _, callErr := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0); ret == 0 {
+		return fmt.Errorf("CredWrite: %w", callErr)
+	}
+	return nil
+}
+
+func (nativeKeychain) delete(service, account string) error {
+	target, err := windows.UTF16PtrFromString(service + ":" + account)
+	if err != nil {
+		return err
+	}
+	if ret, _, callErr := procCredDeleteW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0); ret == 0 && !errors.Is(callErr, windows.ERROR_NOT_FOUND) {
+		return fmt.Errorf("CredDelete: %w", callErr)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package terramate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Default endpoints of DeviceLogin.
+const (
+	DefaultDeviceCodeURL  = "https://github.com/login/device/code"
+	DefaultAccessTokenURL = "https://github.com/login/oauth/access_token"
+)
+
+const (
+	// deviceGrantType is the grant type of the device access token request (RFC 8628).
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+	// defaultDevicePollInterval is the polling interval when the provider sets none.
+	defaultDevicePollInterval = 5 * time.Second
+)
+
+// ErrLoginExpired is returned by DeviceLogin.Wait when the user did not
+// authorize the login before the device code expired.
+var ErrLoginExpired = errors.New("login expired before it was authorized")
+
+// ErrLoginDenied is returned by DeviceLogin.Wait when the user denied the login.
+var ErrLoginDenied = errors.New("login was denied")
+
+// DeviceLogin logs in to Terramate Cloud with GitHub, without a browser on the
+// machine running it: the user authorizes the login on any device (the OAuth
+// 2.0 device authorization grant, RFC 8628), and the GitHub access token is
+// exchanged for Terramate Cloud tokens with Firebase Auth, which backs
+// Terramate Cloud logins. The result is the credential file written by
+// 'terramate cloud login'.
+type DeviceLogin struct {
+	// ClientID is the client ID of the GitHub OAuth app accepted by the
+	// identity provider of Terramate Cloud; required.
+	ClientID string
+
+	// DeviceCodeURL and AccessTokenURL default to the GitHub endpoints.
+	DeviceCodeURL  string
+	AccessTokenURL string
+
+	// SignInEndpoint is the Firebase Auth endpoint exchanging the access token,
+	// including the API key query parameter. Defaults to
+	// https://identitytoolkit.googleapis.com/v1/accounts:signInWithIdp?key=<key>,
+	// where the key can be overridden with TMC_API_IDP_KEY.
+	SignInEndpoint string
+
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// DeviceCode is a pending login the user authorizes by entering UserCode at
+// VerificationURI.
+type DeviceCode struct {
+	UserCode        string    `json:"user_code"`
+	VerificationURI string    `json:"verification_uri"`
+	ExpiresAt       time.Time `json:"expires_at"`
+
+	deviceCode string
+	interval   time.Duration
+}
+
+// LoginResult holds the tokens of a completed login.
+type LoginResult struct {
+	IDToken      string
+	RefreshToken string
+	// Email is the email address of the account, if the provider shares it.
+	Email string
+}
+
+// Start requests a device code for a new login.
+func (d *DeviceLogin) Start(ctx context.Context) (*DeviceCode, error) {
+	if d.ClientID == "" {
+		return nil, fmt.Errorf("login requires the client ID of the GitHub OAuth app")
+	}
+	var resp struct {
+		DeviceCode      string `json:"device_code"`
+		UserCode        string `json:"user_code"`
+		VerificationURI string `json:"verification_uri"`
+		ExpiresIn       int    `json:"expires_in"`
+		Interval        int    `json:"interval"`
+		Error           string `json:"error"`
+		ErrorDesc       string `json:"error_description"`
+	}
+	form := url.Values{"client_id": {d.ClientID}, "scope": {"read:user user:email"}}
+	if err := d.postForm(ctx, defaultString(d.DeviceCodeURL, DefaultDeviceCodeURL), form, &resp); err != nil {
+		return nil, fmt.Errorf("failed to start login: %w", err)
+	}
+	if resp.Error != "" || resp.DeviceCode == "" {
+		return nil, fmt.Errorf("failed to start login: %s", oauthError(resp.Error, resp.ErrorDesc))
+	}
+	interval := time.Duration(resp.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+	return &DeviceCode{
+		UserCode:        resp.UserCode,
+		VerificationURI: resp.VerificationURI,
+		ExpiresAt:       time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second),
+		deviceCode:      resp.DeviceCode,
+		interval:        interval,
+	}, nil
+}
+
+// Wait polls until the user authorized the login of code, then exchanges the
+// access token for Terramate Cloud tokens. It returns ErrLoginExpired or
+// ErrLoginDenied when the login cannot complete, or the error of ctx.
+func (d *DeviceLogin) Wait(ctx context.Context, code *DeviceCode) (*LoginResult, error) {
+	ctx, cancel := context.WithDeadline(ctx, code.ExpiresAt)
+	defer cancel()
+
+	interval := code.interval
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && !time.Now().Before(code.ExpiresAt) {
+				return nil, ErrLoginExpired
+			}
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var resp struct {
+			AccessToken string `json:"access_token"`
+			Error       string `json:"error"`
+			ErrorDesc   string `json:"error_description"`
+		}
+		form := url.Values{"client_id": {d.ClientID}, "device_code": {code.deviceCode}, "grant_type": {deviceGrantType}}
+		if err := d.postForm(ctx, defaultString(d.AccessTokenURL, DefaultAccessTokenURL), form, &resp); err != nil {
+			return nil, fmt.Errorf("failed to poll login: %w", err)
+		}
+		switch resp.Error {
+		case "":
+			return d.signIn(ctx, resp.AccessToken)
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "expired_token":
+			return nil, ErrLoginExpired
+		case "access_denied":
+			return nil, ErrLoginDenied
+		default:
+			return nil, fmt.Errorf("login failed: %s", oauthError(resp.Error, resp.ErrorDesc))
+		}
+	}
+}
+
+// signIn exchanges a GitHub access token for Terramate Cloud tokens.
+func (d *DeviceLogin) signIn(ctx context.Context, accessToken string) (*LoginResult, error) {
+	endpoint := d.SignInEndpoint
+	if endpoint == "" {
+		endpoint = "https://identitytoolkit.googleapis.com/v1/accounts:signInWithIdp?key=" + idpKey()
+	}
+	payload, err := json.Marshal(map[string]any{
+		"postBody":            url.Values{"access_token": {accessToken}, "providerId": {"github.com"}}.Encode(),
+		"requestUri":          "http://localhost",
+		"returnSecureToken":   true,
+		"returnIdpCredential": true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sign-in payload: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sign-in request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var resp struct {
+		IDToken      string `json:"idToken"`
+		RefreshToken string `json:"refreshToken"`
+		Email        string `json:"email"`
+		Error        struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	status, err := d.do(req, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign in: %w", err)
+	}
+	if status != http.StatusOK || resp.IDToken == "" {
+		message := resp.Error.Message
+		if message == "" {
+			message = fmt.Sprintf("status %d", status)
+		}
+		return nil, fmt.Errorf("failed to sign in: %s", message)
+	}
+	return &LoginResult{IDToken: resp.IDToken, RefreshToken: resp.RefreshToken, Email: resp.Email}, nil
+}
+
+// postForm posts form to endpoint, decoding the JSON response into v.
+func (d *DeviceLogin) postForm(ctx context.Context, endpoint string, form url.Values, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	status, err := d.do(req, v)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", status)
+	}
+	return nil
+}
+
+// do sends req and decodes its JSON response into v, returning the status.
+func (d *DeviceLogin) do(req *http.Request, v any) (int, error) {
+	client := d.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response: %w", err)
+	}
+	if parseErr := json.Unmarshal(body, v); parseErr != nil && resp.StatusCode == http.StatusOK {
+		return 0, fmt.Errorf("failed to parse response: %w", parseErr)
+	}
+	return resp.StatusCode, nil
+}
+
+// WriteCredentialFile stores the tokens of result in the credential file at
+// path, in the format of 'terramate cloud login', creating its directory if
+// needed. region is the optional region hint of the file.
+func WriteCredentialFile(path string, result *LoginResult, region string) error {
+	if _, ok := regionBaseURLs[region]; region != "" && !ok {
+		return fmt.Errorf("invalid region: %q", region)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create credential directory: %w", err)
+	}
+	return writeCredentialFile(path, cachedCredential{
+		Provider:     providerGitHub,
+		IDToken:      result.IDToken,
+		RefreshToken: result.RefreshToken,
+		Region:       region,
+	})
+}
+
+// oauthError formats an OAuth error code and description.
+func oauthError(code, description string) string {
+	if code == "" {
+		code = "invalid response"
+	}
+	if description != "" {
+		return code + " - " + description
+	}
+	return code
+}
+
+// defaultString returns s, or def if s is empty.
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
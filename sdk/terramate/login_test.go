@@ -0,0 +1,122 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newLoginServer fakes the GitHub device flow and Firebase sign-in. The
+// login is authorized on the second poll unless deny is set.
+func newLoginServer(t *testing.T, deny bool) (*httptest.Server, *DeviceLogin) {
+	t.Helper()
+	polls := 0
+	idToken := generateMockJWT()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/device/code":
+			if r.PostForm.Get("client_id") != "client-id" {
+				t.Errorf("unexpected client_id %q", r.PostForm.Get("client_id"))
+			}
+			_, _ = w.Write([]byte(`{"device_code":"device","user_code":"ABCD-1234","verification_uri":"https://github.com/login/device","expires_in":900,"interval":5}`))
+		case "/oauth/access_token":
+			polls++
+			switch {
+			case r.PostForm.Get("device_code") != "device" || r.PostForm.Get("grant_type") != deviceGrantType:
+				_, _ = w.Write([]byte(`{"error":"incorrect_device_code"}`))
+			case deny:
+				_, _ = w.Write([]byte(`{"error":"access_denied"}`))
+			case polls < 2:
+				_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+			default:
+				_, _ = w.Write([]byte(`{"access_token":"gho_token"}`))
+			}
+		case "/signin":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), "access_token=gho_token") {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":{"message":"INVALID_IDP_RESPONSE"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"idToken":"` + idToken + `","refreshToken":"refresh","email":"dev@example.com"}`))
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &DeviceLogin{
+		ClientID:       "client-id",
+		DeviceCodeURL:  ts.URL + "/device/code",
+		AccessTokenURL: ts.URL + "/oauth/access_token",
+		SignInEndpoint: ts.URL + "/signin",
+	}
+}
+
+func TestDeviceLogin(t *testing.T) {
+	_, login := newLoginServer(t, false)
+	ctx := context.Background()
+	code, err := login.Start(ctx)
+	if err != nil {
+		t.Fatalf("Start error: %v", err)
+	}
+	if code.UserCode != "ABCD-1234" || code.VerificationURI != "https://github.com/login/device" || code.interval != 5*time.Second {
+		t.Fatalf("unexpected device code: %+v", code)
+	}
+	code.interval = 10 * time.Millisecond
+
+	result, err := login.Wait(ctx, code)
+	if err != nil {
+		t.Fatalf("Wait error: %v", err)
+	}
+	if result.RefreshToken != "refresh" || result.Email != "dev@example.com" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	path := filepath.Join(t.TempDir(), ".terramate.d", "credentials.tmrc.json")
+	if err := WriteCredentialFile(path, result, RegionUS); err != nil {
+		t.Fatalf("WriteCredentialFile error: %v", err)
+	}
+	cred, err := LoadJWTFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadJWTFromFile error: %v", err)
+	}
+	if cred.Name() != "GitHub" || cred.Region() != RegionUS || cred.refreshToken != "refresh" {
+		t.Fatalf("unexpected credential: provider %q region %q", cred.Name(), cred.Region())
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm()&0o077 != 0 && os.PathSeparator == '/' {
+		t.Fatalf("expected a private credential file, got %o", info.Mode().Perm())
+	}
+}
+
+func TestDeviceLogin_Denied(t *testing.T) {
+	_, login := newLoginServer(t, true)
+	code, err := login.Start(context.Background())
+	if err != nil {
+		t.Fatalf("Start error: %v", err)
+	}
+	code.interval = 10 * time.Millisecond
+	if _, err := login.Wait(context.Background(), code); !errors.Is(err, ErrLoginDenied) {
+		t.Fatalf("expected ErrLoginDenied, got %v", err)
+	}
+}
+
+func TestDeviceLogin_Expired(t *testing.T) {
+	_, login := newLoginServer(t, false)
+	code := &DeviceCode{deviceCode: "device", interval: time.Hour, ExpiresAt: time.Now().Add(20 * time.Millisecond)}
+	if _, err := login.Wait(context.Background(), code); !errors.Is(err, ErrLoginExpired) {
+		t.Fatalf("expected ErrLoginExpired, got %v", err)
+	}
+}
+
+func TestDeviceLogin_RequiresClientID(t *testing.T) {
+	if _, err := (&DeviceLogin{}).Start(context.Background()); err == nil {
+		t.Fatal("expected an error without a client ID")
+	}
+}
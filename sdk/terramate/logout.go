@@ -0,0 +1,101 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrLoggedOut is returned when a JWTCredential is used after Logout.
+var ErrLoggedOut = errors.New("logged out of Terramate Cloud; log in again to use the Terramate Cloud tools")
+
+// TokenRevoker revokes a refresh token with the identity provider, so a
+// copy of it cannot be used after the logout.
+type TokenRevoker interface {
+	RevokeToken(ctx context.Context, refreshToken string) error
+}
+
+// OAuthTokenRevoker revokes refresh tokens with an OAuth 2.0 token revocation
+// endpoint (RFC 7009). Firebase Auth, which backs Terramate Cloud logins, has
+// no such endpoint for clients; it is meant for identity providers in front
+// of sovereign cloud deployments that offer one.
+type OAuthTokenRevoker struct {
+	// Endpoint is the revocation endpoint URL; required.
+	Endpoint string
+
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// RevokeToken implements TokenRevoker.
+func (o *OAuthTokenRevoker) RevokeToken(ctx context.Context, refreshToken string) error {
+	if o.Endpoint == "" {
+		return fmt.Errorf("token revocation endpoint not set")
+	}
+	form := url.Values{"token": {refreshToken}, "token_type_hint": {"refresh_token"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create revocation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := o.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("failed to revoke token: status %d: %s", resp.StatusCode, Redact(strings.TrimSpace(string(body))))
+	}
+	return nil
+}
+
+// Logout ends the login of the credential, e.g. on a shared workstation: the
+// refresh token is revoked with revoker (skipped when nil), the file watcher
+// and refresh scheduler are stopped, the tokens are cleared from memory and the credential file is
+// removed (or the credential deleted from the keychain it was loaded from).
+// Requests made with the credential afterwards fail with ErrLoggedOut.
+//
+// The local cleanup happens even when the revocation fails; the returned
+// error then reports that the refresh token may still be valid.
+func (j *JWTCredential) Logout(ctx context.Context, revoker TokenRevoker) error {
+	j.StopWatching()
+	j.StopRefreshScheduler()
+
+	j.mu.Lock()
+	refreshToken := j.refreshToken
+	j.idToken, j.refreshToken, j.lastSelfWriteToken = "", "", ""
+	j.lastRefreshErr = ErrLoggedOut
+	j.loggedOut = true
+	path := j.credentialPath
+	j.mu.Unlock()
+
+	var errs []error
+	if revoker != nil && refreshToken != "" {
+		if err := revoker.RevokeToken(ctx, refreshToken); err != nil {
+			errs = append(errs, fmt.Errorf("the refresh token may still be valid: %w", err))
+		}
+	}
+	if j.keychain != nil {
+		if err := j.keychain.Delete(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if path != "" {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("failed to remove credential file: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,99 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// loadTestCredential writes a credential file and loads it.
+func loadTestCredential(t *testing.T) (*JWTCredential, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	content := createTestCredentialFile("Google", generateMockJWT(), "refresh-token")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cred, err := LoadJWTFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cred, path
+}
+
+func TestJWTCredential_Logout(t *testing.T) {
+	var revoked string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("token_type_hint") != "refresh_token" {
+			t.Errorf("unexpected token_type_hint %q", r.PostForm.Get("token_type_hint"))
+		}
+		revoked = r.PostForm.Get("token")
+	}))
+	defer ts.Close()
+
+	cred, path := loadTestCredential(t)
+	if err := cred.StartWatching(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := cred.Logout(context.Background(), &OAuthTokenRevoker{Endpoint: ts.URL, HTTPClient: ts.Client()}); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+
+	if revoked != "refresh-token" {
+		t.Errorf("revoked token = %q, want the refresh token", revoked)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("credential file still exists: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := cred.ApplyCredentials(req); !errors.Is(err, ErrLoggedOut) {
+		t.Errorf("ApplyCredentials() error = %v, want ErrLoggedOut", err)
+	}
+	if req.Header.Get("Authorization") != "" {
+		t.Error("Authorization header set after logout")
+	}
+	if err := cred.Refresh(context.Background()); err == nil {
+		t.Error("Refresh() succeeded after logout")
+	}
+}
+
+func TestJWTCredential_Logout_RevocationFails(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unsupported_token_type", http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	cred, path := loadTestCredential(t)
+	err := cred.Logout(context.Background(), &OAuthTokenRevoker{Endpoint: ts.URL, HTTPClient: ts.Client()})
+	if err == nil || !strings.Contains(err.Error(), "may still be valid") {
+		t.Fatalf("Logout() error = %v, want the revocation failure", err)
+	}
+	// The local cleanup happens regardless
+	if _, statErr := os.Stat(path); !errors.Is(statErr, os.ErrNotExist) {
+		t.Errorf("credential file still exists: %v", statErr)
+	}
+}
+
+func TestJWTCredential_Logout_DiscardsRefresh(t *testing.T) {
+	cred, path := loadTestCredential(t)
+	if err := cred.Logout(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A refresh finishing after the logout must not restore the tokens
+	if err := cred.updateCredentials(cred.refreshGen, &RefreshedTokens{IDToken: "late", RefreshToken: "rotated"}); !errors.Is(err, ErrLoggedOut) {
+		t.Errorf("updateCredentials() error = %v, want ErrLoggedOut", err)
+	}
+	if err := cred.updateCredentialFile(); !errors.Is(err, ErrLoggedOut) {
+		t.Errorf("updateCredentialFile() error = %v, want ErrLoggedOut", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("credential file was written after logout: %v", err)
+	}
+}
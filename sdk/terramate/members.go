@@ -0,0 +1,93 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// MembersService handles communication with the organization members
+// related methods of the Terramate Cloud API.
+type MembersService struct {
+	client *Client
+}
+
+// buildQuery constructs URL query parameters from MembersListOptions.
+func (opts *MembersListOptions) buildQuery() url.Values {
+	query := url.Values{}
+	if opts == nil {
+		return query
+	}
+
+	addPagination(query, opts.Page, opts.PerPage)
+	addStringSlice(query, "role", opts.Role)
+	addStringSlice(query, "status", opts.Status)
+	addString(query, "search", opts.Search)
+
+	return query
+}
+
+// List retrieves the members of an organization with optional filters.
+//
+// GET /v1/organizations/{org_uuid}/members
+//
+// Members include pending invitations (invited and sso_invited status).
+// Listing members may require the admin role.
+func (s *MembersService) List(ctx context.Context, orgUUID string, opts *MembersListOptions) (*MembersListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+
+	path := fmt.Sprintf("/v1/organizations/%s/members", orgUUID)
+
+	if opts != nil {
+		query := opts.buildQuery()
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result MembersListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// All returns an iterator over the members of an organization, walking the
+// pages of List from opts.Page as the iteration advances.
+func (s *MembersService) All(ctx context.Context, orgUUID string, opts *MembersListOptions) iter.Seq2[Member, error] {
+	return s.listPages(ctx, orgUUID, opts).all()
+}
+
+// ListAll accumulates the members of an organization in a slice, up to
+// maxItems (10000 when not positive), walking the pages of List from
+// opts.Page. The bool reports whether the result was truncated at maxItems.
+func (s *MembersService) ListAll(ctx context.Context, orgUUID string, opts *MembersListOptions, maxItems int) ([]Member, bool, error) {
+	return s.listPages(ctx, orgUUID, opts).collect(maxItems)
+}
+
+// listPages pages through List from the page of opts.
+func (s *MembersService) listPages(ctx context.Context, orgUUID string, opts *MembersListOptions) pages[Member] {
+	var o MembersListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[Member]{opts: o.ListOptions, list: func(page ListOptions) ([]Member, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.List(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Members, result.PaginatedResult, nil
+	}}
+}
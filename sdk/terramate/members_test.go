@@ -0,0 +1,83 @@
+package terramate
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestMembersList_ParsesResponse(t *testing.T) {
+	payload := `{
+		"members": [
+			{"member_id": 1, "user_uuid": "user-1", "email": "admin@acme.example", "display_name": "Admin", "role": "admin", "status": "active"},
+			{"member_id": 2, "email": "new@acme.example", "role": "member", "status": "invited"}
+		],
+		"paginated_result": {"total": 12, "page": 1, "per_page": 2}
+	}`
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/organizations/org-uuid-123/members" {
+			t.Errorf("unexpected path: got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		if _, werr := w.Write([]byte(payload)); werr != nil {
+			panic(werr)
+		}
+	})
+	defer cleanup()
+
+	result, _, err := client.Members.List(context.Background(), "org-uuid-123", nil)
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(result.Members) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(result.Members))
+	}
+	if m := result.Members[0]; m.Email != "admin@acme.example" || m.Role != "admin" || m.Status != "active" {
+		t.Errorf("unexpected member: %+v", m)
+	}
+	if result.Members[1].Status != "invited" {
+		t.Errorf("unexpected status: %s", result.Members[1].Status)
+	}
+	if !result.PaginatedResult.HasNextPage() {
+		t.Error("expected a next page")
+	}
+}
+
+func TestMembersList_WithOptions(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("role"); got != "admin,member" {
+			t.Errorf("role = %q, want admin,member", got)
+		}
+		if got := query.Get("status"); got != "invited" {
+			t.Errorf("status = %q, want invited", got)
+		}
+		if got := query.Get("search"); got != "@acme.example" {
+			t.Errorf("search = %q, want @acme.example", got)
+		}
+		if query.Get("page") != "2" || query.Get("per_page") != "50" {
+			t.Errorf("unexpected pagination: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, werr := w.Write([]byte(`{"members": [], "paginated_result": {"total": 0, "page": 2, "per_page": 50}}`)); werr != nil {
+			panic(werr)
+		}
+	})
+	defer cleanup()
+
+	_, _, err := client.Members.List(context.Background(), "org-uuid-123", &MembersListOptions{
+		ListOptions: ListOptions{Page: 2, PerPage: 50},
+		Role:        []string{"admin", "member"},
+		Status:      []string{"invited"},
+		Search:      "@acme.example",
+	})
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+
+	if _, _, err := client.Members.List(context.Background(), "", nil); err == nil {
+		t.Fatal("expected an error without organization UUID")
+	}
+}
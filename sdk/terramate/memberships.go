@@ -4,6 +4,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+)
+
+// Membership statuses of pending invitations.
+const (
+	MembershipStatusInvited    = "invited"
+	MembershipStatusSSOInvited = "sso_invited"
 )
 
 // MembershipsService handles communication with the memberships related
@@ -37,3 +44,68 @@ func (s *MembershipsService) List(ctx context.Context) ([]Membership, *Response,
 
 	return memberships, resp, nil
 }
+
+// IsInvitation reports whether the membership is a pending invitation.
+func (m Membership) IsInvitation() bool {
+	return m.Status == MembershipStatusInvited || m.Status == MembershipStatusSSOInvited
+}
+
+// ListInvitations retrieves the pending invitations of the authenticated
+// user: the memberships with the invited or sso_invited status.
+//
+// GET /v1/memberships
+func (s *MembershipsService) ListInvitations(ctx context.Context) ([]Membership, *Response, error) {
+	memberships, resp, err := s.List(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+	var invitations []Membership
+	for _, m := range memberships {
+		if m.IsInvitation() {
+			invitations = append(invitations, m)
+		}
+	}
+	return invitations, resp, nil
+}
+
+// AcceptInvitation accepts the pending invitation of the authenticated user
+// to the organization orgUUID, returning the membership it activated.
+//
+// POST /v1/memberships/{org_uuid}/accept
+func (s *MembershipsService) AcceptInvitation(ctx context.Context, orgUUID string) (*Membership, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	path := fmt.Sprintf("/v1/memberships/%s/accept", url.PathEscape(orgUUID))
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var membership Membership
+	resp, err := s.client.do(req, &membership)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &membership, resp, nil
+}
+
+// DeclineInvitation declines the pending invitation of the authenticated user
+// to the organization orgUUID.
+//
+// POST /v1/memberships/{org_uuid}/decline
+func (s *MembershipsService) DeclineInvitation(ctx context.Context, orgUUID string) (*Response, error) {
+	if orgUUID == "" {
+		return nil, fmt.Errorf("organization UUID is required")
+	}
+	path := fmt.Sprintf("/v1/memberships/%s/decline", url.PathEscape(orgUUID))
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
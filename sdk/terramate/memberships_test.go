@@ -34,3 +34,66 @@ func TestMembershipsList_ParsesArray(t *testing.T) {
 		t.Fatalf("unexpected memberships: %+v", members)
 	}
 }
+
+func TestMembershipsListInvitations(t *testing.T) {
+	payload := `[{"org_uuid":"org-1","status":"active"},{"org_uuid":"org-2","status":"invited"},{"org_uuid":"org-3","status":"sso_invited"}]`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, werr := w.Write([]byte(payload)); werr != nil {
+			panic(werr)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	invitations, _, err := c.Memberships.ListInvitations(context.Background())
+	if err != nil {
+		t.Fatalf("ListInvitations error: %v", err)
+	}
+	if len(invitations) != 2 || invitations[0].OrgUUID != "org-2" || invitations[1].OrgUUID != "org-3" {
+		t.Fatalf("unexpected invitations: %+v", invitations)
+	}
+}
+
+func TestMembershipsRespondToInvitation(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/v1/memberships/org-2/decline" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, werr := w.Write([]byte(`{"org_uuid":"org-2","role":"member","status":"active"}`)); werr != nil {
+			panic(werr)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	membership, _, err := c.Memberships.AcceptInvitation(context.Background(), "org-2")
+	if err != nil {
+		t.Fatalf("AcceptInvitation error: %v", err)
+	}
+	if membership.Status != "active" || membership.IsInvitation() {
+		t.Fatalf("unexpected membership: %+v", membership)
+	}
+	if _, err := c.Memberships.DeclineInvitation(context.Background(), "org-2"); err != nil {
+		t.Fatalf("DeclineInvitation error: %v", err)
+	}
+	want := []string{"POST /v1/memberships/org-2/accept", "POST /v1/memberships/org-2/decline"}
+	if len(requests) != 2 || requests[0] != want[0] || requests[1] != want[1] {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+
+	if _, _, err := c.Memberships.AcceptInvitation(context.Background(), ""); err == nil {
+		t.Fatal("expected an error without organization UUID")
+	}
+}
@@ -0,0 +1,217 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// defaultDeliveryMetricsPeriod is the period GetDelivery covers when no From
+// is given.
+const defaultDeliveryMetricsPeriod = 30 * 24 * time.Hour
+
+// MetricsService computes delivery metrics from the deployments recorded in
+// Terramate Cloud.
+type MetricsService struct {
+	client *Client
+}
+
+// GetDelivery computes DORA-style delivery metrics of the stack deployments
+// created in a period: deployment frequency, failure rate and mean time to
+// recovery, overall and, with opts.GroupBy, per stack or repository.
+//
+// A failure is recovered at the fixed_at time of a failed deployment or, when
+// the API does not report it, at the end of the next successful deployment of
+// the stack. Recovery is measured from the first failure of a run of failures.
+//
+// GET /v1/stack_deployments/{org_uuid}
+// GET /v1/stacks/{org_uuid}/{stack_id}/deployments (with opts.StackID)
+//
+// Access: Members of the organization with any role are allowed to query.
+func (s *MetricsService) GetDelivery(ctx context.Context, orgUUID string, opts *DeliveryMetricsOptions) (*DeliveryMetricsReport, error) {
+	if orgUUID == "" {
+		return nil, fmt.Errorf("organization UUID is required")
+	}
+	var o DeliveryMetricsOptions
+	if opts != nil {
+		o = *opts
+	}
+	switch o.GroupBy {
+	case "", DeliveryMetricsByStack, DeliveryMetricsByRepository:
+	default:
+		return nil, fmt.Errorf("unknown group by %q: use %q or %q", o.GroupBy, DeliveryMetricsByStack, DeliveryMetricsByRepository)
+	}
+
+	to := time.Now().UTC()
+	if o.To != nil {
+		to = *o.To
+	}
+	from := to.Add(-defaultDeliveryMetricsPeriod)
+	if o.From != nil {
+		from = *o.From
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("from must be before to")
+	}
+
+	deployments, err := s.listDeployments(ctx, orgUUID, o.StackID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if o.Repository != "" {
+		filtered := deployments[:0]
+		for _, d := range deployments {
+			if d.Stack != nil && d.Stack.Repository == o.Repository {
+				filtered = append(filtered, d)
+			}
+		}
+		deployments = filtered
+	}
+	sort.SliceStable(deployments, func(i, j int) bool {
+		return deployments[i].CreatedAt.Before(deployments[j].CreatedAt)
+	})
+
+	days := to.Sub(from).Hours() / 24
+	report := &DeliveryMetricsReport{
+		From:    from,
+		To:      to,
+		Overall: computeDeliveryMetrics(deployments, days),
+	}
+	if o.GroupBy != "" {
+		report.Groups = groupDeliveryMetrics(deployments, o.GroupBy, days)
+	}
+	return report, nil
+}
+
+// listDeployments fetches the stack deployments created in [from, to), of
+// the stack stackID when positive.
+func (s *MetricsService) listDeployments(ctx context.Context, orgUUID string, stackID int, from, to time.Time) ([]StackDeployment, error) {
+	opts := &StackDeploymentsListOptions{
+		ListOptions:   ListOptions{Page: 1, PerPage: defaultDownloadLogsPerPage},
+		CreatedAtFrom: &from,
+		CreatedAtTo:   &to,
+	}
+	var deployments []StackDeployment
+	for {
+		var result *StackDeploymentsListResponse
+		var err error
+		if stackID > 0 {
+			result, _, err = s.client.Deployments.ListForStack(ctx, orgUUID, stackID, opts)
+		} else {
+			result, _, err = s.client.Deployments.ListStackDeployments(ctx, orgUUID, opts)
+		}
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, result.StackDeployments...)
+		if len(result.StackDeployments) == 0 || !result.PaginatedResult.HasNextPage() {
+			return deployments, nil
+		}
+		opts.Page++
+	}
+}
+
+// groupDeliveryMetrics computes the metrics of deployments per stack or
+// repository, the groups with the most deployments first.
+func groupDeliveryMetrics(deployments []StackDeployment, groupBy string, days float64) []DeliveryMetrics {
+	groups := map[string][]StackDeployment{}
+	var keys []string
+	for _, d := range deployments {
+		key := deploymentStackKey(d)
+		if groupBy == DeliveryMetricsByRepository {
+			key = ""
+			if d.Stack != nil {
+				key = d.Stack.Repository
+			}
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], d)
+	}
+
+	metrics := make([]DeliveryMetrics, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		m := computeDeliveryMetrics(group, days)
+		last := group[len(group)-1]
+		if last.Stack != nil {
+			m.Repository = last.Stack.Repository
+		}
+		if groupBy == DeliveryMetricsByStack {
+			m.Path = last.Path
+			if last.Stack != nil {
+				m.StackID = last.Stack.StackID
+			}
+		}
+		metrics = append(metrics, m)
+	}
+	sort.SliceStable(metrics, func(i, j int) bool {
+		return metrics[i].Deployments > metrics[j].Deployments
+	})
+	return metrics
+}
+
+// computeDeliveryMetrics computes the metrics of deployments, sorted by
+// creation time, over a period of days.
+func computeDeliveryMetrics(deployments []StackDeployment, days float64) DeliveryMetrics {
+	var m DeliveryMetrics
+	failingSince := map[string]time.Time{}
+	var recovery time.Duration
+	for _, d := range deployments {
+		m.Deployments++
+		key := deploymentStackKey(d)
+		end := d.CreatedAt
+		if d.FinishedAt != nil {
+			end = *d.FinishedAt
+		}
+
+		switch d.Status {
+		case "ok":
+			m.Succeeded++
+			if since, ok := failingSince[key]; ok {
+				recovery += end.Sub(since)
+				m.Recoveries++
+				delete(failingSince, key)
+			}
+		case "failed":
+			m.Failed++
+			since, ok := failingSince[key]
+			if !ok {
+				since = end
+				failingSince[key] = since
+			}
+			if d.FixedAt != nil {
+				recovery += d.FixedAt.Sub(since)
+				m.Recoveries++
+				delete(failingSince, key)
+			}
+		case "canceled":
+			m.Canceled++
+		}
+	}
+
+	m.Unrecovered = len(failingSince)
+	if days > 0 {
+		m.DeploymentsPerDay = math.Round(float64(m.Deployments)/days*100) / 100
+	}
+	if finished := m.Succeeded + m.Failed; finished > 0 {
+		rate := math.Round(float64(m.Failed)/float64(finished)*1000) / 1000
+		m.FailureRate = &rate
+	}
+	if m.Recoveries > 0 {
+		mean := math.Round((recovery / time.Duration(m.Recoveries)).Seconds())
+		m.MeanTimeToRecoverySeconds = &mean
+	}
+	return m
+}
+
+// deploymentStackKey identifies the stack of a deployment.
+func deploymentStackKey(d StackDeployment) string {
+	if d.Stack != nil {
+		return fmt.Sprintf("%s:%d", d.Stack.Repository, d.Stack.StackID)
+	}
+	return d.Path
+}
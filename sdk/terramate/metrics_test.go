@@ -0,0 +1,114 @@
+package terramate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMetricsGetDelivery(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * 24 * time.Hour)
+	at := func(hours int) *time.Time {
+		t := from.Add(time.Duration(hours) * time.Hour)
+		return &t
+	}
+	web := &Stack{StackID: 1, Repository: "github.com/acme/infra", Path: "/web"}
+	db := &Stack{StackID: 2, Repository: "github.com/acme/infra", Path: "/db"}
+	dns := &Stack{StackID: 3, Repository: "github.com/acme/dns", Path: "/dns"}
+	deployments := []StackDeployment{
+		// web fails twice, recovered by the next successful deployment 3h after the first failure
+		{ID: 1, Status: "failed", Stack: web, Path: "/web", CreatedAt: *at(0), FinishedAt: at(1)},
+		{ID: 2, Status: "failed", Stack: web, Path: "/web", CreatedAt: *at(2), FinishedAt: at(2)},
+		{ID: 3, Status: "ok", Stack: web, Path: "/web", CreatedAt: *at(3), FinishedAt: at(4)},
+		// db fails and is fixed 1h later
+		{ID: 4, Status: "failed", Stack: db, Path: "/db", CreatedAt: *at(5), FinishedAt: at(5), FixedAt: at(6)},
+		{ID: 5, Status: "ok", Stack: db, Path: "/db", CreatedAt: *at(6), FinishedAt: at(6)},
+		// dns is still failing
+		{ID: 6, Status: "canceled", Stack: dns, Path: "/dns", CreatedAt: *at(7)},
+		{ID: 7, Status: "failed", Stack: dns, Path: "/dns", CreatedAt: *at(8), FinishedAt: at(8)},
+	}
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var result StackDeploymentsListResponse
+		switch r.URL.Path {
+		case "/v1/stack_deployments/org-uuid":
+			// newest first, as the API returns them
+			for i := len(deployments) - 1; i >= 0; i-- {
+				result.StackDeployments = append(result.StackDeployments, deployments[i])
+			}
+		case "/v1/stacks/org-uuid/2/deployments":
+			result.StackDeployments = deployments[3:5]
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("created_at_from") != "2026-03-01T00:00:00Z" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		result.PaginatedResult = PaginatedResult{Total: len(result.StackDeployments), Page: 1, PerPage: 100}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	})
+	defer cleanup()
+
+	report, err := client.Metrics.GetDelivery(context.Background(), "org-uuid", &DeliveryMetricsOptions{
+		From: &from, To: &to, GroupBy: DeliveryMetricsByStack,
+	})
+	if err != nil {
+		t.Fatalf("GetDelivery error: %v", err)
+	}
+	overall := report.Overall
+	if overall.Deployments != 7 || overall.Succeeded != 2 || overall.Failed != 4 || overall.Canceled != 1 {
+		t.Errorf("unexpected counts: %+v", overall)
+	}
+	if overall.DeploymentsPerDay != 0.7 || overall.FailureRate == nil || *overall.FailureRate != 0.667 {
+		t.Errorf("unexpected frequency or failure rate: %+v", overall)
+	}
+	// (3h + 1h) / 2
+	if overall.Recoveries != 2 || overall.MeanTimeToRecoverySeconds == nil || *overall.MeanTimeToRecoverySeconds != 7200 || overall.Unrecovered != 1 {
+		t.Errorf("unexpected recovery: %+v", overall)
+	}
+	if len(report.Groups) != 3 || report.Groups[0].Path != "/web" || report.Groups[0].StackID != 1 || report.Groups[2].Unrecovered != 1 {
+		t.Errorf("unexpected groups: %+v", report.Groups)
+	}
+
+	report, err = client.Metrics.GetDelivery(context.Background(), "org-uuid", &DeliveryMetricsOptions{
+		From: &from, To: &to, Repository: "github.com/acme/dns", GroupBy: DeliveryMetricsByRepository,
+	})
+	if err != nil {
+		t.Fatalf("GetDelivery error: %v", err)
+	}
+	if report.Overall.Deployments != 2 || report.Overall.MeanTimeToRecoverySeconds != nil || len(report.Groups) != 1 {
+		t.Errorf("unexpected repository report: %+v", report)
+	}
+
+	report, err = client.Metrics.GetDelivery(context.Background(), "org-uuid", &DeliveryMetricsOptions{From: &from, To: &to, StackID: 2})
+	if err != nil {
+		t.Fatalf("GetDelivery error: %v", err)
+	}
+	if report.Overall.Deployments != 2 || report.Overall.Recoveries != 1 || report.Groups != nil {
+		t.Errorf("unexpected stack report: %+v", report)
+	}
+}
+
+func TestMetricsGetDelivery_Validation(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s", r.URL.Path)
+	})
+	defer cleanup()
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, opts := range []*DeliveryMetricsOptions{
+		{GroupBy: "team"},
+		{From: &from, To: &from},
+	} {
+		if _, err := client.Metrics.GetDelivery(context.Background(), "org-uuid", opts); err == nil {
+			t.Errorf("expected an error for %+v", opts)
+		}
+	}
+	if _, err := client.Metrics.GetDelivery(context.Background(), "", nil); err == nil {
+		t.Error("expected an error without an organization UUID")
+	}
+}
@@ -0,0 +1,102 @@
+package terramate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// NotificationsService handles communication with the notification
+// integration related methods of the Terramate Cloud API.
+type NotificationsService struct {
+	client *Client
+}
+
+// GetIntegrations retrieves the notification integrations of an organization:
+// its Slack webhooks and email rules.
+//
+// GET /v1/organizations/{org_uuid}/notifications
+func (s *NotificationsService) GetIntegrations(ctx context.Context, orgUUID string) (*NotificationIntegrations, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	path := fmt.Sprintf("/v1/organizations/%s/notifications", orgUUID)
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var integrations NotificationIntegrations
+	resp, err := s.client.do(req, &integrations)
+	if err != nil {
+		return nil, resp, err
+	}
+	integrations.registerSecrets()
+
+	return &integrations, resp, nil
+}
+
+// UpdateIntegrations replaces the notification integrations of an
+// organization and returns them as stored. Slack integrations keeping their
+// IntegrationUUID may omit WebhookURL to keep the stored one.
+//
+// PUT /v1/organizations/{org_uuid}/notifications
+//
+// Updating integrations requires the admin role.
+func (s *NotificationsService) UpdateIntegrations(ctx context.Context, orgUUID string, integrations *NotificationIntegrations) (*NotificationIntegrations, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if integrations == nil {
+		return nil, nil, fmt.Errorf("notification integrations are required")
+	}
+	integrations.registerSecrets()
+	path := fmt.Sprintf("/v1/organizations/%s/notifications", orgUUID)
+
+	body, err := json.Marshal(integrations)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := s.client.newRequest(ctx, http.MethodPut, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var updated NotificationIntegrations
+	resp, err := s.client.do(req, &updated)
+	if err != nil {
+		return nil, resp, err
+	}
+	updated.registerSecrets()
+
+	return &updated, resp, nil
+}
+
+// ForEvent returns the enabled integrations notified of event, e.g. "drift",
+// answering where the notifications of that event go.
+func (n *NotificationIntegrations) ForEvent(event string) NotificationIntegrations {
+	var routed NotificationIntegrations
+	for _, slack := range n.Slack {
+		if slack.Enabled && slices.Contains(slack.Events, event) {
+			routed.Slack = append(routed.Slack, slack)
+		}
+	}
+	for _, rule := range n.EmailRules {
+		if rule.Enabled && slices.Contains(rule.Events, event) {
+			routed.EmailRules = append(routed.EmailRules, rule)
+		}
+	}
+	return routed
+}
+
+// registerSecrets registers the Slack webhook URLs, which authorize posting
+// to their channel, for redaction.
+func (n *NotificationIntegrations) registerSecrets() {
+	for _, slack := range n.Slack {
+		RegisterSecret(slack.WebhookURL)
+	}
+}
@@ -0,0 +1,90 @@
+package terramate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testSlackWebhook = "https://hooks.slack.com/services/T000/B000/notificationsTestWebhook"
+
+func TestNotificationsGetIntegrations(t *testing.T) {
+	payload := `{
+		"slack": [
+			{"integration_uuid": "slack-1", "channel": "#infra-alerts", "webhook_url": "` + testSlackWebhook + `", "events": ["drift", "deployment_failed"], "enabled": true},
+			{"integration_uuid": "slack-2", "channel": "#old", "events": ["drift"], "enabled": false}
+		],
+		"email_rules": [
+			{"rule_uuid": "rule-1", "recipients": ["oncall@acme.example"], "events": ["deployment_failed"], "enabled": true}
+		]
+	}`
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/organizations/org-uuid-123/notifications" {
+			t.Errorf("unexpected path: got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, werr := w.Write([]byte(payload)); werr != nil {
+			panic(werr)
+		}
+	})
+	defer cleanup()
+
+	integrations, _, err := client.Notifications.GetIntegrations(context.Background(), "org-uuid-123")
+	if err != nil {
+		t.Fatalf("GetIntegrations error: %v", err)
+	}
+	if len(integrations.Slack) != 2 || len(integrations.EmailRules) != 1 {
+		t.Fatalf("unexpected integrations: %+v", integrations)
+	}
+
+	drift := integrations.ForEvent("drift")
+	if len(drift.Slack) != 1 || drift.Slack[0].Channel != "#infra-alerts" || len(drift.EmailRules) != 0 {
+		t.Errorf("unexpected drift routing: %+v", drift)
+	}
+	failed := integrations.ForEvent("deployment_failed")
+	if len(failed.Slack) != 1 || len(failed.EmailRules) != 1 {
+		t.Errorf("unexpected deployment_failed routing: %+v", failed)
+	}
+
+	if got := Redact("posting to " + testSlackWebhook); strings.Contains(got, "notificationsTestWebhook") {
+		t.Errorf("expected the webhook URL to be redacted, got %q", got)
+	}
+}
+
+func TestNotificationsUpdateIntegrations(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		var got NotificationIntegrations
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if len(got.EmailRules) != 1 || got.EmailRules[0].Recipients[0] != "oncall@acme.example" {
+			t.Errorf("unexpected request: %+v", got)
+		}
+		got.EmailRules[0].RuleUUID = "rule-1"
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(got); err != nil {
+			panic(err)
+		}
+	})
+	defer cleanup()
+
+	updated, _, err := client.Notifications.UpdateIntegrations(context.Background(), "org-uuid-123", &NotificationIntegrations{
+		EmailRules: []EmailNotificationRule{{Recipients: []string{"oncall@acme.example"}, Events: []string{"drift"}, Enabled: true}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateIntegrations error: %v", err)
+	}
+	if len(updated.EmailRules) != 1 || updated.EmailRules[0].RuleUUID != "rule-1" {
+		t.Errorf("unexpected integrations: %+v", updated)
+	}
+
+	if _, _, err := client.Notifications.UpdateIntegrations(context.Background(), "org-uuid-123", nil); err == nil {
+		t.Fatal("expected an error without integrations")
+	}
+}
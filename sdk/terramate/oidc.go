@@ -0,0 +1,165 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultOIDCAudience is the audience Terramate Cloud accepts OIDC tokens for,
+// the same the Terramate CLI requests.
+const DefaultOIDCAudience = "api.terramate.io"
+
+// oidcExpiryMargin is how long before it expires an OIDC token is replaced,
+// so a request never goes out with a token expiring in flight.
+const oidcExpiryMargin = time.Minute
+
+// OIDCTokenSource issues OIDC tokens identifying the workload running the
+// client, e.g. a GitHub Actions job or a cloud VM.
+type OIDCTokenSource interface {
+	// Token returns a new OIDC token for audience.
+	Token(ctx context.Context, audience string) (string, error)
+}
+
+// OIDCCredential implements Credential with the OIDC tokens of an
+// OIDCTokenSource, sent to Terramate Cloud as is, so no API key or login is
+// needed. Tokens are fetched again shortly before they expire and when the
+// API rejects them.
+type OIDCCredential struct {
+	name     string
+	source   OIDCTokenSource
+	audience string
+
+	mu        sync.Mutex // held while fetching, so concurrent requests share a fetch
+	token     string
+	expiresAt time.Time // zero when the token has no expiry claim
+	lastErr   error     // error of the last fetch, nil after a successful one
+}
+
+// OIDCOption configures the OIDC credentials of the workload constructors,
+// e.g. NewGitHubOIDCCredential.
+type OIDCOption func(*oidcOptions)
+
+type oidcOptions struct {
+	audience   string
+	httpClient *http.Client
+}
+
+// WithOIDCAudience sets the audience of the requested tokens; it defaults to
+// DefaultOIDCAudience.
+func WithOIDCAudience(audience string) OIDCOption {
+	return func(o *oidcOptions) {
+		o.audience = audience
+	}
+}
+
+// WithOIDCHTTPClient sets the HTTP client fetching the tokens; it defaults to
+// a client with a 30 second timeout.
+func WithOIDCHTTPClient(client *http.Client) OIDCOption {
+	return func(o *oidcOptions) {
+		o.httpClient = client
+	}
+}
+
+func newOIDCOptions(opts []OIDCOption) oidcOptions {
+	o := oidcOptions{audience: DefaultOIDCAudience}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// NewOIDCCredential creates a credential named name from the tokens source
+// issues for audience ("" uses DefaultOIDCAudience). It fetches the first
+// token, so a workload lacking the permission to request one fails here
+// rather than on the first request.
+func NewOIDCCredential(ctx context.Context, name string, source OIDCTokenSource, audience string) (*OIDCCredential, error) {
+	if audience == "" {
+		audience = DefaultOIDCAudience
+	}
+	o := &OIDCCredential{name: name, source: source, audience: audience}
+	if err := o.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// ApplyCredentials sets the OIDC token as Bearer token, fetching a new one
+// first when it is about to expire.
+func (o *OIDCCredential) ApplyCredentials(req *http.Request) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.expiresAt.IsZero() && time.Until(o.expiresAt) < oidcExpiryMargin {
+		if err := o.fetch(req.Context()); err != nil {
+			return err
+		}
+	}
+	req.Header.Set("Authorization", "Bearer "+o.token)
+	return nil
+}
+
+// Refresh fetches a new OIDC token.
+func (o *OIDCCredential) Refresh(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.fetch(ctx)
+}
+
+// Status returns the status of the credential. OIDC tokens are always
+// refreshable, by fetching a new one.
+func (o *OIDCCredential) Status() CredentialStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return CredentialStatus{ExpiresAt: o.expiresAt, Refreshable: true, LastRefreshError: o.lastErr}
+}
+
+// Name returns the name of the credential, e.g. "GitHub Actions OIDC".
+func (o *OIDCCredential) Name() string {
+	return o.name
+}
+
+// fetch requests a token from the source. o.mu must be held.
+func (o *OIDCCredential) fetch(ctx context.Context) error {
+	token, err := o.source.Token(ctx, o.audience)
+	if err == nil {
+		// The API verifies the token; its expiry only schedules the next fetch
+		if _, _, parseErr := (&jwt.Parser{}).ParseUnverified(token, jwt.MapClaims{}); parseErr != nil {
+			err = fmt.Errorf("invalid %s token: %w", o.name, parseErr)
+		}
+	}
+	o.lastErr = err
+	if err != nil {
+		return err
+	}
+	o.token = token
+	o.expiresAt, _ = tokenExpiry(token)
+	return nil
+}
+
+// doTokenRequest sends req with client (nil uses a client with a 30 second
+// timeout) and returns the body of a successful response. what names the
+// token in errors.
+func doTokenRequest(client *http.Client, req *http.Request, what string) ([]byte, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request the %s: %w", what, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the %s: %w", what, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s request failed with status %d: %s", what, resp.StatusCode, Redact(strings.TrimSpace(string(body))))
+	}
+	return body, nil
+}
@@ -0,0 +1,96 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// OrganizationsService handles communication with the organization related
+// methods of the Terramate Cloud API
+type OrganizationsService struct {
+	client *Client
+}
+
+// GetFeatures retrieves the plan features enabled for an organization.
+//
+// GET /v1/organizations/{org_uuid}/features
+//
+// Deployments of Terramate Cloud that do not report features respond with
+// 404 Not Found; callers should then assume every feature is available.
+func (s *OrganizationsService) GetFeatures(ctx context.Context, orgUUID string) (*OrganizationFeatures, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+
+	path := fmt.Sprintf("/v1/organizations/%s/features", orgUUID)
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var features OrganizationFeatures
+	resp, err := s.client.do(req, &features)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &features, resp, nil
+}
+
+// GetSSOSettings retrieves the single sign-on configuration of an
+// organization, e.g. to confirm that SSO is enforced.
+//
+// GET /v1/organizations/{org_uuid}/sso
+//
+// Access: Members of the organization with the admin role are allowed to query.
+func (s *OrganizationsService) GetSSOSettings(ctx context.Context, orgUUID string) (*SSOSettings, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+
+	path := fmt.Sprintf("/v1/organizations/%s/sso", orgUUID)
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var settings SSOSettings
+	resp, err := s.client.do(req, &settings)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &settings, resp, nil
+}
+
+// ListDomains retrieves the email domains claimed by an organization and
+// whether their ownership was verified.
+//
+// GET /v1/organizations/{org_uuid}/domains
+//
+// Access: Members of the organization with the admin role are allowed to query.
+func (s *OrganizationsService) ListDomains(ctx context.Context, orgUUID string) ([]OrganizationDomain, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+
+	path := fmt.Sprintf("/v1/organizations/%s/domains", orgUUID)
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result struct {
+		Domains []OrganizationDomain `json:"domains"`
+	}
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return result.Domains, resp, nil
+}
@@ -0,0 +1,99 @@
+package terramate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOrganizationsGetFeatures(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/organizations/org-uuid/features" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"previews":true,"policies":false,"targets":true}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	features, _, err := c.Organizations.GetFeatures(context.Background(), "org-uuid")
+	if err != nil {
+		t.Fatalf("GetFeatures error: %v", err)
+	}
+	if !features.Previews || features.Policies || !features.Targets {
+		t.Fatalf("unexpected features: %+v", features)
+	}
+}
+
+func TestOrganizationsGetFeatures_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := NewClientWithAPIKey("key", WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	_, _, err = c.Organizations.GetFeatures(context.Background(), "org-uuid")
+	apiErr, ok := err.(*APIError)
+	if !ok || !apiErr.IsNotFound() {
+		t.Fatalf("expected not found APIError, got %v", err)
+	}
+}
+
+func TestOrganizationsGetFeatures_RequiresOrg(t *testing.T) {
+	c, err := NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	if _, _, err := c.Organizations.GetFeatures(context.Background(), ""); err == nil {
+		t.Fatal("expected error for empty organization UUID")
+	}
+}
+
+func TestOrganizationsSSOAndDomains(t *testing.T) {
+	c, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/organizations/org-uuid/sso":
+			_, _ = w.Write([]byte(`{"enabled": true, "enforced": true, "protocol": "saml", "provider": "okta", "jit_provisioning": true, "default_role": "member"}`))
+		case "/v1/organizations/org-uuid/domains":
+			_, _ = w.Write([]byte(`{"domains": [{"domain": "acme.com", "verified": true, "verified_at": "2026-01-10T09:00:00Z"}, {"domain": "acme.io", "verified": false}]}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	defer cleanup()
+
+	sso, _, err := c.Organizations.GetSSOSettings(context.Background(), "org-uuid")
+	if err != nil {
+		t.Fatalf("GetSSOSettings error: %v", err)
+	}
+	if !sso.Enabled || !sso.Enforced || sso.Provider != "okta" || sso.DefaultRole != "member" {
+		t.Errorf("unexpected SSO settings: %+v", sso)
+	}
+
+	domains, _, err := c.Organizations.ListDomains(context.Background(), "org-uuid")
+	if err != nil {
+		t.Fatalf("ListDomains error: %v", err)
+	}
+	if len(domains) != 2 || !domains[0].Verified || domains[0].VerifiedAt == nil || domains[1].Verified {
+		t.Errorf("unexpected domains: %+v", domains)
+	}
+
+	if _, _, err := c.Organizations.GetSSOSettings(context.Background(), ""); err == nil {
+		t.Error("expected error for missing organization")
+	}
+	if _, _, err := c.Organizations.ListDomains(context.Background(), ""); err == nil {
+		t.Error("expected error for missing organization")
+	}
+}
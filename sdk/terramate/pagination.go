@@ -0,0 +1,88 @@
+package terramate
+
+import "iter"
+
+// defaultAllPerPage is the page size of the All iterators when
+// ListOptions.PerPage is not set: the largest the API allows.
+const defaultAllPerPage = 100
+
+// pages lists the items of a paginated endpoint: list fetches a page, from
+// page opts.Page (the first when not set) on.
+type pages[T any] struct {
+	opts ListOptions
+	list func(ListOptions) ([]T, PaginatedResult, error)
+}
+
+// first returns the options of the first page to fetch.
+func (p pages[T]) first() ListOptions {
+	opts := p.opts
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	if opts.PerPage < 1 {
+		opts.PerPage = defaultAllPerPage
+	}
+	return opts
+}
+
+// all returns an iterator over the items of the pages, up to the last one.
+// Pages are fetched as the iteration advances; it stops at the first error,
+// yielded with the zero item.
+func (p pages[T]) all() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		opts := p.first()
+		for {
+			items, page, err := p.list(opts)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(items) == 0 || !page.HasNextPage() {
+				return
+			}
+			opts.Page++
+		}
+	}
+}
+
+// defaultListAllMaxItems caps the items the ListAll methods accumulate when
+// maxItems is not positive.
+const defaultListAllMaxItems = 10000
+
+// collect accumulates the items of the pages in a slice, up to maxItems
+// (defaultListAllMaxItems when not positive), and reports whether there were
+// more. No page is fetched once maxItems is reached, so truncation is told
+// from the pagination of the last page fetched. On error, the items collected
+// before it are returned with it.
+func (p pages[T]) collect(maxItems int) ([]T, bool, error) {
+	if maxItems <= 0 {
+		maxItems = defaultListAllMaxItems
+	}
+	items := []T{}
+	opts := p.first()
+	for {
+		page, result, err := p.list(opts)
+		if err != nil {
+			return items, false, err
+		}
+		for _, item := range page {
+			if len(items) == maxItems {
+				return items, true, nil
+			}
+			items = append(items, item)
+		}
+		if len(page) == 0 || !result.HasNextPage() {
+			return items, false, nil
+		}
+		if len(items) == maxItems {
+			return items, true, nil
+		}
+		opts.Page++
+	}
+}
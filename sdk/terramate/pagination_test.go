@@ -0,0 +1,141 @@
+package terramate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestStacksAll(t *testing.T) {
+	var requests []string
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		requests = append(requests, query.Get("page"))
+		if query.Get("per_page") != "2" || query.Get("drift_status") != "drifted" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		page, _ := strconv.Atoi(query.Get("page"))
+		if page == 4 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		result := StacksListResponse{PaginatedResult: PaginatedResult{Total: 5, Page: page, PerPage: 2}}
+		for id := (page-1)*2 + 1; id <= min(page*2, 5); id++ {
+			result.Stacks = append(result.Stacks, Stack{StackID: id})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	})
+	defer cleanup()
+
+	opts := &StacksListOptions{ListOptions: ListOptions{PerPage: 2}, DriftStatus: []string{"drifted"}}
+	var ids []int
+	for stack, err := range client.Stacks.All(context.Background(), "org-uuid", opts) {
+		if err != nil {
+			t.Fatalf("All error: %v", err)
+		}
+		ids = append(ids, stack.StackID)
+	}
+	if len(ids) != 5 || ids[4] != 5 || len(requests) != 3 {
+		t.Errorf("expected 5 stacks in 3 pages, got %v after requests %v", ids, requests)
+	}
+	if opts.Page != 0 {
+		t.Errorf("expected opts to be left untouched, got page %d", opts.Page)
+	}
+
+	// Breaking out of the loop fetches no further page
+	requests = nil
+	for range client.Stacks.All(context.Background(), "org-uuid", opts) {
+		break
+	}
+	if len(requests) != 1 {
+		t.Errorf("expected a single request, got %v", requests)
+	}
+
+	// Errors end the iteration
+	opts.Page = 4
+	var errs int
+	for _, err := range client.Stacks.All(context.Background(), "org-uuid", opts) {
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		errs++
+	}
+	if errs != 1 {
+		t.Errorf("expected a single error, got %d", errs)
+	}
+}
+
+func TestWebhooksAll(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("per_page") != "100" {
+			t.Errorf("expected the default page size, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"webhooks": [{"webhook_uuid": "w1"}, {"webhook_uuid": "w2"}], "paginated_result": {"total": 2, "page": 1, "per_page": 100}}`))
+	})
+	defer cleanup()
+
+	var uuids []string
+	for webhook, err := range client.Webhooks.All(context.Background(), "org-uuid", nil) {
+		if err != nil {
+			t.Fatalf("All error: %v", err)
+		}
+		uuids = append(uuids, webhook.WebhookUUID)
+	}
+	if len(uuids) != 2 || uuids[1] != "w2" {
+		t.Errorf("unexpected webhooks: %v", uuids)
+	}
+}
+
+func TestDriftsListAllForStack(t *testing.T) {
+	var page3Requests atomic.Int32
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 3 {
+			page3Requests.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		result := DriftsListResponse{PaginatedResult: PaginatedResult{Total: 6, Page: page, PerPage: 2}}
+		for id := (page-1)*2 + 1; id <= page*2; id++ {
+			result.Drifts = append(result.Drifts, Drift{ID: id})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	})
+	defer cleanup()
+
+	opts := &DriftsListOptions{ListOptions: ListOptions{PerPage: 2}}
+	drifts, truncated, err := client.Drifts.ListAllForStack(context.Background(), "org-uuid", 42, opts, 3)
+	if err != nil {
+		t.Fatalf("ListAllForStack error: %v", err)
+	}
+	if len(drifts) != 3 || drifts[2].ID != 3 || !truncated {
+		t.Errorf("expected the first 3 drifts truncated, got %+v (truncated %v)", drifts, truncated)
+	}
+
+	// The cap at the end of page 2 stops before requesting page 3
+	drifts, truncated, err = client.Drifts.ListAllForStack(context.Background(), "org-uuid", 42, opts, 4)
+	if err != nil {
+		t.Fatalf("ListAllForStack error: %v", err)
+	}
+	if len(drifts) != 4 || !truncated || page3Requests.Load() != 0 {
+		t.Errorf("expected 4 drifts truncated without requesting page 3, got %d (truncated %v, %d requests)", len(drifts), truncated, page3Requests.Load())
+	}
+
+	// A cap beyond page 2 requests page 3, whose failure is returned
+	drifts, truncated, err = client.Drifts.ListAllForStack(context.Background(), "org-uuid", 42, opts, 5)
+	if err == nil || len(drifts) != 4 || truncated {
+		t.Errorf("expected 4 drifts and the error of page 3, got %d (truncated %v, err %v)", len(drifts), truncated, err)
+	}
+
+	// Errors return the drifts collected before them
+	drifts, truncated, err = client.Drifts.ListAllForStack(context.Background(), "org-uuid", 42, opts, 0)
+	if err == nil || len(drifts) != 4 || truncated {
+		t.Errorf("expected 4 drifts and an error, got %d (truncated %v, err %v)", len(drifts), truncated, err)
+	}
+}
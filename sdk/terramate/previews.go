@@ -5,6 +5,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
+)
+
+// Formats of PreviewsService.GetChangeset.
+const (
+	ChangesetFormatASCII = "ascii"
+	ChangesetFormatJSON  = "json"
 )
 
 // PreviewsService handles communication with the previews related
@@ -25,6 +32,9 @@ func (s *PreviewsService) Get(ctx context.Context, orgUUID string, stackPreviewI
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceReviewRequests, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if stackPreviewID <= 0 {
 		return nil, nil, fmt.Errorf("stack preview ID must be positive")
 	}
@@ -57,6 +67,9 @@ func (s *PreviewsService) GetLogs(ctx context.Context, orgUUID string, stackPrev
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceReviewRequests, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if stackPreviewID <= 0 {
 		return nil, nil, fmt.Errorf("stack preview ID must be positive")
 	}
@@ -86,6 +99,102 @@ func (s *PreviewsService) GetLogs(ctx context.Context, orgUUID string, stackPrev
 	return &result, resp, nil
 }
 
+// GetAllLogs retrieves the complete terraform command logs of a stack
+// preview, walking the pages of the stdout and stderr channels and merging
+// them into one slice ordered by timestamp (then log line).
+//
+// GET /v1/stack_previews/{org_uuid}/{stack_preview_id}/logs
+//
+// Access: All members of the organization with any role are allowed to query.
+func (s *PreviewsService) GetAllLogs(ctx context.Context, orgUUID string, stackPreviewID int) ([]CommandLogLine, error) {
+	var lines []CommandLogLine
+	for _, channel := range []string{"stdout", "stderr"} {
+		channelLines, err := s.channelLogs(ctx, orgUUID, stackPreviewID, channel)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, channelLines...)
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		if !lines[i].Timestamp.Equal(lines[j].Timestamp) {
+			return lines[i].Timestamp.Before(lines[j].Timestamp)
+		}
+		return lines[i].LogLine < lines[j].LogLine
+	})
+	return lines, nil
+}
+
+// channelLogs retrieves all the log lines of a stack preview in channel.
+func (s *PreviewsService) channelLogs(ctx context.Context, orgUUID string, stackPreviewID int, channel string) ([]CommandLogLine, error) {
+	opts := &PreviewLogsOptions{
+		ListOptions: ListOptions{Page: 1, PerPage: defaultDownloadLogsPerPage},
+		Channel:     channel,
+	}
+	var lines []CommandLogLine
+	for {
+		logs, _, err := s.GetLogs(ctx, orgUUID, stackPreviewID, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range logs.StackPreviewLogLines {
+			if line.Channel == "" {
+				line.Channel = channel
+			}
+			lines = append(lines, line)
+		}
+		if len(logs.StackPreviewLogLines) == 0 || !logs.PaginatedResult.HasNextPage() {
+			return lines, nil
+		}
+		opts.Page++
+	}
+}
+
+// GetChangeset retrieves the full plan of a stack preview, in format
+// ChangesetFormatASCII (the default when format is empty) or
+// ChangesetFormatJSON. Only the changeset field of the requested format is
+// set in the result.
+//
+// GET /v1/stack_previews/{org_uuid}/{stack_preview_id}/changeset
+//
+// Plans can be large (see StackPreviewV2.ChangesetASCIISize and
+// ChangesetJSONSize): responses above the client's size limit fail with a
+// *ResponseTooLargeError.
+//
+// Access: All members of the organization with any role are allowed to query.
+func (s *PreviewsService) GetChangeset(ctx context.Context, orgUUID string, stackPreviewID int, format string) (*ChangesetDetails, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceReviewRequests, orgUUID); err != nil {
+		return nil, nil, err
+	}
+	if stackPreviewID <= 0 {
+		return nil, nil, fmt.Errorf("stack preview ID must be positive")
+	}
+	if format == "" {
+		format = ChangesetFormatASCII
+	}
+	if format != ChangesetFormatASCII && format != ChangesetFormatJSON {
+		return nil, nil, fmt.Errorf("unknown changeset format %q (want %s or %s)", format, ChangesetFormatASCII, ChangesetFormatJSON)
+	}
+
+	path := fmt.Sprintf("/v1/stack_previews/%s/%d/changeset?format=%s", orgUUID, stackPreviewID, format)
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var changeset ChangesetDetails
+	resp, err := s.client.do(req, &changeset)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &changeset, resp, nil
+}
+
 // ExplainErrors retrieves an AI-generated explanation of stack preview errors.
 //
 // GET /v1/stack_previews/{org_uuid}/{stack_preview_id}/ai/error_logs_explanation
@@ -98,6 +207,9 @@ func (s *PreviewsService) ExplainErrors(ctx context.Context, orgUUID string, sta
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceReviewRequests, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if stackPreviewID <= 0 {
 		return nil, nil, fmt.Errorf("stack preview ID must be positive")
 	}
@@ -121,3 +233,39 @@ func (s *PreviewsService) ExplainErrors(ctx context.Context, orgUUID string, sta
 
 	return &result, resp, nil
 }
+
+// Retrigger re-runs a stack preview, e.g. an outdated one after a change of
+// the base branch. The preview runs asynchronously in the CI/CD of the
+// review request; the returned preview is pending until it finished.
+//
+// POST /v1/stack_previews/{org_uuid}/{stack_preview_id}/retrigger
+//
+// A 409 Conflict is returned while the preview is already pending or running.
+//
+// Access: Members of the organization with the admin or member role are allowed to retrigger previews.
+func (s *PreviewsService) Retrigger(ctx context.Context, orgUUID string, stackPreviewID int) (*StackPreviewV2, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceReviewRequests, orgUUID); err != nil {
+		return nil, nil, err
+	}
+	if stackPreviewID <= 0 {
+		return nil, nil, fmt.Errorf("stack preview ID must be positive")
+	}
+
+	path := fmt.Sprintf("/v1/stack_previews/%s/%d/retrigger", orgUUID, stackPreviewID)
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var preview StackPreviewV2
+	resp, err := s.client.do(req, &preview)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &preview, resp, nil
+}
@@ -2,7 +2,10 @@ package terramate
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -243,6 +246,67 @@ func TestPreviewsExplainErrors_WithForce(t *testing.T) {
 	}
 }
 
+func TestPreviewsGetChangeset(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/stack_previews/org-uuid/100/changeset" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("format") == ChangesetFormatJSON {
+			_, _ = w.Write([]byte(`{"provisioner": "terraform", "changeset_json": "{\"format_version\": \"1.2\"}"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"provisioner": "terraform", "changeset_ascii": "Plan: 1 to add, 0 to change, 0 to destroy."}`))
+	})
+	defer cleanup()
+
+	changeset, _, err := client.Previews.GetChangeset(context.Background(), "org-uuid", 100, "")
+	if err != nil {
+		t.Fatalf("GetChangeset error: %v", err)
+	}
+	if changeset.ChangesetASCII != "Plan: 1 to add, 0 to change, 0 to destroy." || changeset.ChangesetJSON != "" {
+		t.Errorf("unexpected ASCII changeset: %+v", changeset)
+	}
+
+	changeset, _, err = client.Previews.GetChangeset(context.Background(), "org-uuid", 100, ChangesetFormatJSON)
+	if err != nil {
+		t.Fatalf("GetChangeset error: %v", err)
+	}
+	if changeset.ChangesetJSON != `{"format_version": "1.2"}` {
+		t.Errorf("unexpected JSON changeset: %+v", changeset)
+	}
+
+	if _, _, err := client.Previews.GetChangeset(context.Background(), "org-uuid", 100, "html"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if _, _, err := client.Previews.GetChangeset(context.Background(), "org-uuid", 0, ""); err == nil {
+		t.Error("expected an error for a non-positive stack preview ID")
+	}
+}
+
+func TestPreviewsRetrigger(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/stack_previews/org-uuid/100/retrigger" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 100, "status": "pending", "stack_id": 7, "review_request_id": 42}`))
+	})
+	defer cleanup()
+
+	preview, _, err := client.Previews.Retrigger(context.Background(), "org-uuid", 100)
+	if err != nil {
+		t.Fatalf("Retrigger error: %v", err)
+	}
+	if preview.Status != "pending" || preview.ReviewRequestID != 42 {
+		t.Errorf("unexpected preview: %+v", preview)
+	}
+
+	if _, _, err := client.Previews.Retrigger(context.Background(), "org-uuid", 0); err == nil {
+		t.Error("expected an error for a non-positive stack preview ID")
+	}
+}
+
 func TestPreviewsGetLogs_Validation(t *testing.T) {
 	c, err := NewClientWithAPIKey("key")
 	if err != nil {
@@ -302,3 +366,59 @@ func TestPreviewsGetLogs_RespectsContextTimeout(t *testing.T) {
 		t.Fatal("expected timeout error")
 	}
 }
+
+func TestPreviewsGetAllLogs(t *testing.T) {
+	start := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	logs := map[string][]CommandLogLine{
+		"stderr": {
+			{LogLine: 1, Timestamp: start.Add(50*time.Second + time.Millisecond), Message: "Warning: deprecated"},
+			{LogLine: 2, Timestamp: start.Add(200 * time.Second), Message: "Error: quota exceeded"},
+		},
+	}
+	for n := 1; n <= 101; n++ {
+		logs["stdout"] = append(logs["stdout"], CommandLogLine{
+			LogLine: n, Timestamp: start.Add(time.Duration(n) * time.Second), Channel: "stdout", Message: fmt.Sprintf("line %d", n),
+		})
+	}
+
+	requests := map[string]int{}
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/stack_previews/org-uuid/100/logs" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		channel := r.URL.Query().Get("channel")
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		requests[channel]++
+
+		lines := logs[channel]
+		result := StackPreviewLogsResponse{PaginatedResult: PaginatedResult{Total: len(lines), Page: page, PerPage: perPage}}
+		if from := (page - 1) * perPage; from < len(lines) {
+			result.StackPreviewLogLines = lines[from:min(from+perPage, len(lines))]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	})
+	defer cleanup()
+
+	lines, err := client.Previews.GetAllLogs(context.Background(), "org-uuid", 100)
+	if err != nil {
+		t.Fatalf("GetAllLogs error: %v", err)
+	}
+	if len(lines) != 103 {
+		t.Fatalf("expected 103 lines, got %d", len(lines))
+	}
+	if lines[49].Message != "line 50" || lines[50].Message != "Warning: deprecated" || lines[50].Channel != "stderr" {
+		t.Errorf("expected the stderr warning after line 50, got %+v and %+v", lines[49], lines[50])
+	}
+	if lines[102].Message != "Error: quota exceeded" {
+		t.Errorf("expected the stderr error last, got %+v", lines[102])
+	}
+	if requests["stdout"] != 2 || requests["stderr"] != 1 {
+		t.Errorf("unexpected page requests per channel: %v", requests)
+	}
+
+	if _, err := client.Previews.GetAllLogs(context.Background(), "org-uuid", 0); err == nil {
+		t.Error("expected an error for a non-positive stack preview ID")
+	}
+}
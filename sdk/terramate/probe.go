@@ -0,0 +1,106 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Service identifies a group of API endpoints that a credential may not be
+// allowed to access, e.g. because of its role or the organization's plan.
+type Service string
+
+// Services checked by ProbeServices.
+const (
+	// ServiceStacks covers stacks and their drifts.
+	ServiceStacks Service = "stacks"
+	// ServiceDeployments covers deployments and their logs.
+	ServiceDeployments Service = "deployments"
+	// ServiceReviewRequests covers review requests and their previews.
+	ServiceReviewRequests Service = "review_requests"
+	// ServiceResources covers stack resources.
+	ServiceResources Service = "resources"
+)
+
+// probePaths are the cheapest list requests of each service, by path format
+// taking the organization UUID.
+var probePaths = map[Service]string{
+	ServiceStacks:         "/v1/stacks/%s?per_page=1",
+	ServiceDeployments:    "/v1/stack_deployments/%s?per_page=1",
+	ServiceReviewRequests: "/v1/review_requests/%s?per_page=1",
+	ServiceResources:      "/v1/resources/%s?per_page=1",
+}
+
+// probedServices records the services found unavailable, by organization.
+type probedServices struct {
+	mu          sync.RWMutex
+	unavailable map[string]map[Service]int // org UUID -> service -> probe status
+}
+
+// ProbeServices checks which services the credential can access in the
+// organization, with one single-item list request per service. Services
+// answering 403 Forbidden or 404 Not Found are marked unavailable: their
+// methods then fail fast with a *FeatureUnavailableError instead of sending
+// requests that are bound to be rejected.
+//
+// The returned map holds the outcome of every service that could be probed.
+// Services whose probe failed otherwise, e.g. with a network error, keep
+// their previous state and are reported in the error. Probing again replaces
+// the previous outcome, so access granted later is picked up.
+func (c *Client) ProbeServices(ctx context.Context, orgUUID string) (map[Service]bool, error) {
+	if orgUUID == "" {
+		return nil, fmt.Errorf("organization UUID is required")
+	}
+
+	available := make(map[Service]bool, len(probePaths))
+	var errs []error
+	for service, format := range probePaths {
+		req, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf(format, orgUUID), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		_, err = c.do(req, nil)
+		var apiErr *APIError
+		switch {
+		case err == nil:
+			available[service] = true
+			c.services.set(orgUUID, service, 0)
+		case errors.As(err, &apiErr) && (apiErr.IsForbidden() || apiErr.IsNotFound()):
+			available[service] = false
+			c.services.set(orgUUID, service, apiErr.StatusCode)
+		default:
+			errs = append(errs, fmt.Errorf("probing %s: %w", service, err))
+		}
+	}
+	return available, errors.Join(errs...)
+}
+
+// checkService returns a *FeatureUnavailableError if ProbeServices found
+// service unavailable in the organization.
+func (c *Client) checkService(service Service, orgUUID string) error {
+	c.services.mu.RLock()
+	defer c.services.mu.RUnlock()
+	if status, ok := c.services.unavailable[orgUUID][service]; ok {
+		return &FeatureUnavailableError{Service: service, OrganizationUUID: orgUUID, StatusCode: status}
+	}
+	return nil
+}
+
+// set records the probe status of service; 0 marks it available.
+func (p *probedServices) set(orgUUID string, service Service, status int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if status == 0 {
+		delete(p.unavailable[orgUUID], service)
+		return
+	}
+	if p.unavailable == nil {
+		p.unavailable = map[string]map[Service]int{}
+	}
+	if p.unavailable[orgUUID] == nil {
+		p.unavailable[orgUUID] = map[Service]int{}
+	}
+	p.unavailable[orgUUID][service] = status
+}
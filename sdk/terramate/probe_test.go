@@ -0,0 +1,96 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestProbeServices_MarksForbiddenServicesUnavailable(t *testing.T) {
+	var deploymentsForbidden atomic.Bool
+	deploymentsForbidden.Store(true)
+	var requests atomic.Int32
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch {
+		case r.URL.Path == "/v1/stack_deployments/org-uuid" && deploymentsForbidden.Load():
+			w.WriteHeader(http.StatusForbidden)
+		case strings.HasPrefix(r.URL.Path, "/v1/resources/"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}
+	})
+	defer cleanup()
+
+	ctx := context.Background()
+	available, err := client.ProbeServices(ctx, "org-uuid")
+	if err != nil {
+		t.Fatalf("ProbeServices error: %v", err)
+	}
+	want := map[Service]bool{ServiceStacks: true, ServiceDeployments: false, ServiceReviewRequests: true, ServiceResources: false}
+	for service, ok := range want {
+		if available[service] != ok {
+			t.Errorf("%s: expected available=%v, got %v", service, ok, available[service])
+		}
+	}
+
+	requests.Store(0)
+	_, _, err = client.Deployments.ListStackDeployments(ctx, "org-uuid", nil)
+	var unavailable *FeatureUnavailableError
+	if !errors.Is(err, ErrFeatureUnavailable) || !errors.As(err, &unavailable) {
+		t.Fatalf("expected ErrFeatureUnavailable, got %v", err)
+	}
+	if unavailable.Service != ServiceDeployments || unavailable.OrganizationUUID != "org-uuid" || unavailable.StatusCode != http.StatusForbidden {
+		t.Fatalf("unexpected error: %+v", unavailable)
+	}
+	if _, _, err := client.Resources.Get(ctx, "org-uuid", "res-uuid"); !errors.Is(err, ErrFeatureUnavailable) {
+		t.Fatalf("expected resources to be unavailable, got %v", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Fatalf("expected unavailable services to fail without requests, got %d", n)
+	}
+
+	// Other organizations and available services are unaffected
+	if _, _, err := client.Deployments.ListStackDeployments(ctx, "other-org", nil); err != nil {
+		t.Fatalf("expected another organization to be unaffected, got %v", err)
+	}
+	if _, _, err := client.Stacks.List(ctx, "org-uuid", nil); err != nil {
+		t.Fatalf("expected stacks to stay available, got %v", err)
+	}
+
+	// Probing again picks up granted access
+	deploymentsForbidden.Store(false)
+	if _, err := client.ProbeServices(ctx, "org-uuid"); err != nil {
+		t.Fatalf("ProbeServices error: %v", err)
+	}
+	if _, _, err := client.Deployments.ListStackDeployments(ctx, "org-uuid", nil); err != nil {
+		t.Fatalf("expected deployments to become available, got %v", err)
+	}
+}
+
+func TestProbeServices_UndeterminedOnOtherErrors(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	defer cleanup()
+
+	available, err := client.ProbeServices(context.Background(), "org-uuid")
+	if err == nil {
+		t.Fatal("expected the failed probes to be reported")
+	}
+	if len(available) != 0 {
+		t.Fatalf("expected no outcome, got %v", available)
+	}
+	if err := client.checkService(ServiceStacks, "org-uuid"); err != nil {
+		t.Fatalf("expected an undetermined service to stay usable, got %v", err)
+	}
+
+	if _, err := client.ProbeServices(context.Background(), ""); err == nil {
+		t.Fatal("expected an empty organization UUID to fail")
+	}
+}
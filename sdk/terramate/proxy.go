@@ -0,0 +1,119 @@
+package terramate
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ProxyFunc returns the proxy selection for requests routed through
+// proxyURL, e.g. "http://proxy.corp:3128". Hosts listed in NO_PROXY (or
+// no_proxy) and loopback hosts are still reached directly. An empty proxyURL
+// returns http.ProxyFromEnvironment, which honors HTTPS_PROXY, HTTP_PROXY and
+// NO_PROXY.
+//
+// A proxyURL without a scheme is assumed to be an HTTP proxy.
+func ProxyFunc(proxyURL string) (func(*http.Request) (*url.URL, error), error) {
+	if proxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	if !strings.Contains(proxyURL, "://") {
+		proxyURL = "http://" + proxyURL
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https or socks5", proxyURL)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", proxyURL)
+	}
+
+	noProxy := os.Getenv("NO_PROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("no_proxy")
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL, noProxy) {
+			return nil, nil
+		}
+		return u, nil
+	}, nil
+}
+
+// NewProxyTransport returns a copy of http.DefaultTransport routing requests
+// through proxyURL (see ProxyFunc).
+func NewProxyTransport(proxyURL string) (*http.Transport, error) {
+	proxy, err := ProxyFunc(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	return transport, nil
+}
+
+// WithProxy routes API requests through proxyURL (see ProxyFunc), replacing
+// the transport of the client's HTTP client. An empty proxyURL keeps the
+// default, which honors the proxy environment variables.
+func WithProxy(proxyURL string) ClientOption {
+	return func(c *Client) error {
+		if proxyURL == "" {
+			return nil
+		}
+		transport, err := NewProxyTransport(proxyURL)
+		if err != nil {
+			return err
+		}
+		c.httpClient.Transport = transport
+		return nil
+	}
+}
+
+// bypassProxy reports whether requests to target skip the proxy: loopback
+// hosts and hosts matching an entry of noProxy, a comma-separated list of
+// host names (matching their subdomains too), IP addresses and CIDR ranges,
+// each optionally with a port, or "*" for all hosts.
+func bypassProxy(target *url.URL, noProxy string) bool {
+	host, port := strings.ToLower(target.Hostname()), target.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[target.Scheme]
+	}
+	ip := net.ParseIP(host)
+	if host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return true
+	}
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		if matchNoProxy(strings.ToLower(strings.TrimSpace(entry)), host, port, ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchNoProxy reports whether the NO_PROXY entry matches host:port.
+func matchNoProxy(entry, host, port string, ip net.IP) bool {
+	if entry == "*" {
+		return true
+	}
+	if _, cidr, err := net.ParseCIDR(entry); err == nil {
+		return ip != nil && cidr.Contains(ip)
+	}
+	entryHost, entryPort := entry, ""
+	if h, p, err := net.SplitHostPort(entry); err == nil {
+		entryHost, entryPort = h, p
+	}
+	if entryPort != "" && entryPort != port {
+		return false
+	}
+	entryHost = strings.TrimPrefix(strings.TrimPrefix(entryHost, "*"), ".")
+	return entryHost != "" && (host == entryHost || strings.HasSuffix(host, "."+entryHost))
+}
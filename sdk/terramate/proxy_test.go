@@ -0,0 +1,70 @@
+package terramate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestWithProxy_RoutesRequestsThroughProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host // absolute-form request target of a proxied request
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer proxy.Close()
+
+	client, err := NewClientWithAPIKey("key", WithBaseURL("http://api.example.test"), WithProxy(proxy.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	if _, _, err := client.Memberships.List(context.Background()); err != nil {
+		t.Fatalf("Memberships.List error: %v", err)
+	}
+	if proxiedHost != "api.example.test" {
+		t.Fatalf("expected the request to go through the proxy, got host %q", proxiedHost)
+	}
+}
+
+func TestProxyFunc(t *testing.T) {
+	t.Setenv("NO_PROXY", "internal.corp, .svc.local,10.0.0.0/8,api.example.test:8443")
+	proxy, err := ProxyFunc("proxy.corp:3128")
+	if err != nil {
+		t.Fatalf("ProxyFunc error: %v", err)
+	}
+
+	cases := map[string]bool{
+		"https://api.terramate.io/v1/stacks":  true,
+		"https://internal.corp/x":             false,
+		"https://git.internal.corp/x":         false,
+		"https://auth.svc.local/x":            false,
+		"https://10.1.2.3/x":                  false,
+		"https://api.example.test:8443/x":     false,
+		"https://api.example.test/x":          true,
+		"http://127.0.0.1:8080/x":             false,
+		"https://notinternal.corp/x":          true,
+		"https://securetoken.googleapis.com/": true,
+	}
+	for target, proxied := range cases {
+		u, _ := url.Parse(target)
+		got, err := proxy(&http.Request{URL: u})
+		if err != nil {
+			t.Fatalf("%s: proxy error: %v", target, err)
+		}
+		if (got != nil) != proxied {
+			t.Errorf("%s: expected proxied=%v, got %v", target, proxied, got)
+		}
+		if got != nil && got.String() != "http://proxy.corp:3128" {
+			t.Errorf("%s: unexpected proxy %s", target, got)
+		}
+	}
+
+	for _, invalid := range []string{"ftp://proxy.corp", "http://", "http://%zz"} {
+		if _, err := ProxyFunc(invalid); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package terramate
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Rate limit headers of API responses.
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimit is the request quota reported by the API in the X-RateLimit-*
+// headers of a response.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int `json:"limit"`
+	// Remaining is the number of requests left in the current window.
+	Remaining int `json:"remaining"`
+	// Reset is when the window resets, nil if not reported.
+	Reset *time.Time `json:"reset,omitempty"`
+}
+
+// Exhausted reports whether no request is left before Reset, as of now.
+func (r *RateLimit) Exhausted(now time.Time) bool {
+	return r.Remaining <= 0 && (r.Reset == nil || now.Before(*r.Reset))
+}
+
+// epochThreshold separates X-RateLimit-Reset values given as Unix times from
+// those given as seconds until the reset.
+const epochThreshold = 1_000_000_000
+
+// parseRateLimit returns the rate limit reported by h, or nil when h carries
+// no valid X-RateLimit-Limit and X-RateLimit-Remaining. X-RateLimit-Reset is
+// read as a Unix time or, for small values, as seconds after now.
+func parseRateLimit(h http.Header, now time.Time) *RateLimit {
+	limit, err := strconv.Atoi(h.Get(RateLimitLimitHeader))
+	if err != nil {
+		return nil
+	}
+	remaining, err := strconv.Atoi(h.Get(RateLimitRemainingHeader))
+	if err != nil {
+		return nil
+	}
+	rate := &RateLimit{Limit: limit, Remaining: remaining}
+	reset, err := strconv.ParseInt(h.Get(RateLimitResetHeader), 10, 64)
+	if err == nil && reset >= 0 {
+		at := now.Add(time.Duration(reset) * time.Second).UTC()
+		if reset >= epochThreshold {
+			at = time.Unix(reset, 0).UTC()
+		}
+		rate.Reset = &at
+	}
+	return rate
+}
+
+// RateLimit returns the rate limit reported by the latest API response
+// carrying one, or nil if none did yet. Use Response.Rate for the rate limit
+// of a given response.
+func (c *Client) RateLimit() *RateLimit {
+	rate := c.rate.Load()
+	if rate == nil {
+		return nil
+	}
+	r := *rate
+	if r.Reset != nil {
+		reset := *r.Reset
+		r.Reset = &reset
+	}
+	return &r
+}
@@ -0,0 +1,92 @@
+package terramate
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	header := func(limit, remaining, reset string) http.Header {
+		h := http.Header{}
+		h.Set(RateLimitLimitHeader, limit)
+		h.Set(RateLimitRemainingHeader, remaining)
+		if reset != "" {
+			h.Set(RateLimitResetHeader, reset)
+		}
+		return h
+	}
+
+	if rate := parseRateLimit(http.Header{}, now); rate != nil {
+		t.Fatalf("expected no rate limit without headers, got %+v", rate)
+	}
+	if rate := parseRateLimit(header("100", "many", ""), now); rate != nil {
+		t.Fatalf("expected no rate limit for an invalid remaining count, got %+v", rate)
+	}
+
+	rate := parseRateLimit(header("100", "42", ""), now)
+	if rate == nil || rate.Limit != 100 || rate.Remaining != 42 || rate.Reset != nil {
+		t.Fatalf("unexpected rate limit: %+v", rate)
+	}
+
+	// Unix time
+	rate = parseRateLimit(header("100", "0", "1772366460"), now)
+	if rate.Reset == nil || !rate.Reset.Equal(now.Add(time.Minute)) {
+		t.Fatalf("unexpected reset: %v", rate.Reset)
+	}
+	if !rate.Exhausted(now) || rate.Exhausted(now.Add(2*time.Minute)) {
+		t.Fatal("expected the quota to be exhausted until the reset")
+	}
+
+	// seconds until the reset
+	rate = parseRateLimit(header("100", "0", "30"), now)
+	if rate.Reset == nil || !rate.Reset.Equal(now.Add(30*time.Second)) {
+		t.Fatalf("unexpected reset: %v", rate.Reset)
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	remaining := "99"
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/stacks/org-uuid/1" {
+			w.Header().Set(RateLimitLimitHeader, "100")
+			w.Header().Set(RateLimitRemainingHeader, remaining)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"stack_id": 1}`))
+	})
+	defer cleanup()
+
+	if client.RateLimit() != nil {
+		t.Fatal("expected no rate limit before any request")
+	}
+	_, resp, err := client.Stacks.Get(context.Background(), "org-uuid", 1)
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if resp.Rate == nil || resp.Rate.Limit != 100 || resp.Rate.Remaining != 99 {
+		t.Fatalf("unexpected response rate limit: %+v", resp.Rate)
+	}
+
+	// responses without the headers keep the latest rate limit
+	_, resp, err = client.Stacks.Get(context.Background(), "org-uuid", 2)
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if resp.Rate != nil {
+		t.Fatalf("expected no rate limit, got %+v", resp.Rate)
+	}
+	if rate := client.RateLimit(); rate == nil || rate.Remaining != 99 {
+		t.Fatalf("unexpected client rate limit: %+v", rate)
+	}
+
+	remaining = "98"
+	if _, _, err = client.Stacks.Get(context.Background(), "org-uuid", 1); err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if rate := client.RateLimit(); rate == nil || rate.Remaining != 98 {
+		t.Fatalf("expected the latest rate limit, got %+v", rate)
+	}
+}
@@ -0,0 +1,78 @@
+package terramate
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the secrets removed by Redact.
+const Redacted = "[REDACTED]"
+
+// minSecretLength is the length below which RegisterSecret ignores a secret,
+// as redacting it would also redact ordinary words.
+const minSecretLength = 8
+
+// maxRegisteredSecrets bounds the secrets kept by RegisterSecret, so rotated
+// refresh tokens do not accumulate.
+const maxRegisteredSecrets = 64
+
+var (
+	// secretPattern matches secrets recognizable by their shape: JWTs, GitHub
+	// tokens, and bearer and basic credentials.
+	secretPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+|gh[pousr]_[A-Za-z0-9]{20,}|(?i:bearer)\s+[A-Za-z0-9._~+/=-]+|(?i:basic)\s+[A-Za-z0-9+/]{16,}={0,2}`)
+
+	// secretField matches the values of secret fields in JSON ("refresh_token":
+	// "..."), form or query (api_key=...) and Go map (map[token:...]) encoded
+	// text, capturing the field name to keep it. Prose such as "refresh token:
+	// invalid" is not matched, as the value must follow the separator directly.
+	secretField = regexp.MustCompile(`(?i)("[A-Za-z_-]*(?:token|secret|password|passwd|api[_-]?key)"\s*:\s*"?|[A-Za-z_-]*(?:token|secret|password|passwd|api[_-]?key)[:=])[^"&\s,}[\]]+`)
+
+	// secrets are the registered secrets, longest first so that a secret
+	// containing another is replaced whole, and registered in their order of
+	// registration for eviction.
+	secrets    []string
+	registered []string
+	secretsMu  sync.RWMutex
+)
+
+// RegisterSecret makes Redact replace every occurrence of secret, e.g. an API
+// key or refresh token, which have no recognizable shape. The credentials of
+// the SDK register their secrets themselves. Secrets shorter than 8
+// characters are ignored.
+func RegisterSecret(secret string) {
+	if len(secret) < minSecretLength {
+		return
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	if slices.Contains(registered, secret) {
+		return
+	}
+	if len(registered) == maxRegisteredSecrets {
+		secrets = slices.DeleteFunc(secrets, func(s string) bool { return s == registered[0] })
+		registered = registered[1:]
+	}
+	registered = append(registered, secret)
+	i := slices.IndexFunc(secrets, func(s string) bool { return len(s) < len(secret) })
+	if i < 0 {
+		i = len(secrets)
+	}
+	secrets = slices.Insert(secrets, i, secret)
+}
+
+// Redact returns s with secrets replaced by Redacted: registered secrets (see
+// RegisterSecret), JWTs, GitHub tokens, bearer and basic credentials, and the
+// values of token, secret, password and API key fields. It is applied to the
+// errors of the SDK, and should be applied to any text that may reach logs or
+// agent transcripts.
+func Redact(s string) string {
+	secretsMu.RLock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, Redacted)
+	}
+	secretsMu.RUnlock()
+	s = secretPattern.ReplaceAllString(s, Redacted)
+	return secretField.ReplaceAllString(s, "${1}"+Redacted)
+}
@@ -0,0 +1,234 @@
+package terramate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// RefreshTransport exchanges a refresh token for new tokens with the identity
+// provider. JWTCredential uses it when the API rejects the current ID token.
+type RefreshTransport interface {
+	RefreshTokens(ctx context.Context, refreshToken string) (*RefreshedTokens, error)
+}
+
+// RefreshedTokens is the result of a token refresh.
+type RefreshedTokens struct {
+	IDToken string `json:"id_token"`
+	// RefreshToken is set when the provider rotated the refresh token.
+	RefreshToken string `json:"refresh_token"`
+}
+
+// FirebaseRefreshTransport refreshes tokens through the Firebase Auth secure
+// token API, which backs Terramate Cloud logins. The zero value talks to the
+// public Google endpoint.
+type FirebaseRefreshTransport struct {
+	// Endpoint is the token endpoint URL, including the API key query parameter.
+	// Defaults to https://securetoken.googleapis.com/v1/token?key=<key>, where the
+	// key can be overridden with TMC_API_IDP_KEY.
+	Endpoint string
+
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// RefreshTokens implements RefreshTransport.
+func (f *FirebaseRefreshTransport) RefreshTokens(ctx context.Context, refreshToken string) (*RefreshedTokens, error) {
+	resp, body, err := f.makeRefreshRequest(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, refreshError(resp.StatusCode, body)
+	}
+
+	return parseRefreshResponse(body)
+}
+
+// makeRefreshRequest makes the HTTP request to Firebase Auth.
+func (f *FirebaseRefreshTransport) makeRefreshRequest(ctx context.Context, refreshToken string) (*http.Response, []byte, error) {
+	endpoint := f.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://securetoken.googleapis.com/v1/token?key=%s", idpKey())
+	}
+
+	payload := map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal refresh payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create refresh request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := f.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, nil, fmt.Errorf("failed to read refresh response: %w", err)
+	}
+
+	return resp, body, nil
+}
+
+// OAuthRefreshTransport refreshes tokens with an OAuth 2.0 token endpoint
+// (RFC 6749, section 6), e.g. the identity provider of a self-hosted
+// Terramate Cloud instance that does not use Firebase Auth.
+type OAuthRefreshTransport struct {
+	// Endpoint is the token endpoint URL; required.
+	Endpoint string
+
+	// ClientID is the client ID the refresh token was issued to; required.
+	ClientID string
+
+	// HTTPClient defaults to a client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// RefreshTokens implements RefreshTransport. The id_token of the response is
+// used as the new ID token, or its access_token when the provider returns no
+// id_token on refresh.
+func (o *OAuthRefreshTransport) RefreshTokens(ctx context.Context, refreshToken string) (*RefreshedTokens, error) {
+	if o.Endpoint == "" || o.ClientID == "" {
+		return nil, fmt.Errorf("token refresh endpoint and client ID are required")
+	}
+	form := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refreshToken}, "client_id": {o.ClientID}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.Endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create refresh request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	client := o.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read refresh response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, refreshError(resp.StatusCode, body)
+	}
+
+	var result struct {
+		RefreshedTokens
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse refresh response: %w", err)
+	}
+	if result.IDToken == "" {
+		result.IDToken = result.AccessToken
+	}
+	if result.IDToken == "" {
+		return nil, fmt.Errorf("refresh response missing id_token")
+	}
+	return &result.RefreshedTokens, nil
+}
+
+// idpKey returns the Firebase API key for token refresh.
+// It mirrors Terramate CLI behavior by supporting TMC_API_IDP_KEY override.
+func idpKey() string {
+	key := os.Getenv("TMC_API_IDP_KEY")
+	if key == "" {
+		key = defaultFirebaseAuthAPIKey
+	}
+	return key
+}
+
+// ErrSessionExpired is wrapped by the errors of token refreshes the identity
+// provider rejected because the login session ended, e.g. the SSO session
+// expired or the refresh token was revoked: only logging in again helps.
+var ErrSessionExpired = errors.New("the login session expired")
+
+// sessionExpiredCodes are the refresh error codes meaning that the login
+// session ended: invalid_grant of OAuth 2.0 (RFC 6749) and the messages of
+// Firebase Auth.
+var sessionExpiredCodes = map[string]bool{
+	"invalid_grant":         true,
+	"TOKEN_EXPIRED":         true,
+	"INVALID_REFRESH_TOKEN": true,
+	"USER_DISABLED":         true,
+	"USER_NOT_FOUND":        true,
+}
+
+// refreshError builds the error for a non-200 response from the token
+// endpoint: an OAuth 2.0 error ({"error": "invalid_grant", ...}) or a Firebase
+// Auth one ({"error": {"message": "TOKEN_EXPIRED", ...}}). Errors meaning that
+// the login session ended wrap ErrSessionExpired.
+func refreshError(statusCode int, body []byte) error {
+	var errResp struct {
+		Error            json.RawMessage `json:"error"`
+		ErrorDescription string          `json:"error_description"`
+	}
+	var code string
+	if err := json.Unmarshal(body, &errResp); err == nil && len(errResp.Error) > 0 {
+		var firebaseErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(errResp.Error, &code) != nil && json.Unmarshal(errResp.Error, &firebaseErr) == nil {
+			code = firebaseErr.Message
+		}
+	}
+
+	errorMsg := fmt.Sprintf("token refresh failed (status %d)", statusCode)
+	switch {
+	case code != "" && errResp.ErrorDescription != "":
+		errorMsg = fmt.Sprintf("token refresh failed: %s - %s", code, errResp.ErrorDescription)
+	case code != "":
+		errorMsg = fmt.Sprintf("token refresh failed: %s", code)
+	}
+	// Firebase Auth messages may carry a detail after the code
+	if codeName, _, _ := strings.Cut(code, " "); sessionExpiredCodes[codeName] {
+		return fmt.Errorf("%s: %w", Redact(errorMsg), ErrSessionExpired)
+	}
+	return fmt.Errorf("%s", Redact(errorMsg))
+}
+
+// parseRefreshResponse parses a successful refresh response.
+func parseRefreshResponse(body []byte) (*RefreshedTokens, error) {
+	var result RefreshedTokens
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse refresh response: %w", err)
+	}
+
+	if result.IDToken == "" {
+		return nil, fmt.Errorf("refresh response missing id_token")
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,162 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeRefreshTransport returns scripted tokens without any network access.
+type fakeRefreshTransport struct {
+	tokens *RefreshedTokens
+	err    error
+	calls  int
+}
+
+func (f *fakeRefreshTransport) RefreshTokens(_ context.Context, _ string) (*RefreshedTokens, error) {
+	f.calls++
+	return f.tokens, f.err
+}
+
+func TestWithRefreshTransport(t *testing.T) {
+	newToken := generateMockJWT()
+	transport := &fakeRefreshTransport{tokens: &RefreshedTokens{IDToken: newToken, RefreshToken: "rotated"}}
+
+	cred, err := NewJWTCredential(generateMockJWT(), "Google", WithRefreshTransport(transport))
+	if err != nil {
+		t.Fatalf("NewJWTCredential error: %v", err)
+	}
+	cred.refreshToken = "refresh-token"
+
+	if err := cred.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	if transport.calls != 1 || cred.idToken != newToken || cred.refreshToken != "rotated" {
+		t.Fatalf("expected tokens from the custom transport, got calls=%d refresh=%q", transport.calls, cred.refreshToken)
+	}
+}
+
+func TestWithRefreshTransport_Error(t *testing.T) {
+	transportErr := errors.New("sovereign idp unavailable")
+	cred, err := NewJWTCredential(generateMockJWT(), "Google", WithRefreshTransport(&fakeRefreshTransport{err: transportErr}))
+	if err != nil {
+		t.Fatalf("NewJWTCredential error: %v", err)
+	}
+	cred.refreshToken = "refresh-token"
+
+	if err := cred.Refresh(context.Background()); !errors.Is(err, transportErr) {
+		t.Fatalf("expected transport error, got %v", err)
+	}
+}
+
+func TestWithRefreshToken(t *testing.T) {
+	newToken := generateMockJWT()
+	transport := &fakeRefreshTransport{tokens: &RefreshedTokens{IDToken: newToken, RefreshToken: "rotated"}}
+	cred, err := NewJWTCredential(generateMockJWT(), "", WithRefreshToken("refresh-token"), WithRefreshTransport(transport))
+	if err != nil {
+		t.Fatalf("NewJWTCredential error: %v", err)
+	}
+
+	if err := cred.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	if cred.idToken != newToken || cred.credentialPath != "" {
+		t.Fatalf("expected the refreshed token in memory only, got path %q", cred.credentialPath)
+	}
+}
+
+func TestWithReadOnlyCredentialFile(t *testing.T) {
+	credFile := filepath.Join(t.TempDir(), "credentials.tmrc.json")
+	content := createTestCredentialFile("Google", generateMockJWT(), "refresh-token")
+	if err := os.WriteFile(credFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write credential file: %v", err)
+	}
+
+	newToken := generateMockJWT()
+	transport := &fakeRefreshTransport{tokens: &RefreshedTokens{IDToken: newToken}}
+	cred, err := LoadJWTFromFile(credFile, WithReadOnlyCredentialFile(), WithRefreshTransport(transport))
+	if err != nil {
+		t.Fatalf("LoadJWTFromFile error: %v", err)
+	}
+	if err := cred.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh error: %v", err)
+	}
+	if cred.idToken != newToken {
+		t.Fatal("expected the refreshed token in memory")
+	}
+	if data, _ := os.ReadFile(credFile); string(data) != content {
+		t.Fatalf("expected the read-only credential file to be unchanged, got %s", data)
+	}
+}
+
+func TestFirebaseRefreshTransport_ErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"token revoked"}`))
+	}))
+	defer server.Close()
+
+	transport := &FirebaseRefreshTransport{Endpoint: server.URL, HTTPClient: server.Client()}
+	_, err := transport.RefreshTokens(context.Background(), "refresh-token")
+	if err == nil || err.Error() != "token refresh failed: invalid_grant - token revoked: the login session expired" || !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRefreshError(t *testing.T) {
+	cases := []struct {
+		body    string
+		message string
+		expired bool
+	}{
+		{`{"error":{"code":400,"message":"TOKEN_EXPIRED","status":"INVALID_ARGUMENT"}}`, "token refresh failed: TOKEN_EXPIRED", true},
+		{`{"error":{"code":400,"message":"USER_DISABLED : The user account has been disabled.","status":"INVALID_ARGUMENT"}}`, "token refresh failed: USER_DISABLED : The user account has been disabled.", true},
+		{`{"error":"invalid_client"}`, "token refresh failed: invalid_client", false},
+		{`<html>Service Unavailable</html>`, "token refresh failed (status 400)", false},
+	}
+	for _, c := range cases {
+		err := refreshError(http.StatusBadRequest, []byte(c.body))
+		if got, _ := strings.CutSuffix(err.Error(), ": the login session expired"); got != c.message || errors.Is(err, ErrSessionExpired) != c.expired {
+			t.Errorf("refreshError(%s) = %v, want %q (session expired: %v)", c.body, err, c.message, c.expired)
+		}
+	}
+}
+
+func TestOAuthRefreshTransport(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm error: %v", err)
+		}
+		form = r.PostForm
+		if form.Get("refresh_token") == "revoked" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"new-token","refresh_token":"rotated","token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	transport := &OAuthRefreshTransport{Endpoint: server.URL, ClientID: "mcp-server", HTTPClient: server.Client()}
+	tokens, err := transport.RefreshTokens(context.Background(), "refresh-token")
+	if err != nil {
+		t.Fatalf("RefreshTokens error: %v", err)
+	}
+	if tokens.IDToken != "new-token" || tokens.RefreshToken != "rotated" {
+		t.Fatalf("expected the access token as ID token, got %+v", tokens)
+	}
+	if form.Get("grant_type") != "refresh_token" || form.Get("client_id") != "mcp-server" || form.Get("refresh_token") != "refresh-token" {
+		t.Fatalf("unexpected refresh request: %v", form)
+	}
+
+	if _, err := transport.RefreshTokens(context.Background(), "revoked"); !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
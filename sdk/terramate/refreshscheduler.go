@@ -0,0 +1,179 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultRefreshLead is how long before the ID token expires the refresh
+// scheduler renews it.
+const DefaultRefreshLead = 5 * time.Minute
+
+const (
+	// refreshJitter is the largest random advance of a scheduled refresh, so
+	// servers sharing a credential do not all refresh at the same moment.
+	refreshJitter = time.Minute
+	// minRefreshRetry and maxRefreshRetry bound the backoff after a failed
+	// scheduled refresh.
+	minRefreshRetry = 30 * time.Second
+	maxRefreshRetry = 10 * time.Minute
+	// minRefreshInterval is the least time between two scheduled refreshes,
+	// so a token endpoint issuing tokens already due (e.g. with a lifetime
+	// shorter than the lead) is not called in a loop.
+	minRefreshInterval = 30 * time.Second
+)
+
+// errNoRefreshToken is returned by StartRefreshScheduler for a credential
+// that cannot be refreshed.
+var errNoRefreshToken = errors.New("the credential has no refresh token")
+
+// StartRefreshScheduler refreshes the ID token in the background lead before
+// its exp claim (DefaultRefreshLead when lead <= 0), less a random jitter of
+// up to a minute, instead of waiting for the API to reject it. For tokens
+// with an iat claim, the lead is at most half and the jitter a tenth of their
+// lifetime, and refreshes are at least 30 seconds apart, so short-lived
+// tokens (e.g. the 5 minute access tokens of Keycloak) are not refreshed as
+// soon as they are issued. This avoids a
+// failed first request and the refresh latency after idle periods. A token
+// renewed meanwhile, e.g. by the Terramate CLI through the credential file,
+// postpones the refresh; a failed refresh is retried with backoff until the
+// token changes.
+//
+// The scheduler runs until ctx is done, StopRefreshScheduler is called or the
+// credential is logged out. It returns an error when the credential has no
+// refresh token; starting a running scheduler does nothing.
+func (j *JWTCredential) StartRefreshScheduler(ctx context.Context, lead time.Duration) error {
+	if lead <= 0 {
+		lead = DefaultRefreshLead
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.refreshToken == "" {
+		return errNoRefreshToken
+	}
+	if j.stopScheduler != nil {
+		return nil
+	}
+	j.stopScheduler = make(chan struct{})
+	go j.runRefreshScheduler(ctx, j.stopScheduler, lead)
+	return nil
+}
+
+// StopRefreshScheduler stops the scheduler of StartRefreshScheduler.
+func (j *JWTCredential) StopRefreshScheduler() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.stopScheduler != nil {
+		close(j.stopScheduler)
+		j.stopScheduler = nil
+	}
+}
+
+// runRefreshScheduler is the loop of StartRefreshScheduler.
+func (j *JWTCredential) runRefreshScheduler(ctx context.Context, stop <-chan struct{}, lead time.Duration) {
+	retry := minRefreshRetry
+	for {
+		due, ok := j.refreshDue(lead)
+		if !ok {
+			slog.Debug("The ID token has no expiry; no refresh is scheduled")
+			return
+		}
+		delay := time.Until(due.at)
+		if due.jitter > 0 {
+			delay -= rand.N(due.jitter)
+		}
+		if !waitOrStop(ctx, stop, delay) {
+			return
+		}
+		// The token may have been renewed while sleeping
+		if due, ok = j.refreshDue(lead); ok && time.Until(due.at) > due.jitter {
+			continue
+		}
+
+		err := j.Refresh(ctx)
+		switch {
+		case err == nil:
+			retry = minRefreshRetry
+			if !waitOrStop(ctx, stop, minRefreshInterval) {
+				return
+			}
+		case errors.Is(err, ErrLoggedOut):
+			return
+		default:
+			slog.Warn("Scheduled token refresh failed", "error", err, "retry_in", retry)
+			if !waitOrStop(ctx, stop, retry) {
+				return
+			}
+			retry = min(2*retry, maxRefreshRetry)
+		}
+	}
+}
+
+// refreshSchedule is when the current ID token is due for a refresh.
+type refreshSchedule struct {
+	at time.Time
+	// jitter is the largest random advance of the refresh.
+	jitter time.Duration
+}
+
+// refreshDue returns when the current ID token is due for a refresh, lead
+// before its expiry, or false when it has no expiry. For a token with an iat
+// claim, the lead is clamped to half and the jitter to a tenth of its
+// lifetime.
+func (j *JWTCredential) refreshDue(lead time.Duration) (refreshSchedule, bool) {
+	j.mu.RLock()
+	token := j.idToken
+	j.mu.RUnlock()
+	exp, iat, ok := tokenLifetime(token)
+	if !ok {
+		return refreshSchedule{}, false
+	}
+	jitter := refreshJitter
+	if lifetime := exp.Sub(iat); !iat.IsZero() && lifetime > 0 {
+		lead = min(lead, lifetime/2)
+		jitter = min(jitter, lifetime/10)
+	}
+	return refreshSchedule{at: exp.Add(-lead), jitter: jitter}, true
+}
+
+// tokenExpiry returns the exp claim of the JWT token, without verifying it.
+func tokenExpiry(token string) (time.Time, bool) {
+	exp, _, ok := tokenLifetime(token)
+	return exp, ok
+}
+
+// tokenLifetime returns the exp and, when set, iat claims of the JWT token,
+// without verifying it. It returns false when the token has no exp.
+func tokenLifetime(token string) (exp, iat time.Time, ok bool) {
+	parsed, _, err := (&jwt.Parser{}).ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	expClaim, err := parsed.Claims.GetExpirationTime()
+	if err != nil || expClaim == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	if iatClaim, iatErr := parsed.Claims.GetIssuedAt(); iatErr == nil && iatClaim != nil {
+		iat = iatClaim.Time
+	}
+	return expClaim.Time, iat, true
+}
+
+// waitOrStop waits for d, returning false when ctx is done or stop is closed first.
+func waitOrStop(ctx context.Context, stop <-chan struct{}, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-stop:
+		return false
+	}
+}
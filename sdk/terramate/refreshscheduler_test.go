@@ -0,0 +1,140 @@
+package terramate
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingRefreshTransport returns the same tokens on every refresh, counting
+// the refreshes.
+type countingRefreshTransport struct {
+	tokens *RefreshedTokens
+	calls  atomic.Int32
+}
+
+func (c *countingRefreshTransport) RefreshTokens(_ context.Context, _ string) (*RefreshedTokens, error) {
+	c.calls.Add(1)
+	return c.tokens, nil
+}
+
+// mockJWTExpiringIn returns an unsigned JWT expiring after d.
+func mockJWTExpiringIn(d time.Duration) string {
+	claims := fmt.Sprintf(`{"iss":"https://securetoken.google.com/test","exp":%d}`, time.Now().Add(d).Unix())
+	return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".sig"
+}
+
+func TestStartRefreshScheduler(t *testing.T) {
+	transport := &countingRefreshTransport{tokens: &RefreshedTokens{IDToken: mockJWTExpiringIn(time.Hour)}}
+	cred, err := NewJWTCredential(mockJWTExpiringIn(2*time.Minute), "", WithRefreshToken("refresh"), WithRefreshTransport(transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Due within the 5 minute lead, so the refresh starts right away
+	if err := cred.StartRefreshScheduler(ctx, 0); err != nil {
+		t.Fatalf("StartRefreshScheduler() error = %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for transport.calls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	// The refreshed token is due in 55 minutes, so no second refresh follows
+	time.Sleep(50 * time.Millisecond)
+	if calls := transport.calls.Load(); calls != 1 {
+		t.Fatalf("expected one scheduled refresh, got %d", calls)
+	}
+	cred.StopRefreshScheduler()
+}
+
+// mockJWTWithLifetime returns an unsigned JWT issued now and expiring after d.
+func mockJWTWithLifetime(d time.Duration) string {
+	now := time.Now()
+	claims := fmt.Sprintf(`{"iss":"https://keycloak.example.com/realms/test","iat":%d,"exp":%d}`, now.Unix(), now.Add(d).Unix())
+	return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".sig"
+}
+
+func TestRefreshDue_ShortLivedToken(t *testing.T) {
+	cred, err := NewJWTCredential(mockJWTWithLifetime(5*time.Minute), "", WithRefreshToken("refresh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The 5 minute lead is clamped to half the 5 minute lifetime
+	due, ok := cred.refreshDue(DefaultRefreshLead)
+	if !ok {
+		t.Fatal("expected the token to have an expiry")
+	}
+	if until := time.Until(due.at); until < 2*time.Minute || until > 3*time.Minute {
+		t.Fatalf("expected the refresh due in about 2.5 minutes, got %s", until)
+	}
+	if due.jitter != 30*time.Second {
+		t.Fatalf("expected the jitter clamped to 30s, got %s", due.jitter)
+	}
+}
+
+func TestStartRefreshScheduler_ShortLivedToken(t *testing.T) {
+	// The token endpoint issues 5 minute tokens: without the clamp they would
+	// be due within the default lead and refreshed again and again
+	transport := &countingRefreshTransport{tokens: &RefreshedTokens{IDToken: mockJWTWithLifetime(5 * time.Minute)}}
+	cred, err := NewJWTCredential(mockJWTExpiringIn(time.Minute), "", WithRefreshToken("refresh"), WithRefreshTransport(transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := cred.StartRefreshScheduler(ctx, 0); err != nil {
+		t.Fatalf("StartRefreshScheduler() error = %v", err)
+	}
+	defer cred.StopRefreshScheduler()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for transport.calls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if calls := transport.calls.Load(); calls != 1 {
+		t.Fatalf("expected one scheduled refresh, got %d", calls)
+	}
+}
+
+func TestStartRefreshScheduler_MinInterval(t *testing.T) {
+	// The token endpoint issues tokens already due, without iat to clamp the
+	// lead: refreshes are still spaced by minRefreshInterval
+	transport := &countingRefreshTransport{tokens: &RefreshedTokens{IDToken: mockJWTExpiringIn(time.Minute)}}
+	cred, err := NewJWTCredential(mockJWTExpiringIn(time.Minute), "", WithRefreshToken("refresh"), WithRefreshTransport(transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := cred.StartRefreshScheduler(ctx, 0); err != nil {
+		t.Fatalf("StartRefreshScheduler() error = %v", err)
+	}
+	defer cred.StopRefreshScheduler()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for transport.calls.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if calls := transport.calls.Load(); calls != 1 {
+		t.Fatalf("expected the refreshes to be spaced, got %d within 200ms", calls)
+	}
+}
+
+func TestStartRefreshScheduler_NoRefreshToken(t *testing.T) {
+	cred, err := NewJWTCredential(mockJWTExpiringIn(time.Hour), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cred.StartRefreshScheduler(context.Background(), 0); err == nil {
+		t.Fatal("expected an error for a credential without refresh token")
+	}
+}
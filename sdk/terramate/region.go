@@ -0,0 +1,52 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Terramate Cloud regions.
+const (
+	RegionEU = "eu"
+	RegionUS = "us"
+)
+
+// regionBaseURLs are the API base URLs of the regions.
+var regionBaseURLs = map[string]string{
+	RegionEU: "https://api.terramate.io",
+	RegionUS: "https://us.api.terramate.io",
+}
+
+// ErrRegionNotDetected is returned by DetectRegion when every region rejects
+// the credential.
+var ErrRegionNotDetected = errors.New("credential was rejected in every region")
+
+// DetectRegion returns the region whose API accepts credential, trying the EU
+// region first. A region accepts the credential when listing its memberships
+// succeeds; 401 Unauthorized and 403 Forbidden move on to the next region.
+//
+// opts configure the clients used to probe the regions, e.g. WithProxy; they
+// must not set the base URL.
+func DetectRegion(ctx context.Context, credential Credential, opts ...ClientOption) (string, error) {
+	var errs []error
+	for _, region := range []string{RegionEU, RegionUS} {
+		client, err := NewClient(credential, append(opts, WithRegion(region))...)
+		if err != nil {
+			return "", err
+		}
+		_, _, err = client.Memberships.List(ctx)
+		var apiErr *APIError
+		switch {
+		case err == nil:
+			return region, nil
+		case errors.As(err, &apiErr) && (apiErr.IsUnauthorized() || apiErr.IsForbidden()):
+		default:
+			errs = append(errs, fmt.Errorf("probing region %s: %w", region, err))
+		}
+	}
+	if len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+	return "", ErrRegionNotDetected
+}
@@ -0,0 +1,53 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// regionTransport answers membership requests with the status of the host's region.
+type regionTransport map[string]int
+
+func (t regionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: t[req.URL.Host],
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`[]`)),
+		Request:    req,
+	}, nil
+}
+
+func TestDetectRegion(t *testing.T) {
+	const eu, us = "api.terramate.io", "us.api.terramate.io"
+	tests := []struct {
+		name      string
+		statuses  regionTransport
+		want      string
+		wantError error
+	}{
+		{name: "eu", statuses: regionTransport{eu: http.StatusOK, us: http.StatusUnauthorized}, want: RegionEU},
+		{name: "us", statuses: regionTransport{eu: http.StatusUnauthorized, us: http.StatusOK}, want: RegionUS},
+		{name: "forbidden moves on", statuses: regionTransport{eu: http.StatusForbidden, us: http.StatusOK}, want: RegionUS},
+		{name: "rejected everywhere", statuses: regionTransport{eu: http.StatusUnauthorized, us: http.StatusUnauthorized}, wantError: ErrRegionNotDetected},
+		{name: "unexpected error", statuses: regionTransport{eu: http.StatusUnauthorized, us: http.StatusBadRequest}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region, err := DetectRegion(context.Background(), NewAPIKeyCredential("key"),
+				WithHTTPClient(&http.Client{Transport: tt.statuses}))
+			if tt.want == "" {
+				if err == nil || (tt.wantError != nil && !errors.Is(err, tt.wantError)) {
+					t.Fatalf("expected error %v, got region %q, error %v", tt.wantError, region, err)
+				}
+				return
+			}
+			if err != nil || region != tt.want {
+				t.Fatalf("expected region %q, got %q, error %v", tt.want, region, err)
+			}
+		})
+	}
+}
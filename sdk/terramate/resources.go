@@ -3,6 +3,7 @@ package terramate
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -62,6 +63,9 @@ func (s *ResourcesService) List(ctx context.Context, orgUUID string, opts *Resou
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceResources, orgUUID); err != nil {
+		return nil, nil, err
+	}
 
 	path := fmt.Sprintf("/v1/resources/%s", orgUUID)
 
@@ -86,6 +90,81 @@ func (s *ResourcesService) List(ctx context.Context, orgUUID string, opts *Resou
 	return &result, resp, nil
 }
 
+// All returns an iterator over the resources matching opts, walking the pages
+// of List from opts.Page as the iteration advances.
+func (s *ResourcesService) All(ctx context.Context, orgUUID string, opts *ResourcesListOptions) iter.Seq2[Resource, error] {
+	return s.listPages(ctx, orgUUID, opts).all()
+}
+
+// ListAll accumulates the resources matching opts in a slice, up to maxItems
+// (10000 when not positive), walking the pages of List from opts.Page. The
+// bool reports whether the result was truncated at maxItems.
+func (s *ResourcesService) ListAll(ctx context.Context, orgUUID string, opts *ResourcesListOptions, maxItems int) ([]Resource, bool, error) {
+	return s.listPages(ctx, orgUUID, opts).collect(maxItems)
+}
+
+// listPages pages through List from the page of opts.
+func (s *ResourcesService) listPages(ctx context.Context, orgUUID string, opts *ResourcesListOptions) pages[Resource] {
+	var o ResourcesListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[Resource]{opts: o.ListOptions, list: func(page ListOptions) ([]Resource, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.List(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Resources, result.PaginatedResult, nil
+	}}
+}
+
+// ListForStack retrieves the resource inventory of a stack: the resources
+// counted by its Stack.Resources, with their type, address, provider and last
+// update. The StackID of opts is replaced by stackID.
+//
+// GET /v1/resources/{org_uuid}?stack_id={stack_id}
+func (s *ResourcesService) ListForStack(ctx context.Context, orgUUID string, stackID int, opts *ResourcesListOptions) (*ResourcesListResponse, *Response, error) {
+	if stackID <= 0 {
+		return nil, nil, fmt.Errorf("stack ID must be positive")
+	}
+	stackOpts := ResourcesListOptions{}
+	if opts != nil {
+		stackOpts = *opts
+	}
+	stackOpts.StackID = stackID
+	return s.List(ctx, orgUUID, &stackOpts)
+}
+
+// AllForStack returns an iterator over the resources of a stack, walking the
+// pages of ListForStack from opts.Page as the iteration advances.
+func (s *ResourcesService) AllForStack(ctx context.Context, orgUUID string, stackID int, opts *ResourcesListOptions) iter.Seq2[Resource, error] {
+	return s.forStackPages(ctx, orgUUID, stackID, opts).all()
+}
+
+// ListAllForStack accumulates the resources of a stack in a slice, up to
+// maxItems (10000 when not positive), walking the pages of ListForStack from
+// opts.Page. The bool reports whether the result was truncated at maxItems.
+func (s *ResourcesService) ListAllForStack(ctx context.Context, orgUUID string, stackID int, opts *ResourcesListOptions, maxItems int) ([]Resource, bool, error) {
+	return s.forStackPages(ctx, orgUUID, stackID, opts).collect(maxItems)
+}
+
+// forStackPages pages through ListForStack from the page of opts.
+func (s *ResourcesService) forStackPages(ctx context.Context, orgUUID string, stackID int, opts *ResourcesListOptions) pages[Resource] {
+	var o ResourcesListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[Resource]{opts: o.ListOptions, list: func(page ListOptions) ([]Resource, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.ListForStack(ctx, orgUUID, stackID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Resources, result.PaginatedResult, nil
+	}}
+}
+
 // Get retrieves a specific resource by UUID (includes details such as values when available).
 //
 // GET /v1/resources/{org_uuid}/{resource_uuid}
@@ -93,6 +172,9 @@ func (s *ResourcesService) Get(ctx context.Context, orgUUID, resourceUUID string
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceResources, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if resourceUUID == "" {
 		return nil, nil, fmt.Errorf("resource UUID is required")
 	}
@@ -129,6 +129,29 @@ func TestResourcesList_QueryParams(t *testing.T) {
 	}
 }
 
+func TestResourcesListForStack(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("stack_id") != "7" || q.Get("provider") != "aws" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"resources":[],"paginated_result":{"total":0,"page":1,"per_page":20}}`))
+	})
+	defer cleanup()
+
+	opts := &ResourcesListOptions{StackID: 42, Provider: []string{"aws"}}
+	if _, _, err := client.Resources.ListForStack(context.Background(), "org-uuid", 7, opts); err != nil {
+		t.Fatalf("ListForStack error: %v", err)
+	}
+	if opts.StackID != 42 {
+		t.Errorf("expected the options to be left unchanged, got stack ID %d", opts.StackID)
+	}
+	if _, _, err := client.Resources.ListForStack(context.Background(), "org-uuid", 0, nil); err == nil {
+		t.Fatal("expected an error without stack ID")
+	}
+}
+
 func TestResourcesList_OrgUUIDRequired(t *testing.T) {
 	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {})
 	defer cleanup()
@@ -3,6 +3,7 @@ package terramate
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -52,6 +53,9 @@ func (s *ReviewRequestsService) List(ctx context.Context, orgUUID string, opts *
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceReviewRequests, orgUUID); err != nil {
+		return nil, nil, err
+	}
 
 	path := fmt.Sprintf("/v1/review_requests/%s", orgUUID)
 
@@ -77,6 +81,73 @@ func (s *ReviewRequestsService) List(ctx context.Context, orgUUID string, opts *
 	return &result, resp, nil
 }
 
+// All returns an iterator over the review requests matching opts, walking the
+// pages of List from opts.Page as the iteration advances.
+func (s *ReviewRequestsService) All(ctx context.Context, orgUUID string, opts *ReviewRequestsListOptions) iter.Seq2[ReviewRequest, error] {
+	return s.listPages(ctx, orgUUID, opts).all()
+}
+
+// ListAll accumulates the review requests matching opts in a slice, up to
+// maxItems (10000 when not positive), walking the pages of List from
+// opts.Page. The bool reports whether the result was truncated at maxItems.
+func (s *ReviewRequestsService) ListAll(ctx context.Context, orgUUID string, opts *ReviewRequestsListOptions, maxItems int) ([]ReviewRequest, bool, error) {
+	return s.listPages(ctx, orgUUID, opts).collect(maxItems)
+}
+
+// listPages pages through List from the page of opts.
+func (s *ReviewRequestsService) listPages(ctx context.Context, orgUUID string, opts *ReviewRequestsListOptions) pages[ReviewRequest] {
+	var o ReviewRequestsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[ReviewRequest]{opts: o.ListOptions, list: func(page ListOptions) ([]ReviewRequest, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.List(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.ReviewRequests, result.PaginatedResult, nil
+	}}
+}
+
+// SummarizeResourceChanges sums the resource changes of the current previews
+// of every review request matching opts, walking all pages, e.g. to answer
+// "how many destroys are pending across open pull requests?". When opts sets
+// no Status, open review requests are summarized. Page and PerPage are ignored.
+//
+// GET /v1/review_requests/{org_uuid}
+//
+// Access: Members of the organization with any role are allowed to query.
+func (s *ReviewRequestsService) SummarizeResourceChanges(ctx context.Context, orgUUID string, opts *ReviewRequestsListOptions) (*ResourceChangesAggregate, error) {
+	var o ReviewRequestsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	if len(o.Status) == 0 {
+		o.Status = []string{"open"}
+	}
+	o.Page = 1
+	o.PerPage = defaultDownloadLogsPerPage
+
+	aggregate := &ResourceChangesAggregate{ReviewRequestIDs: []int{}}
+	for {
+		result, _, err := s.List(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, err
+		}
+		for i := range result.ReviewRequests {
+			aggregate.ScannedCount++
+			if !aggregate.addPreview(&result.ReviewRequests[i]) {
+				aggregate.SkippedCount++
+			}
+		}
+		if len(result.ReviewRequests) == 0 || !result.PaginatedResult.HasNextPage() {
+			return aggregate, nil
+		}
+		o.Page++
+	}
+}
+
 // Get retrieves a specific review request by ID with optional stack previews.
 //
 // GET /v1/review_requests/{org_uuid}/{review_request_id}
@@ -89,6 +160,9 @@ func (s *ReviewRequestsService) Get(ctx context.Context, orgUUID string, reviewR
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceReviewRequests, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if reviewRequestID <= 0 {
 		return nil, nil, fmt.Errorf("review request ID must be positive")
 	}
@@ -113,3 +187,227 @@ func (s *ReviewRequestsService) Get(ctx context.Context, orgUUID string, reviewR
 
 	return &result, resp, nil
 }
+
+// ListPreviews retrieves the stack previews of a review request one page at a
+// time, without the review request itself. Unlike Get, which embeds the
+// previews of all affected stacks, it keeps responses small for review
+// requests affecting hundreds of stacks.
+//
+// GET /v1/review_requests/{org_uuid}/{review_request_id}/stack_previews
+//
+// Access: All members of the organization with any role are allowed to query.
+func (s *ReviewRequestsService) ListPreviews(ctx context.Context, orgUUID string, reviewRequestID int, opts *StackPreviewsListOptions) (*StackPreviewsListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceReviewRequests, orgUUID); err != nil {
+		return nil, nil, err
+	}
+	if reviewRequestID <= 0 {
+		return nil, nil, fmt.Errorf("review request ID must be positive")
+	}
+
+	path := fmt.Sprintf("/v1/review_requests/%s/%d/stack_previews", orgUUID, reviewRequestID)
+
+	if opts != nil {
+		query := url.Values{}
+		addPagination(query, opts.Page, opts.PerPage)
+		addStringSlice(query, "status", opts.Status)
+		addString(query, "search", opts.Search)
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result StackPreviewsListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// AllPreviews returns an iterator over the stack previews of a review request,
+// walking the pages of ListPreviews from opts.Page as the iteration advances.
+func (s *ReviewRequestsService) AllPreviews(ctx context.Context, orgUUID string, reviewRequestID int, opts *StackPreviewsListOptions) iter.Seq2[StackPreviewV2, error] {
+	return s.previewsPages(ctx, orgUUID, reviewRequestID, opts).all()
+}
+
+// ListAllPreviews accumulates the stack previews of a review request in a
+// slice, up to maxItems (10000 when not positive), walking the pages of
+// ListPreviews from opts.Page. The bool reports whether the result was
+// truncated at maxItems.
+func (s *ReviewRequestsService) ListAllPreviews(ctx context.Context, orgUUID string, reviewRequestID int, opts *StackPreviewsListOptions, maxItems int) ([]StackPreviewV2, bool, error) {
+	return s.previewsPages(ctx, orgUUID, reviewRequestID, opts).collect(maxItems)
+}
+
+// previewsPages pages through ListPreviews from the page of opts.
+func (s *ReviewRequestsService) previewsPages(ctx context.Context, orgUUID string, reviewRequestID int, opts *StackPreviewsListOptions) pages[StackPreviewV2] {
+	var o StackPreviewsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[StackPreviewV2]{opts: o.ListOptions, list: func(page ListOptions) ([]StackPreviewV2, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.ListPreviews(ctx, orgUUID, reviewRequestID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.StackPreviews, result.PaginatedResult, nil
+	}}
+}
+
+// ListChecks retrieves the CI check runs of the head commit of a review
+// request, the individual runs behind ReviewRequest.ChecksTotalCount,
+// ChecksFailureCount and ChecksSuccessCount.
+//
+// GET /v1/review_requests/{org_uuid}/{review_request_id}/checks
+//
+// Access: All members of the organization with any role are allowed to query.
+func (s *ReviewRequestsService) ListChecks(ctx context.Context, orgUUID string, reviewRequestID int, opts *ReviewRequestChecksListOptions) (*ReviewRequestChecksListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceReviewRequests, orgUUID); err != nil {
+		return nil, nil, err
+	}
+	if reviewRequestID <= 0 {
+		return nil, nil, fmt.Errorf("review request ID must be positive")
+	}
+
+	path := fmt.Sprintf("/v1/review_requests/%s/%d/checks", orgUUID, reviewRequestID)
+
+	if opts != nil {
+		query := url.Values{}
+		addPagination(query, opts.Page, opts.PerPage)
+		addStringSlice(query, "status", opts.Status)
+		addStringSlice(query, "conclusion", opts.Conclusion)
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result ReviewRequestChecksListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// AllChecks returns an iterator over the CI check runs of a review request,
+// walking the pages of ListChecks from opts.Page as the iteration advances.
+func (s *ReviewRequestsService) AllChecks(ctx context.Context, orgUUID string, reviewRequestID int, opts *ReviewRequestChecksListOptions) iter.Seq2[ReviewRequestCheck, error] {
+	return s.checksPages(ctx, orgUUID, reviewRequestID, opts).all()
+}
+
+// ListAllChecks accumulates the CI check runs of a review request in a slice,
+// up to maxItems (10000 when not positive), walking the pages of ListChecks
+// from opts.Page. The bool reports whether the result was truncated at
+// maxItems.
+func (s *ReviewRequestsService) ListAllChecks(ctx context.Context, orgUUID string, reviewRequestID int, opts *ReviewRequestChecksListOptions, maxItems int) ([]ReviewRequestCheck, bool, error) {
+	return s.checksPages(ctx, orgUUID, reviewRequestID, opts).collect(maxItems)
+}
+
+// checksPages pages through ListChecks from the page of opts.
+func (s *ReviewRequestsService) checksPages(ctx context.Context, orgUUID string, reviewRequestID int, opts *ReviewRequestChecksListOptions) pages[ReviewRequestCheck] {
+	var o ReviewRequestChecksListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[ReviewRequestCheck]{opts: o.ListOptions, list: func(page ListOptions) ([]ReviewRequestCheck, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.ListChecks(ctx, orgUUID, reviewRequestID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Checks, result.PaginatedResult, nil
+	}}
+}
+
+// ListComments retrieves the comments and reviews of a review request,
+// oldest first, the individual reviews behind ReviewRequest.ApprovedCount
+// and ChangesRequestedCount.
+//
+// GET /v1/review_requests/{org_uuid}/{review_request_id}/comments
+//
+// Access: All members of the organization with any role are allowed to query.
+func (s *ReviewRequestsService) ListComments(ctx context.Context, orgUUID string, reviewRequestID int, opts *ReviewRequestCommentsListOptions) (*ReviewRequestCommentsListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceReviewRequests, orgUUID); err != nil {
+		return nil, nil, err
+	}
+	if reviewRequestID <= 0 {
+		return nil, nil, fmt.Errorf("review request ID must be positive")
+	}
+
+	path := fmt.Sprintf("/v1/review_requests/%s/%d/comments", orgUUID, reviewRequestID)
+
+	if opts != nil {
+		query := url.Values{}
+		addPagination(query, opts.Page, opts.PerPage)
+		addStringSlice(query, "kind", opts.Kind)
+		addStringSlice(query, "state", opts.State)
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result ReviewRequestCommentsListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// AllComments returns an iterator over the comments and reviews of a review
+// request, walking the pages of ListComments from opts.Page as the iteration
+// advances.
+func (s *ReviewRequestsService) AllComments(ctx context.Context, orgUUID string, reviewRequestID int, opts *ReviewRequestCommentsListOptions) iter.Seq2[ReviewRequestComment, error] {
+	return s.commentsPages(ctx, orgUUID, reviewRequestID, opts).all()
+}
+
+// ListAllComments accumulates the comments and reviews of a review request in
+// a slice, up to maxItems (10000 when not positive), walking the pages of
+// ListComments from opts.Page. The bool reports whether the result was
+// truncated at maxItems.
+func (s *ReviewRequestsService) ListAllComments(ctx context.Context, orgUUID string, reviewRequestID int, opts *ReviewRequestCommentsListOptions, maxItems int) ([]ReviewRequestComment, bool, error) {
+	return s.commentsPages(ctx, orgUUID, reviewRequestID, opts).collect(maxItems)
+}
+
+// commentsPages pages through ListComments from the page of opts.
+func (s *ReviewRequestsService) commentsPages(ctx context.Context, orgUUID string, reviewRequestID int, opts *ReviewRequestCommentsListOptions) pages[ReviewRequestComment] {
+	var o ReviewRequestCommentsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[ReviewRequestComment]{opts: o.ListOptions, list: func(page ListOptions) ([]ReviewRequestComment, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.ListComments(ctx, orgUUID, reviewRequestID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Comments, result.PaginatedResult, nil
+	}}
+}
@@ -329,6 +329,149 @@ func TestReviewRequestsGet_Validation(t *testing.T) {
 	}
 }
 
+func TestReviewRequestsListPreviews(t *testing.T) {
+	payload := `{
+		"stack_previews": [
+			{"id": 11, "status": "failed", "stack_id": 1, "path": "/stacks/a", "changeset_ascii_size": 2048},
+			{"id": 12, "status": "failed", "stack_id": 2, "path": "/stacks/b"}
+		],
+		"paginated_result": {"total": 230, "page": 3, "per_page": 2}
+	}`
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/review_requests/org-uuid/42/stack_previews" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("page") != "3" || query.Get("per_page") != "2" || query.Get("status") != "failed" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	})
+	defer cleanup()
+
+	result, _, err := client.ReviewRequests.ListPreviews(context.Background(), "org-uuid", 42, &StackPreviewsListOptions{
+		ListOptions: ListOptions{Page: 3, PerPage: 2},
+		Status:      []string{"failed"},
+	})
+	if err != nil {
+		t.Fatalf("ListPreviews error: %v", err)
+	}
+	if len(result.StackPreviews) != 2 || result.StackPreviews[0].ChangesetASCIISize != 2048 {
+		t.Fatalf("unexpected stack previews: %+v", result.StackPreviews)
+	}
+	if !result.PaginatedResult.HasNextPage() {
+		t.Error("expected a next page")
+	}
+
+	if _, _, err := client.ReviewRequests.ListPreviews(context.Background(), "org-uuid", 0, nil); err == nil {
+		t.Error("expected an error for a non-positive review request ID")
+	}
+}
+
+func TestReviewRequestsSummarizeResourceChanges(t *testing.T) {
+	pages := map[string]string{
+		"1": `{
+			"review_requests": [
+				{"review_request_id": 1, "preview": {"status": "current", "resource_changes": {"create_count": 2, "delete_count": 1}}},
+				{"review_request_id": 2, "preview": {"status": "outdated", "resource_changes": {"delete_count": 9}}}
+			],
+			"paginated_result": {"total": 3, "page": 1, "per_page": 2}
+		}`,
+		"2": `{
+			"review_requests": [
+				{"review_request_id": 3, "preview": {"status": "current", "resource_changes": {"delete_count": 3, "replace_count": 1}}}
+			],
+			"paginated_result": {"total": 3, "page": 2, "per_page": 2}
+		}`,
+	}
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("status") != "open" || query.Get("per_page") != "100" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(pages[query.Get("page")]))
+	})
+	defer cleanup()
+
+	aggregate, err := client.ReviewRequests.SummarizeResourceChanges(context.Background(), "org-uuid", nil)
+	if err != nil {
+		t.Fatalf("SummarizeResourceChanges error: %v", err)
+	}
+	if aggregate.Actions.DeleteCount != 4 || aggregate.Actions.CreateCount != 2 || aggregate.Actions.ReplaceCount != 1 {
+		t.Errorf("unexpected actions: %+v", aggregate.Actions)
+	}
+	if aggregate.ScannedCount != 3 || aggregate.SkippedCount != 1 || len(aggregate.ReviewRequestIDs) != 2 {
+		t.Errorf("unexpected aggregate: %+v", aggregate)
+	}
+}
+
+func TestReviewRequestsListChecks(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/review_requests/org-uuid/42/checks" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("conclusion") != "failure" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"checks": [{"name": "terramate-preview", "status": "completed", "conclusion": "failure", "app": "GitHub Actions", "completed_at": "2026-03-02T09:30:00Z"}],
+			"paginated_result": {"total": 1, "page": 1, "per_page": 20}
+		}`))
+	})
+	defer cleanup()
+
+	result, _, err := client.ReviewRequests.ListChecks(context.Background(), "org-uuid", 42, &ReviewRequestChecksListOptions{
+		Conclusion: []string{"failure"},
+	})
+	if err != nil {
+		t.Fatalf("ListChecks error: %v", err)
+	}
+	if len(result.Checks) != 1 || result.Checks[0].Name != "terramate-preview" || result.Checks[0].CompletedAt == nil {
+		t.Fatalf("unexpected checks: %+v", result.Checks)
+	}
+
+	if _, _, err := client.ReviewRequests.ListChecks(context.Background(), "org-uuid", 0, nil); err == nil {
+		t.Error("expected an error for a non-positive review request ID")
+	}
+}
+
+func TestReviewRequestsListComments(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/review_requests/org-uuid/42/comments" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("kind") != "review" || r.URL.Query().Get("state") != "changes_requested" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"comments": [{"id": 9, "kind": "review", "state": "changes_requested", "author": {"id": 3, "display_name": "Jane"}, "body": "Pin the provider", "created_at": "2026-03-02T09:30:00Z"}],
+			"paginated_result": {"total": 1, "page": 1, "per_page": 20}
+		}`))
+	})
+	defer cleanup()
+
+	result, _, err := client.ReviewRequests.ListComments(context.Background(), "org-uuid", 42, &ReviewRequestCommentsListOptions{
+		Kind:  []string{"review"},
+		State: []string{"changes_requested"},
+	})
+	if err != nil {
+		t.Fatalf("ListComments error: %v", err)
+	}
+	if len(result.Comments) != 1 || result.Comments[0].Author == nil || result.Comments[0].Author.DisplayName != "Jane" {
+		t.Fatalf("unexpected comments: %+v", result.Comments)
+	}
+
+	if _, _, err := client.ReviewRequests.ListComments(context.Background(), "", 42, nil); err == nil {
+		t.Error("expected an error without an organization UUID")
+	}
+}
+
 func TestReviewRequestsList_HandlesAPIError(t *testing.T) {
 	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -1,12 +1,45 @@
 package terramate
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
 
+// ErrStackNotFound matches, with errors.Is, the errors returned by
+// Stacks.GetByMetaID when no stack has the meta ID.
+var ErrStackNotFound = errors.New("stack not found")
+
+// StackNotFoundError is returned by Stacks.GetByMetaID when no stack of the
+// repository and target has the meta ID.
+type StackNotFoundError struct {
+	Repository string
+	Target     string
+	MetaID     string
+}
+
+// Error implements the error interface
+func (e *StackNotFoundError) Error() string {
+	if e.Target == "" {
+		return fmt.Sprintf("no stack with meta ID %q in repository %s", e.MetaID, e.Repository)
+	}
+	return fmt.Sprintf("no stack with meta ID %q in repository %s and target %s", e.MetaID, e.Repository, e.Target)
+}
+
+// Is reports whether target is ErrStackNotFound.
+func (e *StackNotFoundError) Is(target error) bool {
+	return target == ErrStackNotFound
+}
+
+// TODO: The API does not expose the stack dependency graph (parents, children
+// and order of execution) yet. Add it to StacksService, with a tool returning
+// it as adjacency lists for blast-radius questions, once it does.
+
 // StacksService handles communication with the stacks related
 // methods of the Terramate Cloud API
 type StacksService struct {
@@ -69,6 +102,9 @@ func (s *StacksService) List(ctx context.Context, orgUUID string, opts *StacksLi
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceStacks, orgUUID); err != nil {
+		return nil, nil, err
+	}
 
 	path := fmt.Sprintf("/v1/stacks/%s", orgUUID)
 
@@ -94,6 +130,35 @@ func (s *StacksService) List(ctx context.Context, orgUUID string, opts *StacksLi
 	return &result, resp, nil
 }
 
+// All returns an iterator over the stacks matching opts, walking the pages of
+// List from opts.Page as the iteration advances.
+func (s *StacksService) All(ctx context.Context, orgUUID string, opts *StacksListOptions) iter.Seq2[Stack, error] {
+	return s.listPages(ctx, orgUUID, opts).all()
+}
+
+// ListAll accumulates the stacks matching opts in a slice, up to maxItems
+// (10000 when not positive), walking the pages of List from opts.Page. The
+// bool reports whether the result was truncated at maxItems.
+func (s *StacksService) ListAll(ctx context.Context, orgUUID string, opts *StacksListOptions, maxItems int) ([]Stack, bool, error) {
+	return s.listPages(ctx, orgUUID, opts).collect(maxItems)
+}
+
+// listPages pages through List from the page of opts.
+func (s *StacksService) listPages(ctx context.Context, orgUUID string, opts *StacksListOptions) pages[Stack] {
+	var o StacksListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[Stack]{opts: o.ListOptions, list: func(page ListOptions) ([]Stack, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.List(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Stacks, result.PaginatedResult, nil
+	}}
+}
+
 // Get retrieves a specific stack by ID.
 //
 // GET /v1/stacks/{org_uuid}/{stack_id}
@@ -105,6 +170,9 @@ func (s *StacksService) Get(ctx context.Context, orgUUID string, stackID int) (*
 	if orgUUID == "" {
 		return nil, nil, fmt.Errorf("organization UUID is required")
 	}
+	if err := s.client.checkService(ServiceStacks, orgUUID); err != nil {
+		return nil, nil, err
+	}
 	if stackID <= 0 {
 		return nil, nil, fmt.Errorf("stack ID must be positive")
 	}
@@ -124,3 +192,164 @@ func (s *StacksService) Get(ctx context.Context, orgUUID string, stackID int) (*
 
 	return &stack, resp, nil
 }
+
+// GetByMetaID retrieves the stack of a repository with the given meta ID, the
+// identifier of the stack in its stack.tm.hcl. Target selects the deployment
+// target of the stack; when empty the meta ID must match a single stack
+// across all targets.
+//
+// GET /v1/stacks/{org_uuid}?repository={repository}&target={target}&meta_id={meta_id}
+//
+// A *StackNotFoundError is returned when no stack matches, and an error when
+// several stacks match.
+//
+// Access: Members of the organization with any role are allowed to query.
+func (s *StacksService) GetByMetaID(ctx context.Context, orgUUID, repository, target, metaID string) (*Stack, *Response, error) {
+	if repository == "" {
+		return nil, nil, fmt.Errorf("repository is required")
+	}
+	if metaID == "" {
+		return nil, nil, fmt.Errorf("meta ID is required")
+	}
+
+	opts := &StacksListOptions{
+		// Two stacks are enough to tell an ambiguous meta ID apart.
+		ListOptions: ListOptions{PerPage: 2},
+		Repository:  []string{repository},
+		MetaID:      metaID,
+	}
+	if target != "" {
+		opts.Target = []string{target}
+	}
+
+	result, resp, err := s.List(ctx, orgUUID, opts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	switch len(result.Stacks) {
+	case 0:
+		return nil, resp, &StackNotFoundError{Repository: repository, Target: target, MetaID: metaID}
+	case 1:
+		return &result.Stacks[0], resp, nil
+	default:
+		total := result.PaginatedResult.Total
+		if total < len(result.Stacks) {
+			total = len(result.Stacks)
+		}
+		return nil, resp, fmt.Errorf("meta ID %q matches %d stacks in repository %s; specify a target", metaID, total, repository)
+	}
+}
+
+// ListPolicyFindings retrieves the findings of the latest policy check of a
+// stack: the rules its resources failed, counted by Stack.Resources.PolicyCheck.
+//
+// GET /v1/stacks/{org_uuid}/{stack_id}/policy_check/findings
+//
+// Access: All members of the organization with any role are allowed to query.
+func (s *StacksService) ListPolicyFindings(ctx context.Context, orgUUID string, stackID int, opts *PolicyFindingsListOptions) (*PolicyFindingsListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceStacks, orgUUID); err != nil {
+		return nil, nil, err
+	}
+	if stackID <= 0 {
+		return nil, nil, fmt.Errorf("stack ID must be positive")
+	}
+
+	path := fmt.Sprintf("/v1/stacks/%s/%d/policy_check/findings", orgUUID, stackID)
+
+	if opts != nil {
+		query := url.Values{}
+		addPagination(query, opts.Page, opts.PerPage)
+		addStringSlice(query, "severity", opts.Severity)
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result PolicyFindingsListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// AllPolicyFindings returns an iterator over the policy findings of a stack,
+// walking the pages of ListPolicyFindings from opts.Page as the iteration
+// advances.
+func (s *StacksService) AllPolicyFindings(ctx context.Context, orgUUID string, stackID int, opts *PolicyFindingsListOptions) iter.Seq2[PolicyFinding, error] {
+	return s.policyFindingsPages(ctx, orgUUID, stackID, opts).all()
+}
+
+// ListAllPolicyFindings accumulates the policy findings of a stack in a slice,
+// up to maxItems (10000 when not positive), walking the pages of
+// ListPolicyFindings from opts.Page. The bool reports whether the result was
+// truncated at maxItems.
+func (s *StacksService) ListAllPolicyFindings(ctx context.Context, orgUUID string, stackID int, opts *PolicyFindingsListOptions, maxItems int) ([]PolicyFinding, bool, error) {
+	return s.policyFindingsPages(ctx, orgUUID, stackID, opts).collect(maxItems)
+}
+
+// policyFindingsPages pages through ListPolicyFindings from the page of opts.
+func (s *StacksService) policyFindingsPages(ctx context.Context, orgUUID string, stackID int, opts *PolicyFindingsListOptions) pages[PolicyFinding] {
+	var o PolicyFindingsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[PolicyFinding]{opts: o.ListOptions, list: func(page ListOptions) ([]PolicyFinding, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.ListPolicyFindings(ctx, orgUUID, stackID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Findings, result.PaginatedResult, nil
+	}}
+}
+
+// Update changes the metadata of a stack (name, description and tags) and
+// returns the updated stack.
+//
+// PATCH /v1/stacks/{org_uuid}/{stack_id}
+//
+// Access: Members of the organization with the admin role are allowed to update.
+func (s *StacksService) Update(ctx context.Context, orgUUID string, stackID int, opts UpdateStackOptions) (*Stack, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if err := s.client.checkService(ServiceStacks, orgUUID); err != nil {
+		return nil, nil, err
+	}
+	if stackID <= 0 {
+		return nil, nil, fmt.Errorf("stack ID must be positive")
+	}
+	if opts.MetaName == nil && opts.MetaDescription == nil && opts.MetaTags == nil {
+		return nil, nil, fmt.Errorf("at least one of meta_name, meta_description or meta_tags is required")
+	}
+
+	path := fmt.Sprintf("/v1/stacks/%s/%d", orgUUID, stackID)
+
+	body, err := json.Marshal(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := s.client.newRequest(ctx, http.MethodPatch, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var stack Stack
+	resp, err := s.client.do(req, &stack)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &stack, resp, nil
+}
@@ -2,6 +2,8 @@ package terramate
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -506,6 +508,74 @@ func TestStacksGet_HandlesAPIError(t *testing.T) {
 }
 
 // Test authentication headers
+func TestStacksUpdate(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v1/stacks/org-uuid/42" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != `{"meta_description":"Core VPC","meta_tags":[]}` {
+			t.Errorf("unexpected body: %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"stack_id": 42, "meta_id": "vpc", "meta_description": "Core VPC", "meta_tags": []}`))
+	})
+	defer cleanup()
+
+	description := "Core VPC"
+	tags := []string{}
+	stack, _, err := client.Stacks.Update(context.Background(), "org-uuid", 42, UpdateStackOptions{
+		MetaDescription: &description,
+		MetaTags:        &tags,
+	})
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	if stack.MetaDescription != "Core VPC" || len(stack.MetaTags) != 0 {
+		t.Errorf("unexpected stack: %+v", stack)
+	}
+
+	if _, _, err := client.Stacks.Update(context.Background(), "org-uuid", 42, UpdateStackOptions{}); err == nil {
+		t.Error("expected an error without changes")
+	}
+}
+
+func TestStacksListPolicyFindings(t *testing.T) {
+	payload := `{
+		"findings": [
+			{"rule_id": "CKV_AWS_20", "title": "S3 bucket is publicly readable", "severity": "high", "resource_address": "aws_s3_bucket.logs", "message": "Remove the public-read ACL"}
+		],
+		"paginated_result": {"total": 1, "page": 1, "per_page": 20}
+	}`
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/stacks/org-uuid/42/policy_check/findings" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("severity"); got != "high,medium" {
+			t.Errorf("severity = %q, want high,medium", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	})
+	defer cleanup()
+
+	result, _, err := client.Stacks.ListPolicyFindings(context.Background(), "org-uuid", 42,
+		&PolicyFindingsListOptions{Severity: []string{"high", "medium"}})
+	if err != nil {
+		t.Fatalf("ListPolicyFindings error: %v", err)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(result.Findings))
+	}
+	if f := result.Findings[0]; f.RuleID != "CKV_AWS_20" || f.Severity != "high" || f.ResourceAddress != "aws_s3_bucket.logs" {
+		t.Errorf("unexpected finding: %+v", f)
+	}
+}
+
 func TestStacksList_SendsAuthHeader(t *testing.T) {
 	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
 		username, password, ok := r.BasicAuth()
@@ -626,3 +696,52 @@ func TestStacksList_RespectsContextTimeout(t *testing.T) {
 		t.Fatal("expected timeout error")
 	}
 }
+
+func TestStacksGetByMetaID(t *testing.T) {
+	c, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("repository") != "github.com/acme/infra" {
+			t.Errorf("repository = %q", q.Get("repository"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch q.Get("meta_id") {
+		case "vpc":
+			if q.Get("target") != "prod" {
+				t.Errorf("target = %q, want prod", q.Get("target"))
+			}
+			_, _ = w.Write([]byte(`{"stacks": [{"stack_id": 7, "meta_id": "vpc", "target": "prod"}], "paginated_result": {"total": 1}}`))
+		case "shared":
+			_, _ = w.Write([]byte(`{"stacks": [{"stack_id": 1}, {"stack_id": 2}], "paginated_result": {"total": 3}}`))
+		default:
+			_, _ = w.Write([]byte(`{"stacks": [], "paginated_result": {"total": 0}}`))
+		}
+	})
+	defer cleanup()
+	ctx := context.Background()
+
+	stack, _, err := c.Stacks.GetByMetaID(ctx, "org-uuid", "github.com/acme/infra", "prod", "vpc")
+	if err != nil {
+		t.Fatalf("GetByMetaID error: %v", err)
+	}
+	if stack.StackID != 7 {
+		t.Errorf("stack ID = %d, want 7", stack.StackID)
+	}
+
+	_, _, err = c.Stacks.GetByMetaID(ctx, "org-uuid", "github.com/acme/infra", "", "missing")
+	var notFound *StackNotFoundError
+	if !errors.As(err, &notFound) || !errors.Is(err, ErrStackNotFound) {
+		t.Fatalf("expected StackNotFoundError, got %v", err)
+	}
+	if notFound.MetaID != "missing" {
+		t.Errorf("MetaID = %q", notFound.MetaID)
+	}
+
+	_, _, err = c.Stacks.GetByMetaID(ctx, "org-uuid", "github.com/acme/infra", "", "shared")
+	if err == nil || errors.Is(err, ErrStackNotFound) {
+		t.Fatalf("expected an ambiguity error, got %v", err)
+	}
+
+	if _, _, err = c.Stacks.GetByMetaID(ctx, "org-uuid", "", "", "vpc"); err == nil {
+		t.Error("expected an error without repository")
+	}
+}
@@ -0,0 +1,124 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Kinds of SummaryTarget.
+const (
+	SummaryKindDrift           = "drift"
+	SummaryKindStackDeployment = "stack_deployment"
+	SummaryKindStackPreview    = "stack_preview"
+)
+
+// ErrSummaryPending is returned by SummariesService.Get while the requested
+// summary is still being generated.
+var ErrSummaryPending = errors.New("summary is still being generated")
+
+// SummariesService handles communication with the AI summary related methods
+// of the Terramate Cloud API.
+type SummariesService struct {
+	client *Client
+}
+
+// SummaryTarget identifies the drift, stack deployment or stack preview to
+// summarize.
+type SummaryTarget struct {
+	// Kind is SummaryKindDrift, SummaryKindStackDeployment or
+	// SummaryKindStackPreview.
+	Kind string
+	// ID is the ID of the drift, stack deployment or stack preview.
+	ID int
+	// StackID is the ID of the stack of a drift; required for drifts only.
+	StackID int
+}
+
+// path returns the summary path of the target and the service it belongs to.
+func (t SummaryTarget) path(orgUUID string) (string, Service, error) {
+	if t.ID <= 0 {
+		return "", "", fmt.Errorf("%s ID must be positive", t.Kind)
+	}
+	switch t.Kind {
+	case SummaryKindDrift:
+		if t.StackID <= 0 {
+			return "", "", fmt.Errorf("stack ID must be positive")
+		}
+		return fmt.Sprintf("/v1/drifts/%s/%d/%d/ai/summary", orgUUID, t.StackID, t.ID), ServiceStacks, nil
+	case SummaryKindStackDeployment:
+		return fmt.Sprintf("/v1/stack_deployments/%s/%d/ai/summary", orgUUID, t.ID), ServiceDeployments, nil
+	case SummaryKindStackPreview:
+		return fmt.Sprintf("/v1/stack_previews/%s/%d/ai/summary", orgUUID, t.ID), ServiceReviewRequests, nil
+	default:
+		return "", "", fmt.Errorf("unknown summary kind %q (want %s, %s or %s)",
+			t.Kind, SummaryKindDrift, SummaryKindStackDeployment, SummaryKindStackPreview)
+	}
+}
+
+// Request starts generating the AI summary of target. Summaries take a while
+// to generate: poll Get until it stops returning ErrSummaryPending. With
+// force, a summary is generated again even if one already exists.
+//
+// POST /v1/{drifts|stack_deployments|stack_previews}/{org_uuid}/.../ai/summary
+func (s *SummariesService) Request(ctx context.Context, orgUUID string, target SummaryTarget, force bool) (*Response, error) {
+	path, err := s.summaryPath(orgUUID, target)
+	if err != nil {
+		return nil, err
+	}
+	if force {
+		path += "?force=true"
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
+
+// Get retrieves the AI summary of target. It returns ErrSummaryPending while
+// the summary requested with Request is being generated, and a not found
+// *APIError if no summary was requested.
+//
+// GET /v1/{drifts|stack_deployments|stack_previews}/{org_uuid}/.../ai/summary
+func (s *SummariesService) Get(ctx context.Context, orgUUID string, target SummaryTarget) (*SummaryResponse, *Response, error) {
+	path, err := s.summaryPath(orgUUID, target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result SummaryResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+	if resp.HTTPResponse.StatusCode == http.StatusAccepted {
+		return nil, resp, ErrSummaryPending
+	}
+
+	return &result, resp, nil
+}
+
+// summaryPath validates the organization and target and returns the path of
+// the summary.
+func (s *SummariesService) summaryPath(orgUUID string, target SummaryTarget) (string, error) {
+	if orgUUID == "" {
+		return "", fmt.Errorf("organization UUID is required")
+	}
+	path, service, err := target.path(orgUUID)
+	if err != nil {
+		return "", err
+	}
+	if err = s.client.checkService(service, orgUUID); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,82 @@
+package terramate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestSummariesRequestAndGet(t *testing.T) {
+	var requests []string
+	generated := false
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusAccepted)
+		case !generated:
+			generated = true
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"summary": {"contents": ["The bucket policy drifted."], "created_at": "2024-04-12T07:06:00Z"}}`))
+		}
+	})
+	defer cleanup()
+
+	target := SummaryTarget{Kind: SummaryKindDrift, ID: 42, StackID: 7}
+	if _, err := client.Summaries.Request(context.Background(), "org-uuid", target, true); err != nil {
+		t.Fatalf("Request error: %v", err)
+	}
+	if _, _, err := client.Summaries.Get(context.Background(), "org-uuid", target); !errors.Is(err, ErrSummaryPending) {
+		t.Fatalf("expected ErrSummaryPending, got %v", err)
+	}
+	summary, _, err := client.Summaries.Get(context.Background(), "org-uuid", target)
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if len(summary.Summary.Contents) != 1 || summary.Summary.Contents[0] != "The bucket policy drifted." {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	want := []string{
+		"POST /v1/drifts/org-uuid/7/42/ai/summary?force=true",
+		"GET /v1/drifts/org-uuid/7/42/ai/summary",
+		"GET /v1/drifts/org-uuid/7/42/ai/summary",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("request %d = %s, want %s", i, requests[i], want[i])
+		}
+	}
+}
+
+func TestSummaryTargetPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  SummaryTarget
+		want    string
+		wantErr bool
+	}{
+		{name: "stack deployment", target: SummaryTarget{Kind: SummaryKindStackDeployment, ID: 3}, want: "/v1/stack_deployments/org/3/ai/summary"},
+		{name: "stack preview", target: SummaryTarget{Kind: SummaryKindStackPreview, ID: 5}, want: "/v1/stack_previews/org/5/ai/summary"},
+		{name: "drift without stack", target: SummaryTarget{Kind: SummaryKindDrift, ID: 1}, wantErr: true},
+		{name: "missing ID", target: SummaryTarget{Kind: SummaryKindStackPreview}, wantErr: true},
+		{name: "unknown kind", target: SummaryTarget{Kind: "review_request", ID: 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := tt.target.path("org")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("path() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("path() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// TeamsService handles communication with the teams related methods of the
+// Terramate Cloud API.
+type TeamsService struct {
+	client *Client
+}
+
+// buildQuery constructs URL query parameters from TeamsListOptions.
+func (opts *TeamsListOptions) buildQuery() url.Values {
+	query := url.Values{}
+	if opts == nil {
+		return query
+	}
+
+	addPagination(query, opts.Page, opts.PerPage)
+	addStringSlice(query, "stack_tag", opts.StackTag)
+	addString(query, "search", opts.Search)
+
+	return query
+}
+
+// List retrieves the teams of an organization with optional filters. Filter
+// by StackTag to find the teams owning the stacks with a tag.
+//
+// GET /v1/organizations/{org_uuid}/teams
+//
+// Access: Members of the organization with any role are allowed to query.
+func (s *TeamsService) List(ctx context.Context, orgUUID string, opts *TeamsListOptions) (*TeamsListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+
+	path := fmt.Sprintf("/v1/organizations/%s/teams", orgUUID)
+
+	if opts != nil {
+		query := opts.buildQuery()
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result TeamsListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// All returns an iterator over the teams of an organization, walking the pages
+// of List from opts.Page as the iteration advances.
+func (s *TeamsService) All(ctx context.Context, orgUUID string, opts *TeamsListOptions) iter.Seq2[Team, error] {
+	return s.listPages(ctx, orgUUID, opts).all()
+}
+
+// ListAll accumulates the teams of an organization in a slice, up to maxItems
+// (10000 when not positive), walking the pages of List from opts.Page. The
+// bool reports whether the result was truncated at maxItems.
+func (s *TeamsService) ListAll(ctx context.Context, orgUUID string, opts *TeamsListOptions, maxItems int) ([]Team, bool, error) {
+	return s.listPages(ctx, orgUUID, opts).collect(maxItems)
+}
+
+// listPages pages through List from the page of opts.
+func (s *TeamsService) listPages(ctx context.Context, orgUUID string, opts *TeamsListOptions) pages[Team] {
+	var o TeamsListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[Team]{opts: o.ListOptions, list: func(page ListOptions) ([]Team, PaginatedResult, error) {
+		o.ListOptions = page
+		result, _, err := s.List(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Teams, result.PaginatedResult, nil
+	}}
+}
+
+// ListMembers retrieves the members of a team.
+//
+// GET /v1/organizations/{org_uuid}/teams/{team_uuid}/members
+//
+// Access: Members of the organization with any role are allowed to query.
+func (s *TeamsService) ListMembers(ctx context.Context, orgUUID, teamUUID string, opts *ListOptions) (*MembersListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	if teamUUID == "" {
+		return nil, nil, fmt.Errorf("team UUID is required")
+	}
+
+	path := fmt.Sprintf("/v1/organizations/%s/teams/%s/members", orgUUID, url.PathEscape(teamUUID))
+
+	if opts != nil {
+		query := url.Values{}
+		addPagination(query, opts.Page, opts.PerPage)
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result MembersListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// AllMembers returns an iterator over the members of a team, walking the pages
+// of ListMembers from opts.Page as the iteration advances.
+func (s *TeamsService) AllMembers(ctx context.Context, orgUUID, teamUUID string, opts *ListOptions) iter.Seq2[Member, error] {
+	return s.membersPages(ctx, orgUUID, teamUUID, opts).all()
+}
+
+// ListAllMembers accumulates the members of a team in a slice, up to maxItems
+// (10000 when not positive), walking the pages of ListMembers from opts.Page.
+// The bool reports whether the result was truncated at maxItems.
+func (s *TeamsService) ListAllMembers(ctx context.Context, orgUUID, teamUUID string, opts *ListOptions, maxItems int) ([]Member, bool, error) {
+	return s.membersPages(ctx, orgUUID, teamUUID, opts).collect(maxItems)
+}
+
+// membersPages pages through ListMembers from the page of opts.
+func (s *TeamsService) membersPages(ctx context.Context, orgUUID, teamUUID string, opts *ListOptions) pages[Member] {
+	var o ListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[Member]{opts: o, list: func(page ListOptions) ([]Member, PaginatedResult, error) {
+		o = page
+		result, _, err := s.ListMembers(ctx, orgUUID, teamUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Members, result.PaginatedResult, nil
+	}}
+}
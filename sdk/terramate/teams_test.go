@@ -0,0 +1,55 @@
+package terramate
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestTeamsList_WithOptions(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/organizations/org-uuid/teams" {
+			t.Errorf("unexpected path: got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("stack_tag") != "payments" || r.URL.Query().Get("per_page") != "10" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"teams": [{"team_uuid": "team-1", "name": "Payments", "member_count": 4, "stack_tags": ["payments", "billing"]}], "paginated_result": {"total": 1, "page": 1, "per_page": 10}}`))
+	})
+	defer cleanup()
+
+	result, _, err := client.Teams.List(context.Background(), "org-uuid", &TeamsListOptions{
+		ListOptions: ListOptions{PerPage: 10},
+		StackTag:    []string{"payments"},
+	})
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(result.Teams) != 1 || result.Teams[0].Name != "Payments" || len(result.Teams[0].StackTags) != 2 {
+		t.Errorf("unexpected teams: %+v", result.Teams)
+	}
+}
+
+func TestTeamsListMembers(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/organizations/org-uuid/teams/team-1/members" {
+			t.Errorf("unexpected path: got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"members": [{"member_id": 1, "email": "jane@example.com", "role": "member", "status": "active"}], "paginated_result": {"total": 1, "page": 1, "per_page": 20}}`))
+	})
+	defer cleanup()
+
+	result, _, err := client.Teams.ListMembers(context.Background(), "org-uuid", "team-1", nil)
+	if err != nil {
+		t.Fatalf("ListMembers error: %v", err)
+	}
+	if len(result.Members) != 1 || result.Members[0].Email != "jane@example.com" {
+		t.Errorf("unexpected members: %+v", result.Members)
+	}
+
+	if _, _, err := client.Teams.ListMembers(context.Background(), "org-uuid", "", nil); err == nil {
+		t.Error("expected error for missing team UUID")
+	}
+}
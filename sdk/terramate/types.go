@@ -2,6 +2,11 @@ package terramate
 
 import "time"
 
+// TODO: Generate the types of this file and the query encoders of the list
+// options (go:generate) from the Terramate Cloud OpenAPI spec, keeping the
+// handwritten methods and helpers, once the spec is vendored in this
+// repository. Until then, check additions against openapi.yml by hand.
+
 // Organization represents a Terramate Cloud organization
 type Organization struct {
 	UUID        string    `json:"org_uuid"`
@@ -34,6 +39,158 @@ type Membership struct {
 	Status         string `json:"status"` // active, inactive, invited, sso_invited, trusted
 }
 
+// AuditEvent represents an entry of the audit log of an organization: an
+// action taken by a user, an API key or Terramate Cloud itself
+// Maps to AuditEvent in the OpenAPI spec
+type AuditEvent struct {
+	EventUUID    string                 `json:"event_uuid"`
+	Action       string                 `json:"action"`     // e.g. stack.archived, api_key.rotated, drift.checked
+	ActorType    string                 `json:"actor_type"` // user, api_key, system
+	Actor        *User                  `json:"actor,omitempty"`
+	ResourceType string                 `json:"resource_type"` // e.g. stack, api_key, membership
+	ResourceID   string                 `json:"resource_id,omitempty"`
+	Stack        *Stack                 `json:"stack,omitempty"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+// AuditEventsListResponse represents the response from listing audit events
+// Maps to AuditEventsCollection in the OpenAPI spec
+type AuditEventsListResponse struct {
+	Events          []AuditEvent    `json:"events"`
+	PaginatedResult PaginatedResult `json:"paginated_result"`
+}
+
+// AuditEventsListOptions represents options for listing audit events
+type AuditEventsListOptions struct {
+	ListOptions
+	// Action filters by action (e.g. stack.archived)
+	Action []string
+	// ResourceType filters by the type of the resource acted on
+	ResourceType []string
+	// UserUUID filters by the user who took the action
+	UserUUID      []string
+	StackID       []int
+	CreatedAtFrom *time.Time
+	CreatedAtTo   *time.Time
+}
+
+// OrganizationUsage reports the usage of an organization against the limits
+// of its plan in the current billing period
+// Maps to OrganizationUsage in the OpenAPI spec
+type OrganizationUsage struct {
+	Plan        string      `json:"plan,omitempty"`
+	PeriodStart *time.Time  `json:"period_start,omitempty"`
+	PeriodEnd   *time.Time  `json:"period_end,omitempty"`
+	Stacks      UsageMetric `json:"stacks"`      // active stacks
+	Deployments UsageMetric `json:"deployments"` // stack deployments in the billing period
+	Seats       UsageMetric `json:"seats"`       // members, including pending invitations
+}
+
+// UsageMetric represents the usage of one plan limit
+type UsageMetric struct {
+	Used  int  `json:"used"`
+	Limit *int `json:"limit,omitempty"` // nil when the plan sets no limit
+}
+
+// UsedFraction returns the fraction of the limit used, e.g. 0.8 at 80%. It
+// returns 0 when the plan sets no limit.
+func (m UsageMetric) UsedFraction() float64 {
+	if m.Limit == nil || *m.Limit <= 0 {
+		return 0
+	}
+	return float64(m.Used) / float64(*m.Limit)
+}
+
+// Member represents a member of an organization
+// Maps to Member in the OpenAPI spec
+type Member struct {
+	MemberID    int        `json:"member_id"`
+	UserUUID    string     `json:"user_uuid,omitempty"`
+	Email       string     `json:"email"`
+	DisplayName string     `json:"display_name,omitempty"`
+	Position    string     `json:"position,omitempty"`
+	Role        string     `json:"role"`   // admin or member
+	Status      string     `json:"status"` // active, inactive, invited, sso_invited, trusted
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// MembersListResponse represents the response from listing organization members
+// Maps to MembersCollection in the OpenAPI spec
+type MembersListResponse struct {
+	Members         []Member        `json:"members"`
+	PaginatedResult PaginatedResult `json:"paginated_result"`
+}
+
+// MembersListOptions represents options for listing organization members
+type MembersListOptions struct {
+	ListOptions
+	// Role filters by role (admin, member)
+	Role []string
+	// Status filters by status (active, inactive, invited, sso_invited, trusted)
+	Status []string
+	// Search matches the member email (substring)
+	Search string
+}
+
+// Team represents a team of organization members
+// Maps to Team in the OpenAPI spec
+type Team struct {
+	TeamUUID    string `json:"team_uuid"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MemberCount int    `json:"member_count"`
+	// StackTags are the tags of the stacks the team owns
+	StackTags []string   `json:"stack_tags,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// TeamsListResponse represents the response from listing teams
+// Maps to TeamsCollection in the OpenAPI spec
+type TeamsListResponse struct {
+	Teams           []Team          `json:"teams"`
+	PaginatedResult PaginatedResult `json:"paginated_result"`
+}
+
+// TeamsListOptions represents options for listing teams
+type TeamsListOptions struct {
+	ListOptions
+	// StackTag filters by the stack tags the teams own
+	StackTag []string
+	// Search matches the team name (substring)
+	Search string
+}
+
+// OrganizationFeatures reports which plan features are enabled for an organization
+// Maps to OrganizationFeaturesObject in the OpenAPI spec
+type OrganizationFeatures struct {
+	Previews bool `json:"previews"` // pull request previews
+	Policies bool `json:"policies"` // policy checks
+	Targets  bool `json:"targets"`  // deployment targets
+}
+
+// SSOSettings reports the single sign-on configuration of an organization
+// Maps to OrganizationSSOObject in the OpenAPI spec
+type SSOSettings struct {
+	Enabled bool `json:"enabled"`
+	// Enforced is true when members must sign in through SSO
+	Enforced bool   `json:"enforced"`
+	Protocol string `json:"protocol,omitempty"` // saml, oidc
+	Provider string `json:"provider,omitempty"` // e.g. okta, azure_ad, google
+	// JITProvisioning is true when first SSO sign-ins create memberships
+	JITProvisioning bool       `json:"jit_provisioning"`
+	DefaultRole     string     `json:"default_role,omitempty"` // role of provisioned members
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+}
+
+// OrganizationDomain represents an email domain claimed by an organization
+// Maps to OrganizationDomainObject in the OpenAPI spec
+type OrganizationDomain struct {
+	Domain     string     `json:"domain"`
+	Verified   bool       `json:"verified"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
+
 // PaginatedResult represents pagination information from API responses
 // Maps to PaginatedResultObject in the OpenAPI spec
 type PaginatedResult struct {
@@ -127,6 +284,32 @@ type PolicyCheckCounters struct {
 	SeverityHighCount   int `json:"severity_high_count"`
 }
 
+// PolicyFinding represents a policy rule a resource of a stack failed, behind
+// the counters of a StackPolicyCheck
+// Maps to PolicyCheckFinding in the OpenAPI spec
+type PolicyFinding struct {
+	RuleID          string `json:"rule_id"`
+	Title           string `json:"title,omitempty"`
+	Severity        string `json:"severity"` // low, medium, high
+	ResourceUUID    string `json:"resource_uuid,omitempty"`
+	ResourceAddress string `json:"resource_address,omitempty"`
+	Message         string `json:"message"`
+	Guideline       string `json:"guideline,omitempty"` // URL of the remediation guideline
+}
+
+// PolicyFindingsListResponse represents the response from listing policy findings
+// Maps to PolicyCheckFindingsCollection in the OpenAPI spec
+type PolicyFindingsListResponse struct {
+	Findings        []PolicyFinding `json:"findings"`
+	PaginatedResult PaginatedResult `json:"paginated_result"`
+}
+
+// PolicyFindingsListOptions represents options for listing policy findings
+type PolicyFindingsListOptions struct {
+	ListOptions
+	Severity []string // low, medium, high
+}
+
 // StacksListResponse represents the response from listing stacks
 // Maps to GetStacksResponseObject in the OpenAPI spec
 type StacksListResponse struct {
@@ -158,6 +341,15 @@ type StacksListOptions struct {
 	Sort           []string
 }
 
+// UpdateStackOptions represents the metadata changes of a stack update.
+// Nil fields are left unchanged; a pointer to an empty MetaTags clears the tags.
+// Maps to UpdateStackRequest in the OpenAPI spec
+type UpdateStackOptions struct {
+	MetaName        *string   `json:"meta_name,omitempty"`
+	MetaDescription *string   `json:"meta_description,omitempty"`
+	MetaTags        *[]string `json:"meta_tags,omitempty"`
+}
+
 // UserInfo represents user information in drift/deployment contexts
 // Maps to UserInfo in the OpenAPI spec
 type UserInfo struct {
@@ -199,6 +391,26 @@ type Drift struct {
 	DriftDetails *ChangesetDetails      `json:"drift_details,omitempty"` // Only populated when getting specific drift
 	GroupingKey  string                 `json:"grouping_key,omitempty"`
 	Cmd          []string               `json:"cmd,omitempty"`
+	// Acknowledgement is set while the drift is acknowledged as known
+	Acknowledgement *DriftAcknowledgement `json:"acknowledgement,omitempty"`
+}
+
+// DriftAcknowledgement records that a drift is known and silenced
+// Maps to DriftAcknowledgement in the OpenAPI spec
+type DriftAcknowledgement struct {
+	AcknowledgedAt time.Time `json:"acknowledged_at"`
+	AcknowledgedBy *User     `json:"acknowledged_by,omitempty"`
+	Comment        string    `json:"comment,omitempty"`
+	// Until is when the acknowledgement expires; nil when it lasts until revoked
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// AcknowledgeDriftOptions represents options for acknowledging a drift
+type AcknowledgeDriftOptions struct {
+	// Comment explains why the drift is known, e.g. a pending change
+	Comment string `json:"comment,omitempty"`
+	// Until expires the acknowledgement; nil keeps it until revoked
+	Until *time.Time `json:"until,omitempty"`
 }
 
 // DriftsListResponse represents the response from listing drifts
@@ -217,6 +429,78 @@ type DriftsListOptions struct {
 	GroupingKey string
 }
 
+// ResourceDriftHistoryOptions represents options for listing the drift runs
+// of a resource
+type ResourceDriftHistoryOptions struct {
+	// MaxRuns caps the drifted runs inspected, newest first (default 20)
+	MaxRuns int
+	// MaxMatches stops the scan once the resource drifted in that many runs,
+	// e.g. 1 for its latest drift (default: no limit)
+	MaxMatches int
+	// Concurrency caps the drifted runs fetched in parallel (default 4)
+	Concurrency int
+}
+
+// ResourceDriftRun represents a drift run in which a resource drifted
+type ResourceDriftRun struct {
+	DriftID    int        `json:"drift_id"`
+	Address    string     `json:"address"` // the drifted instance, e.g. with its index
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	// Actions are the plan actions of the resource; empty when only the
+	// ASCII plan was available
+	Actions []string `json:"actions,omitempty"`
+}
+
+// ResourceDriftHistory represents the drift runs of a stack in which a
+// resource drifted
+type ResourceDriftHistory struct {
+	StackID int                `json:"stack_id"`
+	Address string             `json:"address"`
+	Runs    []ResourceDriftRun `json:"runs"`
+	// ScannedCount is the number of drifted runs of the stack inspected
+	ScannedCount int `json:"scanned_count"`
+	// HasMore is set when older drifted runs were not inspected
+	HasMore bool `json:"has_more"`
+}
+
+// DriftCheckRequest reports a drift check requested with Drifts.Trigger
+// Maps to DriftCheckRequest in the OpenAPI spec
+type DriftCheckRequest struct {
+	StackID     int       `json:"stack_id"`
+	Status      string    `json:"status"` // queued, running
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// DriftGroupingKey represents a drift check group: the drift runs sharing a
+// grouping key, e.g. one scheduled drift detection job
+// Maps to DriftGroupingKey in the OpenAPI spec
+type DriftGroupingKey struct {
+	GroupingKey      string     `json:"grouping_key"`
+	Repository       string     `json:"repository,omitempty"`
+	DriftCount       int        `json:"drift_count"`
+	StackCount       int        `json:"stack_count"`
+	LatestStatus     string     `json:"latest_status"` // ok, drifted, failed
+	LatestStartedAt  *time.Time `json:"latest_started_at,omitempty"`
+	LatestFinishedAt *time.Time `json:"latest_finished_at,omitempty"`
+}
+
+// DriftGroupingKeysListResponse represents the response from listing drift grouping keys
+// Maps to DriftGroupingKeysCollection in the OpenAPI spec
+type DriftGroupingKeysListResponse struct {
+	GroupingKeys    []DriftGroupingKey `json:"grouping_keys"`
+	PaginatedResult PaginatedResult    `json:"paginated_result"`
+}
+
+// DriftGroupingKeysListOptions represents options for listing drift grouping keys
+type DriftGroupingKeysListOptions struct {
+	ListOptions
+	// Repository filters by exact repository URLs
+	Repository []string
+	// LatestStatus filters by the status of the latest drift run (ok, drifted, failed)
+	LatestStatus []string
+}
+
 // VCSLabel represents a label on a pull/merge request
 // Maps to VCSLabel in the OpenAPI spec
 type VCSLabel struct {
@@ -239,6 +523,46 @@ type ResourceChangesActionsSummary struct {
 	ForgetCount  int `json:"forget_count"`
 }
 
+// add accumulates the counts of other into s.
+func (s *ResourceChangesActionsSummary) add(other *ResourceChangesActionsSummary) {
+	s.CreateCount += other.CreateCount
+	s.DeleteCount += other.DeleteCount
+	s.NoopCount += other.NoopCount
+	s.ReadCount += other.ReadCount
+	s.ReplaceCount += other.ReplaceCount
+	s.UpdateCount += other.UpdateCount
+	s.ImportCount += other.ImportCount
+	s.MoveCount += other.MoveCount
+	s.ForgetCount += other.ForgetCount
+}
+
+// ResourceChangesAggregate represents resource changes summed across the
+// previews of several review requests
+type ResourceChangesAggregate struct {
+	Actions ResourceChangesActionsSummary `json:"actions"`
+	// ReviewRequestIDs lists the review requests whose preview was counted
+	ReviewRequestIDs []int `json:"review_request_ids"`
+	// ScannedCount is the number of review requests or deployments scanned
+	ScannedCount int `json:"scanned_count"`
+	// SkippedCount is the number scanned without a current preview summary
+	SkippedCount int `json:"skipped_count"`
+}
+
+// addPreview accumulates the preview of rr, once per review request.
+func (a *ResourceChangesAggregate) addPreview(rr *ReviewRequest) bool {
+	if rr == nil || rr.Preview == nil || rr.Preview.ResourceChanges == nil || rr.Preview.Status == "outdated" {
+		return false
+	}
+	for _, id := range a.ReviewRequestIDs {
+		if id == rr.ReviewRequestID {
+			return true
+		}
+	}
+	a.Actions.add(rr.Preview.ResourceChanges)
+	a.ReviewRequestIDs = append(a.ReviewRequestIDs, rr.ReviewRequestID)
+	return true
+}
+
 // Preview represents a preview summary for a review request
 // Maps to Preview in the OpenAPI spec
 type Preview struct {
@@ -354,6 +678,77 @@ type ReviewRequestGetOptions struct {
 	ExcludeStackPreviews bool
 }
 
+// StackPreviewsListResponse represents a page of the stack previews of a review request
+// Maps to StackPreviewsCollection in the OpenAPI spec
+type StackPreviewsListResponse struct {
+	StackPreviews   []StackPreviewV2 `json:"stack_previews"`
+	PaginatedResult PaginatedResult  `json:"paginated_result"`
+}
+
+// StackPreviewsListOptions represents options for listing the stack previews of a review request
+type StackPreviewsListOptions struct {
+	ListOptions
+	Status []string // affected, pending, running, changed, unchanged, failed, canceled
+	Search string   // Searches stack path, name and ID
+}
+
+// ReviewRequestCheck represents a CI check run on the head commit of a review
+// request, one of those behind the checks_*_count counters
+// Maps to ReviewRequestCheck in the OpenAPI spec
+type ReviewRequestCheck struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`               // queued, in_progress, completed
+	Conclusion  string     `json:"conclusion,omitempty"` // success, failure, neutral, cancelled, skipped, timed_out, action_required
+	App         string     `json:"app,omitempty"`        // e.g. GitHub Actions
+	URL         string     `json:"url,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// ReviewRequestChecksListResponse represents a page of the checks of a review request
+// Maps to ReviewRequestChecksCollection in the OpenAPI spec
+type ReviewRequestChecksListResponse struct {
+	Checks          []ReviewRequestCheck `json:"checks"`
+	PaginatedResult PaginatedResult      `json:"paginated_result"`
+}
+
+// ReviewRequestChecksListOptions represents options for listing the checks of a review request
+type ReviewRequestChecksListOptions struct {
+	ListOptions
+	Status     []string // queued, in_progress, completed
+	Conclusion []string // success, failure, neutral, cancelled, skipped, timed_out, action_required
+}
+
+// ReviewRequestComment represents a review or comment on a review request,
+// one of those behind the approved_count and changes_requested_count counters
+// Maps to ReviewRequestComment in the OpenAPI spec
+type ReviewRequestComment struct {
+	ID     int                        `json:"id"`
+	Kind   string                     `json:"kind"`            // comment, review, review_comment
+	State  string                     `json:"state,omitempty"` // reviews: approved, changes_requested, commented, dismissed
+	Author *ReviewRequestCollaborator `json:"author,omitempty"`
+	Body   string                     `json:"body,omitempty"`
+	// Path and Line locate review comments on the diff
+	Path      string    `json:"path,omitempty"`
+	Line      int       `json:"line,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ReviewRequestCommentsListResponse represents a page of the comments of a review request
+// Maps to ReviewRequestCommentsCollection in the OpenAPI spec
+type ReviewRequestCommentsListResponse struct {
+	Comments        []ReviewRequestComment `json:"comments"`
+	PaginatedResult PaginatedResult        `json:"paginated_result"`
+}
+
+// ReviewRequestCommentsListOptions represents options for listing the comments of a review request
+type ReviewRequestCommentsListOptions struct {
+	ListOptions
+	Kind  []string // comment, review, review_comment
+	State []string // approved, changes_requested, commented, dismissed
+}
+
 // WorkflowDeploymentGroup represents a CI/CD workflow deployment run
 // Maps to WorkflowDeploymentGroup in the OpenAPI spec
 type WorkflowDeploymentGroup struct {
@@ -477,7 +872,7 @@ type SummaryResponse struct {
 // - logs_stdout_count: Number of output log lines (not the logs)
 //
 // To get the actual logs, use Previews.GetLogs().
-// To get the full plan, use the changeset endpoint (not yet implemented).
+// To get the full plan, use Previews.GetChangeset().
 //
 // Use this when: Getting preview details with Previews.Get()
 // This is useful for checking preview status without loading large plan content.
@@ -520,6 +915,37 @@ type DeploymentLogsOptions struct {
 	Channel string // stdout, stderr
 }
 
+// DownloadLogsOptions represents options for downloading deployment logs
+type DownloadLogsOptions struct {
+	Channel string // stdout, stderr; empty for both
+	PerPage int    // page size used to walk the logs (default: 100)
+
+	// StartPage and StartLine resume an interrupted download: pages before
+	// StartPage are not fetched and lines numbered below StartLine are
+	// skipped. Pass the Page and NextLine of the DownloadLogsProgress
+	// returned by the interrupted call.
+	StartPage int
+	StartLine int
+}
+
+// StreamLogsOptions represents options for following deployment logs
+type StreamLogsOptions struct {
+	Channel      string        // stdout, stderr; empty for both
+	PerPage      int           // page size used to walk the logs (default: 100)
+	PollInterval time.Duration // time between polls for new lines (default: 5s)
+
+	// StartPage and StartLine resume a stream, as for DownloadLogsOptions.
+	StartPage int
+	StartLine int
+}
+
+// DownloadLogsProgress reports how far a log download or stream got
+type DownloadLogsProgress struct {
+	Lines    int // lines written by this call
+	Page     int // page holding NextLine
+	NextLine int // log_line number following the last line written
+}
+
 // Resource represents a unique resource within an organization (stack resource from plan/state).
 // Maps to Resource in the OpenAPI spec. The Details field is only set when getting a specific resource.
 type Resource struct {
@@ -604,3 +1030,152 @@ type ResourcesListOptions struct {
 	Search string
 	Sort   []string
 }
+
+// Alert represents an alert raised for a stack, e.g. when it drifted or a
+// deployment failed
+// Maps to Alert in the OpenAPI spec
+type Alert struct {
+	AlertUUID              string     `json:"alert_uuid"`
+	Type                   string     `json:"type"`     // drift, deployment_failed
+	Severity               string     `json:"severity"` // low, medium, high, critical
+	Status                 string     `json:"status"`   // active, acknowledged, resolved
+	Title                  string     `json:"title"`
+	Description            string     `json:"description,omitempty"`
+	Stack                  *Stack     `json:"stack,omitempty"`
+	DeploymentUUID         string     `json:"deployment_uuid,omitempty"`
+	DriftID                int        `json:"drift_id,omitempty"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              *time.Time `json:"updated_at,omitempty"`
+	AcknowledgedAt         *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedByUserUUID string     `json:"acknowledged_by_user_uuid,omitempty"`
+	ResolvedAt             *time.Time `json:"resolved_at,omitempty"`
+}
+
+// AlertsListResponse represents the response from listing alerts
+// Maps to AlertsCollection in the OpenAPI spec
+type AlertsListResponse struct {
+	Alerts          []Alert         `json:"alerts"`
+	PaginatedResult PaginatedResult `json:"paginated_result"`
+}
+
+// AlertsListOptions represents options for listing alerts
+type AlertsListOptions struct {
+	ListOptions
+	// Severity filters by severity (low, medium, high, critical)
+	Severity []string
+	// Status filters by status (active, acknowledged, resolved)
+	Status []string
+	// Type filters by alert type (drift, deployment_failed)
+	Type []string
+	// StackID filters by stack IDs
+	StackID []int
+}
+
+// Webhook represents a webhook subscription: Terramate Cloud posts the
+// subscribed events to URL
+// Maps to Webhook in the OpenAPI spec
+type Webhook struct {
+	WebhookUUID string     `json:"webhook_uuid"`
+	URL         string     `json:"url"`
+	Events      []string   `json:"events"` // deployment_finished, drift_detected
+	Description string     `json:"description,omitempty"`
+	Enabled     bool       `json:"enabled"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// WebhooksListResponse represents the response from listing webhooks
+// Maps to WebhooksCollection in the OpenAPI spec
+type WebhooksListResponse struct {
+	Webhooks        []Webhook       `json:"webhooks"`
+	PaginatedResult PaginatedResult `json:"paginated_result"`
+}
+
+// CreateWebhookOptions represents the webhook subscription to create
+type CreateWebhookOptions struct {
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	Description string   `json:"description,omitempty"`
+	// Secret signs the deliveries, so the receiver can verify they come from
+	// Terramate Cloud
+	Secret string `json:"secret,omitempty"`
+}
+
+// NotificationIntegrations represents where an organization's notifications
+// are sent
+// Maps to NotificationIntegrations in the OpenAPI spec
+type NotificationIntegrations struct {
+	Slack      []SlackIntegration      `json:"slack"`
+	EmailRules []EmailNotificationRule `json:"email_rules"`
+}
+
+// SlackIntegration represents a Slack webhook receiving notifications
+// Maps to SlackIntegration in the OpenAPI spec
+type SlackIntegration struct {
+	IntegrationUUID string   `json:"integration_uuid,omitempty"`
+	Channel         string   `json:"channel,omitempty"`
+	WebhookURL      string   `json:"webhook_url,omitempty"` // secret, registered for redaction
+	Events          []string `json:"events"`                // e.g. drift, deployment_failed, preview_failed
+	Enabled         bool     `json:"enabled"`
+}
+
+// EmailNotificationRule represents an email rule receiving notifications
+// Maps to EmailNotificationRule in the OpenAPI spec
+type EmailNotificationRule struct {
+	RuleUUID   string   `json:"rule_uuid,omitempty"`
+	Recipients []string `json:"recipients"`
+	Events     []string `json:"events"` // e.g. drift, deployment_failed, preview_failed
+	Enabled    bool     `json:"enabled"`
+}
+
+// DeliveryMetricsGroupBy values group delivery metrics
+const (
+	DeliveryMetricsByStack      = "stack"
+	DeliveryMetricsByRepository = "repository"
+)
+
+// DeliveryMetricsOptions represents options for computing delivery metrics
+type DeliveryMetricsOptions struct {
+	// From and To bound the period by deployment creation time; they default
+	// to the 30 days before now
+	From *time.Time
+	To   *time.Time
+	// StackID restricts the metrics to a single stack
+	StackID int
+	// Repository restricts the metrics to the stacks of a repository
+	Repository string
+	// GroupBy breaks the metrics down by stack or repository
+	GroupBy string
+}
+
+// DeliveryMetrics represents DORA-style delivery metrics of stack deployments
+// over a period
+type DeliveryMetrics struct {
+	StackID    int    `json:"stack_id,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Path       string `json:"path,omitempty"`
+
+	Deployments       int     `json:"deployments"`
+	Succeeded         int     `json:"succeeded"`
+	Failed            int     `json:"failed"`
+	Canceled          int     `json:"canceled"`
+	DeploymentsPerDay float64 `json:"deployments_per_day"`
+	// FailureRate is the fraction of finished (succeeded or failed)
+	// deployments that failed, or nil without any
+	FailureRate *float64 `json:"failure_rate,omitempty"`
+	// Recoveries counts the failures followed by a fix within the period
+	Recoveries int `json:"recoveries"`
+	// MeanTimeToRecoverySeconds is the mean time from the first failure of a
+	// stack to its fix, or nil without recoveries
+	MeanTimeToRecoverySeconds *float64 `json:"mean_time_to_recovery_seconds,omitempty"`
+	// Unrecovered counts the stacks still failing at the end of the period
+	Unrecovered int `json:"unrecovered"`
+}
+
+// DeliveryMetricsReport represents delivery metrics over a period, overall
+// and per group
+type DeliveryMetricsReport struct {
+	From    time.Time         `json:"from"`
+	To      time.Time         `json:"to"`
+	Overall DeliveryMetrics   `json:"overall"`
+	Groups  []DeliveryMetrics `json:"groups,omitempty"`
+}
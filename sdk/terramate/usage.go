@@ -0,0 +1,40 @@
+package terramate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// UsageService handles communication with the usage and billing related
+// methods of the Terramate Cloud API.
+type UsageService struct {
+	client *Client
+}
+
+// Get retrieves the usage of an organization (stacks, deployments and seats)
+// against the limits of its plan in the current billing period.
+//
+// GET /v1/organizations/{org_uuid}/usage
+//
+// Access: Members of the organization with the admin role are allowed to query.
+func (s *UsageService) Get(ctx context.Context, orgUUID string) (*OrganizationUsage, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+
+	path := fmt.Sprintf("/v1/organizations/%s/usage", orgUUID)
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var usage OrganizationUsage
+	resp, err := s.client.do(req, &usage)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &usage, resp, nil
+}
@@ -0,0 +1,44 @@
+package terramate
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestUsageGet(t *testing.T) {
+	payload := `{
+		"plan": "business",
+		"period_start": "2026-10-01T00:00:00Z",
+		"period_end": "2026-11-01T00:00:00Z",
+		"stacks": {"used": 450, "limit": 500},
+		"deployments": {"used": 1200},
+		"seats": {"used": 20, "limit": 25}
+	}`
+
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/organizations/org-uuid/usage" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	})
+	defer cleanup()
+
+	usage, _, err := client.Usage.Get(context.Background(), "org-uuid")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	if usage.Plan != "business" || usage.PeriodEnd == nil {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+	if got := usage.Stacks.UsedFraction(); got != 0.9 {
+		t.Errorf("stacks used fraction = %v, want 0.9", got)
+	}
+	if usage.Deployments.Limit != nil || usage.Deployments.UsedFraction() != 0 {
+		t.Errorf("expected unlimited deployments, got %+v", usage.Deployments)
+	}
+	if got := usage.Seats.UsedFraction(); got != 0.8 {
+		t.Errorf("seats used fraction = %v, want 0.8", got)
+	}
+}
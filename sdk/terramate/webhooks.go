@@ -0,0 +1,148 @@
+package terramate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// Events webhooks can subscribe to.
+const (
+	WebhookEventDeploymentFinished = "deployment_finished"
+	WebhookEventDriftDetected      = "drift_detected"
+)
+
+// WebhooksService handles communication with the webhook subscription
+// related methods of the Terramate Cloud API.
+type WebhooksService struct {
+	client *Client
+}
+
+// List retrieves the webhook subscriptions of an organization.
+//
+// GET /v1/organizations/{org_uuid}/webhooks
+//
+// Access: Members of the organization with the admin role are allowed to query.
+func (s *WebhooksService) List(ctx context.Context, orgUUID string, opts *ListOptions) (*WebhooksListResponse, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+
+	path := fmt.Sprintf("/v1/organizations/%s/webhooks", orgUUID)
+
+	if opts != nil {
+		query := url.Values{}
+		addPagination(query, opts.Page, opts.PerPage)
+		if len(query) > 0 {
+			path = path + "?" + query.Encode()
+		}
+	}
+
+	req, err := s.client.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result WebhooksListResponse
+	resp, err := s.client.do(req, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &result, resp, nil
+}
+
+// All returns an iterator over the webhook subscriptions of an organization,
+// walking the pages of List from opts.Page as the iteration advances.
+func (s *WebhooksService) All(ctx context.Context, orgUUID string, opts *ListOptions) iter.Seq2[Webhook, error] {
+	return s.listPages(ctx, orgUUID, opts).all()
+}
+
+// ListAll accumulates the webhook subscriptions of an organization in a slice,
+// up to maxItems (10000 when not positive), walking the pages of List from
+// opts.Page. The bool reports whether the result was truncated at maxItems.
+func (s *WebhooksService) ListAll(ctx context.Context, orgUUID string, opts *ListOptions, maxItems int) ([]Webhook, bool, error) {
+	return s.listPages(ctx, orgUUID, opts).collect(maxItems)
+}
+
+// listPages pages through List from the page of opts.
+func (s *WebhooksService) listPages(ctx context.Context, orgUUID string, opts *ListOptions) pages[Webhook] {
+	var o ListOptions
+	if opts != nil {
+		o = *opts
+	}
+	return pages[Webhook]{opts: o, list: func(page ListOptions) ([]Webhook, PaginatedResult, error) {
+		o = page
+		result, _, err := s.List(ctx, orgUUID, &o)
+		if err != nil {
+			return nil, PaginatedResult{}, err
+		}
+		return result.Webhooks, result.PaginatedResult, nil
+	}}
+}
+
+// Create subscribes a URL to events of an organization, e.g.
+// WebhookEventDeploymentFinished.
+//
+// POST /v1/organizations/{org_uuid}/webhooks
+//
+// Access: Members of the organization with the admin role are allowed to create webhooks.
+func (s *WebhooksService) Create(ctx context.Context, orgUUID string, opts CreateWebhookOptions) (*Webhook, *Response, error) {
+	if orgUUID == "" {
+		return nil, nil, fmt.Errorf("organization UUID is required")
+	}
+	target, err := url.Parse(opts.URL)
+	if err != nil || (target.Scheme != "https" && target.Scheme != "http") || target.Host == "" {
+		return nil, nil, fmt.Errorf("webhook URL must be an http or https URL")
+	}
+	if len(opts.Events) == 0 {
+		return nil, nil, fmt.Errorf("at least one event is required")
+	}
+	RegisterSecret(opts.Secret)
+
+	path := fmt.Sprintf("/v1/organizations/%s/webhooks", orgUUID)
+
+	body, err := json.Marshal(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := s.client.newRequest(ctx, http.MethodPost, path, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var webhook Webhook
+	resp, err := s.client.do(req, &webhook)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &webhook, resp, nil
+}
+
+// Delete removes a webhook subscription.
+//
+// DELETE /v1/organizations/{org_uuid}/webhooks/{webhook_uuid}
+//
+// Access: Members of the organization with the admin role are allowed to delete webhooks.
+func (s *WebhooksService) Delete(ctx context.Context, orgUUID, webhookUUID string) (*Response, error) {
+	if orgUUID == "" {
+		return nil, fmt.Errorf("organization UUID is required")
+	}
+	if webhookUUID == "" {
+		return nil, fmt.Errorf("webhook UUID is required")
+	}
+
+	path := fmt.Sprintf("/v1/organizations/%s/webhooks/%s", orgUUID, url.PathEscape(webhookUUID))
+
+	req, err := s.client.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	return s.client.do(req, nil)
+}
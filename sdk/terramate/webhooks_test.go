@@ -0,0 +1,84 @@
+package terramate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWebhooks(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/organizations/org-uuid/webhooks":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"webhooks": [{"webhook_uuid": "hook-1", "url": "https://hooks.example.com/tmc", "events": ["drift_detected"], "enabled": true}], "paginated_result": {"total": 1, "page": 1, "per_page": 20}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/organizations/org-uuid/webhooks":
+			var opts CreateWebhookOptions
+			if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+				t.Errorf("decode: %v", err)
+			}
+			if opts.URL != "https://hooks.example.com/tmc" || strings.Join(opts.Events, ",") != "deployment_finished" || opts.Secret != "signing-secret-123" {
+				t.Errorf("unexpected webhook: %+v", opts)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"webhook_uuid": "hook-2", "url": "https://hooks.example.com/tmc", "events": ["deployment_finished"], "enabled": true}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/organizations/org-uuid/webhooks/hook-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer cleanup()
+	ctx := context.Background()
+
+	list, _, err := client.Webhooks.List(ctx, "org-uuid", nil)
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(list.Webhooks) != 1 || list.Webhooks[0].WebhookUUID != "hook-1" {
+		t.Errorf("unexpected webhooks: %+v", list.Webhooks)
+	}
+
+	webhook, _, err := client.Webhooks.Create(ctx, "org-uuid", CreateWebhookOptions{
+		URL:    "https://hooks.example.com/tmc",
+		Events: []string{WebhookEventDeploymentFinished},
+		Secret: "signing-secret-123",
+	})
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if webhook.WebhookUUID != "hook-2" {
+		t.Errorf("unexpected webhook: %+v", webhook)
+	}
+	if redacted := Redact("secret is signing-secret-123"); strings.Contains(redacted, "signing-secret-123") {
+		t.Errorf("expected the signing secret to be redacted, got %q", redacted)
+	}
+
+	if _, err := client.Webhooks.Delete(ctx, "org-uuid", "hook-1"); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+}
+
+func TestWebhooks_Validation(t *testing.T) {
+	client, cleanup := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("unexpected request")
+	})
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, opts := range []CreateWebhookOptions{
+		{URL: "hooks.example.com", Events: []string{WebhookEventDriftDetected}},
+		{URL: "ftp://hooks.example.com", Events: []string{WebhookEventDriftDetected}},
+		{URL: "https://hooks.example.com"},
+	} {
+		if _, _, err := client.Webhooks.Create(ctx, "org-uuid", opts); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+	if _, err := client.Webhooks.Delete(ctx, "org-uuid", ""); err == nil {
+		t.Error("expected error for missing webhook UUID")
+	}
+}
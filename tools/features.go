@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+)
+
+// previewTools are only offered to organizations with previews enabled.
+var previewTools = map[string]bool{
+	"tmc_get_stack_preview_logs":      true,
+	"tmc_get_stack_preview_changeset": true,
+	"tmc_retrigger_stack_preview":     true,
+}
+
+// policyTools are only offered to organizations with policies enabled.
+var policyTools = map[string]bool{
+	"tmc_get_policy_findings": true,
+}
+
+// targetParam is the filter parameter only offered to organizations with
+// deployment targets enabled.
+const targetParam = "target"
+
+// FeatureFilter returns a tool filter that tailors the listed tools to the
+// plan features of the session's default organization: tools for disabled
+// features are hidden and their parameters removed. Until an organization is
+// selected, or when its features cannot be fetched, all tools are listed.
+func FeatureFilter(store *tmc.SessionStore, client *terramate.Client) server.ToolFilterFunc {
+	return func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		session := store.Get(sessionID(ctx))
+		orgUUID := session.DefaultOrganization()
+		if orgUUID == "" {
+			return tools
+		}
+
+		features, err := tmc.OrganizationFeatures(tmc.WithSession(ctx, session), client, orgUUID)
+		if err != nil {
+			slog.Warn("Failed to fetch organization features", "organization", orgUUID, "error", err)
+			return tools
+		}
+		return filterByFeatures(tools, features)
+	}
+}
+
+// filterByFeatures removes the tools and parameters not supported by features.
+func filterByFeatures(tools []mcp.Tool, features *terramate.OrganizationFeatures) []mcp.Tool {
+	filtered := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if !features.Previews && previewTools[tool.Name] {
+			continue
+		}
+		if !features.Policies && policyTools[tool.Name] {
+			continue
+		}
+		if !features.Targets {
+			tool = withoutParam(tool, targetParam)
+		}
+		filtered = append(filtered, tool)
+	}
+	return filtered
+}
+
+// withoutParam returns a copy of tool without the named parameter and without
+// the "- name:" line documenting it in the description.
+func withoutParam(tool mcp.Tool, name string) mcp.Tool {
+	if _, ok := tool.InputSchema.Properties[name]; !ok {
+		return tool
+	}
+
+	properties := make(map[string]any, len(tool.InputSchema.Properties))
+	for k, v := range tool.InputSchema.Properties {
+		if k != name {
+			properties[k] = v
+		}
+	}
+	tool.InputSchema.Properties = properties
+
+	required := make([]string, 0, len(tool.InputSchema.Required))
+	for _, r := range tool.InputSchema.Required {
+		if r != name {
+			required = append(required, r)
+		}
+	}
+	tool.InputSchema.Required = required
+
+	lines := strings.Split(tool.Description, "\n")
+	kept := lines[:0:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "- "+name+":") {
+			kept = append(kept, line)
+		}
+	}
+	tool.Description = strings.Join(kept, "\n")
+	return tool
+}
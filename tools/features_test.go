@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+)
+
+func toolNames(tools []mcp.Tool) map[string]mcp.Tool {
+	byName := map[string]mcp.Tool{}
+	for _, tool := range tools {
+		byName[tool.Name] = tool
+	}
+	return byName
+}
+
+func TestFeatureFilter_HidesDisabledFeatures(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/organizations/org-a/features" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"previews":false,"policies":false,"targets":false}`))
+	}))
+	defer ts.Close()
+
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	var all []mcp.Tool
+	for _, tool := range New(c).Tools() {
+		all = append(all, tool.Tool)
+	}
+
+	store := tmc.NewSessionStore(0)
+	store.Get("a").SetDefaultOrganization("org-a")
+	filter := FeatureFilter(store, c)
+	mcpServer := server.NewMCPServer("test", "0.0.0")
+
+	// No organization selected: everything is listed
+	unselected := filter(mcpServer.WithContext(context.Background(), fakeClientSession{id: "b"}), all)
+	if len(unselected) != len(all) {
+		t.Fatalf("expected all %d tools without an organization, got %d", len(all), len(unselected))
+	}
+
+	got := toolNames(filter(mcpServer.WithContext(context.Background(), fakeClientSession{id: "a"}), all))
+	if _, ok := got["tmc_get_stack_preview_logs"]; ok {
+		t.Fatal("expected preview tool to be hidden")
+	}
+	if _, ok := got["tmc_get_policy_findings"]; ok {
+		t.Fatal("expected policy tool to be hidden")
+	}
+	stacks, ok := got["tmc_list_stacks"]
+	if !ok {
+		t.Fatal("expected tmc_list_stacks to be listed")
+	}
+	if _, ok := stacks.InputSchema.Properties["target"]; ok {
+		t.Fatal("expected target parameter to be removed")
+	}
+	if strings.Contains(stacks.Description, "- target:") {
+		t.Fatal("expected target parameter to be removed from the description")
+	}
+
+	// The registered tool definitions are left untouched
+	if _, ok := toolNames(all)["tmc_list_stacks"].InputSchema.Properties["target"]; !ok {
+		t.Fatal("filter must not modify the registered tools")
+	}
+}
+
+func TestFeatureFilter_ListsAllOnError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	all := []mcp.Tool{{Name: "tmc_get_stack_preview_logs"}, {Name: "tmc_list_stacks"}}
+
+	store := tmc.NewSessionStore(0)
+	store.Get("").SetDefaultOrganization("org-a")
+	if got := FeatureFilter(store, c)(context.Background(), all); len(got) != len(all) {
+		t.Fatalf("expected all tools on error, got %d", len(got))
+	}
+}
@@ -0,0 +1,309 @@
+// Package guardrail evaluates planned infrastructure changes against an
+// organization's apply policy: protected resources that must not change (or
+// only with approval) and the change windows in which applying is allowed.
+package guardrail
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata" // change window time zones must resolve in minimal container images
+)
+
+// Decisions, from least to most restrictive.
+const (
+	ApplyNow      = "apply_now"
+	NeedsApproval = "needs_approval"
+	Blocked       = "blocked"
+)
+
+// Resource actions of a planned change.
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionReplace = "replace"
+)
+
+// severity orders the decisions.
+var severity = map[string]int{ApplyNow: 0, NeedsApproval: 1, Blocked: 2}
+
+// weekdays maps the day names accepted in change windows.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Policy is an organization's apply policy. The zero value allows every
+// change at any time.
+type Policy struct {
+	// ProtectedResources are checked against every planned change.
+	ProtectedResources []ProtectedResource `yaml:"protected_resources"`
+	// ChangeWindows are the times applying is allowed. Empty means always.
+	ChangeWindows []ChangeWindow `yaml:"change_windows"`
+	// OutsideWindow is the decision for changes outside every change window
+	// (default: needs_approval).
+	OutsideWindow string `yaml:"outside_window"`
+}
+
+// ProtectedResource matches planned changes of sensitive resources.
+type ProtectedResource struct {
+	// Address is a glob (path.Match syntax) of resource addresses, e.g.
+	// "aws_db_instance.*" or "module.network.*".
+	Address string `yaml:"address"`
+	// Stack is an optional glob of stack paths, e.g. "/prod/*".
+	Stack string `yaml:"stack"`
+	// Actions restricts the rule to these actions (default: delete and
+	// replace).
+	Actions []string `yaml:"actions"`
+	// Decision is needs_approval or blocked (default: blocked).
+	Decision string `yaml:"decision"`
+	// Reason is shown to the user, e.g. "production database".
+	Reason string `yaml:"reason"`
+}
+
+// ChangeWindow is a recurring time range in which applying is allowed.
+type ChangeWindow struct {
+	// Days are lowercase three-letter day names (default: every day).
+	Days []string `yaml:"days"`
+	// Start and End are "15:04" times; End before Start spans midnight.
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+	// Timezone is an IANA name (default: UTC).
+	Timezone string `yaml:"timezone"`
+}
+
+// Change is a planned change of a single resource.
+type Change struct {
+	Stack   string `json:"stack"`
+	Address string `json:"address"`
+	Action  string `json:"action"`
+}
+
+// Reason explains one finding behind an advice.
+type Reason struct {
+	Decision string `json:"decision"`
+	Message  string `json:"message"`
+	Stack    string `json:"stack,omitempty"`
+	Address  string `json:"address,omitempty"`
+}
+
+// Advice is the outcome of evaluating a set of changes.
+type Advice struct {
+	Decision string   `json:"decision"`
+	Reasons  []Reason `json:"reasons"`
+}
+
+// Add records a finding, raising the decision if it is more restrictive.
+func (a *Advice) Add(r Reason) {
+	a.Reasons = append(a.Reasons, r)
+	if severity[r.Decision] > severity[a.Decision] {
+		a.Decision = r.Decision
+	}
+}
+
+// Validate reports the first invalid setting of the policy.
+func (p *Policy) Validate() error {
+	if p.OutsideWindow != "" && p.OutsideWindow != NeedsApproval && p.OutsideWindow != Blocked {
+		return fmt.Errorf("outside_window: must be %q or %q", NeedsApproval, Blocked)
+	}
+	for i, rule := range p.ProtectedResources {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("protected_resources[%d]: %w", i, err)
+		}
+	}
+	for i, window := range p.ChangeWindows {
+		if err := window.validate(); err != nil {
+			return fmt.Errorf("change_windows[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Empty reports whether the policy has no rules.
+func (p *Policy) Empty() bool {
+	return len(p.ProtectedResources) == 0 && len(p.ChangeWindows) == 0
+}
+
+// Evaluate advises whether changes can be applied at now. Every protected
+// resource rule matching a change and a missed change window contribute a
+// reason; the most restrictive one decides.
+func (p *Policy) Evaluate(changes []Change, now time.Time) Advice {
+	advice := Advice{Decision: ApplyNow, Reasons: []Reason{}}
+	for _, change := range changes {
+		for _, rule := range p.ProtectedResources {
+			if rule.matches(change) {
+				advice.Add(rule.reason(change))
+			}
+		}
+	}
+
+	if len(changes) > 0 && len(p.ChangeWindows) > 0 && !p.inChangeWindow(now) {
+		decision := p.OutsideWindow
+		if decision == "" {
+			decision = NeedsApproval
+		}
+		advice.Add(Reason{
+			Decision: decision,
+			Message:  fmt.Sprintf("%s is outside of the change windows (%s)", now.UTC().Format(time.RFC3339), p.describeWindows()),
+		})
+	}
+	return advice
+}
+
+func (p *Policy) inChangeWindow(now time.Time) bool {
+	for _, window := range p.ChangeWindows {
+		if window.contains(now) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Policy) describeWindows() string {
+	descriptions := make([]string, len(p.ChangeWindows))
+	for i, window := range p.ChangeWindows {
+		descriptions[i] = window.String()
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+func (r ProtectedResource) validate() error {
+	if r.Address == "" {
+		return fmt.Errorf("address is required")
+	}
+	if _, err := path.Match(r.Address, ""); err != nil {
+		return fmt.Errorf("invalid address pattern %q: %w", r.Address, err)
+	}
+	if _, err := path.Match(r.Stack, ""); err != nil {
+		return fmt.Errorf("invalid stack pattern %q: %w", r.Stack, err)
+	}
+	for _, action := range r.Actions {
+		if !slices.Contains([]string{ActionCreate, ActionUpdate, ActionDelete, ActionReplace}, action) {
+			return fmt.Errorf("unknown action %q (must be create, update, delete or replace)", action)
+		}
+	}
+	if r.Decision != "" && r.Decision != NeedsApproval && r.Decision != Blocked {
+		return fmt.Errorf("decision must be %q or %q", NeedsApproval, Blocked)
+	}
+	return nil
+}
+
+func (r ProtectedResource) matches(change Change) bool {
+	actions := r.Actions
+	if len(actions) == 0 {
+		actions = []string{ActionDelete, ActionReplace}
+	}
+	if !slices.Contains(actions, change.Action) {
+		return false
+	}
+	if ok, _ := path.Match(r.Address, change.Address); !ok {
+		return false
+	}
+	if r.Stack == "" {
+		return true
+	}
+	ok, _ := path.Match(r.Stack, change.Stack)
+	return ok
+}
+
+func (r ProtectedResource) reason(change Change) Reason {
+	decision := r.Decision
+	if decision == "" {
+		decision = Blocked
+	}
+	message := fmt.Sprintf("%s would be %s; it matches protected resource %q", change.Address, pastTense(change.Action), r.Address)
+	if r.Reason != "" {
+		message += " (" + r.Reason + ")"
+	}
+	return Reason{Decision: decision, Message: message, Stack: change.Stack, Address: change.Address}
+}
+
+func pastTense(action string) string {
+	switch action {
+	case ActionCreate:
+		return "created"
+	case ActionUpdate:
+		return "updated"
+	case ActionDelete:
+		return "destroyed"
+	default:
+		return "replaced"
+	}
+}
+
+func (w ChangeWindow) validate() error {
+	for _, day := range w.Days {
+		if _, ok := weekdays[day]; !ok {
+			return fmt.Errorf("unknown day %q (must be one of mon, tue, wed, thu, fri, sat, sun)", day)
+		}
+	}
+	for _, t := range []string{w.Start, w.End} {
+		if _, err := time.Parse("15:04", t); err != nil {
+			return fmt.Errorf("invalid time %q (must be HH:MM)", t)
+		}
+	}
+	_, err := w.location()
+	return err
+}
+
+func (w ChangeWindow) location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", w.Timezone, err)
+	}
+	return loc, nil
+}
+
+// contains reports whether t falls into the window. A window spanning
+// midnight belongs to the day it starts on.
+func (w ChangeWindow) contains(t time.Time) bool {
+	loc, err := w.location()
+	if err != nil {
+		return false
+	}
+	t = t.In(loc)
+	start, _ := time.Parse("15:04", w.Start)
+	end, _ := time.Parse("15:04", w.End)
+	minute := t.Hour()*60 + t.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	if startMinute <= endMinute {
+		return minute >= startMinute && minute < endMinute && w.onDay(t.Weekday())
+	}
+	if minute >= startMinute {
+		return w.onDay(t.Weekday())
+	}
+	return minute < endMinute && w.onDay((t.Weekday()+6)%7) // started the day before
+}
+
+func (w ChangeWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, name := range w.Days {
+		if weekdays[name] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the window, e.g. "mon,fri 09:00-17:00 Europe/Berlin".
+func (w ChangeWindow) String() string {
+	days := "daily"
+	if len(w.Days) > 0 {
+		days = strings.Join(w.Days, ",")
+	}
+	tz := w.Timezone
+	if tz == "" {
+		tz = "UTC"
+	}
+	return fmt.Sprintf("%s %s-%s %s", days, w.Start, w.End, tz)
+}
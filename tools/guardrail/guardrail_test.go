@@ -0,0 +1,122 @@
+package guardrail
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPolicy() *Policy {
+	return &Policy{
+		ProtectedResources: []ProtectedResource{
+			{Address: "aws_db_instance.*", Reason: "production database"},
+			{Address: "aws_iam_*", Actions: []string{ActionCreate, ActionUpdate, ActionDelete, ActionReplace}, Decision: NeedsApproval},
+			{Address: "*", Stack: "/prod/*", Actions: []string{ActionDelete}, Decision: NeedsApproval},
+		},
+		ChangeWindows: []ChangeWindow{
+			{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", End: "17:00", Timezone: "Europe/Berlin"},
+		},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	// Wednesday 10:00 in Berlin
+	inWindow := time.Date(2026, 1, 7, 9, 0, 0, 0, time.UTC)
+	// Saturday
+	outsideWindow := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		changes     []Change
+		now         time.Time
+		want        string
+		wantReasons int
+	}{
+		{name: "no changes", now: outsideWindow, want: ApplyNow},
+		{name: "unprotected change in window", changes: []Change{{Stack: "/dev/app", Address: "aws_s3_bucket.logs", Action: ActionUpdate}},
+			now: inWindow, want: ApplyNow},
+		{name: "outside change window", changes: []Change{{Stack: "/dev/app", Address: "aws_s3_bucket.logs", Action: ActionUpdate}},
+			now: outsideWindow, want: NeedsApproval, wantReasons: 1},
+		{name: "protected resource needs approval", changes: []Change{{Stack: "/dev/app", Address: "aws_iam_role.ci", Action: ActionCreate}},
+			now: inWindow, want: NeedsApproval, wantReasons: 1},
+		{name: "update of protected resource not covered by rule actions", changes: []Change{{Stack: "/dev/db", Address: "aws_db_instance.main", Action: ActionUpdate}},
+			now: inWindow, want: ApplyNow},
+		{name: "protected resource replaced", changes: []Change{{Stack: "/dev/db", Address: "aws_db_instance.main", Action: ActionReplace}},
+			now: inWindow, want: Blocked, wantReasons: 1},
+		{name: "most restrictive wins", changes: []Change{
+			{Stack: "/prod/db", Address: "aws_db_instance.main", Action: ActionDelete},
+			{Stack: "/prod/app", Address: "aws_iam_role.ci", Action: ActionUpdate},
+		}, now: outsideWindow, want: Blocked, wantReasons: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			advice := testPolicy().Evaluate(tt.changes, tt.now)
+			if advice.Decision != tt.want || len(advice.Reasons) != tt.wantReasons {
+				t.Fatalf("expected %s with %d reasons, got %+v", tt.want, tt.wantReasons, advice)
+			}
+		})
+	}
+}
+
+func TestEvaluate_Reasons(t *testing.T) {
+	advice := testPolicy().Evaluate([]Change{{Stack: "/dev/db", Address: "aws_db_instance.main", Action: ActionDelete}},
+		time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC))
+
+	if got := advice.Reasons[0]; got.Decision != Blocked || got.Address != "aws_db_instance.main" || got.Stack != "/dev/db" ||
+		!strings.Contains(got.Message, "would be destroyed") || !strings.Contains(got.Message, "production database") {
+		t.Fatalf("unexpected resource reason: %+v", got)
+	}
+	if got := advice.Reasons[1]; got.Decision != NeedsApproval || !strings.Contains(got.Message, "mon,tue,wed,thu,fri 09:00-17:00 Europe/Berlin") {
+		t.Fatalf("unexpected change window reason: %+v", got)
+	}
+}
+
+func TestChangeWindow_SpansMidnight(t *testing.T) {
+	window := ChangeWindow{Days: []string{"fri"}, Start: "22:00", End: "02:00"}
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{at: time.Date(2026, 1, 9, 23, 0, 0, 0, time.UTC), want: true},   // Friday night
+		{at: time.Date(2026, 1, 10, 1, 0, 0, 0, time.UTC), want: true},   // early Saturday
+		{at: time.Date(2026, 1, 10, 23, 0, 0, 0, time.UTC), want: false}, // Saturday night
+		{at: time.Date(2026, 1, 9, 1, 0, 0, 0, time.UTC), want: false},   // early Friday
+	}
+	for _, tt := range tests {
+		if got := window.contains(tt.at); got != tt.want {
+			t.Errorf("contains(%s) = %v, want %v", tt.at.Format(time.RFC3339), got, tt.want)
+		}
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		wantErr string
+	}{
+		{name: "valid", policy: *testPolicy()},
+		{name: "missing address", policy: Policy{ProtectedResources: []ProtectedResource{{}}}, wantErr: "address is required"},
+		{name: "bad pattern", policy: Policy{ProtectedResources: []ProtectedResource{{Address: "aws_["}}}, wantErr: "invalid address pattern"},
+		{name: "bad action", policy: Policy{ProtectedResources: []ProtectedResource{{Address: "*", Actions: []string{"destroy"}}}}, wantErr: "unknown action"},
+		{name: "bad decision", policy: Policy{ProtectedResources: []ProtectedResource{{Address: "*", Decision: "apply_now"}}}, wantErr: "decision must be"},
+		{name: "bad day", policy: Policy{ChangeWindows: []ChangeWindow{{Days: []string{"monday"}, Start: "09:00", End: "17:00"}}}, wantErr: "unknown day"},
+		{name: "bad time", policy: Policy{ChangeWindows: []ChangeWindow{{Start: "9am", End: "17:00"}}}, wantErr: "invalid time"},
+		{name: "bad timezone", policy: Policy{ChangeWindows: []ChangeWindow{{Start: "09:00", End: "17:00", Timezone: "Mars/Olympus"}}}, wantErr: "invalid timezone"},
+		{name: "bad outside window", policy: Policy{OutsideWindow: "apply_now"}, wantErr: "outside_window"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
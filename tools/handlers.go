@@ -1,56 +1,391 @@
 package tools
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/guardrail"
+	"github.com/terramate-io/terramate-mcp-server/tools/index"
 	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+	"github.com/terramate-io/terramate-mcp-server/tools/vcs"
 )
 
 // ToolHandlers contains all MCP tool handlers
 type ToolHandlers struct {
-	tmcClient *terramate.Client
+	tmcClient    *terramate.Client
+	issueTracker vcs.IssueTracker
+	index        *index.Index                // local index; nil disables the index tools
+	guardrails   *guardrail.Policy           // apply policy; nil disables tmc_advise_apply
+	freshness    tmc.FreshnessRecorder       // receives tmc_data_freshness observations; may be nil
+	callLog      *tmc.CallLog                // recorded calls; nil disables the debug tools
+	reportCache  *tmc.ReportCache            // datasets shared by the report tools
+	connect      func(context.Context) error // loads a missing credential; see WithConnector
+	serverInfo   *tmc.ServerInfo             // nil disables tmc_server_info
+	credStatus   tmc.CredentialStatusFunc    // nil disables tmc_credential_status
+	login        tmc.LoginFunc               // nil disables tmc_login
+	logout       func(context.Context) error // nil disables tmc_logout
+	toolsets     map[string]bool             // nil enables all toolsets
+	// credentialOverrides documents api_key and credential_profile on the
+	// cloud tools; see WithCredentialOverrides
+	credentialOverrides bool
+}
+
+// Toolsets group related tools so a deployment can expose only what it needs.
+const (
+	ToolsetStacks         = "stacks"
+	ToolsetDrifts         = "drifts"
+	ToolsetReviewRequests = "review_requests"
+	ToolsetDeployments    = "deployments"
+	ToolsetPreviews       = "previews"
+	ToolsetResources      = "resources"
+	ToolsetReports        = "reports"
+	ToolsetSummaries      = "summaries"
+	ToolsetTeams          = "teams"
+	ToolsetWebhooks       = "webhooks"
+	ToolsetIndex          = "index" // requires WithIndex
+
+	// ToolsetReviews groups the review request and preview toolsets.
+	ToolsetReviews = "reviews"
+)
+
+// toolsetGroups are toolset names enabling several toolsets at once.
+var toolsetGroups = map[string][]string{
+	ToolsetReviews: {ToolsetReviewRequests, ToolsetPreviews},
+}
+
+// Toolsets lists the available toolsets: the built-in ones followed by those
+// added with RegisterToolset.
+func Toolsets() []string {
+	return append(builtinToolsets(), externalToolsetNames()...)
+}
+
+// builtinToolsets lists the toolsets of the tools in this module.
+func builtinToolsets() []string {
+	return []string{
+		ToolsetStacks,
+		ToolsetDrifts,
+		ToolsetReviewRequests,
+		ToolsetDeployments,
+		ToolsetPreviews,
+		ToolsetResources,
+		ToolsetReports,
+		ToolsetSummaries,
+		ToolsetTeams,
+		ToolsetWebhooks,
+		ToolsetIndex,
+	}
+}
+
+// ValidateToolsets returns an error naming the first unknown toolset. Names
+// may be toolsets or toolset groups.
+func ValidateToolsets(names []string) error {
+	known := map[string]bool{}
+	for _, name := range Toolsets() {
+		known[name] = true
+	}
+	for _, name := range names {
+		if !known[strings.TrimSpace(name)] && toolsetGroups[strings.TrimSpace(name)] == nil {
+			return fmt.Errorf("unknown toolset %q (available: %s)", name, strings.Join(toolsetNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// toolsetNames lists the toolsets followed by the toolset groups.
+func toolsetNames() []string {
+	groups := make([]string, 0, len(toolsetGroups))
+	for name := range toolsetGroups {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+	return append(Toolsets(), groups...)
+}
+
+// Option configures optional tool handler integrations.
+type Option func(*ToolHandlers)
+
+// WithIssueTracker enables filing issues from tools that draft them.
+func WithIssueTracker(tracker vcs.IssueTracker) Option {
+	return func(th *ToolHandlers) {
+		th.issueTracker = tracker
+	}
+}
+
+// WithIndex serves the index tools from idx.
+func WithIndex(idx *index.Index) Option {
+	return func(th *ToolHandlers) {
+		th.index = idx
+	}
+}
+
+// WithGuardrails enables advising on review requests against policy.
+func WithGuardrails(policy *guardrail.Policy) Option {
+	return func(th *ToolHandlers) {
+		th.guardrails = policy
+	}
+}
+
+// WithFreshnessRecorder reports the data freshness observed by
+// tmc_data_freshness to recorder.
+func WithFreshnessRecorder(recorder tmc.FreshnessRecorder) Option {
+	return func(th *ToolHandlers) {
+		th.freshness = recorder
+	}
+}
+
+// WithCallLog enables the debug tools, which show the calls recorded in log
+// (see RecordCalls).
+func WithCallLog(log *tmc.CallLog) Option {
+	return func(th *ToolHandlers) {
+		th.callLog = log
+	}
+}
+
+// WithConnector serves tmc_authenticate without a Terramate Cloud client,
+// calling connect to load the credential (see tmc.Connect).
+func WithConnector(connect func(context.Context) error) Option {
+	return func(th *ToolHandlers) {
+		th.connect = connect
+	}
+}
+
+// WithServerInfo enables tmc_server_info, reporting info along with the
+// enabled toolsets.
+func WithServerInfo(info tmc.ServerInfo) Option {
+	return func(th *ToolHandlers) {
+		th.serverInfo = &info
+	}
+}
+
+// WithCredentialStatus enables tmc_credential_status, reporting the status
+// returns.
+func WithCredentialStatus(status tmc.CredentialStatusFunc) Option {
+	return func(th *ToolHandlers) {
+		th.credStatus = status
+	}
+}
+
+// WithLogin enables tmc_login, starting logins with start.
+func WithLogin(start tmc.LoginFunc) Option {
+	return func(th *ToolHandlers) {
+		th.login = start
+	}
 }
 
-// New creates new tool handlers
-func New(tmcClient *terramate.Client) *ToolHandlers {
-	return &ToolHandlers{
-		tmcClient: tmcClient,
+// WithLogout enables tmc_logout, ending the stored login with logout.
+func WithLogout(logout func(context.Context) error) Option {
+	return func(th *ToolHandlers) {
+		th.logout = logout
 	}
 }
 
-// Tools returns all MCP tools for Terramate Cloud
+// WithCredentialOverrides documents the api_key and credential_profile
+// arguments, served by the CredentialOverrides middleware, on the Terramate
+// Cloud tools.
+func WithCredentialOverrides() Option {
+	return func(th *ToolHandlers) {
+		th.credentialOverrides = true
+	}
+}
+
+// WithToolsets restricts the registered tools to the named toolsets or
+// toolset groups. An empty list keeps all toolsets enabled.
+func WithToolsets(names []string) Option {
+	return func(th *ToolHandlers) {
+		if len(names) == 0 {
+			th.toolsets = nil
+			return
+		}
+		th.toolsets = make(map[string]bool, len(names))
+		for _, name := range names {
+			name = strings.TrimSpace(name)
+			th.toolsets[name] = true
+			for _, toolset := range toolsetGroups[name] {
+				th.toolsets[toolset] = true
+			}
+		}
+	}
+}
+
+// New creates new tool handlers. A nil tmcClient serves only the local tools
+// (index and debug tools) and, with WithConnector, tmc_authenticate.
+func New(tmcClient *terramate.Client, opts ...Option) *ToolHandlers {
+	th := &ToolHandlers{
+		tmcClient:   tmcClient,
+		reportCache: tmc.NewReportCache(tmc.DefaultReportCacheTTL),
+	}
+	for _, opt := range opts {
+		opt(th)
+	}
+	return th
+}
+
+// Tools returns the MCP tools for Terramate Cloud of all enabled toolsets.
+// The authentication tool and, with WithLogin, WithLogout, WithServerInfo and
+// WithCredentialStatus, tmc_login, tmc_logout, tmc_server_info and
+// tmc_credential_status are always registered.
 func (th *ToolHandlers) Tools() []server.ServerTool {
+	var tools []server.ServerTool
+	if th.tmcClient != nil {
+		tools = th.cloudTools()
+		if th.credentialOverrides {
+			for i, tool := range tools {
+				tools[i] = withCredentialOverrideArguments(tool)
+			}
+		}
+	} else if th.connect != nil {
+		tools = append(tools, tmc.Connect(th.connect))
+	}
+
+	// Register local index tools
+	if th.index != nil && th.enabled(ToolsetIndex) {
+		tools = append(tools, tmc.IndexListStacks(th.index))
+		tools = append(tools, tmc.IndexListDrifts(th.index))
+		tools = append(tools, tmc.IndexListDeployments(th.index))
+		tools = append(tools, tmc.IndexStatus(th.index))
+		tools = append(tools, tmc.Search(th.index))
+	}
+
+	// Register debug tools
+	if th.callLog != nil {
+		tools = append(tools, tmc.ReplayLast(th.callLog))
+		tools = append(tools, tmc.InspectCall(th.callLog))
+	}
+
+	// Register external toolsets (see RegisterToolset)
+	if th.tmcClient != nil {
+		tools = th.externalTools(tools)
+	}
+
+	if th.login != nil {
+		tools = append(tools, tmc.Login(th.login))
+	}
+	if th.logout != nil {
+		tools = append(tools, tmc.Logout(th.logout))
+	}
+	if th.serverInfo != nil {
+		info := *th.serverInfo
+		info.Toolsets = th.enabledToolsets()
+		tools = append(tools, tmc.ServerInfoTool(info, th.tmcClient))
+	}
+	if th.credStatus != nil {
+		tools = append(tools, tmc.CredentialStatusTool(th.credStatus))
+	}
+
+	for i, tool := range tools {
+		tools[i] = withCorrelationIDArgument(tool)
+	}
+	return tools
+}
+
+// cloudTools returns the tools calling the Terramate Cloud API.
+func (th *ToolHandlers) cloudTools() []server.ServerTool {
 	tools := []server.ServerTool{}
 
 	// Register authentication tool
 	tools = append(tools, tmc.Authenticate(th.tmcClient))
 
 	// Register stacks tools
-	tools = append(tools, tmc.ListStacks(th.tmcClient))
-	tools = append(tools, tmc.GetStack(th.tmcClient))
+	if th.enabled(ToolsetStacks) {
+		tools = append(tools, tmc.ListStacks(th.tmcClient))
+		tools = append(tools, tmc.GetStack(th.tmcClient))
+		tools = append(tools, tmc.ListRepositories(th.tmcClient))
+		tools = append(tools, tmc.GetPolicyFindings(th.tmcClient))
+	}
 
 	// Register drift tools
-	tools = append(tools, tmc.ListDrifts(th.tmcClient))
-	tools = append(tools, tmc.GetDrift(th.tmcClient))
+	if th.enabled(ToolsetDrifts) {
+		tools = append(tools, tmc.ListDrifts(th.tmcClient))
+		tools = append(tools, tmc.GetDrift(th.tmcClient))
+		tools = append(tools, tmc.DraftDriftIssue(th.tmcClient, th.issueTracker))
+		tools = append(tools, tmc.TriggerDriftCheck(th.tmcClient))
+		tools = append(tools, tmc.AcknowledgeDrift(th.tmcClient))
+		tools = append(tools, tmc.GetResourceDriftHistory(th.tmcClient))
+	}
 
 	// Register review request tools
-	tools = append(tools, tmc.ListReviewRequests(th.tmcClient))
-	tools = append(tools, tmc.GetReviewRequest(th.tmcClient))
+	if th.enabled(ToolsetReviewRequests) {
+		tools = append(tools, tmc.ListReviewRequests(th.tmcClient))
+		tools = append(tools, tmc.GetReviewRequest(th.tmcClient))
+		if th.guardrails != nil {
+			tools = append(tools, tmc.AdviseApply(th.tmcClient, th.guardrails))
+		}
+	}
 
 	// Register deployment tools
-	tools = append(tools, tmc.ListDeployments(th.tmcClient))
-	tools = append(tools, tmc.GetStackDeployment(th.tmcClient))
-	tools = append(tools, tmc.GetDeploymentLogs(th.tmcClient))
+	if th.enabled(ToolsetDeployments) {
+		tools = append(tools, tmc.ListDeployments(th.tmcClient))
+		tools = append(tools, tmc.GetStackDeployment(th.tmcClient))
+		tools = append(tools, tmc.GetDeploymentLogs(th.tmcClient))
+		tools = append(tools, tmc.WatchDeploymentLogs(th.tmcClient))
+		tools = append(tools, tmc.ListStackDeploymentHistory(th.tmcClient))
+		tools = append(tools, tmc.DataFreshness(th.tmcClient, th.freshness))
+	}
 
 	// Register preview tools
-	tools = append(tools, tmc.GetStackPreviewLogs(th.tmcClient))
+	if th.enabled(ToolsetPreviews) {
+		tools = append(tools, tmc.GetStackPreviewLogs(th.tmcClient))
+		tools = append(tools, tmc.GetStackPreviewChangeset(th.tmcClient))
+		tools = append(tools, tmc.RetriggerStackPreview(th.tmcClient))
+	}
 
 	// Register resources tools
-	tools = append(tools, tmc.ListResources(th.tmcClient))
-	tools = append(tools, tmc.GetResource(th.tmcClient))
+	if th.enabled(ToolsetResources) {
+		tools = append(tools, tmc.ListResources(th.tmcClient))
+		tools = append(tools, tmc.GetResource(th.tmcClient))
+	}
+
+	// Register report tools
+	if th.enabled(ToolsetReports) {
+		tools = append(tools, tmc.WeeklyReview(th.tmcClient, th.reportCache))
+		tools = append(tools, tmc.GetUsage(th.tmcClient))
+		tools = append(tools, tmc.ListAuditEvents(th.tmcClient))
+		tools = append(tools, tmc.SummarizeResourceChanges(th.tmcClient))
+		tools = append(tools, tmc.GetStackMetrics(th.tmcClient))
+	}
+
+	// Register AI summary tools
+	if th.enabled(ToolsetSummaries) {
+		tools = append(tools, tmc.RequestSummary(th.tmcClient))
+		tools = append(tools, tmc.GetSummary(th.tmcClient))
+	}
+
+	// Register team tools
+	if th.enabled(ToolsetTeams) {
+		tools = append(tools, tmc.ListTeams(th.tmcClient))
+		tools = append(tools, tmc.ListTeamMembers(th.tmcClient))
+	}
+
+	// Register webhook tools
+	if th.enabled(ToolsetWebhooks) {
+		tools = append(tools, tmc.ListWebhooks(th.tmcClient))
+		tools = append(tools, tmc.CreateWebhook(th.tmcClient))
+		tools = append(tools, tmc.DeleteWebhook(th.tmcClient))
+	}
 
 	// TODO: Add more tools here
 	// tools = append(tools, tmc.ListAlerts(th.tmcClient))
 
 	return tools
 }
+
+// enabledToolsets lists the enabled toolsets; the index toolset counts only
+// when an index is configured.
+func (th *ToolHandlers) enabledToolsets() []string {
+	names := []string{}
+	for _, name := range Toolsets() {
+		if th.enabled(name) && (name != ToolsetIndex || th.index != nil) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// enabled reports whether the tools of toolset should be registered.
+func (th *ToolHandlers) enabled(toolset string) bool {
+	return th.toolsets == nil || th.toolsets[toolset]
+}
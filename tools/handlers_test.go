@@ -1,9 +1,19 @@
 package tools
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/guardrail"
+	"github.com/terramate-io/terramate-mcp-server/tools/index"
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+	"github.com/terramate-io/terramate-mcp-server/tools/vcs"
 )
 
 func TestNew(t *testing.T) {
@@ -42,4 +52,242 @@ func TestTools(t *testing.T) {
 	if !found {
 		t.Fatal("expected tmc_authenticate tool to be registered")
 	}
+	for _, tool := range tools {
+		if _, ok := tool.Tool.InputSchema.Properties["correlation_id"]; !ok {
+			t.Fatalf("expected %s to accept correlation_id", tool.Tool.Name)
+		}
+	}
+}
+
+func TestNew_WithIssueTracker(t *testing.T) {
+	c, err := terramate.NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	tracker, err := vcs.NewGitHub("gh-token")
+	if err != nil {
+		t.Fatalf("NewGitHub error: %v", err)
+	}
+	th := New(c, WithIssueTracker(tracker))
+	if th.issueTracker != tracker {
+		t.Fatal("expected issue tracker to be set")
+	}
+}
+
+func TestTools_WithToolsets(t *testing.T) {
+	c, err := terramate.NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	tools := New(c, WithToolsets([]string{ToolsetDrifts})).Tools()
+
+	names := map[string]bool{}
+	for _, tool := range tools {
+		names[tool.Tool.Name] = true
+	}
+	if !names["tmc_authenticate"] || !names["tmc_list_drifts"] {
+		t.Fatalf("expected authentication and drift tools, got %v", names)
+	}
+	if names["tmc_list_stacks"] || names["tmc_list_deployments"] {
+		t.Fatalf("expected tools of disabled toolsets to be omitted, got %v", names)
+	}
+	if len(New(c, WithToolsets(nil)).Tools()) != len(New(c).Tools()) {
+		t.Fatal("expected an empty toolset list to enable all tools")
+	}
+}
+
+func TestTools_WithToolsetGroup(t *testing.T) {
+	c, err := terramate.NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	names := map[string]bool{}
+	for _, tool := range New(c, WithToolsets([]string{" " + ToolsetReviews})).Tools() {
+		names[tool.Tool.Name] = true
+	}
+	if !names["tmc_list_review_requests"] || !names["tmc_get_stack_preview_logs"] || names["tmc_list_stacks"] {
+		t.Fatalf("expected the review request and preview tools only, got %v", names)
+	}
+}
+
+func TestValidateToolsets(t *testing.T) {
+	if err := ValidateToolsets(Toolsets()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateToolsets([]string{ToolsetReviews, " drifts"}); err != nil {
+		t.Fatalf("expected toolset groups and padded names to be accepted: %v", err)
+	}
+	if err := ValidateToolsets([]string{"stacks", "alerts"}); err == nil {
+		t.Fatal("expected error for unknown toolset")
+	}
+}
+
+func TestTools_WithGuardrails(t *testing.T) {
+	c, err := terramate.NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	hasAdvisor := func(th *ToolHandlers) bool {
+		for _, tool := range th.Tools() {
+			if tool.Tool.Name == "tmc_advise_apply" {
+				return true
+			}
+		}
+		return false
+	}
+	policy := &guardrail.Policy{ProtectedResources: []guardrail.ProtectedResource{{Address: "*"}}}
+	if hasAdvisor(New(c)) {
+		t.Fatal("expected tmc_advise_apply to require WithGuardrails")
+	}
+	if !hasAdvisor(New(c, WithGuardrails(policy))) {
+		t.Fatal("expected tmc_advise_apply with WithGuardrails")
+	}
+	if hasAdvisor(New(c, WithGuardrails(policy), WithToolsets([]string{ToolsetStacks}))) {
+		t.Fatal("expected tmc_advise_apply to follow the review_requests toolset")
+	}
+}
+
+func TestTools_WithIndex(t *testing.T) {
+	c, err := terramate.NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	idx, err := index.Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open error: %v", err)
+	}
+	defer func() { _ = idx.Close() }()
+
+	hasIndexTools := func(th *ToolHandlers) bool {
+		for _, tool := range th.Tools() {
+			if tool.Tool.Name == "tmc_index_list_stacks" {
+				return true
+			}
+		}
+		return false
+	}
+	if hasIndexTools(New(c)) {
+		t.Fatal("expected index tools to require WithIndex")
+	}
+	if !hasIndexTools(New(c, WithIndex(idx))) {
+		t.Fatal("expected index tools with WithIndex")
+	}
+	if hasIndexTools(New(c, WithIndex(idx), WithToolsets([]string{ToolsetStacks}))) {
+		t.Fatal("expected index tools to follow the index toolset")
+	}
+}
+
+func TestTools_WithCallLog(t *testing.T) {
+	c, err := terramate.NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	debugTools := func(th *ToolHandlers) int {
+		n := 0
+		for _, tool := range th.Tools() {
+			if tool.Tool.Name == tmc.ReplayLastToolName || tool.Tool.Name == tmc.InspectCallToolName {
+				n++
+			}
+		}
+		return n
+	}
+	if debugTools(New(c)) != 0 {
+		t.Fatal("expected the debug tools to require WithCallLog")
+	}
+	if debugTools(New(c, WithCallLog(tmc.NewCallLog(0)), WithToolsets([]string{ToolsetStacks}))) != 2 {
+		t.Fatal("expected the debug tools with WithCallLog, whatever the toolsets")
+	}
+}
+
+func TestTools_WithServerInfo(t *testing.T) {
+	c, err := terramate.NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	for _, tool := range New(c).Tools() {
+		if tool.Tool.Name == "tmc_server_info" {
+			t.Fatal("expected tmc_server_info to require WithServerInfo")
+		}
+	}
+
+	th := New(nil, WithServerInfo(tmc.ServerInfo{Version: "1.2.3", Credential: "none"}), WithToolsets([]string{ToolsetReviews, ToolsetIndex}))
+	for _, tool := range th.Tools() {
+		if tool.Tool.Name != "tmc_server_info" {
+			continue
+		}
+		result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+		if err != nil || result.IsError {
+			t.Fatalf("unexpected error: %v %+v", err, result)
+		}
+		var info tmc.ServerInfo
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &info); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if info.Version != "1.2.3" || strings.Join(info.Toolsets, ",") != "review_requests,previews" {
+			t.Fatalf("expected the info with the enabled toolsets but the unconfigured index, got %+v", info)
+		}
+		return
+	}
+	t.Fatal("expected tmc_server_info without a client")
+}
+
+func TestTools_ServerInfoRateLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(terramate.RateLimitLimitHeader, "100")
+		w.Header().Set(terramate.RateLimitRemainingHeader, "7")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	if _, _, err = c.Memberships.List(context.Background()); err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+
+	for _, tool := range New(c, WithServerInfo(tmc.ServerInfo{Version: "1.2.3"})).Tools() {
+		if tool.Tool.Name != "tmc_server_info" {
+			continue
+		}
+		result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+		if err != nil || result.IsError {
+			t.Fatalf("unexpected error: %v %+v", err, result)
+		}
+		var info struct {
+			RateLimit *terramate.RateLimit `json:"rate_limit"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &info); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if info.RateLimit == nil || info.RateLimit.Limit != 100 || info.RateLimit.Remaining != 7 {
+			t.Fatalf("expected the latest rate limit, got %+v", info.RateLimit)
+		}
+		return
+	}
+	t.Fatal("expected tmc_server_info")
+}
+
+func TestTools_WithCredentialOverrides(t *testing.T) {
+	c, err := terramate.NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	hasOverrideArguments := func(th *ToolHandlers) bool {
+		for _, tool := range th.Tools() {
+			if tool.Tool.Name == "tmc_list_stacks" {
+				_, ok := tool.Tool.InputSchema.Properties["credential_profile"]
+				return ok
+			}
+		}
+		t.Fatal("expected tmc_list_stacks")
+		return false
+	}
+	if hasOverrideArguments(New(c)) {
+		t.Fatal("expected credential_profile to require WithCredentialOverrides")
+	}
+	if !hasOverrideArguments(New(c, WithCredentialOverrides())) {
+		t.Fatal("expected credential_profile with WithCredentialOverrides")
+	}
 }
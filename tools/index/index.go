@@ -0,0 +1,312 @@
+// Package index keeps a local SQLite copy of an organization's stack, drift
+// and deployment metadata, plus a full-text index over stacks, review
+// requests and deployment errors. It is synced incrementally in the
+// background and serves queries in milliseconds, also while Terramate Cloud
+// is unreachable.
+package index
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+// DefaultLimit is the number of rows returned by queries without a limit.
+const DefaultLimit = 50
+
+// MaxLimit is the largest number of rows a single query returns.
+const MaxLimit = 500
+
+// Index is a local SQLite index. It is safe for concurrent use.
+type Index struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// Open opens (creating if needed) the index database at path. Use ":memory:"
+// for a throwaway index.
+func Open(path string) (*Index, error) {
+	dsn := path
+	if path != ":memory:" {
+		dsn = "file:" + path + "?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)"
+	}
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index: %w", err)
+	}
+	// A single connection serializes writes (and keeps ":memory:" databases
+	// shared); queries are fast enough that readers do not notice.
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &Index{db: db, now: time.Now}, nil
+}
+
+// Close closes the index database.
+func (x *Index) Close() error {
+	return x.db.Close()
+}
+
+// StackQuery filters stacks. Empty fields do not filter.
+type StackQuery struct {
+	Repository  []string
+	Target      []string
+	Status      []string
+	DriftStatus []string
+	// Tags matches stacks having all of these tags
+	Tags []string
+	// Search matches a substring of meta_id, meta_name, meta_description or path
+	Search     string
+	IsArchived *bool
+	Limit      int
+	Offset     int
+}
+
+// Stacks returns the indexed stacks matching q, most recently updated first,
+// and the total number of matches.
+func (x *Index) Stacks(ctx context.Context, orgUUID string, q StackQuery) ([]terramate.Stack, int, error) {
+	w := newWhere(orgUUID)
+	w.in("repository", q.Repository)
+	w.in("target", q.Target)
+	w.in("status", q.Status)
+	w.in("drift_status", q.DriftStatus)
+	for _, tag := range q.Tags {
+		w.add("EXISTS (SELECT 1 FROM json_each(meta_tags) WHERE value = ?)", tag)
+	}
+	if q.Search != "" {
+		like := "%" + escapeLike(q.Search) + "%"
+		w.add(`(meta_id LIKE ? ESCAPE '\' OR meta_name LIKE ? ESCAPE '\' OR meta_description LIKE ? ESCAPE '\' OR path LIKE ? ESCAPE '\')`,
+			like, like, like, like)
+	}
+	if q.IsArchived != nil {
+		w.add("is_archived = ?", *q.IsArchived)
+	}
+
+	var stacks []terramate.Stack
+	total, err := x.query(ctx, "stacks", w, "updated_at DESC, stack_id", q.Limit, q.Offset, func(data []byte) error {
+		var stack terramate.Stack
+		if err := json.Unmarshal(data, &stack); err != nil {
+			return err
+		}
+		stacks = append(stacks, stack)
+		return nil
+	})
+	return stacks, total, err
+}
+
+// DriftQuery filters drift runs. Empty fields do not filter.
+type DriftQuery struct {
+	StackID int
+	Status  []string
+	Limit   int
+	Offset  int
+}
+
+// Drifts returns the indexed drift runs matching q, newest first, and the
+// total number of matches. Only the most recent runs of each stack are
+// indexed.
+func (x *Index) Drifts(ctx context.Context, orgUUID string, q DriftQuery) ([]terramate.Drift, int, error) {
+	w := newWhere(orgUUID)
+	if q.StackID != 0 {
+		w.add("stack_id = ?", q.StackID)
+	}
+	w.in("status", q.Status)
+
+	var drifts []terramate.Drift
+	total, err := x.query(ctx, "drifts", w, "started_at DESC, drift_id DESC", q.Limit, q.Offset, func(data []byte) error {
+		var drift terramate.Drift
+		if err := json.Unmarshal(data, &drift); err != nil {
+			return err
+		}
+		drifts = append(drifts, drift)
+		return nil
+	})
+	return drifts, total, err
+}
+
+// DeploymentQuery filters stack deployments. Empty fields do not filter.
+type DeploymentQuery struct {
+	StackID    int
+	Repository []string
+	Status     []string
+	Limit      int
+	Offset     int
+}
+
+// Deployments returns the indexed stack deployments matching q, newest first,
+// and the total number of matches.
+func (x *Index) Deployments(ctx context.Context, orgUUID string, q DeploymentQuery) ([]terramate.StackDeployment, int, error) {
+	w := newWhere(orgUUID)
+	if q.StackID != 0 {
+		w.add("stack_id = ?", q.StackID)
+	}
+	w.in("repository", q.Repository)
+	w.in("status", q.Status)
+
+	var deployments []terramate.StackDeployment
+	total, err := x.query(ctx, "deployments", w, "created_at DESC, deployment_id DESC", q.Limit, q.Offset, func(data []byte) error {
+		var deployment terramate.StackDeployment
+		if err := json.Unmarshal(data, &deployment); err != nil {
+			return err
+		}
+		deployments = append(deployments, deployment)
+		return nil
+	})
+	return deployments, total, err
+}
+
+// Status describes the index contents of an organization.
+type Status struct {
+	OrgUUID     string     `json:"organization_uuid"`
+	SyncedAt    *time.Time `json:"synced_at,omitempty"` // nil until the first successful sync
+	LastError   string     `json:"last_error,omitempty"`
+	Stacks      int        `json:"stacks"`
+	Drifts      int        `json:"drifts"`
+	Deployments int        `json:"deployments"`
+	Documents   int        `json:"documents"` // searchable stacks, review requests and deployment errors
+}
+
+// Status reports when orgUUID was last synced and how many rows are indexed.
+func (x *Index) Status(ctx context.Context, orgUUID string) (*Status, error) {
+	status := &Status{OrgUUID: orgUUID}
+	state, err := x.syncState(ctx, orgUUID)
+	if err != nil {
+		return nil, err
+	}
+	if state.syncedAt != 0 {
+		syncedAt := time.Unix(0, state.syncedAt).UTC()
+		status.SyncedAt = &syncedAt
+	}
+	status.LastError = state.lastError
+
+	counts := map[string]*int{"stacks": &status.Stacks, "drifts": &status.Drifts, "deployments": &status.Deployments, "documents": &status.Documents}
+	for table, count := range counts {
+		row := x.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE org_uuid = ?", orgUUID)
+		if err := row.Scan(count); err != nil {
+			return nil, fmt.Errorf("failed to count %s: %w", table, err)
+		}
+	}
+	return status, nil
+}
+
+// query runs a filtered, paginated SELECT of the data column of table and
+// returns the total number of matching rows.
+func (x *Index) query(ctx context.Context, table string, w *where, orderBy string, limit, offset int, scan func([]byte) error) (int, error) {
+	var total int
+	countQuery := "SELECT COUNT(*) FROM " + table + " WHERE " + w.String()
+	if err := x.db.QueryRowContext(ctx, countQuery, w.args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+	offset = max(offset, 0)
+
+	rowsQuery := "SELECT data FROM " + table + " WHERE " + w.String() + " ORDER BY " + orderBy + " LIMIT ? OFFSET ?"
+	rows, err := x.db.QueryContext(ctx, rowsQuery, append(w.args, limit, offset)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return 0, fmt.Errorf("failed to read %s: %w", table, err)
+		}
+		if err := scan(data); err != nil {
+			return 0, fmt.Errorf("failed to decode %s: %w", table, err)
+		}
+	}
+	return total, rows.Err()
+}
+
+// syncState is the per-organization sync bookkeeping.
+type syncState struct {
+	syncedAt                int64
+	stacksWatermark         int64 // updated_at of the most recently updated indexed stack
+	deploymentsWatermark    int64 // created_at from which deployments are re-fetched
+	reviewRequestsWatermark int64 // platform_updated_at of the most recently updated review request
+	lastError               string
+}
+
+func (x *Index) syncState(ctx context.Context, orgUUID string) (syncState, error) {
+	var state syncState
+	err := x.db.QueryRowContext(ctx,
+		`SELECT synced_at, stacks_watermark, deployments_watermark, review_requests_watermark, last_error
+		FROM sync_state WHERE org_uuid = ?`,
+		orgUUID,
+	).Scan(&state.syncedAt, &state.stacksWatermark, &state.deploymentsWatermark, &state.reviewRequestsWatermark, &state.lastError)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return state, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	return state, nil
+}
+
+func (x *Index) saveSyncState(ctx context.Context, orgUUID string, state syncState) error {
+	_, err := x.db.ExecContext(ctx, `
+		INSERT INTO sync_state (org_uuid, synced_at, stacks_watermark, deployments_watermark, review_requests_watermark, last_error)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (org_uuid) DO UPDATE SET
+			synced_at = excluded.synced_at,
+			stacks_watermark = excluded.stacks_watermark,
+			deployments_watermark = excluded.deployments_watermark,
+			review_requests_watermark = excluded.review_requests_watermark,
+			last_error = excluded.last_error`,
+		orgUUID, state.syncedAt, state.stacksWatermark, state.deploymentsWatermark, state.reviewRequestsWatermark, state.lastError)
+	if err != nil {
+		return fmt.Errorf("failed to save sync state: %w", err)
+	}
+	return nil
+}
+
+// where accumulates the conditions of a WHERE clause, always scoped to an
+// organization.
+type where struct {
+	conds []string
+	args  []any
+}
+
+func newWhere(orgUUID string) *where {
+	return &where{conds: []string{"org_uuid = ?"}, args: []any{orgUUID}}
+}
+
+func (w *where) add(cond string, args ...any) {
+	w.conds = append(w.conds, cond)
+	w.args = append(w.args, args...)
+}
+
+// in adds "column IN (...)" unless values is empty.
+func (w *where) in(column string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(values)), ",")
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	w.add(column+" IN ("+placeholders+")", args...)
+}
+
+func (w *where) String() string {
+	return strings.Join(w.conds, " AND ")
+}
+
+// escapeLike escapes the LIKE wildcards of s for use with ESCAPE '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
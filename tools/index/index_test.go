@@ -0,0 +1,260 @@
+package index
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// fakeAPI serves the stack, drift, stack deployment and review request list
+// endpoints and the deployment logs endpoint from in-memory data.
+type fakeAPI struct {
+	mu             sync.Mutex
+	stacks         []terramate.Stack
+	deployments    []terramate.StackDeployment
+	reviewRequests []terramate.ReviewRequest
+	stderr         map[string][]string // deployment UUID -> stderr lines
+	driftRequests  map[int]int         // stack ID -> drift list requests
+	logRequests    int
+	fail           bool
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.fail {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var body any
+	switch {
+	case r.URL.Path == "/v1/stacks/org-uuid":
+		if got := r.URL.Query().Get("sort"); got != "-updated_at" {
+			http.Error(w, "unexpected sort "+got, http.StatusBadRequest)
+			return
+		}
+		stacks := append([]terramate.Stack(nil), f.stacks...)
+		sort.Slice(stacks, func(i, j int) bool { return stacks[i].UpdatedAt.After(stacks[j].UpdatedAt) })
+		body = terramate.StacksListResponse{
+			Stacks:          stacks,
+			PaginatedResult: terramate.PaginatedResult{Total: len(stacks), Page: 1, PerPage: syncPerPage},
+		}
+	case strings.HasSuffix(r.URL.Path, "/drifts"):
+		var stackID int
+		for _, stack := range f.stacks {
+			if r.URL.Path == "/v1/stacks/org-uuid/"+strconv.Itoa(stack.StackID)+"/drifts" {
+				stackID = stack.StackID
+			}
+		}
+		f.driftRequests[stackID]++
+		started := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		body = terramate.DriftsListResponse{
+			Drifts: []terramate.Drift{
+				{ID: stackID*100 + f.driftRequests[stackID], StackID: stackID, Status: "drifted", StartedAt: &started},
+			},
+			PaginatedResult: terramate.PaginatedResult{Total: 1, Page: 1, PerPage: driftsPerStack},
+		}
+	case r.URL.Path == "/v1/stack_deployments/org-uuid":
+		if r.URL.Query().Get("created_at_from") == "" {
+			http.Error(w, "missing created_at_from", http.StatusBadRequest)
+			return
+		}
+		body = terramate.StackDeploymentsListResponse{
+			StackDeployments: f.deployments,
+			PaginatedResult:  terramate.PaginatedResult{Total: len(f.deployments), Page: 1, PerPage: syncPerPage},
+		}
+	case r.URL.Path == "/v1/review_requests/org-uuid":
+		body = terramate.ReviewRequestsListResponse{
+			ReviewRequests:  f.reviewRequests,
+			PaginatedResult: terramate.PaginatedResult{Total: len(f.reviewRequests), Page: 1, PerPage: syncPerPage},
+		}
+	case strings.HasSuffix(r.URL.Path, "/logs"):
+		f.logRequests++
+		if got := r.URL.Query().Get("channel"); got != "stderr" {
+			http.Error(w, "unexpected channel "+got, http.StatusBadRequest)
+			return
+		}
+		parts := strings.Split(r.URL.Path, "/")
+		lines, ok := f.stderr[parts[len(parts)-2]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		var logLines []terramate.CommandLogLine
+		for i, line := range lines {
+			logLines = append(logLines, terramate.CommandLogLine{LogLine: i + 1, Channel: "stderr", Message: line + "\n"})
+		}
+		body = terramate.DeploymentLogsResponse{
+			DeploymentLogLines: logLines,
+			PaginatedResult:    terramate.PaginatedResult{Total: len(logLines), Page: 1, PerPage: syncPerPage},
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func newTestIndex(t *testing.T) (*Index, *fakeAPI, *terramate.Client) {
+	t.Helper()
+	updated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	api := &fakeAPI{
+		stacks: []terramate.Stack{
+			{StackID: 1, Repository: "github.com/acme/infra", Path: "/network", MetaName: "network", MetaTags: []string{"core"}, Status: "ok", DriftStatus: "ok", UpdatedAt: updated},
+			{StackID: 2, Repository: "github.com/acme/infra", Path: "/database", MetaName: "database", MetaDescription: "Postgres 100% managed", Status: "drifted", DriftStatus: "drifted", UpdatedAt: updated.Add(time.Hour)},
+			{StackID: 3, Repository: "github.com/acme/apps", Path: "/web", MetaName: "web", MetaTags: []string{"core", "frontend"}, Status: "failed", DriftStatus: "ok", IsArchived: true, UpdatedAt: updated.Add(2 * time.Hour)},
+		},
+		deployments: []terramate.StackDeployment{
+			{ID: 10, Status: "ok", CreatedAt: updated, Stack: &terramate.Stack{StackID: 1, Repository: "github.com/acme/infra"}},
+			{ID: 11, Status: "running", CreatedAt: updated.Add(time.Hour), Stack: &terramate.Stack{StackID: 2, Repository: "github.com/acme/infra"}},
+			{ID: 12, DeploymentUUID: "dep-12", Path: "/web", Status: "failed", CreatedAt: updated, Stack: &terramate.Stack{StackID: 3, Repository: "github.com/acme/apps"}},
+			{ID: 13, DeploymentUUID: "dep-13", Path: "/network", Status: "failed", CreatedAt: updated, Stack: &terramate.Stack{StackID: 1, Repository: "github.com/acme/infra"}},
+		},
+		reviewRequests: []terramate.ReviewRequest{
+			{ReviewRequestID: 7, Number: 42, Title: "Upgrade postgres to 16", Branch: "db-upgrade", Repository: "github.com/acme/infra", URL: "https://github.com/acme/infra/pull/42", PlatformUpdatedAt: &updated},
+		},
+		stderr: map[string][]string{
+			"dep-12": {"Error: creating S3 bucket: BucketAlreadyExists", "  with aws_s3_bucket.assets"},
+		},
+		driftRequests: map[int]int{},
+	}
+	ts := httptest.NewServer(api)
+	t.Cleanup(ts.Close)
+
+	client, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	idx, err := Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open error: %v", err)
+	}
+	t.Cleanup(func() { _ = idx.Close() })
+	return idx, api, client
+}
+
+func TestSync_IndexesAndQueries(t *testing.T) {
+	idx, _, client := newTestIndex(t)
+	ctx := context.Background()
+
+	if err := idx.Sync(ctx, client, "org-uuid"); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+
+	active := false
+	tests := []struct {
+		name string
+		q    StackQuery
+		want []int
+	}{
+		{name: "all, most recently updated first", q: StackQuery{}, want: []int{3, 2, 1}},
+		{name: "repository", q: StackQuery{Repository: []string{"github.com/acme/infra"}}, want: []int{2, 1}},
+		{name: "tags", q: StackQuery{Tags: []string{"core", "frontend"}}, want: []int{3}},
+		{name: "search path", q: StackQuery{Search: "netw"}, want: []int{1}},
+		{name: "search escapes wildcards", q: StackQuery{Search: "100%"}, want: []int{2}},
+		{name: "active only", q: StackQuery{IsArchived: &active}, want: []int{2, 1}},
+		{name: "limit and offset", q: StackQuery{Limit: 1, Offset: 1}, want: []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stacks, total, err := idx.Stacks(ctx, "org-uuid", tt.q)
+			if err != nil {
+				t.Fatalf("Stacks error: %v", err)
+			}
+			var got []int
+			for _, stack := range stacks {
+				got = append(got, stack.StackID)
+			}
+			if !equalInts(got, tt.want) {
+				t.Fatalf("expected stacks %v, got %v", tt.want, got)
+			}
+			if tt.q.Limit == 0 && total != len(tt.want) {
+				t.Fatalf("expected total %d, got %d", len(tt.want), total)
+			}
+		})
+	}
+
+	drifts, total, err := idx.Drifts(ctx, "org-uuid", DriftQuery{StackID: 2})
+	if err != nil || total != 1 || drifts[0].StackID != 2 {
+		t.Fatalf("unexpected drifts %+v (total %d, err %v)", drifts, total, err)
+	}
+	deployments, total, err := idx.Deployments(ctx, "org-uuid", DeploymentQuery{Status: []string{"running"}})
+	if err != nil || total != 1 || deployments[0].ID != 11 {
+		t.Fatalf("unexpected deployments %+v (total %d, err %v)", deployments, total, err)
+	}
+
+	status, err := idx.Status(ctx, "org-uuid")
+	if err != nil {
+		t.Fatalf("Status error: %v", err)
+	}
+	if status.SyncedAt == nil || status.Stacks != 3 || status.Drifts != 3 || status.Deployments != 4 || status.Documents != 6 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	// Other organizations are not visible
+	if _, total, _ := idx.Stacks(ctx, "other-org", StackQuery{}); total != 0 {
+		t.Fatalf("expected no stacks for another organization, got %d", total)
+	}
+}
+
+func TestOpen_MigratesExistingIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open error: %v", err)
+	}
+	for _, stmt := range []string{
+		migrations[0],
+		"PRAGMA user_version = 1",
+		"INSERT INTO sync_state VALUES ('org-uuid', 1, 2, 3, '')",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("Exec error: %v", err)
+		}
+	}
+	_ = db.Close()
+
+	idx, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open error: %v", err)
+	}
+	defer func() { _ = idx.Close() }()
+
+	state, err := idx.syncState(context.Background(), "org-uuid")
+	if err != nil {
+		t.Fatalf("syncState error: %v", err)
+	}
+	if state.syncedAt != 1 || state.stacksWatermark != 0 || state.deploymentsWatermark != 3 {
+		t.Fatalf("unexpected migrated sync state %+v", state)
+	}
+	if _, err := idx.Search(context.Background(), "org-uuid", SearchQuery{Text: "x"}); err != nil {
+		t.Fatalf("expected the search index to exist, got %v", err)
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
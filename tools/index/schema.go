@@ -0,0 +1,133 @@
+package index
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migrations evolve the index schema. migrations[i] upgrades a database at
+// user_version i to i+1; append new steps, never edit released ones.
+//
+// Every row keeps the API object as JSON in data so queries return the same
+// shape as the live API; the other columns exist for filtering and ordering.
+// Timestamps are Unix nanoseconds.
+var migrations = []string{
+	// 1: stacks, drifts and deployments
+	`
+CREATE TABLE stacks (
+	org_uuid          TEXT    NOT NULL,
+	stack_id          INTEGER NOT NULL,
+	repository        TEXT    NOT NULL,
+	target            TEXT    NOT NULL,
+	path              TEXT    NOT NULL,
+	meta_id           TEXT    NOT NULL,
+	meta_name         TEXT    NOT NULL,
+	meta_description  TEXT    NOT NULL,
+	meta_tags         TEXT    NOT NULL, -- JSON array
+	status            TEXT    NOT NULL,
+	drift_status      TEXT    NOT NULL,
+	deployment_status TEXT    NOT NULL,
+	is_archived       INTEGER NOT NULL,
+	updated_at        INTEGER NOT NULL,
+	data              TEXT    NOT NULL,
+	PRIMARY KEY (org_uuid, stack_id)
+);
+CREATE INDEX stacks_updated_at ON stacks (org_uuid, updated_at);
+
+CREATE TABLE drifts (
+	org_uuid    TEXT    NOT NULL,
+	drift_id    INTEGER NOT NULL,
+	stack_id    INTEGER NOT NULL,
+	status      TEXT    NOT NULL,
+	started_at  INTEGER NOT NULL,
+	data        TEXT    NOT NULL,
+	PRIMARY KEY (org_uuid, drift_id)
+);
+CREATE INDEX drifts_stack ON drifts (org_uuid, stack_id, started_at);
+
+CREATE TABLE deployments (
+	org_uuid      TEXT    NOT NULL,
+	deployment_id INTEGER NOT NULL,
+	stack_id      INTEGER NOT NULL,
+	repository    TEXT    NOT NULL,
+	status        TEXT    NOT NULL,
+	created_at    INTEGER NOT NULL,
+	data          TEXT    NOT NULL,
+	PRIMARY KEY (org_uuid, deployment_id)
+);
+CREATE INDEX deployments_created_at ON deployments (org_uuid, created_at);
+
+CREATE TABLE sync_state (
+	org_uuid              TEXT PRIMARY KEY,
+	synced_at             INTEGER NOT NULL,
+	stacks_watermark      INTEGER NOT NULL,
+	deployments_watermark INTEGER NOT NULL,
+	last_error            TEXT    NOT NULL
+);
+`,
+
+	// 2: full-text search over stacks, review requests and deployment errors.
+	// documents holds the searchable text; search is an external content FTS5
+	// index over it, kept in sync by triggers.
+	`
+CREATE TABLE documents (
+	id       INTEGER PRIMARY KEY,
+	org_uuid TEXT NOT NULL,
+	kind     TEXT NOT NULL,
+	key      TEXT NOT NULL,
+	title    TEXT NOT NULL,
+	body     TEXT NOT NULL,
+	ref      TEXT NOT NULL, -- JSON HitRef
+	UNIQUE (org_uuid, kind, key)
+);
+
+CREATE VIRTUAL TABLE search USING fts5(title, body, content = 'documents', content_rowid = 'id');
+
+CREATE TRIGGER documents_ai AFTER INSERT ON documents BEGIN
+	INSERT INTO search (rowid, title, body) VALUES (new.id, new.title, new.body);
+END;
+CREATE TRIGGER documents_ad AFTER DELETE ON documents BEGIN
+	INSERT INTO search (search, rowid, title, body) VALUES ('delete', old.id, old.title, old.body);
+END;
+CREATE TRIGGER documents_au AFTER UPDATE ON documents BEGIN
+	INSERT INTO search (search, rowid, title, body) VALUES ('delete', old.id, old.title, old.body);
+	INSERT INTO search (rowid, title, body) VALUES (new.id, new.title, new.body);
+END;
+
+ALTER TABLE sync_state ADD COLUMN review_requests_watermark INTEGER NOT NULL DEFAULT 0;
+
+-- Re-walk all stacks so existing ones become searchable
+UPDATE sync_state SET stacks_watermark = 0;
+`,
+}
+
+// migrate applies the migrations the database has not seen yet.
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read index schema version: %w", err)
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("index schema version %d is newer than supported (%d)", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to migrate index schema to version %d: %w", i+1, err)
+		}
+		// PRAGMA does not accept placeholders
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to migrate index schema to version %d: %w", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to migrate index schema to version %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
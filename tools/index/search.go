@@ -0,0 +1,191 @@
+package index
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// Kinds of search hits.
+const (
+	KindStack           = "stack"
+	KindReviewRequest   = "review_request"
+	KindDeploymentError = "deployment_error"
+)
+
+// Kinds lists the searchable kinds.
+func Kinds() []string {
+	return []string{KindStack, KindReviewRequest, KindDeploymentError}
+}
+
+// Hit is a single search result.
+type Hit struct {
+	Kind    string  `json:"kind"`
+	Title   string  `json:"title"`
+	Snippet string  `json:"snippet"` // matching text, matches wrapped in [ ]
+	Ref     HitRef  `json:"ref"`
+	Score   float64 `json:"score"` // higher is more relevant
+}
+
+// HitRef identifies the object a hit refers to, for follow-up calls with the
+// live tools (tmc_get_stack, tmc_get_review_request, tmc_get_stack_deployment).
+type HitRef struct {
+	StackID           int    `json:"stack_id,omitempty"`
+	ReviewRequestID   int    `json:"review_request_id,omitempty"`
+	StackDeploymentID int    `json:"stack_deployment_id,omitempty"`
+	Repository        string `json:"repository,omitempty"`
+	Path              string `json:"path,omitempty"`
+	URL               string `json:"url,omitempty"`
+}
+
+// SearchQuery is a full-text search request.
+type SearchQuery struct {
+	// Text is matched word by word; every word must match, as a prefix.
+	Text string
+	// Kinds restricts the hits to these kinds (default: all).
+	Kinds []string
+	Limit int
+}
+
+// ErrEmptyQuery is returned by Search for queries without words.
+var ErrEmptyQuery = fmt.Errorf("search query must contain at least one word")
+
+// Search runs a full-text search over the indexed stacks (name, description,
+// path, tags), review requests (title, description, branch) and recent
+// deployment error logs, best matches first. Title matches weigh more than
+// body matches.
+func (x *Index) Search(ctx context.Context, orgUUID string, q SearchQuery) ([]Hit, error) {
+	match := matchExpression(q.Text)
+	if match == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	w := &where{conds: []string{"search MATCH ?", "d.org_uuid = ?"}, args: []any{match, orgUUID}}
+	w.in("d.kind", q.Kinds)
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	rows, err := x.db.QueryContext(ctx, `
+		SELECT d.kind, d.title, snippet(search, -1, '[', ']', '…', 16), d.ref, bm25(search, 5.0, 1.0) AS score
+		FROM search JOIN documents d ON d.id = search.rowid
+		WHERE `+w.String()+`
+		ORDER BY score
+		LIMIT ?`,
+		append(w.args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search index: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	hits := []Hit{}
+	for rows.Next() {
+		var hit Hit
+		var ref []byte
+		if err := rows.Scan(&hit.Kind, &hit.Title, &hit.Snippet, &ref, &hit.Score); err != nil {
+			return nil, fmt.Errorf("failed to read search hit: %w", err)
+		}
+		if err := json.Unmarshal(ref, &hit.Ref); err != nil {
+			return nil, fmt.Errorf("failed to decode search hit: %w", err)
+		}
+		// bm25 is lower for better matches; report a positive relevance
+		hit.Score = -hit.Score
+		hits = append(hits, hit)
+	}
+	return hits, rows.Err()
+}
+
+// matchExpression turns free text into an FTS5 expression matching every
+// word as a prefix. Words are quoted so FTS5 operators in the input are
+// taken literally.
+func matchExpression(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"*`
+	}
+	return strings.Join(terms, " ")
+}
+
+// document is the searchable text of an indexed object.
+type document struct {
+	kind  string
+	key   string
+	title string
+	body  []string
+	ref   HitRef
+}
+
+func stackDocument(stack terramate.Stack) document {
+	title := stack.MetaName
+	if title == "" {
+		title = stack.Path
+	}
+	return document{
+		kind:  KindStack,
+		key:   strconv.Itoa(stack.StackID),
+		title: title,
+		body: append([]string{stack.MetaID, stack.MetaDescription, stack.Path, stack.Repository, stack.Target},
+			stack.MetaTags...),
+		ref: HitRef{StackID: stack.StackID, Repository: stack.Repository, Path: stack.Path},
+	}
+}
+
+func reviewRequestDocument(rr terramate.ReviewRequest) document {
+	return document{
+		kind:  KindReviewRequest,
+		key:   strconv.Itoa(rr.ReviewRequestID),
+		title: rr.Title,
+		body:  []string{rr.Description, rr.Branch, rr.Repository, "#" + strconv.Itoa(rr.Number)},
+		ref:   HitRef{ReviewRequestID: rr.ReviewRequestID, Repository: rr.Repository, URL: rr.URL},
+	}
+}
+
+func deploymentErrorDocument(deployment terramate.StackDeployment, snippet string) document {
+	ref := HitRef{StackDeploymentID: deployment.ID, Path: deployment.Path}
+	if deployment.Stack != nil {
+		ref.StackID = deployment.Stack.StackID
+		ref.Repository = deployment.Stack.Repository
+	}
+	return document{
+		kind:  KindDeploymentError,
+		key:   strconv.Itoa(deployment.ID),
+		title: "Failed deployment of " + deployment.Path,
+		body:  []string{snippet, ref.Repository},
+		ref:   ref,
+	}
+}
+
+// upsertDocument adds or replaces doc in the search index.
+func upsertDocument(ctx context.Context, tx *sql.Tx, orgUUID string, doc document) error {
+	ref, err := json.Marshal(doc.ref)
+	if err != nil {
+		return err
+	}
+	var body []string
+	for _, part := range doc.body {
+		if part != "" {
+			body = append(body, part)
+		}
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO documents (org_uuid, kind, key, title, body, ref) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (org_uuid, kind, key) DO UPDATE SET
+			title = excluded.title, body = excluded.body, ref = excluded.ref`,
+		orgUUID, doc.kind, doc.key, doc.title, strings.Join(body, "\n"), string(ref))
+	if err != nil {
+		return fmt.Errorf("failed to index %s %s for search: %w", doc.kind, doc.key, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package index
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestSearch(t *testing.T) {
+	idx, api, client := newTestIndex(t)
+	ctx := context.Background()
+
+	if err := idx.Sync(ctx, client, "org-uuid"); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		q        SearchQuery
+		wantKind string
+		wantRef  HitRef
+	}{
+		{name: "stack by name prefix", q: SearchQuery{Text: "netw"}, wantKind: KindStack,
+			wantRef: HitRef{StackID: 1, Repository: "github.com/acme/infra", Path: "/network"}},
+		{name: "stack by description", q: SearchQuery{Text: "managed", Kinds: []string{KindStack}}, wantKind: KindStack,
+			wantRef: HitRef{StackID: 2, Repository: "github.com/acme/infra", Path: "/database"}},
+		{name: "review request by title", q: SearchQuery{Text: "postgres upgrade", Kinds: []string{KindReviewRequest}}, wantKind: KindReviewRequest,
+			wantRef: HitRef{ReviewRequestID: 7, Repository: "github.com/acme/infra", URL: "https://github.com/acme/infra/pull/42"}},
+		{name: "deployment error log", q: SearchQuery{Text: "BucketAlreadyExists"}, wantKind: KindDeploymentError,
+			wantRef: HitRef{StackID: 3, StackDeploymentID: 12, Repository: "github.com/acme/apps", Path: "/web"}},
+		{name: "operators are literal", q: SearchQuery{Text: `"web" OR NOT`}, wantKind: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := idx.Search(ctx, "org-uuid", tt.q)
+			if err != nil {
+				t.Fatalf("Search error: %v", err)
+			}
+			if tt.wantKind == "" {
+				if len(hits) != 0 {
+					t.Fatalf("expected no hits, got %+v", hits)
+				}
+				return
+			}
+			if len(hits) == 0 {
+				t.Fatal("expected hits")
+			}
+			if hits[0].Kind != tt.wantKind || hits[0].Ref != tt.wantRef {
+				t.Fatalf("unexpected first hit %+v", hits[0])
+			}
+			if !strings.Contains(hits[0].Snippet, "[") || hits[0].Score <= 0 {
+				t.Fatalf("expected a highlighted snippet and a positive score, got %+v", hits[0])
+			}
+		})
+	}
+
+	if _, err := idx.Search(ctx, "org-uuid", SearchQuery{Text: " -- "}); err != ErrEmptyQuery {
+		t.Fatalf("expected ErrEmptyQuery, got %v", err)
+	}
+	if hits, _ := idx.Search(ctx, "other-org", SearchQuery{Text: "network"}); len(hits) != 0 {
+		t.Fatalf("expected no hits for another organization, got %+v", hits)
+	}
+
+	// Error logs are fetched once per failed deployment, including those
+	// without logs
+	requests := api.logRequests
+	if err := idx.Sync(ctx, client, "org-uuid"); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	if requests != 2 || api.logRequests != requests {
+		t.Fatalf("expected 2 log requests in total, got %d then %d", requests, api.logRequests)
+	}
+}
+
+func TestSearch_RanksTitleMatchesFirst(t *testing.T) {
+	idx, api, client := newTestIndex(t)
+	ctx := context.Background()
+
+	updated := *api.reviewRequests[0].PlatformUpdatedAt
+	api.mu.Lock()
+	api.reviewRequests = append(api.reviewRequests, terramate.ReviewRequest{
+		ReviewRequestID: 9, Number: 44, Title: "Split subnets", Description: "Moves the network peering out",
+		Repository: "github.com/acme/infra", PlatformUpdatedAt: &updated,
+	})
+	api.mu.Unlock()
+	if err := idx.Sync(ctx, client, "org-uuid"); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+
+	hits, err := idx.Search(ctx, "org-uuid", SearchQuery{Text: "network"})
+	if err != nil {
+		t.Fatalf("Search error: %v", err)
+	}
+	if len(hits) < 2 || hits[0].Ref.StackID != 1 || hits[0].Score <= hits[len(hits)-1].Score {
+		t.Fatalf("expected the stack named network before body matches, got %+v", hits)
+	}
+
+	if hits, _ := idx.Search(ctx, "org-uuid", SearchQuery{Text: "acme", Limit: 2}); len(hits) != 2 {
+		t.Fatalf("expected the limit to cap the hits, got %+v", hits)
+	}
+}
+
+func TestMatchExpression(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "postgres upgrade", want: `"postgres"* "upgrade"*`},
+		{text: `"web" OR NOT*`, want: `"web"* "OR"* "NOT"*`},
+		{text: "aws_s3_bucket.assets", want: `"aws"* "s3"* "bucket"* "assets"*`},
+		{text: " -- ", want: ""},
+	}
+	for _, tt := range tests {
+		if got := matchExpression(tt.text); got != tt.want {
+			t.Errorf("matchExpression(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,426 @@
+package index
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/internal/fanout"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+const (
+	// syncPerPage is the page size used when walking the API.
+	syncPerPage = 100
+
+	// driftsPerStack is how many recent drift runs are kept per stack.
+	driftsPerStack = 10
+
+	// initialDeploymentsWindow limits how far back the first sync fetches
+	// deployments, so large organizations do not download their whole history.
+	initialDeploymentsWindow = 30 * 24 * time.Hour
+
+	// initialReviewRequestsWindow limits how far back the first sync fetches
+	// review requests.
+	initialReviewRequestsWindow = 30 * 24 * time.Hour
+
+	// errorLogsPerSync bounds how many failed deployments get their error
+	// logs fetched by a single sync; the rest follow in later syncs.
+	errorLogsPerSync = 50
+
+	// errorSnippetLines and errorSnippetSize bound the tail of the stderr log
+	// indexed for a failed deployment.
+	errorSnippetLines = 20
+	errorSnippetSize  = 2000
+)
+
+// stacksByRecentUpdate sorts stacks by updated_at, newest first. Walking in
+// this order lets a sync stop at the first stack it has already indexed.
+var stacksByRecentUpdate = []string{"-updated_at"}
+
+// Sync brings the index of orgUUID up to date:
+//
+//   - stacks updated since the last sync are re-fetched (all stacks on the
+//     first sync), including archived ones
+//   - the recent drift runs of those stacks are re-fetched
+//   - stack deployments created since the oldest unfinished deployment are
+//     re-fetched (the last 30 days on the first sync)
+//   - review requests updated since the last sync are re-fetched (those
+//     created in the last 30 days on the first sync)
+//   - the tail of the stderr log of recently failed deployments is fetched
+//     for search
+//
+// Parallel requests are bounded by the fan-out limit of ctx (see
+// tmc.WithConcurrencyLimit). The outcome, including errors, is recorded in
+// the sync state reported by Status. Data synced before an error is kept.
+func (x *Index) Sync(ctx context.Context, client *terramate.Client, orgUUID string) error {
+	state, err := x.syncState(ctx, orgUUID)
+	if err != nil {
+		return err
+	}
+
+	syncErr := x.sync(ctx, client, orgUUID, &state)
+	state.lastError = ""
+	if syncErr != nil {
+		state.lastError = syncErr.Error()
+	} else {
+		state.syncedAt = x.now().UnixNano()
+	}
+	if err := x.saveSyncState(ctx, orgUUID, state); err != nil {
+		return err
+	}
+	return syncErr
+}
+
+func (x *Index) sync(ctx context.Context, client *terramate.Client, orgUUID string, state *syncState) error {
+	changed, err := x.syncStacks(ctx, client, orgUUID, state)
+	if err != nil {
+		return fmt.Errorf("failed to sync stacks: %w", err)
+	}
+	if err := x.syncDrifts(ctx, client, orgUUID, changed); err != nil {
+		return fmt.Errorf("failed to sync drifts: %w", err)
+	}
+	if err := x.syncDeployments(ctx, client, orgUUID, state); err != nil {
+		return fmt.Errorf("failed to sync deployments: %w", err)
+	}
+	if err := x.syncReviewRequests(ctx, client, orgUUID, state); err != nil {
+		return fmt.Errorf("failed to sync review requests: %w", err)
+	}
+	if err := x.syncDeploymentErrors(ctx, client, orgUUID); err != nil {
+		return fmt.Errorf("failed to sync deployment errors: %w", err)
+	}
+	return nil
+}
+
+// syncStacks upserts the stacks updated after the stacks watermark and
+// returns their IDs.
+func (x *Index) syncStacks(ctx context.Context, client *terramate.Client, orgUUID string, state *syncState) ([]int, error) {
+	var changed []int
+	watermark := state.stacksWatermark
+	for page := 1; ; page++ {
+		result, _, err := client.Stacks.List(ctx, orgUUID, &terramate.StacksListOptions{
+			ListOptions: terramate.ListOptions{Page: page, PerPage: syncPerPage},
+			IsArchived:  []bool{false, true},
+			Sort:        stacksByRecentUpdate,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var updated []terramate.Stack
+		for _, stack := range result.Stacks {
+			if stack.UpdatedAt.UnixNano() > watermark {
+				updated = append(updated, stack)
+			}
+		}
+		if err := x.upsertStacks(ctx, orgUUID, updated); err != nil {
+			return nil, err
+		}
+		for _, stack := range updated {
+			changed = append(changed, stack.StackID)
+			state.stacksWatermark = max(state.stacksWatermark, stack.UpdatedAt.UnixNano())
+		}
+
+		if len(updated) < len(result.Stacks) || page >= result.PaginatedResult.TotalPages() {
+			return changed, nil
+		}
+	}
+}
+
+// syncDrifts upserts the recent drift runs of the given stacks.
+func (x *Index) syncDrifts(ctx context.Context, client *terramate.Client, orgUUID string, stackIDs []int) error {
+	return fanout.Run(ctx, len(stackIDs), func(ctx context.Context, i int) error {
+		result, _, err := client.Drifts.ListForStack(ctx, orgUUID, stackIDs[i], &terramate.DriftsListOptions{
+			ListOptions: terramate.ListOptions{Page: 1, PerPage: driftsPerStack},
+		})
+		if err != nil {
+			return err
+		}
+		return x.upsertDrifts(ctx, orgUUID, stackIDs[i], result.Drifts)
+	})
+}
+
+// syncDeployments upserts the stack deployments created after the
+// deployments watermark. The new watermark is the creation time of the oldest
+// deployment still in progress, so its final status is picked up later.
+func (x *Index) syncDeployments(ctx context.Context, client *terramate.Client, orgUUID string, state *syncState) error {
+	from := time.Unix(0, state.deploymentsWatermark)
+	if state.deploymentsWatermark == 0 {
+		from = x.now().Add(-initialDeploymentsWindow)
+	}
+
+	var newest, oldestUnfinished int64
+	for page := 1; ; page++ {
+		result, _, err := client.Deployments.ListStackDeployments(ctx, orgUUID, &terramate.StackDeploymentsListOptions{
+			ListOptions:   terramate.ListOptions{Page: page, PerPage: syncPerPage},
+			CreatedAtFrom: &from,
+		})
+		if err != nil {
+			return err
+		}
+		if err := x.upsertDeployments(ctx, orgUUID, result.StackDeployments); err != nil {
+			return err
+		}
+		for _, deployment := range result.StackDeployments {
+			created := deployment.CreatedAt.UnixNano()
+			newest = max(newest, created)
+			if !deploymentFinished(deployment.Status) && (oldestUnfinished == 0 || created < oldestUnfinished) {
+				oldestUnfinished = created
+			}
+		}
+		if page >= result.PaginatedResult.TotalPages() {
+			break
+		}
+	}
+
+	switch {
+	case oldestUnfinished != 0:
+		state.deploymentsWatermark = oldestUnfinished
+	case newest != 0:
+		state.deploymentsWatermark = newest
+	default:
+		state.deploymentsWatermark = from.UnixNano()
+	}
+	return nil
+}
+
+// syncReviewRequests indexes the review requests updated after the review
+// requests watermark for search. The API lists them most recently updated
+// first, so the walk stops at the first one already indexed.
+func (x *Index) syncReviewRequests(ctx context.Context, client *terramate.Client, orgUUID string, state *syncState) error {
+	opts := &terramate.ReviewRequestsListOptions{}
+	watermark := state.reviewRequestsWatermark
+	if watermark == 0 {
+		from := x.now().Add(-initialReviewRequestsWindow)
+		opts.CreatedAtFrom = &from
+	}
+
+	for page := 1; ; page++ {
+		opts.ListOptions = terramate.ListOptions{Page: page, PerPage: syncPerPage}
+		result, _, err := client.ReviewRequests.List(ctx, orgUUID, opts)
+		if err != nil {
+			return err
+		}
+
+		var docs []document
+		for _, rr := range result.ReviewRequests {
+			var updated int64
+			if rr.PlatformUpdatedAt != nil {
+				updated = rr.PlatformUpdatedAt.UnixNano()
+			}
+			if watermark != 0 && updated <= watermark {
+				continue
+			}
+			docs = append(docs, reviewRequestDocument(rr))
+			state.reviewRequestsWatermark = max(state.reviewRequestsWatermark, updated)
+		}
+		if err := x.upsertDocuments(ctx, orgUUID, docs); err != nil {
+			return err
+		}
+
+		if len(docs) < len(result.ReviewRequests) || page >= result.PaginatedResult.TotalPages() {
+			return nil
+		}
+	}
+}
+
+// syncDeploymentErrors indexes the tail of the stderr log of the most recent
+// failed deployments that are not searchable yet.
+func (x *Index) syncDeploymentErrors(ctx context.Context, client *terramate.Client, orgUUID string) error {
+	failed, err := x.unindexedFailedDeployments(ctx, orgUUID)
+	if err != nil {
+		return err
+	}
+	return fanout.Run(ctx, len(failed), func(ctx context.Context, i int) error {
+		snippet, err := errorSnippet(ctx, client, orgUUID, failed[i])
+		if err != nil {
+			return err
+		}
+		return x.upsertDocuments(ctx, orgUUID, []document{deploymentErrorDocument(failed[i], snippet)})
+	})
+}
+
+// unindexedFailedDeployments returns the newest failed deployments without a
+// deployment error document.
+func (x *Index) unindexedFailedDeployments(ctx context.Context, orgUUID string) ([]terramate.StackDeployment, error) {
+	rows, err := x.db.QueryContext(ctx, `
+		SELECT data FROM deployments d
+		WHERE org_uuid = ? AND status = 'failed' AND NOT EXISTS (
+			SELECT 1 FROM documents
+			WHERE org_uuid = d.org_uuid AND kind = ? AND key = CAST(d.deployment_id AS TEXT))
+		ORDER BY created_at DESC
+		LIMIT ?`,
+		orgUUID, KindDeploymentError, errorLogsPerSync)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var deployments []terramate.StackDeployment
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var deployment terramate.StackDeployment
+		if err := json.Unmarshal(data, &deployment); err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, deployment)
+	}
+	return deployments, rows.Err()
+}
+
+// errorSnippet returns the last lines of the stderr log of a deployment, or
+// "" if its logs are not available.
+func errorSnippet(ctx context.Context, client *terramate.Client, orgUUID string, deployment terramate.StackDeployment) (string, error) {
+	if deployment.Stack == nil || deployment.DeploymentUUID == "" {
+		return "", nil
+	}
+
+	opts := &terramate.DeploymentLogsOptions{
+		ListOptions: terramate.ListOptions{Page: 1, PerPage: syncPerPage},
+		Channel:     "stderr",
+	}
+	logs, _, err := client.Deployments.GetDeploymentLogs(ctx, orgUUID, deployment.Stack.StackID, deployment.DeploymentUUID, opts)
+	if err == nil && logs.PaginatedResult.TotalPages() > 1 {
+		// Errors are usually at the end of the log
+		opts.Page = logs.PaginatedResult.TotalPages()
+		logs, _, err = client.Deployments.GetDeploymentLogs(ctx, orgUUID, deployment.Stack.StackID, deployment.DeploymentUUID, opts)
+	}
+	if err != nil {
+		if apiErr, ok := err.(*terramate.APIError); ok && apiErr.IsNotFound() {
+			return "", nil
+		}
+		return "", err
+	}
+
+	lines := logs.DeploymentLogLines
+	if len(lines) > errorSnippetLines {
+		lines = lines[len(lines)-errorSnippetLines:]
+	}
+	messages := make([]string, len(lines))
+	for i, line := range lines {
+		messages[i] = strings.TrimRight(line.Message, "\n")
+	}
+	snippet := strings.Join(messages, "\n")
+	if len(snippet) > errorSnippetSize {
+		snippet = strings.ToValidUTF8(snippet[len(snippet)-errorSnippetSize:], "")
+	}
+	return snippet, nil
+}
+
+// deploymentFinished reports whether a stack deployment status is final.
+func deploymentFinished(status string) bool {
+	return status != "pending" && status != "running"
+}
+
+func (x *Index) upsertStacks(ctx context.Context, orgUUID string, stacks []terramate.Stack) error {
+	return x.inTx(ctx, func(tx *sql.Tx) error {
+		for _, stack := range stacks {
+			data, err := json.Marshal(stack)
+			if err != nil {
+				return err
+			}
+			tags, err := json.Marshal(append([]string{}, stack.MetaTags...))
+			if err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx, `
+				INSERT OR REPLACE INTO stacks (org_uuid, stack_id, repository, target, path, meta_id, meta_name,
+					meta_description, meta_tags, status, drift_status, deployment_status, is_archived, updated_at, data)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				orgUUID, stack.StackID, stack.Repository, stack.Target, stack.Path, stack.MetaID, stack.MetaName,
+				stack.MetaDescription, string(tags), stack.Status, stack.DriftStatus, stack.DeploymentStatus,
+				stack.IsArchived, stack.UpdatedAt.UnixNano(), string(data))
+			if err != nil {
+				return fmt.Errorf("failed to index stack %d: %w", stack.StackID, err)
+			}
+			if err := upsertDocument(ctx, tx, orgUUID, stackDocument(stack)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (x *Index) upsertDocuments(ctx context.Context, orgUUID string, docs []document) error {
+	return x.inTx(ctx, func(tx *sql.Tx) error {
+		for _, doc := range docs {
+			if err := upsertDocument(ctx, tx, orgUUID, doc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (x *Index) upsertDrifts(ctx context.Context, orgUUID string, stackID int, drifts []terramate.Drift) error {
+	return x.inTx(ctx, func(tx *sql.Tx) error {
+		for _, drift := range drifts {
+			data, err := json.Marshal(drift)
+			if err != nil {
+				return err
+			}
+			var startedAt int64
+			if drift.StartedAt != nil {
+				startedAt = drift.StartedAt.UnixNano()
+			}
+			_, err = tx.ExecContext(ctx, `
+				INSERT OR REPLACE INTO drifts (org_uuid, drift_id, stack_id, status, started_at, data)
+				VALUES (?, ?, ?, ?, ?, ?)`,
+				orgUUID, drift.ID, stackID, drift.Status, startedAt, string(data))
+			if err != nil {
+				return fmt.Errorf("failed to index drift %d: %w", drift.ID, err)
+			}
+		}
+		// Keep only the most recent runs per stack
+		_, err := tx.ExecContext(ctx, `
+			DELETE FROM drifts WHERE org_uuid = ? AND stack_id = ? AND drift_id NOT IN (
+				SELECT drift_id FROM drifts WHERE org_uuid = ? AND stack_id = ?
+				ORDER BY started_at DESC, drift_id DESC LIMIT ?)`,
+			orgUUID, stackID, orgUUID, stackID, driftsPerStack)
+		return err
+	})
+}
+
+func (x *Index) upsertDeployments(ctx context.Context, orgUUID string, deployments []terramate.StackDeployment) error {
+	return x.inTx(ctx, func(tx *sql.Tx) error {
+		for _, deployment := range deployments {
+			data, err := json.Marshal(deployment)
+			if err != nil {
+				return err
+			}
+			var stackID int
+			var repository string
+			if deployment.Stack != nil {
+				stackID = deployment.Stack.StackID
+				repository = deployment.Stack.Repository
+			}
+			_, err = tx.ExecContext(ctx, `
+				INSERT OR REPLACE INTO deployments (org_uuid, deployment_id, stack_id, repository, status, created_at, data)
+				VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				orgUUID, deployment.ID, stackID, repository, deployment.Status, deployment.CreatedAt.UnixNano(), string(data))
+			if err != nil {
+				return fmt.Errorf("failed to index deployment %d: %w", deployment.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// inTx runs fn in a transaction, committing if it succeeds.
+func (x *Index) inTx(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := x.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,120 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestSync_IsIncremental(t *testing.T) {
+	idx, api, client := newTestIndex(t)
+	ctx := context.Background()
+
+	if err := idx.Sync(ctx, client, "org-uuid"); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+
+	api.mu.Lock()
+	api.stacks[0].Status = "drifted"
+	api.stacks[0].UpdatedAt = api.stacks[2].UpdatedAt.Add(time.Hour)
+	api.deployments[1].Status = "ok"
+	api.mu.Unlock()
+
+	if err := idx.Sync(ctx, client, "org-uuid"); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+
+	if api.driftRequests[1] != 2 || api.driftRequests[2] != 1 || api.driftRequests[3] != 1 {
+		t.Fatalf("expected only the updated stack's drifts to be re-fetched, got %v", api.driftRequests)
+	}
+	stacks, _, err := idx.Stacks(ctx, "org-uuid", StackQuery{Status: []string{"drifted"}})
+	if err != nil || len(stacks) != 2 {
+		t.Fatalf("expected updated stack to be re-indexed, got %+v (err %v)", stacks, err)
+	}
+	if _, total, _ := idx.Deployments(ctx, "org-uuid", DeploymentQuery{Status: []string{"running"}}); total != 0 {
+		t.Fatal("expected the unfinished deployment to be refreshed")
+	}
+}
+
+func TestSync_RecordsErrors(t *testing.T) {
+	idx, api, client := newTestIndex(t)
+	ctx := context.Background()
+
+	if err := idx.Sync(ctx, client, "org-uuid"); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	api.mu.Lock()
+	api.fail = true
+	api.mu.Unlock()
+
+	if err := idx.Sync(ctx, client, "org-uuid"); err == nil {
+		t.Fatal("expected sync error")
+	}
+	status, err := idx.Status(ctx, "org-uuid")
+	if err != nil {
+		t.Fatalf("Status error: %v", err)
+	}
+	if status.LastError == "" || status.SyncedAt == nil || status.Stacks != 3 {
+		t.Fatalf("expected the error to be recorded and previous data kept, got %+v", status)
+	}
+}
+
+func TestSync_IndexesTheTailOfErrorLogs(t *testing.T) {
+	idx, api, client := newTestIndex(t)
+	ctx := context.Background()
+
+	var lines []string
+	for i := 1; i <= 30; i++ {
+		lines = append(lines, fmt.Sprintf("line%02d", i))
+	}
+	api.mu.Lock()
+	api.stderr["dep-13"] = lines
+	api.mu.Unlock()
+
+	if err := idx.Sync(ctx, client, "org-uuid"); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	hits, err := idx.Search(ctx, "org-uuid", SearchQuery{Text: "line30", Kinds: []string{KindDeploymentError}})
+	if err != nil || len(hits) != 1 || hits[0].Ref.StackDeploymentID != 13 {
+		t.Fatalf("expected the last log line to be searchable, got %+v (err %v)", hits, err)
+	}
+	if hits, _ := idx.Search(ctx, "org-uuid", SearchQuery{Text: "line10"}); len(hits) != 0 {
+		t.Fatalf("expected lines before the last %d not to be indexed, got %+v", errorSnippetLines, hits)
+	}
+}
+
+func TestSync_ReindexesUpdatedReviewRequests(t *testing.T) {
+	idx, api, client := newTestIndex(t)
+	ctx := context.Background()
+
+	if err := idx.Sync(ctx, client, "org-uuid"); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+
+	updated := api.reviewRequests[0].PlatformUpdatedAt.Add(time.Hour)
+	api.mu.Lock()
+	api.reviewRequests = append(api.reviewRequests, terramate.ReviewRequest{
+		ReviewRequestID: 8, Number: 43, Title: "Rotate vault credentials", Repository: "github.com/acme/infra", PlatformUpdatedAt: &updated,
+	})
+	api.reviewRequests[0].Title = "Stale title, not updated"
+	api.mu.Unlock()
+
+	if err := idx.Sync(ctx, client, "org-uuid"); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	if hits, _ := idx.Search(ctx, "org-uuid", SearchQuery{Text: "vault"}); len(hits) != 1 || hits[0].Ref.ReviewRequestID != 8 {
+		t.Fatalf("expected the updated review request to be indexed, got %+v", hits)
+	}
+	// Review requests not updated since the last sync are not fetched again
+	if hits, _ := idx.Search(ctx, "org-uuid", SearchQuery{Text: "stale"}); len(hits) != 0 {
+		t.Fatalf("expected the unchanged review request to be kept, got %+v", hits)
+	}
+	hits, _ := idx.Search(ctx, "org-uuid", SearchQuery{Text: "postgres", Kinds: []string{KindReviewRequest}})
+	if len(hits) != 1 || !strings.Contains(hits[0].Title, "postgres") {
+		t.Fatalf("expected the previously indexed title, got %+v", hits)
+	}
+}
@@ -0,0 +1,360 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/tmc"
+)
+
+// maxCorrelationIDLength bounds the correlation IDs accepted from clients.
+const maxCorrelationIDLength = 128
+
+// ConcurrencyLimits returns a middleware that attaches the fan-out limit for
+// the invoked tool to the handler context. perTool entries take precedence
+// over defaultLimit; a non-positive defaultLimit keeps tmc.DefaultConcurrencyLimit.
+func ConcurrencyLimits(defaultLimit int, perTool map[string]int) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			limit := defaultLimit
+			if n, ok := perTool[request.Params.Name]; ok {
+				limit = n
+			}
+			return next(tmc.WithConcurrencyLimit(ctx, limit), request)
+		}
+	}
+}
+
+// Timeouts returns a middleware that cancels the handler context of a tool
+// after its timeout in perTool. A call that runs out of time returns an error
+// result naming the timeout. Tools without an entry are not limited.
+func Timeouts(perTool map[string]time.Duration) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			timeout, ok := perTool[request.Params.Name]
+			if !ok {
+				return next(ctx, request)
+			}
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			result, err := next(ctx, request)
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) && (err != nil || result == nil || result.IsError) {
+				slog.WarnContext(ctx, "Tool call timed out", "tool", request.Params.Name, "timeout", timeout)
+				return mcp.NewToolResultError(fmt.Sprintf("%s timed out after %s.", request.Params.Name, timeout)), nil
+			}
+			return result, err
+		}
+	}
+}
+
+// MaxConcurrentCalls returns a middleware that runs at most n tool calls at a
+// time. Excess calls queue until a slot is free or their context is canceled.
+// A non-positive n means unlimited.
+func MaxConcurrentCalls(n int) server.ToolHandlerMiddleware {
+	if n <= 0 {
+		return func(next server.ToolHandlerFunc) server.ToolHandlerFunc { return next }
+	}
+	slots := make(chan struct{}, n)
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			select {
+			case slots <- struct{}{}:
+			default:
+				slog.DebugContext(ctx, "Tool call queued", "tool", request.Params.Name, "max_concurrent_tools", n)
+				select {
+				case slots <- struct{}{}:
+				case <-ctx.Done():
+					return nil, fmt.Errorf("waiting for a tool call slot: %w", ctx.Err())
+				}
+			}
+			defer func() { <-slots }()
+			return next(ctx, request)
+		}
+	}
+}
+
+// Sessions returns a middleware that attaches the calling client's session to
+// the handler context and fills in organization_uuid from the session's
+// default organization when the call omits it.
+func Sessions(store *tmc.SessionStore) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			session := store.Get(sessionID(ctx))
+
+			if orgUUID := session.DefaultOrganization(); orgUUID != "" {
+				args := request.GetArguments()
+				if _, ok := args["organization_uuid"]; !ok {
+					// Copy so the default does not leak into the caller's arguments
+					withOrg := make(map[string]any, len(args)+1)
+					for k, v := range args {
+						withOrg[k] = v
+					}
+					withOrg["organization_uuid"] = orgUUID
+					request.Params.Arguments = withOrg
+				}
+			}
+
+			return next(tmc.WithSession(ctx, session), request)
+		}
+	}
+}
+
+// CorrelationIDs returns a middleware that propagates the correlation_id
+// argument of a call: it is attached to the handler context (and so sent with
+// every Terramate Cloud API request and included in context-aware logs),
+// logged with the call's outcome and echoed in the result's _meta.
+func CorrelationIDs() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			id := request.GetString("correlation_id", "")
+			if id == "" {
+				return next(ctx, request)
+			}
+			if !validCorrelationID(id) {
+				return mcp.NewToolResultError("correlation_id must be at most 128 printable ASCII characters."), nil
+			}
+
+			ctx = terramate.WithCorrelationID(ctx, id)
+			result, err := next(ctx, request)
+			if err != nil {
+				slog.WarnContext(ctx, "Tool call failed", "tool", request.Params.Name, "error", err)
+				return result, err
+			}
+			if result != nil {
+				slog.InfoContext(ctx, "Tool call completed", "tool", request.Params.Name, "is_error", result.IsError)
+				if result.Meta == nil {
+					result.Meta = &mcp.Meta{}
+				}
+				if result.Meta.AdditionalFields == nil {
+					result.Meta.AdditionalFields = map[string]any{}
+				}
+				result.Meta.AdditionalFields["correlation_id"] = id
+			}
+			return result, nil
+		}
+	}
+}
+
+// CredentialResolver returns the credential of the api_key or, when it is
+// empty, the credential_profile argument of a tool call.
+type CredentialResolver func(ctx context.Context, apiKey, profile string) (terramate.Credential, error)
+
+// CredentialOverrides returns a middleware that authenticates the Terramate
+// Cloud API requests of a call setting the api_key or credential_profile
+// argument with the credential resolve returns, instead of the server's. A nil
+// resolve rejects such calls, so they never silently run with the server's
+// credential.
+func CredentialOverrides(resolve CredentialResolver) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			apiKey, profile := request.GetString("api_key", ""), request.GetString("credential_profile", "")
+			switch {
+			case apiKey == "" && profile == "":
+				return next(ctx, request)
+			case resolve == nil:
+				return mcp.NewToolResultError("api_key and credential_profile are disabled; the server must be started with --allow-credential-override."), nil
+			case apiKey != "" && profile != "":
+				return mcp.NewToolResultError("Set either api_key or credential_profile, not both."), nil
+			}
+			credential, err := resolve(ctx, apiKey, profile)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to load the credential of this call: %v", err)), nil
+			}
+			slog.DebugContext(ctx, "Tool call uses an overriding credential", "tool", request.Params.Name, "credential", credential.Name(), "profile", profile)
+			return next(terramate.WithCredential(ctx, credential), request)
+		}
+	}
+}
+
+// OrganizationCredentialResolver returns the credential authenticating the
+// calls targeting the organization orgUUID, or nil to use the server's.
+type OrganizationCredentialResolver func(ctx context.Context, orgUUID string) (terramate.Credential, error)
+
+// OrganizationCredentials returns a middleware that authenticates the
+// Terramate Cloud API requests of a call with the credential resolve returns
+// for its organization_uuid argument, so calls targeting organizations with
+// their own API key use it transparently. It must run after Sessions, which
+// fills in the default organization, and after CredentialOverrides: a
+// credential set with api_key or credential_profile takes precedence.
+func OrganizationCredentials(resolve OrganizationCredentialResolver) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID := request.GetString("organization_uuid", "")
+			if resolve == nil || orgUUID == "" || terramate.CredentialFromContext(ctx) != nil {
+				return next(ctx, request)
+			}
+			credential, err := resolve(ctx, orgUUID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to load the credential of organization %s: %v", orgUUID, err)), nil
+			}
+			if credential == nil {
+				return next(ctx, request)
+			}
+			slog.DebugContext(ctx, "Tool call uses the credential of its organization", "tool", request.Params.Name, "organization_uuid", orgUUID, "credential", credential.Name())
+			return next(terramate.WithCredential(ctx, credential), request)
+		}
+	}
+}
+
+// RecordCalls returns a middleware that records every tool call in log for
+// the debug tools, except the calls of the debug tools themselves.
+func RecordCalls(log *tmc.CallLog) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			switch request.Params.Name {
+			case tmc.ReplayLastToolName, tmc.InspectCallToolName:
+				return next(ctx, request)
+			}
+			ctx, call := log.Start(ctx, request)
+			result, err := next(ctx, request)
+			log.Finish(call, result, err)
+			return result, err
+		}
+	}
+}
+
+// RedactErrors returns a middleware that redacts secrets (see
+// terramate.Redact) from the text of error results and from returned errors,
+// so a failed token refresh or an API error echoing a credential does not
+// leak it into agent transcripts. Successful results are left untouched.
+func RedactErrors() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if err != nil {
+				err = redactedError{err}
+			}
+			if result != nil && result.IsError {
+				for i, content := range result.Content {
+					if text, ok := mcp.AsTextContent(content); ok {
+						text.Text = terramate.Redact(text.Text)
+						result.Content[i] = *text
+					}
+				}
+			}
+			return result, err
+		}
+	}
+}
+
+// Reauthentication returns a middleware that replaces the error result of a
+// call failing while expired reports a terramate.ErrSessionExpired refresh
+// error with tmc.SessionExpiredResult, so the agent can offer the user a
+// one-shot re-login instead of generic login instructions. The login of the
+// result is started with login, which may be nil or return a nil code when no
+// device login is available; its code is shared by the failing calls until it
+// expires or the session is restored.
+func Reauthentication(expired func() error, login tmc.LoginFunc) server.ToolHandlerMiddleware {
+	var (
+		mu      sync.Mutex
+		pending *terramate.DeviceCode
+	)
+	pendingLogin := func(ctx context.Context) *terramate.DeviceCode {
+		mu.Lock()
+		defer mu.Unlock()
+		if login == nil || (pending != nil && time.Now().Before(pending.ExpiresAt)) {
+			return pending
+		}
+		code, err := login(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to start the re-login", "error", err)
+			return nil
+		}
+		pending = code
+		return pending
+	}
+
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			cause := expired()
+			if !errors.Is(cause, terramate.ErrSessionExpired) {
+				// Logged in again: a pending code is used or obsolete
+				mu.Lock()
+				pending = nil
+				mu.Unlock()
+				return result, err
+			}
+			if err != nil || result == nil || !result.IsError || request.Params.Name == tmc.LoginToolName {
+				return result, err
+			}
+			return tmc.SessionExpiredResult(cause, pendingLogin(ctx)), nil
+		}
+	}
+}
+
+// redactedError is an error whose message is redacted, keeping the wrapped
+// error available to errors.Is and errors.As.
+type redactedError struct {
+	err error
+}
+
+func (e redactedError) Error() string { return terramate.Redact(e.err.Error()) }
+
+func (e redactedError) Unwrap() error { return e.err }
+
+// validCorrelationID reports whether id is safe to send as an HTTP header
+// value and to log.
+func validCorrelationID(id string) bool {
+	if len(id) > maxCorrelationIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// withCorrelationIDArgument documents the correlation_id argument, which
+// CorrelationIDs accepts on every tool, in the input schema of tool.
+func withCorrelationIDArgument(tool server.ServerTool) server.ServerTool {
+	properties := make(map[string]any, len(tool.Tool.InputSchema.Properties)+1)
+	for k, v := range tool.Tool.InputSchema.Properties {
+		properties[k] = v
+	}
+	properties["correlation_id"] = map[string]any{
+		"type":        "string",
+		"description": "Optional ID from your ticketing or workflow system, echoed in the result's _meta and sent with the Terramate Cloud API requests of this call",
+	}
+	tool.Tool.InputSchema.Properties = properties
+	return tool
+}
+
+// withCredentialOverrideArguments documents the api_key and
+// credential_profile arguments, which CredentialOverrides accepts, in the
+// input schema of tool.
+func withCredentialOverrideArguments(tool server.ServerTool) server.ServerTool {
+	properties := make(map[string]any, len(tool.Tool.InputSchema.Properties)+2)
+	for k, v := range tool.Tool.InputSchema.Properties {
+		properties[k] = v
+	}
+	properties["api_key"] = map[string]any{
+		"type":        "string",
+		"description": "Optional Terramate Cloud API key authenticating this call instead of the server's credential, e.g. to query another organization",
+	}
+	properties["credential_profile"] = map[string]any{
+		"type":        "string",
+		"description": "Optional config file profile whose credential authenticates this call instead of the server's credential",
+	}
+	tool.Tool.InputSchema.Properties = properties
+	return tool
+}
+
+// sessionID returns the MCP session ID of the calling client, or "" when the
+// transport has no sessions.
+func sessionID(ctx context.Context) string {
+	if clientSession := server.ClientSessionFromContext(ctx); clientSession != nil {
+		return clientSession.SessionID()
+	}
+	return ""
+}
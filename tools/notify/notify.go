@@ -0,0 +1,172 @@
+// Package notify renders drift and deployment digests as chat and webhook
+// payloads and posts them, so scheduled runs can notify a channel directly.
+//
+// Two formats are supported: Slack Block Kit messages for Slack incoming
+// webhooks, and a generic JSON document for any other webhook receiver.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Payload formats.
+const (
+	FormatSlack   = "slack"
+	FormatWebhook = "webhook"
+)
+
+// WebhookType identifies digests in generic webhook payloads.
+const WebhookType = "terramate.digest"
+
+// Digest summarizes the drifts and deployments of an organization.
+type Digest struct {
+	Title        string            `json:"title"`
+	Organization string            `json:"organization,omitempty"`
+	GeneratedAt  time.Time         `json:"generated_at"`
+	Drifts       []DriftEntry      `json:"drifts"`
+	Deployments  []DeploymentEntry `json:"deployments"`
+}
+
+// DriftEntry is a stack whose drift detection found changes or failed.
+type DriftEntry struct {
+	Repository string     `json:"repository"`
+	Path       string     `json:"path"`
+	Target     string     `json:"target,omitempty"`
+	Status     string     `json:"status"` // drifted, failed
+	DetectedAt *time.Time `json:"detected_at,omitempty"`
+	URL        string     `json:"url,omitempty"`
+}
+
+// DeploymentEntry is a stack deployment.
+type DeploymentEntry struct {
+	Repository  string     `json:"repository"`
+	Path        string     `json:"path"`
+	Status      string     `json:"status"` // ok, failed, canceled, ...
+	CommitTitle string     `json:"commit_title,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+	URL         string     `json:"url,omitempty"`
+}
+
+// Target is where a digest is posted.
+type Target struct {
+	URL    string
+	Format string // FormatSlack or FormatWebhook
+}
+
+// ParseTarget parses a post_to value: a URL optionally prefixed with its
+// format, e.g. "slack:https://hooks.slack.com/services/..." or
+// "webhook:https://ops.example.com/hooks/terramate". Without a prefix, Slack
+// webhook hosts get the Slack format and any other URL the webhook format.
+func ParseTarget(s string) (Target, error) {
+	format, rawURL := "", s
+	for _, prefix := range []string{FormatSlack, FormatWebhook} {
+		if rest, ok := strings.CutPrefix(s, prefix+":"); ok && !strings.HasPrefix(rest, "//") {
+			format, rawURL = prefix, rest
+			break
+		}
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return Target{}, fmt.Errorf("invalid target %q: must be an http(s) URL, optionally prefixed with slack: or webhook:", s)
+	}
+	if format == "" {
+		format = FormatWebhook
+		if u.Hostname() == "hooks.slack.com" {
+			format = FormatSlack
+		}
+	}
+	return Target{URL: u.String(), Format: format}, nil
+}
+
+// Render encodes d in format.
+func Render(d Digest, format string) ([]byte, error) {
+	switch format {
+	case FormatSlack:
+		return json.Marshal(slackMessage(d))
+	case FormatWebhook:
+		return json.Marshal(webhookPayload{Type: WebhookType, Summary: summarize(d), Digest: d})
+	default:
+		return nil, fmt.Errorf("unknown format %q (must be %s or %s)", format, FormatSlack, FormatWebhook)
+	}
+}
+
+// webhookPayload is the document posted to generic webhooks.
+type webhookPayload struct {
+	Type    string  `json:"type"`
+	Summary Summary `json:"summary"`
+	Digest  Digest  `json:"digest"`
+}
+
+// Summary counts the entries of a digest.
+type Summary struct {
+	Drifted           int `json:"drifted"`
+	DriftFailed       int `json:"drift_failed"`
+	Deployments       int `json:"deployments"`
+	FailedDeployments int `json:"failed_deployments"`
+}
+
+func summarize(d Digest) Summary {
+	s := Summary{Deployments: len(d.Deployments)}
+	for _, drift := range d.Drifts {
+		if drift.Status == "failed" {
+			s.DriftFailed++
+		} else {
+			s.Drifted++
+		}
+	}
+	for _, deployment := range d.Deployments {
+		if deployment.Status == "failed" {
+			s.FailedDeployments++
+		}
+	}
+	return s
+}
+
+// Poster posts digests to targets.
+type Poster struct {
+	httpClient *http.Client
+}
+
+// NewPoster creates a Poster using httpClient (a client with a 30s timeout
+// when nil).
+func NewPoster(httpClient *http.Client) *Poster {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return &Poster{httpClient: httpClient}
+}
+
+// Post renders d in the target's format and posts it.
+func (p *Poster) Post(ctx context.Context, target Target, d Digest) error {
+	payload, err := Render(d, target.Format)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post digest: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("posting digest failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
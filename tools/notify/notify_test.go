@@ -0,0 +1,124 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/internal/golden"
+)
+
+func testDigest() Digest {
+	detectedAt := time.Date(2026, 1, 7, 10, 30, 0, 0, time.UTC)
+	finishedAt := time.Date(2026, 1, 7, 11, 0, 0, 0, time.UTC)
+	return Digest{
+		Title:        "Weekly infrastructure digest",
+		Organization: "acme",
+		GeneratedAt:  time.Date(2026, 1, 8, 9, 0, 0, 0, time.UTC),
+		Drifts: []DriftEntry{
+			{Repository: "github.com/acme/infra", Path: "/stacks/web", Target: "prod", Status: "drifted", DetectedAt: &detectedAt, URL: "https://cloud.terramate.io/o/acme/stacks/456"},
+			{Repository: "github.com/acme/infra", Path: "/stacks/<db>", Status: "failed"},
+		},
+		Deployments: []DeploymentEntry{
+			{Repository: "github.com/acme/infra", Path: "/stacks/api", Status: "failed", CommitTitle: "Bump instance size & disk", FinishedAt: &finishedAt, URL: "https://cloud.terramate.io/o/acme/deployments/9"},
+		},
+	}
+}
+
+func assertRenderSnapshot(t *testing.T, name, format string) {
+	t.Helper()
+	payload, err := Render(testDigest(), format)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, payload, "", "  "); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	out.WriteByte('\n')
+	golden.Assert(t, name, out.Bytes())
+}
+
+func TestSnapshot_Webhook(t *testing.T) {
+	assertRenderSnapshot(t, "webhook_digest", FormatWebhook)
+}
+
+func TestRender_UnknownFormat(t *testing.T) {
+	if _, err := Render(testDigest(), "teams"); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Target
+		wantErr bool
+	}{
+		{in: "https://hooks.slack.com/services/T/B/X", want: Target{URL: "https://hooks.slack.com/services/T/B/X", Format: FormatSlack}},
+		{in: "https://ops.example.com/hooks", want: Target{URL: "https://ops.example.com/hooks", Format: FormatWebhook}},
+		{in: "slack:https://chat.example.com/hook", want: Target{URL: "https://chat.example.com/hook", Format: FormatSlack}},
+		{in: "webhook:https://hooks.slack.com/services/T/B/X", want: Target{URL: "https://hooks.slack.com/services/T/B/X", Format: FormatWebhook}},
+		{in: "ftp://example.com/hook", wantErr: true},
+		{in: "slack:", wantErr: true},
+		{in: "#ops", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseTarget(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTarget() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoster_Post(t *testing.T) {
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected request %s with Content-Type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	err := NewPoster(ts.Client()).Post(context.Background(), Target{URL: ts.URL, Format: FormatWebhook}, testDigest())
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+
+	var payload struct {
+		Type    string  `json:"type"`
+		Summary Summary `json:"summary"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	want := Summary{Drifted: 1, DriftFailed: 1, Deployments: 1, FailedDeployments: 1}
+	if payload.Type != WebhookType || payload.Summary != want {
+		t.Errorf("payload = %+v, want type %q and summary %+v", payload, WebhookType, want)
+	}
+}
+
+func TestPoster_PostError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	err := NewPoster(ts.Client()).Post(context.Background(), Target{URL: ts.URL, Format: FormatSlack}, testDigest())
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "invalid_token") {
+		t.Errorf("Post() error = %v, want the status and response body", err)
+	}
+}
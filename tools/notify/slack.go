@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxSlackEntries caps the entries listed per section, keeping messages within
+// Slack's 3000 character limit for section text.
+const maxSlackEntries = 15
+
+// slackPayload is a Slack incoming webhook message.
+type slackPayload struct {
+	Text   string       `json:"text"` // notification fallback
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// slackBlock is a Block Kit layout block.
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+// slackText is a Block Kit text object.
+type slackText struct {
+	Type string `json:"type"` // plain_text, mrkdwn
+	Text string `json:"text"`
+}
+
+func slackMessage(d Digest) slackPayload {
+	summary := summarize(d)
+	headline := fmt.Sprintf("%d drifted, %d failed drift checks, %d of %d deployments failed",
+		summary.Drifted, summary.DriftFailed, summary.FailedDeployments, summary.Deployments)
+
+	context := "Generated " + d.GeneratedAt.UTC().Format("2006-01-02 15:04 UTC")
+	if d.Organization != "" {
+		context = d.Organization + " · " + context
+	}
+
+	blocks := []slackBlock{
+		{Type: "header", Text: &slackText{Type: "plain_text", Text: d.Title}},
+		{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: slackEscape(context)}}},
+		{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*" + headline + "*"}},
+	}
+
+	if len(d.Drifts) > 0 {
+		lines := make([]string, len(d.Drifts))
+		for i, drift := range d.Drifts {
+			lines[i] = fmt.Sprintf("• %s — %s", slackStack(drift.Path, drift.Target, drift.URL), drift.Status)
+			if drift.DetectedAt != nil {
+				lines[i] += " · " + slackTime(*drift.DetectedAt)
+			}
+		}
+		blocks = append(blocks, slackBlock{Type: "divider"}, slackSection("Drift", lines))
+	}
+
+	if len(d.Deployments) > 0 {
+		lines := make([]string, len(d.Deployments))
+		for i, deployment := range d.Deployments {
+			lines[i] = fmt.Sprintf("• %s — %s", slackStack(deployment.Path, "", deployment.URL), deployment.Status)
+			if deployment.CommitTitle != "" {
+				lines[i] += " · " + slackEscape(deployment.CommitTitle)
+			}
+		}
+		blocks = append(blocks, slackBlock{Type: "divider"}, slackSection("Deployments", lines))
+	}
+
+	return slackPayload{Text: d.Title + ": " + headline, Blocks: blocks}
+}
+
+// slackSection lists lines under a bold heading, eliding the lines beyond
+// maxSlackEntries.
+func slackSection(heading string, lines []string) slackBlock {
+	if len(lines) > maxSlackEntries {
+		more := len(lines) - maxSlackEntries
+		lines = append(lines[:maxSlackEntries:maxSlackEntries], fmt.Sprintf("_…and %d more_", more))
+	}
+	return slackBlock{Type: "section", Text: &slackText{
+		Type: "mrkdwn",
+		Text: fmt.Sprintf("*%s*\n%s", heading, strings.Join(lines, "\n")),
+	}}
+}
+
+// slackStack renders a stack path, with its target, linked to url if set.
+func slackStack(path, target, url string) string {
+	label := path
+	if target != "" {
+		label += " (" + target + ")"
+	}
+	if url == "" {
+		return "`" + slackEscape(label) + "`"
+	}
+	return "<" + url + "|" + slackEscape(label) + ">"
+}
+
+func slackTime(t time.Time) string {
+	return t.UTC().Format("Jan 2 15:04 UTC")
+}
+
+// slackEscape escapes the characters Slack mrkdwn treats as control
+// characters.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
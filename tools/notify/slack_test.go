@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSnapshot_Slack(t *testing.T) {
+	assertRenderSnapshot(t, "slack_digest", FormatSlack)
+}
+
+func TestSlackMessage_CapsEntries(t *testing.T) {
+	d := Digest{Title: "Drift"}
+	for i := 0; i < maxSlackEntries+5; i++ {
+		d.Drifts = append(d.Drifts, DriftEntry{Path: fmt.Sprintf("/stacks/s%d", i), Status: "drifted"})
+	}
+
+	msg := slackMessage(d)
+	section := msg.Blocks[len(msg.Blocks)-1].Text.Text
+	if got := strings.Count(section, "• "); got != maxSlackEntries {
+		t.Errorf("listed %d entries, want %d", got, maxSlackEntries)
+	}
+	if !strings.Contains(section, "…and 5 more") {
+		t.Errorf("section does not mention the elided entries:\n%s", section)
+	}
+}
+
+func TestSlackMessage_QuietDigest(t *testing.T) {
+	d := testDigest()
+	d.Organization = ""
+	d.Drifts = nil
+	d.Deployments = nil
+
+	msg := slackMessage(d)
+	if msg.Text != "Weekly infrastructure digest: 0 drifted, 0 failed drift checks, 0 of 0 deployments failed" {
+		t.Errorf("unexpected fallback text %q", msg.Text)
+	}
+	if len(msg.Blocks) != 3 {
+		t.Fatalf("expected only the header, context and headline blocks, got %+v", msg.Blocks)
+	}
+	if got := msg.Blocks[1].Elements[0].Text; got != "Generated 2026-01-08 09:00 UTC" {
+		t.Errorf("unexpected context %q", got)
+	}
+}
+
+func TestSlackStack(t *testing.T) {
+	tests := []struct {
+		path, target, url string
+		want              string
+	}{
+		{path: "/stacks/web", want: "`/stacks/web`"},
+		{path: "/stacks/web", target: "prod", want: "`/stacks/web (prod)`"},
+		{path: "/stacks/<a&b>", url: "https://cloud.terramate.io/s/1", want: "<https://cloud.terramate.io/s/1|/stacks/&lt;a&amp;b&gt;>"},
+	}
+	for _, tt := range tests {
+		if got := slackStack(tt.path, tt.target, tt.url); got != tt.want {
+			t.Errorf("slackStack(%q, %q, %q) = %q, want %q", tt.path, tt.target, tt.url, got, tt.want)
+		}
+	}
+}
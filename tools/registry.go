@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// ToolsetFactory builds the tools of an external toolset from the Terramate
+// Cloud client of the server. It is called whenever the tools are registered,
+// i.e. at startup and on every configuration reload.
+type ToolsetFactory func(client *terramate.Client) []server.ServerTool
+
+// externalToolsets are the toolsets added with RegisterToolset, by name.
+var externalToolsets = struct {
+	sync.RWMutex
+	factories map[string]ToolsetFactory
+}{factories: map[string]ToolsetFactory{}}
+
+// RegisterToolset adds an external toolset, letting downstream builds compile
+// in their own tools without changing the tool registration code. Call it
+// from the init function of a package imported by the server binary, e.g.
+//
+//	func init() {
+//		tools.RegisterToolset("acme", func(client *terramate.Client) []server.ServerTool {
+//			return []server.ServerTool{acmeTool(client)}
+//		})
+//	}
+//
+// The toolset is enabled like the built-in ones (--toolsets acme) and its
+// tools are served once a Terramate Cloud credential is configured. Tools
+// named like an already registered tool are skipped with a warning.
+//
+// RegisterToolset panics if name is empty, factory is nil, or name is already
+// used by a toolset or toolset group.
+func RegisterToolset(name string, factory ToolsetFactory) {
+	if name == "" || factory == nil {
+		panic("tools: RegisterToolset requires a name and a factory")
+	}
+	externalToolsets.Lock()
+	defer externalToolsets.Unlock()
+	if slices.Contains(builtinToolsets(), name) || toolsetGroups[name] != nil || externalToolsets.factories[name] != nil {
+		panic(fmt.Sprintf("tools: toolset %q is already registered", name))
+	}
+	externalToolsets.factories[name] = factory
+}
+
+// externalToolsetNames lists the registered external toolsets, sorted.
+func externalToolsetNames() []string {
+	externalToolsets.RLock()
+	defer externalToolsets.RUnlock()
+	names := make([]string, 0, len(externalToolsets.factories))
+	for name := range externalToolsets.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// externalTools appends the tools of the enabled external toolsets to tools,
+// skipping those named like a tool already in the list.
+func (th *ToolHandlers) externalTools(tools []server.ServerTool) []server.ServerTool {
+	registered := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		registered[tool.Tool.Name] = true
+	}
+	for _, name := range externalToolsetNames() {
+		if !th.enabled(name) {
+			continue
+		}
+		externalToolsets.RLock()
+		factory := externalToolsets.factories[name]
+		externalToolsets.RUnlock()
+		for _, tool := range factory(th.tmcClient) {
+			if registered[tool.Tool.Name] {
+				slog.Warn("Skipping tool of external toolset: name already registered", "toolset", name, "tool", tool.Tool.Name)
+				continue
+			}
+			registered[tool.Tool.Name] = true
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// registerTestToolset registers an external toolset for the duration of the test.
+func registerTestToolset(t *testing.T, name string, factory ToolsetFactory) {
+	t.Helper()
+	RegisterToolset(name, factory)
+	t.Cleanup(func() {
+		externalToolsets.Lock()
+		delete(externalToolsets.factories, name)
+		externalToolsets.Unlock()
+	})
+}
+
+func TestRegisterToolset(t *testing.T) {
+	var got *terramate.Client
+	registerTestToolset(t, "acme", func(client *terramate.Client) []server.ServerTool {
+		got = client
+		handler := func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) { return nil, nil }
+		return []server.ServerTool{
+			{Tool: mcp.NewTool("acme_list_services"), Handler: handler},
+			{Tool: mcp.NewTool("tmc_list_stacks"), Handler: handler}, // clashes with a built-in tool
+		}
+	})
+	c, err := terramate.NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	if err := ValidateToolsets([]string{"acme"}); err != nil {
+		t.Fatalf("expected the external toolset to be accepted: %v", err)
+	}
+	count := map[string]int{}
+	for _, tool := range New(c).Tools() {
+		count[tool.Tool.Name]++
+	}
+	if got != c || count["acme_list_services"] != 1 || count["tmc_list_stacks"] != 1 {
+		t.Fatalf("expected the external tool to be registered once, without replacing built-in tools: %v", count)
+	}
+	for _, tool := range New(c, WithToolsets([]string{ToolsetStacks})).Tools() {
+		if tool.Tool.Name == "acme_list_services" {
+			t.Fatal("expected a disabled external toolset to be omitted")
+		}
+	}
+	for _, tool := range New(nil).Tools() {
+		if tool.Tool.Name == "acme_list_services" {
+			t.Fatal("expected external toolsets to wait for a Terramate Cloud client")
+		}
+	}
+}
+
+func TestRegisterToolset_PanicsOnDuplicate(t *testing.T) {
+	for _, name := range []string{ToolsetStacks, ToolsetReviews, ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected registering toolset %q to panic", name)
+				}
+			}()
+			RegisterToolset(name, func(*terramate.Client) []server.ServerTool { return nil })
+		}()
+	}
+}
@@ -24,11 +24,20 @@ This tool verifies the API key is valid and returns essential organization detai
 - User's role (admin or member)
 - Membership status
 
-Use this tool first before calling other Terramate Cloud operations to get the organization UUID.`,
+Use this tool first before calling other Terramate Cloud operations to get the organization UUID.
+
+The selected organization becomes the default for this client session: later tool calls may
+omit organization_uuid. With a single membership it is selected automatically; with several,
+pass organization_uuid to choose one.`,
 			InputSchema: mcp.ToolInputSchema{
-				Type:       "object",
-				Properties: map[string]interface{}{},
-				Required:   []string{},
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization to select as the session default (required when you belong to several)",
+					},
+				},
+				Required: []string{},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -49,6 +58,25 @@ Use this tool first before calling other Terramate Cloud operations to get the o
 				return mcp.NewToolResultError("No organization memberships found for this API key"), nil
 			}
 
+			session := SessionFromContext(ctx)
+			session.SetMemberships(memberships)
+
+			// Select the session's default organization
+			selected := request.GetString("organization_uuid", "")
+			if selected != "" && !hasMembership(memberships, selected) {
+				return mcp.NewToolResultError(fmt.Sprintf("Organization %s is not one of your memberships.", selected)), nil
+			}
+			if selected == "" && len(memberships) == 1 {
+				selected = memberships[0].OrgUUID
+			}
+			if selected != "" && selected != session.DefaultOrganization() {
+				session.SetDefaultOrganization(selected)
+				// The tools offered depend on the selected organization's plan
+				if srv := server.ServerFromContext(ctx); srv != nil {
+					_ = srv.SendNotificationToClient(ctx, mcp.MethodNotificationToolsListChanged, nil)
+				}
+			}
+
 			// Format response with all memberships
 			response := map[string]interface{}{
 				"authenticated": true,
@@ -65,6 +93,9 @@ Use this tool first before calling other Terramate Cloud operations to get the o
 				response["role"] = memberships[0].Role
 				response["status"] = memberships[0].Status
 			}
+			if selected != "" {
+				response["default_organization_uuid"] = selected
+			}
 
 			jsonData, err := json.MarshalIndent(response, "", "  ")
 			if err != nil {
@@ -75,3 +106,52 @@ Use this tool first before calling other Terramate Cloud operations to get the o
 		},
 	}
 }
+
+// Connect creates the tmc_authenticate tool served while no Terramate Cloud
+// credential is configured. It calls connect to load the credential; once that
+// succeeds, the Terramate Cloud tools are registered and the call is handed to
+// their tmc_authenticate.
+func Connect(connect func(context.Context) error) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_authenticate",
+			Description: `Connect to Terramate Cloud and retrieve organization membership information.
+
+No Terramate Cloud credential is configured yet, so only the local tools are available. Ask the
+user to run 'terramate cloud login' (or to configure an API key), then call this tool: it loads
+the credential, makes the Terramate Cloud tools available and returns the organization details.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization to select as the session default (required when you belong to several)",
+					},
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if err := connect(ctx); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Not connected to Terramate Cloud: %v\n\n"+
+					"Run 'terramate cloud login' in a terminal (or configure an API key), then call tmc_authenticate again.", err)), nil
+			}
+			if srv := server.ServerFromContext(ctx); srv != nil {
+				if tool := srv.GetTool(request.Params.Name); tool != nil {
+					return tool.Handler(ctx, request)
+				}
+			}
+			return mcp.NewToolResultText("Connected to Terramate Cloud. Call tmc_authenticate again to select an organization."), nil
+		},
+	}
+}
+
+// hasMembership reports whether memberships include orgUUID.
+func hasMembership(memberships []terramate.Membership, orgUUID string) bool {
+	for _, m := range memberships {
+		if m.OrgUUID == orgUUID {
+			return true
+		}
+	}
+	return false
+}
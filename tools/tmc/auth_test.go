@@ -144,3 +144,48 @@ func TestAuthenticate_ServerError(t *testing.T) {
 		t.Fatalf("expected error result for 500")
 	}
 }
+
+func TestAuthenticate_SelectsSessionDefault(t *testing.T) {
+	payload := `[{"org_uuid":"org-a","org_name":"a"},{"org_uuid":"org-b","org_name":"b"}]`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer ts.Close()
+
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	tool := Authenticate(c)
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "several memberships without selection", args: nil, want: ""},
+		{name: "explicit selection", args: map[string]interface{}{"organization_uuid": "org-b"}, want: "org-b"},
+		{name: "unknown organization", args: map[string]interface{}{"organization_uuid": "org-x"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := &Session{}
+			ctx := WithSession(context.Background(), session)
+			result, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tt.args}})
+			if err != nil {
+				t.Fatalf("Handler error: %v", err)
+			}
+			if result.IsError != tt.wantErr {
+				t.Fatalf("expected IsError=%v, got %v", tt.wantErr, result.IsError)
+			}
+			if got := session.DefaultOrganization(); got != tt.want {
+				t.Fatalf("expected default organization %q, got %q", tt.want, got)
+			}
+			if memberships, ok := session.Memberships(); !ok || len(memberships) != 2 {
+				t.Fatalf("expected memberships to be cached, got %v", memberships)
+			}
+		})
+	}
+}
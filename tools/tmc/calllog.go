@@ -0,0 +1,259 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// DefaultCallLogSize is how many tool calls a CallLog keeps by default.
+const DefaultCallLogSize = 50
+
+// maxRecordedResultBytes bounds the result text kept per call, so large
+// results such as deployment logs do not pin memory.
+const maxRecordedResultBytes = 16 << 10
+
+// redacted replaces secrets in recorded calls.
+const redacted = terramate.Redacted
+
+// sensitiveKey matches argument, field and query parameter names whose
+// values are redacted.
+var sensitiveKey = regexp.MustCompile(`(?i)token|secret|password|passwd|api[_-]?key|authorization|credential|cookie`)
+
+// RecordedCall is a tool invocation kept by a CallLog, with secrets redacted.
+type RecordedCall struct {
+	ID              string            `json:"id"`
+	Tool            string            `json:"tool"`
+	StartedAt       time.Time         `json:"started_at"`
+	Duration        string            `json:"duration"`
+	Arguments       map[string]any    `json:"arguments"`
+	APIRequests     []RecordedRequest `json:"api_requests"`
+	IsError         bool              `json:"is_error"`
+	Error           string            `json:"error,omitempty"`
+	Result          string            `json:"result,omitempty"`
+	ResultTruncated bool              `json:"result_truncated,omitempty"`
+
+	session *Session   // calls are only shown to the session that made them
+	mu      sync.Mutex // guards APIRequests, appended by concurrent fan-out requests
+}
+
+// RecordedRequest is a Terramate Cloud API request made during a call.
+type RecordedRequest struct {
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	Query    string `json:"query,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+// CallLog keeps the most recent tool calls in a ring buffer so the debug
+// tools can show what an earlier call sent and received.
+type CallLog struct {
+	mu    sync.Mutex
+	calls []*RecordedCall // ring buffer; next is the oldest once full
+	next  int
+	seq   int
+}
+
+// NewCallLog creates a call log keeping the last size calls; a non-positive
+// size keeps DefaultCallLogSize.
+func NewCallLog(size int) *CallLog {
+	if size <= 0 {
+		size = DefaultCallLogSize
+	}
+	return &CallLog{calls: make([]*RecordedCall, 0, size)}
+}
+
+// Size returns how many calls the log keeps.
+func (l *CallLog) Size() int {
+	return cap(l.calls)
+}
+
+// Start records the beginning of a call made by the session in ctx. The
+// returned context collects the API requests of the call (see
+// CallLogInstrumentation); pass the call to Finish once it returns.
+func (l *CallLog) Start(ctx context.Context, request mcp.CallToolRequest) (context.Context, *RecordedCall) {
+	call := &RecordedCall{
+		Tool:        request.Params.Name,
+		StartedAt:   time.Now(),
+		Arguments:   redactMap(request.GetArguments()),
+		APIRequests: []RecordedRequest{},
+		session:     SessionFromContext(ctx),
+	}
+
+	l.mu.Lock()
+	l.seq++
+	call.ID = "call-" + strconv.Itoa(l.seq)
+	if len(l.calls) < cap(l.calls) {
+		l.calls = append(l.calls, call)
+	} else {
+		l.calls[l.next] = call
+		l.next = (l.next + 1) % len(l.calls)
+	}
+	l.mu.Unlock()
+
+	return context.WithValue(ctx, recordedCallKey{}, call), call
+}
+
+// Finish records the outcome of call.
+func (l *CallLog) Finish(call *RecordedCall, result *mcp.CallToolResult, err error) {
+	call.mu.Lock()
+	defer call.mu.Unlock()
+	call.Duration = time.Since(call.StartedAt).Round(time.Millisecond).String()
+	if err != nil {
+		call.IsError = true
+		call.Error = redactText(err.Error())
+		return
+	}
+	if result == nil {
+		return
+	}
+	call.IsError = result.IsError
+	var text strings.Builder
+	for _, content := range result.Content {
+		if c, ok := content.(mcp.TextContent); ok {
+			text.WriteString(c.Text)
+		}
+	}
+	call.Result = redactResult(text.String())
+	if len(call.Result) > maxRecordedResultBytes {
+		call.Result = strings.ToValidUTF8(call.Result[:maxRecordedResultBytes], "")
+		call.ResultTruncated = true
+	}
+}
+
+// Recent returns up to n calls of session, most recent first, optionally
+// only those of tool.
+func (l *CallLog) Recent(session *Session, tool string, n int) []*RecordedCall {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var calls []*RecordedCall
+	for i := len(l.calls) - 1; i >= 0 && len(calls) < n; i-- {
+		call := l.calls[(l.next+i)%len(l.calls)]
+		if call.session == session && (tool == "" || call.Tool == tool) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Get returns the call of session with id, if still kept.
+func (l *CallLog) Get(session *Session, id string) (*RecordedCall, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, call := range l.calls {
+		if call.ID == id && call.session == session {
+			return call, true
+		}
+	}
+	return nil, false
+}
+
+// MarshalJSON implements json.Marshaler, holding the call's lock so a call
+// still in flight can be shown.
+func (c *RecordedCall) MarshalJSON() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	type plain RecordedCall
+	return json.Marshal((*plain)(c))
+}
+
+type recordedCallKey struct{}
+
+// CallLogInstrumentation adds the Terramate Cloud API requests made on behalf
+// of a recorded call to that call.
+type CallLogInstrumentation struct {
+	terramate.NopInstrumentation
+}
+
+// OnRequestEnd implements terramate.Instrumentation.
+func (CallLogInstrumentation) OnRequestEnd(ctx context.Context, info terramate.RequestInfo) {
+	call, ok := ctx.Value(recordedCallKey{}).(*RecordedCall)
+	if !ok {
+		return
+	}
+	req := RecordedRequest{
+		Method:   info.Method,
+		Path:     info.Path,
+		Query:    redactQuery(info.Query),
+		Status:   info.StatusCode,
+		Duration: info.Duration.Round(time.Millisecond).String(),
+	}
+	if info.Err != nil {
+		req.Error = redactText(info.Err.Error())
+	}
+	call.mu.Lock()
+	call.APIRequests = append(call.APIRequests, req)
+	call.mu.Unlock()
+}
+
+// redactResult redacts a result, field by field when it is JSON.
+func redactResult(text string) string {
+	var v any
+	if err := json.Unmarshal([]byte(text), &v); err != nil {
+		return redactText(text)
+	}
+	data, err := json.MarshalIndent(redactValue(v), "", "  ")
+	if err != nil {
+		return redactText(text)
+	}
+	return string(data)
+}
+
+// redactMap returns a copy of m with secrets redacted.
+func redactMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if sensitiveKey.MatchString(k) {
+			out[k] = redacted
+			continue
+		}
+		out[k] = redactValue(v)
+	}
+	return out
+}
+
+// redactValue redacts secrets in a decoded JSON value.
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return redactMap(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = redactValue(e)
+		}
+		return out
+	case string:
+		return redactText(v)
+	default:
+		return v
+	}
+}
+
+// redactText redacts the secrets found by terramate.Redact.
+func redactText(s string) string {
+	return terramate.Redact(s)
+}
+
+// redactQuery redacts the values of sensitive query parameters, keeping the
+// query otherwise as sent.
+func redactQuery(query string) string {
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(key); err == nil && sensitiveKey.MatchString(name) {
+			params[i] = key + "=" + redacted
+		}
+	}
+	return redactText(strings.Join(params, "&"))
+}
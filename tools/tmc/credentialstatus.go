@@ -0,0 +1,111 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Credential types of CredentialStatus.
+const (
+	CredentialTypeAPIKey = "api_key"
+	CredentialTypeJWT    = "jwt"
+	CredentialTypeOIDC   = "oidc"
+	CredentialTypeDemo   = "demo"
+	CredentialTypeNone   = "none"
+)
+
+// CredentialStatus describes the credential of the server for
+// tmc_credential_status. It holds no secrets.
+type CredentialStatus struct {
+	// Type is one of the CredentialType constants.
+	Type string `json:"type"`
+	// Source describes where the credential was loaded from, e.g. "API key"
+	// or "credential file /home/me/.terramate.d/credentials.tmrc.json".
+	Source string `json:"source,omitempty"`
+	// Provider is the name of the credential, e.g. "Google" for a JWT.
+	Provider       string     `json:"provider,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Refreshable    bool       `json:"refreshable"`
+	CredentialFile string     `json:"credential_file,omitempty"`
+	// LastRefreshError is the error of the last token refresh, if it failed.
+	LastRefreshError string `json:"last_refresh_error,omitempty"`
+	// Reason explains why there is no credential.
+	Reason string `json:"reason,omitempty"`
+}
+
+// CredentialStatusFunc returns the current status of the credential.
+type CredentialStatusFunc func() CredentialStatus
+
+// credentialStatusResponse is the payload returned by tmc_credential_status.
+type credentialStatusResponse struct {
+	CredentialStatus
+	Expired bool     `json:"expired,omitempty"`
+	Hints   []string `json:"hints"`
+}
+
+// CredentialStatusTool creates an MCP tool reporting the status of the
+// credential of the server, as returned by status at the time of the call.
+func CredentialStatusTool(status CredentialStatusFunc) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_credential_status",
+			Description: `Report the Terramate Cloud credential of this MCP server and how to fix it.
+
+Use this tool when Terramate Cloud tools fail with authentication errors, or to answer
+"why are my Terramate calls failing?". No API request is made and no secret is returned.
+
+Response includes:
+- type: api_key, jwt, oidc (GitHub Actions or workload identity), demo, or none
+- source: Where the credential was loaded from, e.g. the credential file or the OS keychain
+- provider: Credential name, e.g. the identity provider of a JWT
+- expires_at, expired: Expiry of the current token, if it has one
+- refreshable: Whether an expired token is renewed automatically
+- credential_file: Credential file in use, if any
+- last_refresh_error: Error of the last token refresh, if it failed
+- reason: Why no credential is configured, for type none
+- hints: Actionable steps to fix the credential`,
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+				Required:   []string{},
+			},
+		},
+		Handler: func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			response := credentialStatusResponse{CredentialStatus: status()}
+			if response.ExpiresAt != nil {
+				response.Expired = time.Now().After(*response.ExpiresAt)
+			}
+			response.Hints = credentialHints(response)
+			jsonData, err := json.MarshalIndent(response, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// credentialHints returns the steps fixing the credential of status.
+func credentialHints(status credentialStatusResponse) []string {
+	var hints []string
+	switch {
+	case status.Type == CredentialTypeNone:
+		hints = append(hints, "No credential is configured: run 'terramate cloud login' (or tmc_login, if served), or start the server with --api-key.")
+	case status.Type == CredentialTypeAPIKey:
+		hints = append(hints, "API keys do not expire on their own; if calls fail with 401, check that the key was not revoked and that the region matches its organization (see tmc_server_info).")
+	case status.Expired && !status.Refreshable:
+		hints = append(hints, "The token expired and cannot be refreshed: run 'terramate cloud login' again or provide a new token.")
+	}
+	if status.LastRefreshError != "" {
+		hints = append(hints, "The last token refresh failed; if the error persists, run 'terramate cloud login' again.")
+	}
+	if len(hints) == 0 {
+		hints = append(hints, "The credential looks valid; if calls still fail, check the region (see tmc_server_info) and that organization_uuid is an organization of the credential (see tmc_authenticate).")
+	}
+	return hints
+}
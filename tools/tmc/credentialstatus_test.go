@@ -0,0 +1,56 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func callCredentialStatus(t *testing.T, status CredentialStatus) credentialStatusResponse {
+	t.Helper()
+	tool := CredentialStatusTool(func() CredentialStatus { return status })
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %v", textContent.Text)
+	}
+	var response credentialStatusResponse
+	if err := json.Unmarshal([]byte(textContent.Text), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	return response
+}
+
+func TestCredentialStatusTool(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+	response := callCredentialStatus(t, CredentialStatus{Type: CredentialTypeJWT, Provider: "Google", ExpiresAt: &expired})
+	if !response.Expired || len(response.Hints) != 1 || !strings.Contains(response.Hints[0], "cannot be refreshed") {
+		t.Fatalf("expected an expired, not refreshable JWT, got %+v", response)
+	}
+
+	response = callCredentialStatus(t, CredentialStatus{Type: CredentialTypeJWT, ExpiresAt: &expired, Refreshable: true, LastRefreshError: "invalid_grant"})
+	if len(response.Hints) != 1 || !strings.Contains(response.Hints[0], "last token refresh failed") {
+		t.Fatalf("expected the refresh error hint, got %+v", response)
+	}
+
+	response = callCredentialStatus(t, CredentialStatus{Type: CredentialTypeNone, Reason: "no credential found"})
+	if response.Reason != "no credential found" || !strings.Contains(response.Hints[0], "terramate cloud login") {
+		t.Fatalf("expected login instructions without a credential, got %+v", response)
+	}
+
+	valid := time.Now().Add(time.Hour)
+	response = callCredentialStatus(t, CredentialStatus{Type: CredentialTypeOIDC, ExpiresAt: &valid, Refreshable: true})
+	if response.Expired || !strings.Contains(response.Hints[0], "looks valid") {
+		t.Fatalf("expected a valid credential, got %+v", response)
+	}
+}
@@ -3,7 +3,9 @@ package tmc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -44,7 +46,7 @@ Response includes:
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"repository": map[string]interface{}{
 						"type":        "array",
@@ -73,7 +75,7 @@ Response includes:
 						"description": "Number of items per page (max: 100)",
 					},
 				},
-				Required: []string{"organization_uuid"},
+				Required: []string{},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -100,6 +102,9 @@ Response includes:
 
 			result, _, err := client.Deployments.List(ctx, orgUUID, opts)
 			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
@@ -146,14 +151,14 @@ which is useful for understanding what infrastructure changes were made.`,
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"stack_deployment_id": map[string]interface{}{
 						"type":        "number",
 						"description": "Stack Deployment ID",
 					},
 				},
-				Required: []string{"organization_uuid", "stack_deployment_id"},
+				Required: []string{"stack_deployment_id"},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -172,6 +177,9 @@ which is useful for understanding what infrastructure changes were made.`,
 
 			deployment, _, err := client.Deployments.GetStackDeployment(ctx, orgUUID, stackDeploymentID)
 			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
@@ -226,7 +234,7 @@ Note: Requires stack_id and deployment_uuid from the deployment object.`,
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"stack_id": map[string]interface{}{
 						"type":        "number",
@@ -249,7 +257,7 @@ Note: Requires stack_id and deployment_uuid from the deployment object.`,
 						"description": "Number of items per page",
 					},
 				},
-				Required: []string{"organization_uuid", "stack_id", "deployment_uuid"},
+				Required: []string{"stack_id", "deployment_uuid"},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -282,19 +290,275 @@ Note: Requires stack_id and deployment_uuid from the deployment object.`,
 
 			logs, _, err := client.Deployments.GetDeploymentLogs(ctx, orgUUID, stackID, deploymentUUID, opts)
 			if err != nil {
+				return deploymentLogsErrorResult(err, "get", stackID, deploymentUUID), nil
+			}
+
+			jsonData, err := json.MarshalIndent(logs, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+const (
+	// defaultWatchLogsWait and maxWatchLogsWait bound how long one call of
+	// tmc_watch_deployment_logs follows the logs.
+	defaultWatchLogsWait = 30 * time.Second
+	maxWatchLogsWait     = 2 * time.Minute
+
+	// defaultWatchLogsLines and maxWatchLogsLines bound the log lines one
+	// call of tmc_watch_deployment_logs returns.
+	defaultWatchLogsLines = 200
+	maxWatchLogsLines     = 1000
+)
+
+// watchLogsPollInterval is how often tmc_watch_deployment_logs polls for new
+// log lines.
+var watchLogsPollInterval = 5 * time.Second
+
+// watchLogsResponse is the result of tmc_watch_deployment_logs.
+type watchLogsResponse struct {
+	Lines    []terramate.CommandLogLine `json:"lines"`
+	Finished bool                       `json:"finished"`
+	NextPage int                        `json:"next_page"`
+	NextLine int                        `json:"next_line"`
+}
+
+// WatchDeploymentLogs creates an MCP tool that follows the logs of a running stack deployment.
+func WatchDeploymentLogs(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_watch_deployment_logs",
+			Description: `Follow the terraform logs of a running stack deployment.
+
+Waits for new log lines for up to wait_seconds and returns the lines logged since start_line.
+Call it again with the returned next_page and next_line as start_page and start_line to keep
+following the deployment until finished is true.
+
+Response includes:
+- lines: Log lines (log_line, timestamp, channel, message)
+- finished: Whether the deployment is no longer pending or running and all its logs were returned
+- next_page, next_line: Where to resume following the logs
+
+Note: Requires stack_id and deployment_uuid from the deployment object.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"stack_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Stack ID from the deployment",
+					},
+					"deployment_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Deployment UUID from stack deployment object",
+					},
+					"channel": map[string]interface{}{
+						"type":        "string",
+						"description": "Filter by channel (stdout or stderr)",
+					},
+					"start_page": map[string]interface{}{
+						"type":        "number",
+						"description": "next_page of the previous call",
+					},
+					"start_line": map[string]interface{}{
+						"type":        "number",
+						"description": "next_line of the previous call",
+					},
+					"wait_seconds": map[string]interface{}{
+						"type":        "number",
+						"description": "How long to wait for new lines (default: 30, max: 120)",
+					},
+					"max_lines": map[string]interface{}{
+						"type":        "number",
+						"description": "Maximum number of lines to return (default: 200, max: 1000)",
+					},
+				},
+				Required: []string{"stack_id", "deployment_uuid"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			stackID, err := request.RequireInt("stack_id")
+			if err != nil {
+				return mcp.NewToolResultError("Stack ID is required and must be a number."), nil
+			}
+			if stackID <= 0 {
+				return mcp.NewToolResultError("Stack ID must be positive."), nil
+			}
+
+			deploymentUUID, err := request.RequireString("deployment_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Deployment UUID is required and must be a string."), nil
+			}
+
+			wait := defaultWatchLogsWait
+			if seconds := request.GetInt("wait_seconds", 0); seconds > 0 {
+				wait = min(time.Duration(seconds)*time.Second, maxWatchLogsWait)
+			}
+			maxLines := defaultWatchLogsLines
+			if n := request.GetInt("max_lines", 0); n > 0 {
+				maxLines = min(n, maxWatchLogsLines)
+			}
+
+			watchCtx, cancel := context.WithTimeout(ctx, wait)
+			defer cancel()
+
+			response := watchLogsResponse{Lines: []terramate.CommandLogLine{}}
+			progress, err := client.Deployments.StreamLogs(watchCtx, orgUUID, stackID, deploymentUUID, func(line terramate.CommandLogLine) error {
+				response.Lines = append(response.Lines, line)
+				if len(response.Lines) >= maxLines {
+					return terramate.ErrStopStreaming
+				}
+				return nil
+			}, &terramate.StreamLogsOptions{
+				Channel:      request.GetString("channel", ""),
+				PollInterval: watchLogsPollInterval,
+				StartPage:    request.GetInt("start_page", 0),
+				StartLine:    request.GetInt("start_line", 0),
+			})
+			// Running out of wait time ends the call, not the watch.
+			if err != nil && !(errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil) {
+				return deploymentLogsErrorResult(err, "watch", stackID, deploymentUUID), nil
+			}
+
+			response.Finished = err == nil && len(response.Lines) < maxLines
+			response.NextPage = progress.Page
+			response.NextLine = progress.NextLine
+
+			jsonData, err := json.MarshalIndent(response, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// deploymentLogsErrorResult maps an error reading the logs of a stack
+// deployment to a tool error result; action names the failed operation.
+func deploymentLogsErrorResult(err error, action string, stackID int, deploymentUUID string) *mcp.CallToolResult {
+	if result, ok := knownErrorResult(err); ok {
+		return result
+	}
+	if apiErr, ok := err.(*terramate.APIError); ok {
+		if apiErr.IsUnauthorized() {
+			return mcp.NewToolResultError(terramate.ErrAuthenticationFailed)
+		}
+		if apiErr.IsNotFound() {
+			return mcp.NewToolResultError(fmt.Sprintf("Deployment logs not found for stack %d and deployment %s.", stackID, deploymentUUID))
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error()))
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to %s deployment logs: %v", action, err))
+}
+
+// ListStackDeploymentHistory creates an MCP tool that lists the deployment history of a single stack.
+func ListStackDeploymentHistory(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_list_stack_deployment_history",
+			Description: `List the deployment history of a single stack, newest first.
+
+Use this to audit a stack: when it was deployed, by which commit, and with what outcome,
+optionally restricted to a time range.
+
+Supported filters:
+- status: Filter by deployment status (canceled, failed, ok, pending, running)
+- created_at_from, created_at_to: RFC3339 time range of the deployments
+- page, per_page: Pagination (max: 100)
+
+Response includes:
+- stack_deployments: Array of stack deployments with status, commit and timestamps
+- paginated_result: Pagination info
+
+Use tmc_get_stack_deployment for the terraform output of one deployment.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"stack_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Stack ID (from tmc_list_stacks)",
+					},
+					"status": map[string]interface{}{
+						"type":        "array",
+						"description": "Filter by status (canceled, failed, ok, pending, running)",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"created_at_from": map[string]interface{}{
+						"type":        "string",
+						"description": "Only deployments created at or after this RFC3339 time, e.g. 2026-01-01T00:00:00Z",
+					},
+					"created_at_to": map[string]interface{}{
+						"type":        "string",
+						"description": "Only deployments created at or before this RFC3339 time",
+					},
+					"page": map[string]interface{}{
+						"type":        "number",
+						"description": "Page number for pagination",
+					},
+					"per_page": map[string]interface{}{
+						"type":        "number",
+						"description": "Number of items per page (max: 100)",
+					},
+				},
+				Required: []string{"stack_id"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			stackID, err := request.RequireInt("stack_id")
+			if err != nil {
+				return mcp.NewToolResultError("Stack ID is required and must be a number."), nil
+			}
+			if stackID <= 0 {
+				return mcp.NewToolResultError("Stack ID must be positive."), nil
+			}
+
+			opts, result := stackDeploymentHistoryOptions(request)
+			if result != nil {
+				return result, nil
+			}
+
+			history, _, err := client.Deployments.ListForStack(ctx, orgUUID, stackID, opts)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
 					}
 					if apiErr.IsNotFound() {
-						return mcp.NewToolResultError(fmt.Sprintf("Deployment logs not found for stack %d and deployment %s.", stackID, deploymentUUID)), nil
+						return mcp.NewToolResultError(fmt.Sprintf("Stack with ID %d not found.", stackID)), nil
 					}
 					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
 				}
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to get deployment logs: %v", err)), nil
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list stack deployments: %v", err)), nil
 			}
 
-			jsonData, err := json.MarshalIndent(logs, "", "  ")
+			jsonData, err := json.MarshalIndent(history, "", "  ")
 			if err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
 			}
@@ -303,3 +567,45 @@ Note: Requires stack_id and deployment_uuid from the deployment object.`,
 		},
 	}
 }
+
+// stackDeploymentHistoryOptions reads the filters of
+// tmc_list_stack_deployment_history, or returns the error result of invalid
+// ones.
+func stackDeploymentHistoryOptions(request mcp.CallToolRequest) (*terramate.StackDeploymentsListOptions, *mcp.CallToolResult) {
+	opts := &terramate.StackDeploymentsListOptions{
+		Status: request.GetStringSlice("status", nil),
+	}
+	if page := request.GetInt("page", 0); page > 0 {
+		opts.Page = page
+	}
+	if perPage := request.GetInt("per_page", 0); perPage > 0 {
+		if perPage > 100 {
+			return nil, mcp.NewToolResultError("Per page value must not exceed 100.")
+		}
+		opts.PerPage = perPage
+	}
+	if result := createdAtRange(request, &opts.CreatedAtFrom, &opts.CreatedAtTo); result != nil {
+		return nil, result
+	}
+	return opts, nil
+}
+
+// createdAtRange reads the RFC3339 created_at_from and created_at_to
+// arguments into from and to, or returns the error result of invalid ones.
+func createdAtRange(request mcp.CallToolRequest, from, to **time.Time) *mcp.CallToolResult {
+	for name, field := range map[string]**time.Time{
+		"created_at_from": from,
+		"created_at_to":   to,
+	} {
+		value := request.GetString(name, "")
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("%s must be an RFC3339 time, e.g. 2026-01-10T09:00:00Z.", name))
+		}
+		*field = &t
+	}
+	return nil
+}
@@ -222,3 +222,108 @@ func TestGetStackDeployment_InvalidID(t *testing.T) {
 		t.Fatal("expected error result for invalid id")
 	}
 }
+
+func TestListStackDeploymentHistory(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/stacks/org-uuid/42/deployments" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("created_at_from") != "2026-01-01T00:00:00Z" || query.Get("created_at_to") != "" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"stack_deployments": [{"id": 3, "status": "ok"}], "paginated_result": {"total": 1, "page": 1, "per_page": 20}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	call := func(args map[string]interface{}) (*mcp.CallToolResult, string) {
+		t.Helper()
+		result, err := ListStackDeploymentHistory(c).Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return result, text.Text
+	}
+
+	result, text := call(map[string]interface{}{
+		"organization_uuid": "org-uuid",
+		"stack_id":          float64(42),
+		"created_at_from":   "2026-01-01T00:00:00Z",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", text)
+	}
+	var history terramate.StackDeploymentsListResponse
+	if err := json.Unmarshal([]byte(text), &history); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(history.StackDeployments) != 1 || history.StackDeployments[0].ID != 3 {
+		t.Errorf("unexpected history: %+v", history)
+	}
+
+	if result, _ := call(map[string]interface{}{
+		"organization_uuid": "org-uuid",
+		"stack_id":          float64(42),
+		"created_at_to":     "yesterday",
+	}); !result.IsError {
+		t.Fatal("expected an error result for an invalid time")
+	}
+}
+
+func TestWatchDeploymentLogs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/stacks/org-uuid/42/deployments":
+			// The deployment is no longer pending or running
+			_, _ = w.Write([]byte(`{"stack_deployments": [], "paginated_result": {"total": 0, "page": 1, "per_page": 100}}`))
+		case "/v1/stacks/org-uuid/42/deployments/deploy-uuid/logs":
+			_, _ = w.Write([]byte(`{"deployment_log_lines": [
+				{"log_line": 1, "channel": "stdout", "message": "Plan: 1 to add"},
+				{"log_line": 2, "channel": "stdout", "message": "Apply complete!"},
+				{"log_line": 3, "channel": "stdout", "message": "Outputs:"}
+			], "paginated_result": {"total": 3, "page": 1, "per_page": 100}}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	call := func(args map[string]interface{}) watchLogsResponse {
+		t.Helper()
+		args["organization_uuid"] = "org-uuid"
+		args["stack_id"] = float64(42)
+		args["deployment_uuid"] = "deploy-uuid"
+		result, err := WatchDeploymentLogs(c).Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		if result.IsError {
+			t.Fatalf("unexpected error: %s", text.Text)
+		}
+		var response watchLogsResponse
+		if err := json.Unmarshal([]byte(text.Text), &response); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return response
+	}
+
+	response := call(map[string]interface{}{"max_lines": float64(2)})
+	if len(response.Lines) != 2 || response.Finished || response.NextLine != 3 || response.NextPage != 1 {
+		t.Errorf("unexpected response with max_lines: %+v", response)
+	}
+
+	response = call(map[string]interface{}{"start_page": float64(response.NextPage), "start_line": float64(response.NextLine)})
+	if len(response.Lines) != 1 || response.Lines[0].Message != "Outputs:" || !response.Finished {
+		t.Errorf("unexpected resumed response: %+v", response)
+	}
+}
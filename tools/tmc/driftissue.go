@@ -0,0 +1,351 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/vcs"
+)
+
+// consoleBaseURL is the Terramate Cloud web console.
+const consoleBaseURL = "https://cloud.terramate.io"
+
+// maxIssuePlanChars bounds the plan excerpt embedded in an issue body; GitHub
+// rejects bodies over 65536 characters.
+const maxIssuePlanChars = 30000
+
+// planResourceLine matches the per-resource headers of a terraform/tofu plan,
+// e.g. "  # aws_s3_bucket.logs will be updated in-place".
+var planResourceLine = regexp.MustCompile(`(?m)^\s*# (\S+) (will be created|will be destroyed|will be updated in-place|must be replaced|has changed|has been deleted)`)
+
+// planChanges groups resource addresses by the action a plan reports for them.
+type planChanges struct {
+	Create         []string
+	Update         []string
+	Replace        []string
+	Destroy        []string
+	ChangedOutside []string
+}
+
+func (p planChanges) empty() bool {
+	return len(p.Create)+len(p.Update)+len(p.Replace)+len(p.Destroy)+len(p.ChangedOutside) == 0
+}
+
+// driftIssueDraft is the payload returned by tmc_draft_drift_issue.
+type driftIssueDraft struct {
+	Title      string            `json:"title"`
+	Body       string            `json:"body"`
+	Repository string            `json:"repository"`
+	ConsoleURL string            `json:"console_url,omitempty"`
+	Issue      *vcs.CreatedIssue `json:"issue,omitempty"` // Set when the issue was posted
+}
+
+// DraftDriftIssue creates an MCP tool that renders a drift into a ready-to-file
+// issue and optionally posts it through tracker. tracker may be nil, in which
+// case only drafting is available.
+func DraftDriftIssue(client *terramate.Client, tracker vcs.IssueTracker) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_draft_drift_issue",
+			Description: `Render a drift detection run into a ready-to-file issue (markdown), optionally posting it.
+
+The issue body contains:
+- Summary: repository, stack path, target, drift status and detection time
+- Console link to the stack in Terramate Cloud
+- Suspected cause, derived from the resource actions in the plan
+- Remediation checklist
+- A collapsible excerpt of the terraform plan
+
+Workflow:
+1. Use tmc_list_stacks with drift_status=["drifted"] to find drifted stacks
+2. Use tmc_list_drifts to get a drift_id
+3. Use tmc_draft_drift_issue to draft the issue, review it, then call again with post=true
+
+Posting requires the server to be configured with a VCS integration (--github-token) and
+the stack's repository to be hosted on that provider.
+
+Response includes:
+- title, body: The rendered issue
+- repository: Repository the issue belongs to
+- console_url: Link to the stack in Terramate Cloud (when the organization name is known)
+- issue: Number and URL of the created issue (only when post=true)`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"stack_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Stack ID",
+					},
+					"drift_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Drift ID (get from tmc_list_drifts)",
+					},
+					"organization_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization short name (org_name from tmc_authenticate) used for the console link; looked up when omitted",
+					},
+					"labels": map[string]interface{}{
+						"type":        "array",
+						"description": "Labels to apply when posting the issue",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"post": map[string]interface{}{
+						"type":        "boolean",
+						"description": "File the issue through the configured VCS integration (default: false, draft only)",
+					},
+				},
+				Required: []string{"stack_id", "drift_id"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			stackID, err := request.RequireInt("stack_id")
+			if err != nil {
+				return mcp.NewToolResultError("Stack ID is required and must be a number."), nil
+			}
+			if stackID <= 0 {
+				return mcp.NewToolResultError("Stack ID must be positive."), nil
+			}
+
+			driftID, err := request.RequireInt("drift_id")
+			if err != nil {
+				return mcp.NewToolResultError("Drift ID is required and must be a number."), nil
+			}
+			if driftID <= 0 {
+				return mcp.NewToolResultError("Drift ID must be positive."), nil
+			}
+
+			post := request.GetBool("post", false)
+			if post && tracker == nil {
+				return mcp.NewToolResultError("Posting issues requires a VCS integration. Start the server with --github-token or call again with post=false."), nil
+			}
+
+			drift, stack, err := fetchDriftWithStack(ctx, client, orgUUID, stackID, driftID)
+			if err != nil {
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					if apiErr.IsNotFound() {
+						return mcp.NewToolResultError(fmt.Sprintf("Drift with ID %d not found for stack %d.", driftID, stackID)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get drift: %v", err)), nil
+			}
+
+			orgName := request.GetString("organization_name", "")
+			if orgName == "" {
+				orgName = lookupOrganizationName(ctx, client, orgUUID)
+			}
+
+			draft := renderDriftIssue(drift, stack, orgName)
+			if post {
+				issue := vcs.Issue{Title: draft.Title, Body: draft.Body, Labels: request.GetStringSlice("labels", nil)}
+				created, err := tracker.CreateIssue(ctx, stack.Repository, issue)
+				if err != nil {
+					if errors.Is(err, vcs.ErrUnsupportedRepository) {
+						return mcp.NewToolResultError(fmt.Sprintf("Cannot post issues for repository %s with the configured VCS integration. Call again with post=false and file the draft manually.", stack.Repository)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to create issue: %v", err)), nil
+				}
+				draft.Issue = created
+			}
+
+			jsonData, err := json.MarshalIndent(draft, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// fetchDriftWithStack retrieves a drift and its stack. The stack is embedded in
+// the drift response; it is fetched separately only if the API omitted it.
+func fetchDriftWithStack(ctx context.Context, client *terramate.Client, orgUUID string, stackID, driftID int) (*terramate.Drift, *terramate.Stack, error) {
+	drift, _, err := client.Drifts.Get(ctx, orgUUID, stackID, driftID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if drift.Stack != nil {
+		return drift, drift.Stack, nil
+	}
+	stack, _, err := client.Stacks.Get(ctx, orgUUID, stackID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return drift, stack, nil
+}
+
+// lookupOrganizationName resolves the organization short name used in console
+// URLs, preferring the memberships cached in the session. The console link is
+// best-effort, so failures yield an empty name.
+func lookupOrganizationName(ctx context.Context, client *terramate.Client, orgUUID string) string {
+	session := SessionFromContext(ctx)
+	memberships, ok := session.Memberships()
+	if !ok {
+		var err error
+		memberships, _, err = client.Memberships.List(ctx)
+		if err != nil {
+			return ""
+		}
+		session.SetMemberships(memberships)
+	}
+	for _, m := range memberships {
+		if m.OrgUUID == orgUUID {
+			return m.OrgName
+		}
+	}
+	return ""
+}
+
+// parsePlanChanges extracts resource actions from an ASCII plan.
+func parsePlanChanges(plan string) planChanges {
+	var changes planChanges
+	for _, match := range planResourceLine.FindAllStringSubmatch(plan, -1) {
+		address, action := match[1], match[2]
+		switch action {
+		case "will be created":
+			changes.Create = append(changes.Create, address)
+		case "will be updated in-place":
+			changes.Update = append(changes.Update, address)
+		case "must be replaced":
+			changes.Replace = append(changes.Replace, address)
+		case "will be destroyed":
+			changes.Destroy = append(changes.Destroy, address)
+		default: // has changed, has been deleted
+			changes.ChangedOutside = append(changes.ChangedOutside, address)
+		}
+	}
+	return changes
+}
+
+// suspectedCauses explains the drift in terms of the plan's resource actions.
+func suspectedCauses(status string, changes planChanges) []string {
+	var causes []string
+	if status == "failed" {
+		causes = append(causes, "The drift detection run failed, so the plan may be incomplete. Check the run command, provider credentials and backend access.")
+	}
+	if n := len(changes.Update) + len(changes.ChangedOutside); n > 0 {
+		causes = append(causes, fmt.Sprintf("%d resource(s) were modified outside of Terraform (manual console changes, another automation, or provider-side defaults).", n))
+	}
+	if n := len(changes.Create); n > 0 {
+		causes = append(causes, fmt.Sprintf("%d resource(s) no longer exist and would be re-created; they were likely deleted outside of Terraform.", n))
+	}
+	if n := len(changes.Replace); n > 0 {
+		causes = append(causes, fmt.Sprintf("%d resource(s) would be replaced; an immutable attribute was changed outside of Terraform.", n))
+	}
+	if n := len(changes.Destroy); n > 0 {
+		causes = append(causes, fmt.Sprintf("%d resource(s) would be destroyed; code may have been changed without being applied.", n))
+	}
+	if len(causes) == 0 {
+		causes = append(causes, "No resource changes could be identified in the plan output. Review the plan below.")
+	}
+	return causes
+}
+
+// renderDriftIssue builds the issue title and markdown body for a drift.
+func renderDriftIssue(drift *terramate.Drift, stack *terramate.Stack, orgName string) driftIssueDraft {
+	name := stack.MetaName
+	if name == "" {
+		name = stack.Path
+	}
+	title := fmt.Sprintf("Drift detected in %s", name)
+	if stack.Target != "" {
+		title += fmt.Sprintf(" (%s)", stack.Target)
+	}
+
+	draft := driftIssueDraft{Title: title, Repository: stack.Repository}
+	if orgName != "" {
+		draft.ConsoleURL = fmt.Sprintf("%s/o/%s/stacks/%d", consoleBaseURL, orgName, stack.StackID)
+	}
+
+	var plan string
+	if drift.DriftDetails != nil {
+		plan = drift.DriftDetails.ChangesetASCII
+	}
+	changes := parsePlanChanges(plan)
+
+	var b strings.Builder
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Repository | `%s` |\n", stack.Repository)
+	fmt.Fprintf(&b, "| Stack | `%s` |\n", stack.Path)
+	if stack.Target != "" {
+		fmt.Fprintf(&b, "| Target | `%s` |\n", stack.Target)
+	}
+	fmt.Fprintf(&b, "| Drift status | %s |\n", drift.Status)
+	if drift.FinishedAt != nil {
+		fmt.Fprintf(&b, "| Detected at | %s |\n", drift.FinishedAt.UTC().Format("2006-01-02 15:04 UTC"))
+	}
+	fmt.Fprintf(&b, "| Drift ID | %d |\n", drift.ID)
+	if draft.ConsoleURL != "" {
+		fmt.Fprintf(&b, "\n[View stack in Terramate Cloud](%s)\n", draft.ConsoleURL)
+	}
+
+	b.WriteString("\n## Suspected cause\n\n")
+	for _, cause := range suspectedCauses(drift.Status, changes) {
+		fmt.Fprintf(&b, "- %s\n", cause)
+	}
+
+	if !changes.empty() {
+		b.WriteString("\n## Affected resources\n")
+		writeResourceGroup(&b, "Modified outside of Terraform", changes.ChangedOutside)
+		writeResourceGroup(&b, "Update in-place", changes.Update)
+		writeResourceGroup(&b, "Re-create", changes.Create)
+		writeResourceGroup(&b, "Replace", changes.Replace)
+		writeResourceGroup(&b, "Destroy", changes.Destroy)
+	}
+
+	b.WriteString("\n## Remediation checklist\n\n")
+	b.WriteString("- [ ] Review the plan and confirm whether the out-of-band changes were intentional\n")
+	fmt.Fprintf(&b, "- [ ] If intentional, update the code in `%s` to match and open a pull request\n", stack.Path)
+	fmt.Fprintf(&b, "- [ ] If not, re-apply the stack (`terramate run -C %s -- terraform apply`)\n", stack.Path)
+	if len(changes.Replace) > 0 {
+		b.WriteString("- [ ] Schedule the apply: some resources will be replaced\n")
+	}
+	b.WriteString("- [ ] Re-run drift detection and confirm the stack is back to `ok`\n")
+
+	if plan != "" {
+		excerpt := plan
+		if len(excerpt) > maxIssuePlanChars {
+			excerpt = excerpt[:maxIssuePlanChars] + "\n... (truncated, see Terramate Cloud for the full plan)"
+		}
+		b.WriteString("\n<details>\n<summary>Plan output</summary>\n\n```\n")
+		b.WriteString(strings.TrimRight(excerpt, "\n"))
+		b.WriteString("\n```\n\n</details>\n")
+	}
+
+	draft.Body = b.String()
+	return draft
+}
+
+// writeResourceGroup writes a labeled list of resource addresses.
+func writeResourceGroup(b *strings.Builder, label string, addresses []string) {
+	if len(addresses) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n**%s** (%d)\n", label, len(addresses))
+	for _, address := range addresses {
+		fmt.Fprintf(b, "- `%s`\n", address)
+	}
+}
@@ -0,0 +1,185 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/vcs"
+)
+
+const driftIssuePlan = `Note: Objects have changed outside of Terraform
+
+  # aws_security_group.web has changed
+  ~ resource "aws_security_group" "web" {}
+
+Terraform will perform the following actions:
+
+  # aws_s3_bucket.logs will be created
+  + resource "aws_s3_bucket" "logs" {}
+
+  # aws_instance.web must be replaced
+-/+ resource "aws_instance" "web" {}
+
+Plan: 2 to add, 0 to change, 1 to destroy.
+`
+
+// fakeIssueTracker records the issues it is asked to create.
+type fakeIssueTracker struct {
+	repository string
+	issue      vcs.Issue
+	err        error
+}
+
+func (f *fakeIssueTracker) CreateIssue(_ context.Context, repository string, issue vcs.Issue) (*vcs.CreatedIssue, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.repository = repository
+	f.issue = issue
+	return &vcs.CreatedIssue{Number: 7, URL: "https://github.com/acme/infra/issues/7"}, nil
+}
+
+func newDriftIssueTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	drift := terramate.Drift{
+		ID:           100,
+		StackID:      456,
+		Status:       "drifted",
+		Stack:        &terramate.Stack{StackID: 456, Repository: "github.com/acme/infra", Path: "/stacks/web", Target: "prod"},
+		DriftDetails: &terramate.ChangesetDetails{ChangesetASCII: driftIssuePlan},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/drifts/org-uuid/456/100":
+			_ = json.NewEncoder(w).Encode(drift)
+		case "/v1/memberships":
+			_, _ = fmt.Fprint(w, `[{"org_uuid":"org-uuid","org_name":"acme"}]`)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func callDraftDriftIssue(t *testing.T, tracker vcs.IssueTracker, args map[string]interface{}) *mcp.CallToolResult {
+	t.Helper()
+	ts := newDriftIssueTestServer(t)
+	t.Cleanup(ts.Close)
+
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	base := map[string]interface{}{"organization_uuid": "org-uuid", "stack_id": float64(456), "drift_id": float64(100)}
+	for k, v := range args {
+		base[k] = v
+	}
+	result, err := DraftDriftIssue(c, tracker).Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: base},
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	return result
+}
+
+func TestDraftDriftIssue_RendersDraft(t *testing.T) {
+	result := callDraftDriftIssue(t, nil, nil)
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", textContent.Text)
+	}
+
+	var draft driftIssueDraft
+	if err := json.Unmarshal([]byte(textContent.Text), &draft); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if draft.Title != "Drift detected in /stacks/web (prod)" {
+		t.Fatalf("unexpected title: %q", draft.Title)
+	}
+	if draft.ConsoleURL != "https://cloud.terramate.io/o/acme/stacks/456" {
+		t.Fatalf("unexpected console URL: %q", draft.ConsoleURL)
+	}
+	if draft.Issue != nil {
+		t.Fatal("expected no issue to be posted")
+	}
+	for _, want := range []string{
+		"[View stack in Terramate Cloud](https://cloud.terramate.io/o/acme/stacks/456)",
+		"1 resource(s) were modified outside of Terraform",
+		"1 resource(s) no longer exist",
+		"- `aws_instance.web`",
+		"- [ ] Schedule the apply",
+		"<summary>Plan output</summary>",
+	} {
+		if !strings.Contains(draft.Body, want) {
+			t.Errorf("expected body to contain %q\n%s", want, draft.Body)
+		}
+	}
+}
+
+func TestDraftDriftIssue_Posts(t *testing.T) {
+	tracker := &fakeIssueTracker{}
+	result := callDraftDriftIssue(t, tracker, map[string]interface{}{
+		"post":              true,
+		"organization_name": "acme",
+		"labels":            []interface{}{"drift"},
+	})
+	textContent, _ := mcp.AsTextContent(result.Content[0])
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", textContent.Text)
+	}
+
+	var draft driftIssueDraft
+	if err := json.Unmarshal([]byte(textContent.Text), &draft); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if draft.Issue == nil || draft.Issue.Number != 7 {
+		t.Fatalf("expected created issue in response, got %+v", draft.Issue)
+	}
+	if tracker.repository != "github.com/acme/infra" || tracker.issue.Title != draft.Title || len(tracker.issue.Labels) != 1 {
+		t.Fatalf("unexpected tracker call: %s %+v", tracker.repository, tracker.issue)
+	}
+}
+
+func TestDraftDriftIssue_PostErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tracker vcs.IssueTracker
+		want    string
+	}{
+		{name: "no integration", tracker: nil, want: "requires a VCS integration"},
+		{name: "unsupported repository", tracker: &fakeIssueTracker{err: fmt.Errorf("%w: x", vcs.ErrUnsupportedRepository)}, want: "Cannot post issues for repository github.com/acme/infra"},
+		{name: "tracker failure", tracker: &fakeIssueTracker{err: fmt.Errorf("boom")}, want: "Failed to create issue: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callDraftDriftIssue(t, tt.tracker, map[string]interface{}{"post": true, "organization_name": "acme"})
+			textContent, _ := mcp.AsTextContent(result.Content[0])
+			if !result.IsError || !strings.Contains(textContent.Text, tt.want) {
+				t.Fatalf("expected error containing %q, got %q", tt.want, textContent.Text)
+			}
+		})
+	}
+}
+
+func TestSuspectedCauses(t *testing.T) {
+	causes := suspectedCauses("failed", planChanges{})
+	if len(causes) != 1 || !strings.Contains(causes[0], "run failed") {
+		t.Fatalf("unexpected causes for failed run: %v", causes)
+	}
+	causes = suspectedCauses("drifted", planChanges{})
+	if len(causes) != 1 || !strings.Contains(causes[0], "No resource changes") {
+		t.Fatalf("unexpected causes for empty plan: %v", causes)
+	}
+}
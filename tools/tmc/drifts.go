@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -43,7 +45,7 @@ Use tmc_get_drift to retrieve the full plan output.`,
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"stack_id": map[string]interface{}{
 						"type":        "number",
@@ -70,7 +72,7 @@ Use tmc_get_drift to retrieve the full plan output.`,
 						"description": "Number of items per page (max: 100)",
 					},
 				},
-				Required: []string{"organization_uuid", "stack_id"},
+				Required: []string{"stack_id"},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -112,6 +114,9 @@ Use tmc_get_drift to retrieve the full plan output.`,
 			// Call the API.
 			result, _, err := client.Drifts.ListForStack(ctx, orgUUID, stackID, opts)
 			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
@@ -163,7 +168,7 @@ Response includes the complete Drift object with all fields populated.`,
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"stack_id": map[string]interface{}{
 						"type":        "number",
@@ -174,7 +179,7 @@ Response includes the complete Drift object with all fields populated.`,
 						"description": "Drift ID (get from tmc_list_drifts)",
 					},
 				},
-				Required: []string{"organization_uuid", "stack_id", "drift_id"},
+				Required: []string{"stack_id", "drift_id"},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -205,6 +210,9 @@ Response includes the complete Drift object with all fields populated.`,
 			// Call the API.
 			drift, _, err := client.Drifts.Get(ctx, orgUUID, stackID, driftID)
 			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
@@ -227,3 +235,283 @@ Response includes the complete Drift object with all fields populated.`,
 		},
 	}
 }
+
+// TriggerDriftCheck creates an MCP tool that requests a new drift check of a stack.
+func TriggerDriftCheck(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_trigger_drift_check",
+			Description: `Request a new drift check of a stack in Terramate Cloud, e.g. after remediating drift.
+
+The check runs asynchronously: its result appears in tmc_list_drifts once it finished.
+Requires the admin role.
+
+Response includes:
+- stack_id: The checked stack
+- status: queued or running
+- requested_at: When the check was requested`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"stack_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Stack ID to check for drift",
+					},
+				},
+				Required: []string{"stack_id"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			stackID, err := request.RequireInt("stack_id")
+			if err != nil {
+				return mcp.NewToolResultError("Stack ID is required and must be a number."), nil
+			}
+			if stackID <= 0 {
+				return mcp.NewToolResultError("Stack ID must be positive."), nil
+			}
+
+			check, _, err := client.Drifts.Trigger(ctx, orgUUID, stackID)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					switch {
+					case apiErr.IsUnauthorized():
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					case apiErr.IsForbidden():
+						return mcp.NewToolResultError("Drift checks can only be triggered by the admins of an organization."), nil
+					case apiErr.IsNotFound():
+						return mcp.NewToolResultError(fmt.Sprintf("Stack with ID %d not found.", stackID)), nil
+					case apiErr.StatusCode == http.StatusConflict:
+						return mcp.NewToolResultError(fmt.Sprintf("A drift check of stack %d is already queued or running; check tmc_list_drifts for its result.", stackID)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to trigger drift check: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(check, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// GetResourceDriftHistory creates an MCP tool that lists the drift runs of a stack in which a resource drifted.
+func GetResourceDriftHistory(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_get_resource_drift_history",
+			Description: `List the drift runs of a stack in which a given resource drifted, newest first.
+
+Use this to answer "how often does aws_security_group.web drift?". The address matches the
+resource and its instances (aws_security_group.web matches aws_security_group.web[0]).
+
+Each drifted run of the stack is fetched to inspect its plan, newest first, up to max_runs.
+
+Response includes:
+- runs[]: drift_id, the drifted address, started_at, finished_at and the plan actions
+  (empty when only the ASCII plan was available)
+- scanned_count: Drifted runs inspected
+- has_more: Set when older drifted runs were not inspected
+
+Use tmc_get_drift with a drift_id to see the drifted attributes.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"stack_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Stack ID (get from tmc_list_stacks)",
+					},
+					"address": map[string]interface{}{
+						"type":        "string",
+						"description": "Resource address, e.g. aws_security_group.web or module.vpc.aws_subnet.private",
+					},
+					"max_runs": map[string]interface{}{
+						"type":        "number",
+						"description": "Maximum drifted runs to inspect, newest first (default: 20, max: 100)",
+					},
+				},
+				Required: []string{"stack_id", "address"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			stackID, err := request.RequireInt("stack_id")
+			if err != nil {
+				return mcp.NewToolResultError("Stack ID is required and must be a number."), nil
+			}
+			if stackID <= 0 {
+				return mcp.NewToolResultError("Stack ID must be positive."), nil
+			}
+			address, err := request.RequireString("address")
+			if err != nil || address == "" {
+				return mcp.NewToolResultError("Address is required and must be a string."), nil
+			}
+			maxRuns := request.GetInt("max_runs", 20)
+			if maxRuns <= 0 || maxRuns > 100 {
+				return mcp.NewToolResultError("max_runs must be between 1 and 100."), nil
+			}
+
+			history, err := client.Drifts.ListForResource(ctx, orgUUID, stackID, address, &terramate.ResourceDriftHistoryOptions{
+				MaxRuns:     maxRuns,
+				Concurrency: ConcurrencyLimit(ctx),
+			})
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					if apiErr.IsNotFound() {
+						return mcp.NewToolResultError(fmt.Sprintf("Stack with ID %d not found.", stackID)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get resource drift history: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(history, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// AcknowledgeDrift creates an MCP tool that acknowledges a drift as known, or revokes the acknowledgement.
+func AcknowledgeDrift(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_acknowledge_drift",
+			Description: `Acknowledge a drift as known in Terramate Cloud, silencing it, or revoke the acknowledgement.
+
+Use this for stacks whose drift is expected, e.g. until a pending change lands, so the
+drift stops being reported. Set until to expire the acknowledgement automatically and
+revoke=true to report the drift again.
+
+Returns the updated drift with its acknowledgement (acknowledged_at, acknowledged_by,
+comment, until).`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"stack_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Stack ID of the drift",
+					},
+					"drift_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Drift ID (from tmc_list_drifts)",
+					},
+					"comment": map[string]interface{}{
+						"type":        "string",
+						"description": "Why the drift is known, e.g. the pending change",
+					},
+					"until": map[string]interface{}{
+						"type":        "string",
+						"description": "RFC3339 time the acknowledgement expires (default: until revoked)",
+					},
+					"revoke": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Revoke the acknowledgement instead (default: false)",
+					},
+				},
+				Required: []string{"stack_id", "drift_id"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			stackID, err := request.RequireInt("stack_id")
+			if err != nil {
+				return mcp.NewToolResultError("Stack ID is required and must be a number."), nil
+			}
+			if stackID <= 0 {
+				return mcp.NewToolResultError("Stack ID must be positive."), nil
+			}
+
+			driftID, err := request.RequireInt("drift_id")
+			if err != nil {
+				return mcp.NewToolResultError("Drift ID is required and must be a number."), nil
+			}
+			if driftID <= 0 {
+				return mcp.NewToolResultError("Drift ID must be positive."), nil
+			}
+
+			var drift *terramate.Drift
+			if request.GetBool("revoke", false) {
+				drift, _, err = client.Drifts.Unacknowledge(ctx, orgUUID, stackID, driftID)
+			} else {
+				opts := terramate.AcknowledgeDriftOptions{Comment: request.GetString("comment", "")}
+				if until := request.GetString("until", ""); until != "" {
+					t, perr := time.Parse(time.RFC3339, until)
+					if perr != nil {
+						return mcp.NewToolResultError("until must be an RFC3339 time, e.g. 2026-01-10T09:00:00Z."), nil
+					}
+					opts.Until = &t
+				}
+				drift, _, err = client.Drifts.Acknowledge(ctx, orgUUID, stackID, driftID, opts)
+			}
+			if err != nil {
+				return driftErrorResult(err, "acknowledge drift", stackID, driftID), nil
+			}
+
+			jsonData, err := json.MarshalIndent(drift, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// driftErrorResult maps an error of the API of a drift to a tool error
+// result; action names the failed operation.
+func driftErrorResult(err error, action string, stackID, driftID int) *mcp.CallToolResult {
+	if result, ok := knownErrorResult(err); ok {
+		return result
+	}
+	if apiErr, ok := err.(*terramate.APIError); ok {
+		if apiErr.IsUnauthorized() {
+			return mcp.NewToolResultError(terramate.ErrAuthenticationFailed)
+		}
+		if apiErr.IsNotFound() {
+			return mcp.NewToolResultError(fmt.Sprintf("Drift with ID %d not found for stack %d.", driftID, stackID))
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error()))
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to %s: %v", action, err))
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
@@ -672,3 +674,134 @@ func TestGetDrift_NotFound(t *testing.T) {
 		t.Fatalf("unexpected error message: %s", textContent.Text)
 	}
 }
+
+func TestTriggerDriftCheck(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/drifts/org-uuid/42/trigger":
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"stack_id": 42, "status": "queued", "requested_at": "2026-03-02T09:30:00Z"}`))
+		case "/v1/drifts/org-uuid/43/trigger":
+			w.WriteHeader(http.StatusConflict)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	call := func(stackID float64) (*mcp.CallToolResult, string) {
+		t.Helper()
+		result, err := TriggerDriftCheck(c).Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]interface{}{"organization_uuid": "org-uuid", "stack_id": stackID}},
+		})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return result, text.Text
+	}
+
+	if result, text := call(42); result.IsError || !strings.Contains(text, `"status": "queued"`) {
+		t.Errorf("unexpected result: %s", text)
+	}
+	if result, text := call(43); !result.IsError || !strings.Contains(text, "already queued or running") {
+		t.Errorf("expected the conflict error, got %s", text)
+	}
+}
+
+func TestAcknowledgeDrift(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/drifts/org-uuid/42/7/acknowledge" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete {
+			_, _ = w.Write([]byte(`{"id": 7, "stack_id": 42, "status": "drifted"}`))
+			return
+		}
+		var opts terramate.AcknowledgeDriftOptions
+		_ = json.NewDecoder(r.Body).Decode(&opts)
+		if opts.Until == nil || opts.Until.Format(time.RFC3339) != "2026-03-09T00:00:00Z" {
+			t.Errorf("unexpected until: %v", opts.Until)
+		}
+		_, _ = w.Write([]byte(`{"id": 7, "stack_id": 42, "status": "drifted", "acknowledgement": {"acknowledged_at": "2026-03-02T09:30:00Z", "comment": "known"}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	call := func(args map[string]interface{}) (*mcp.CallToolResult, string) {
+		t.Helper()
+		args["organization_uuid"] = "org-uuid"
+		args["stack_id"] = float64(42)
+		result, err := AcknowledgeDrift(c).Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return result, text.Text
+	}
+
+	if result, text := call(map[string]interface{}{"drift_id": float64(7), "comment": "known", "until": "2026-03-09T00:00:00Z"}); result.IsError || !strings.Contains(text, `"comment": "known"`) {
+		t.Errorf("unexpected result: %s", text)
+	}
+	if result, text := call(map[string]interface{}{"drift_id": float64(7), "revoke": true}); result.IsError || strings.Contains(text, "acknowledgement") {
+		t.Errorf("unexpected revoke result: %s", text)
+	}
+	if result, _ := call(map[string]interface{}{"drift_id": float64(7), "until": "next week"}); !result.IsError {
+		t.Error("expected an error result for an invalid until")
+	}
+	if result, text := call(map[string]interface{}{"drift_id": float64(8)}); !result.IsError || !strings.Contains(text, "Drift with ID 8 not found") {
+		t.Errorf("expected a not found error, got %s", text)
+	}
+}
+
+func TestGetResourceDriftHistory(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/stacks/org-uuid/42/drifts":
+			_, _ = w.Write([]byte(`{"drifts": [{"id": 8}, {"id": 7}], "paginated_result": {"total": 2, "page": 1, "per_page": 20}}`))
+		case "/v1/drifts/org-uuid/42/8":
+			_, _ = w.Write([]byte(`{"id": 8, "stack_id": 42, "status": "drifted", "drift_details": {"changeset_json": "{\"resource_changes\": [{\"address\": \"aws_security_group.web\", \"change\": {\"actions\": [\"update\"]}}]}"}}`))
+		case "/v1/drifts/org-uuid/42/7":
+			_, _ = w.Write([]byte(`{"id": 7, "stack_id": 42, "status": "drifted", "drift_details": {"changeset_ascii": "  # aws_s3_bucket.logs has changed"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	call := func(args map[string]interface{}) (*mcp.CallToolResult, string) {
+		t.Helper()
+		args["organization_uuid"] = "org-uuid"
+		result, err := GetResourceDriftHistory(c).Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return result, text.Text
+	}
+
+	result, text := call(map[string]interface{}{"stack_id": float64(42), "address": "aws_security_group.web"})
+	if result.IsError || !strings.Contains(text, `"drift_id": 8`) || strings.Contains(text, `"drift_id": 7`) || !strings.Contains(text, `"scanned_count": 2`) {
+		t.Fatalf("unexpected result: %s", text)
+	}
+
+	result, text = call(map[string]interface{}{"stack_id": float64(404), "address": "aws_security_group.web"})
+	if !result.IsError || !strings.Contains(text, "Stack with ID 404 not found") {
+		t.Errorf("expected a not found error, got %s", text)
+	}
+
+	result, _ = call(map[string]interface{}{"stack_id": float64(42), "address": "aws_security_group.web", "max_runs": float64(500)})
+	if !result.IsError {
+		t.Error("expected an error for max_runs above 100")
+	}
+}
@@ -0,0 +1,149 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// ListAuditEvents creates an MCP tool that lists the audit log of an organization.
+func ListAuditEvents(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_list_audit_events",
+			Description: `List the audit log of a Terramate Cloud organization, newest first.
+
+Use this for compliance questions: who archived a stack, who rotated an API key, when drift
+checks ran. Requires the admin role.
+
+Supported filters:
+- action: Filter by action (e.g. stack.archived, api_key.rotated, drift.checked)
+- resource_type: Filter by the type of the resource acted on (e.g. stack, api_key)
+- user_uuid: Filter by the user who took the action
+- stack_id: Filter by stack IDs
+- created_at_from, created_at_to: RFC3339 time range of the events
+- page, per_page: Pagination (max: 100)
+
+Response includes:
+- events: Array of events with action, actor, resource, details and created_at
+- paginated_result: Pagination info`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"action": map[string]interface{}{
+						"type":        "array",
+						"description": "Filter by action (e.g. stack.archived, api_key.rotated, drift.checked)",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"resource_type": map[string]interface{}{
+						"type":        "array",
+						"description": "Filter by the type of the resource acted on (e.g. stack, api_key)",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"user_uuid": map[string]interface{}{
+						"type":        "array",
+						"description": "Filter by the UUIDs of the users who took the action",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"stack_id": map[string]interface{}{
+						"type":        "array",
+						"description": "Filter by stack IDs",
+						"items": map[string]interface{}{
+							"type": "number",
+						},
+					},
+					"created_at_from": map[string]interface{}{
+						"type":        "string",
+						"description": "Only events at or after this RFC3339 time, e.g. 2026-01-01T00:00:00Z",
+					},
+					"created_at_to": map[string]interface{}{
+						"type":        "string",
+						"description": "Only events at or before this RFC3339 time",
+					},
+					"page": map[string]interface{}{
+						"type":        "number",
+						"description": "Page number for pagination",
+					},
+					"per_page": map[string]interface{}{
+						"type":        "number",
+						"description": "Number of items per page (max: 100)",
+					},
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			opts, result := auditEventsOptions(request)
+			if result != nil {
+				return result, nil
+			}
+
+			events, _, err := client.Events.List(ctx, orgUUID, opts)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					if apiErr.IsForbidden() {
+						return mcp.NewToolResultError("The audit log of an organization is only available to its admins."), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list audit events: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(events, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// auditEventsOptions reads the filters of tmc_list_audit_events, or returns
+// the error result of invalid ones.
+func auditEventsOptions(request mcp.CallToolRequest) (*terramate.AuditEventsListOptions, *mcp.CallToolResult) {
+	opts := &terramate.AuditEventsListOptions{
+		Action:       request.GetStringSlice("action", nil),
+		ResourceType: request.GetStringSlice("resource_type", nil),
+		UserUUID:     request.GetStringSlice("user_uuid", nil),
+		StackID:      request.GetIntSlice("stack_id", nil),
+	}
+	if page := request.GetInt("page", 0); page > 0 {
+		opts.Page = page
+	}
+	if perPage := request.GetInt("per_page", 0); perPage > 0 {
+		if perPage > 100 {
+			return nil, mcp.NewToolResultError("Per page value must not exceed 100.")
+		}
+		opts.PerPage = perPage
+	}
+	if result := createdAtRange(request, &opts.CreatedAtFrom, &opts.CreatedAtTo); result != nil {
+		return nil, result
+	}
+	return opts, nil
+}
@@ -0,0 +1,68 @@
+package tmc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestListAuditEvents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/organizations/org-uuid/events" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("action") != "stack.archived" || query.Get("stack_id") != "7" || query.Get("created_at_from") != "2026-03-01T00:00:00Z" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		if query.Get("user_uuid") == "outsider" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"events": [{"event_uuid": "event-1", "action": "stack.archived", "actor_type": "user", "actor": {"email": "jane@example.com"}, "resource_type": "stack", "created_at": "2026-03-02T09:30:00Z"}], "paginated_result": {"total": 1, "page": 1, "per_page": 20}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	call := func(args map[string]interface{}) (*mcp.CallToolResult, string) {
+		t.Helper()
+		args["organization_uuid"] = "org-uuid"
+		result, err := ListAuditEvents(c).Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return result, text.Text
+	}
+	filters := func(extra map[string]interface{}) map[string]interface{} {
+		args := map[string]interface{}{
+			"action":          []interface{}{"stack.archived"},
+			"stack_id":        []interface{}{float64(7)},
+			"created_at_from": "2026-03-01T00:00:00Z",
+		}
+		for k, v := range extra {
+			args[k] = v
+		}
+		return args
+	}
+
+	result, text := call(filters(nil))
+	if result.IsError || !strings.Contains(text, `"email": "jane@example.com"`) {
+		t.Fatalf("unexpected result: %s", text)
+	}
+
+	if result, text := call(filters(map[string]interface{}{"user_uuid": []interface{}{"outsider"}})); !result.IsError || !strings.Contains(text, "admins") {
+		t.Errorf("expected the admin role error, got %s", text)
+	}
+	if result, _ := call(map[string]interface{}{"created_at_to": "last week"}); !result.IsError {
+		t.Error("expected an error result for an invalid time")
+	}
+}
@@ -0,0 +1,23 @@
+package tmc
+
+import (
+	"context"
+
+	"github.com/terramate-io/terramate-mcp-server/internal/fanout"
+)
+
+// DefaultConcurrencyLimit is the number of API calls a single tool invocation
+// may run in parallel when no explicit limit has been configured.
+const DefaultConcurrencyLimit = fanout.DefaultLimit
+
+// WithConcurrencyLimit returns a context that caps how many API calls the
+// fan-out helpers may run in parallel for the current tool invocation.
+// Non-positive values leave the default in place.
+func WithConcurrencyLimit(ctx context.Context, n int) context.Context {
+	return fanout.WithLimit(ctx, n)
+}
+
+// ConcurrencyLimit returns the fan-out limit configured on ctx.
+func ConcurrencyLimit(ctx context.Context) int {
+	return fanout.Limit(ctx)
+}
@@ -0,0 +1,15 @@
+package tmc
+
+import (
+	"context"
+	"testing"
+)
+
+func TestConcurrencyLimit_Default(t *testing.T) {
+	if got := ConcurrencyLimit(context.Background()); got != DefaultConcurrencyLimit {
+		t.Fatalf("expected default %d, got %d", DefaultConcurrencyLimit, got)
+	}
+	if got := ConcurrencyLimit(WithConcurrencyLimit(context.Background(), 0)); got != DefaultConcurrencyLimit {
+		t.Fatalf("expected default for zero limit, got %d", got)
+	}
+}
@@ -0,0 +1,97 @@
+package tmc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// allFeatures is assumed for organizations whose plan features are not
+// reported by the API.
+var allFeatures = terramate.OrganizationFeatures{Previews: true, Policies: true, Targets: true}
+
+// OrganizationFeatures returns the plan features of an organization, cached in
+// the session of ctx. A 404 from the features endpoint means the API does not
+// report features, in which case everything is assumed to be enabled. Other
+// errors are returned and not cached, so the lookup is retried later.
+func OrganizationFeatures(ctx context.Context, client *terramate.Client, orgUUID string) (*terramate.OrganizationFeatures, error) {
+	session := SessionFromContext(ctx)
+	if features, ok := session.Features(orgUUID); ok {
+		return features, nil
+	}
+
+	features, _, err := client.Organizations.GetFeatures(ctx, orgUUID)
+	if err != nil {
+		apiErr, ok := err.(*terramate.APIError)
+		if !ok || !apiErr.IsNotFound() {
+			return nil, err
+		}
+		all := allFeatures
+		features = &all
+	}
+
+	session.SetFeatures(orgUUID, features)
+	return features, nil
+}
+
+// serviceNames describe the services of the SDK to users.
+var serviceNames = map[terramate.Service]string{
+	terramate.ServiceStacks:         "Stacks and drift",
+	terramate.ServiceDeployments:    "Deployments",
+	terramate.ServiceReviewRequests: "Review requests and previews",
+	terramate.ServiceResources:      "Resources",
+}
+
+// unavailableResult translates an error of a service the credential cannot
+// access, as found when the server probed it at startup, into a tool result
+// explaining the missing capability.
+func unavailableResult(err error) (*mcp.CallToolResult, bool) {
+	var unavailable *terramate.FeatureUnavailableError
+	if !errors.As(err, &unavailable) {
+		return nil, false
+	}
+	name, ok := serviceNames[unavailable.Service]
+	if !ok {
+		name = string(unavailable.Service)
+	}
+	reason := "is not allowed to use"
+	if unavailable.StatusCode == http.StatusNotFound {
+		reason = "has no access to"
+	}
+	return mcp.NewToolResultError(fmt.Sprintf(
+		"Capability unavailable: the configured credential %s the %s API in organization %s. "+
+			"Ask an organization admin for access or configure a credential with a broader role; tools for other capabilities keep working.",
+		reason, name, unavailable.OrganizationUUID)), true
+}
+
+// knownErrorResult translates the errors tools handle alike into a tool
+// result: unavailable services (see unavailableResult), rate limiting, and
+// responses larger than the size limit of the client, which are worth
+// retrying with a smaller page. The second result reports whether err was
+// translated.
+func knownErrorResult(err error) (*mcp.CallToolResult, bool) {
+	if result, ok := unavailableResult(err); ok {
+		return result, true
+	}
+	var apiErr *terramate.APIError
+	if errors.Is(err, terramate.ErrRateLimited) && errors.As(err, &apiErr) {
+		wait := "a moment"
+		if apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter.String()
+		}
+		return mcp.NewToolResultError(fmt.Sprintf(
+			"Rate limited by Terramate Cloud: %v. Wait %s before calling the tool again, and fetch fewer pages at once.",
+			apiErr, wait)), true
+	}
+	var tooLarge *terramate.ResponseTooLargeError
+	if !errors.As(err, &tooLarge) {
+		return nil, false
+	}
+	return mcp.NewToolResultError(fmt.Sprintf(
+		"Response too large: %v. Lower per_page and page through the results, or narrow them down with filters; "+
+			"the server operator can raise the limit with --max-response-size.", tooLarge)), true
+}
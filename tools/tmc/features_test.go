@@ -0,0 +1,116 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestOrganizationFeatures_CachesInSession(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"previews":true,"policies":false,"targets":false}`))
+	}))
+	defer ts.Close()
+
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	ctx := WithSession(context.Background(), &Session{})
+	for i := 0; i < 2; i++ {
+		features, err := OrganizationFeatures(ctx, c, "org-uuid")
+		if err != nil {
+			t.Fatalf("OrganizationFeatures error: %v", err)
+		}
+		if !features.Previews || features.Targets {
+			t.Fatalf("unexpected features: %+v", features)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Fatalf("expected features to be fetched once, got %d requests", requests.Load())
+	}
+}
+
+func TestOrganizationFeatures_NotFoundEnablesAll(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	features, err := OrganizationFeatures(context.Background(), c, "org-uuid")
+	if err != nil {
+		t.Fatalf("OrganizationFeatures error: %v", err)
+	}
+	if *features != allFeatures {
+		t.Fatalf("expected all features enabled, got %+v", features)
+	}
+}
+
+func TestUnavailableResult(t *testing.T) {
+	err := fmt.Errorf("listing: %w", &terramate.FeatureUnavailableError{
+		Service: terramate.ServiceReviewRequests, OrganizationUUID: "org-uuid", StatusCode: http.StatusForbidden,
+	})
+	result, ok := unavailableResult(err)
+	if !ok || !result.IsError {
+		t.Fatalf("expected a capability error result, got %+v", result)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"Review requests and previews API", "org-uuid", "is not allowed"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in %q", want, text)
+		}
+	}
+
+	if _, ok := unavailableResult(&terramate.APIError{StatusCode: http.StatusForbidden}); ok {
+		t.Fatal("expected other errors to be left to the tool")
+	}
+}
+
+func TestKnownErrorResult_ResponseTooLarge(t *testing.T) {
+	err := fmt.Errorf("listing: %w", &terramate.ResponseTooLargeError{Method: "GET", Path: "/v1/stacks/org-uuid", Limit: 1 << 20})
+	result, ok := knownErrorResult(err)
+	if !ok || !result.IsError {
+		t.Fatalf("expected a size limit error result, got %+v", result)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"GET /v1/stacks/org-uuid", "1 MiB", "per_page", "--max-response-size"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in %q", want, text)
+		}
+	}
+}
+
+func TestKnownErrorResult_RateLimited(t *testing.T) {
+	err := fmt.Errorf("listing: %w", &terramate.APIError{
+		StatusCode: http.StatusTooManyRequests, Message: "slow down", RequestID: "req-123", RetryAfter: 30 * time.Second,
+	})
+	result, ok := knownErrorResult(err)
+	if !ok || !result.IsError {
+		t.Fatalf("expected a rate limit error result, got %+v", result)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"Rate limited", "slow down", "req-123", "Wait 30s"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in %q", want, text)
+		}
+	}
+
+	if _, ok = knownErrorResult(&terramate.APIError{StatusCode: http.StatusNotFound}); ok {
+		t.Fatal("expected a 404 not to be translated")
+	}
+}
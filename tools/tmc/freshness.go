@@ -0,0 +1,211 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/internal/fanout"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// defaultMaxDataAge is the age beyond which tmc_data_freshness reports data
+// as stale when the call does not set max_age.
+const defaultMaxDataAge = 24 * time.Hour
+
+// driftStacksSampled is how many recently updated stacks tmc_data_freshness
+// checks for their newest drift run. There is no organization-wide drift
+// listing, and a drift run updates its stack, so the newest drift run is
+// found among the most recently updated stacks.
+const driftStacksSampled = 10
+
+// Freshness is the newest data of an organization seen in Terramate Cloud.
+type Freshness struct {
+	OrganizationUUID   string
+	CheckedAt          time.Time
+	NewestDeploymentAt *time.Time // nil when the organization has no deployments
+	NewestDriftAt      *time.Time // nil when no drift run was found
+}
+
+// FreshnessRecorder receives the freshness observed by tmc_data_freshness,
+// e.g. to export it as a metric.
+type FreshnessRecorder interface {
+	RecordFreshness(f Freshness)
+}
+
+// dataAge describes the newest data of one kind.
+type dataAge struct {
+	NewestAt   *time.Time `json:"newest_at,omitempty"`
+	Age        string     `json:"age,omitempty"`
+	AgeSeconds *int64     `json:"age_seconds,omitempty"`
+	Stale      bool       `json:"stale"`
+}
+
+// dataFreshnessResponse is the payload returned by tmc_data_freshness.
+type dataFreshnessResponse struct {
+	OrganizationUUID string  `json:"organization_uuid"`
+	CheckedAt        string  `json:"checked_at"`
+	MaxAge           string  `json:"max_age"`
+	Stale            bool    `json:"stale"`
+	Deployments      dataAge `json:"deployments"`
+	Drifts           dataAge `json:"drifts"`
+}
+
+// DataFreshness creates an MCP tool that reports how old the newest
+// deployment and drift run of an organization are, so a CI pipeline that
+// stopped syncing to Terramate Cloud is noticed. recorder, when not nil,
+// receives every observation.
+func DataFreshness(client *terramate.Client, recorder FreshnessRecorder) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_data_freshness",
+			Description: `Report how old the newest deployment and drift run of a Terramate Cloud organization are.
+
+Use this tool to check whether CI is still syncing to Terramate Cloud: when deployments or drift
+detection runs stop arriving, the data other tools report gets silently out of date.
+
+The newest deployment is the most recently updated CI/CD deployment run. The newest drift run
+is searched among the ` + fmt.Sprint(driftStacksSampled) + ` most recently updated stacks with drift detection.
+
+Response includes:
+- deployments / drifts: Each with:
+  * newest_at: Time of the newest data (absent when none was found)
+  * age, age_seconds: How long ago that was
+  * stale: True when older than max_age or absent
+- stale: True when either kind is stale
+- max_age: Threshold used (default: 24h)`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"max_age": map[string]interface{}{
+						"type":        "string",
+						"description": "Age beyond which data is reported as stale, as a duration, e.g. 6h or 90m (default: 24h)",
+					},
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			maxAge := defaultMaxDataAge
+			if s := request.GetString("max_age", ""); s != "" {
+				maxAge, err = time.ParseDuration(s)
+				if err != nil || maxAge <= 0 {
+					return mcp.NewToolResultError(fmt.Sprintf("Invalid max_age %q: must be a positive duration, e.g. 24h.", s)), nil
+				}
+			}
+
+			freshness, err := checkFreshness(ctx, client, orgUUID)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to check data freshness: %v", err)), nil
+			}
+			if recorder != nil {
+				recorder.RecordFreshness(freshness)
+			}
+
+			jsonData, err := json.MarshalIndent(freshnessResponse(freshness, maxAge), "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// checkFreshness finds the newest deployment and drift run of orgUUID.
+func checkFreshness(ctx context.Context, client *terramate.Client, orgUUID string) (Freshness, error) {
+	f := Freshness{OrganizationUUID: orgUUID, CheckedAt: time.Now()}
+
+	deployments, _, err := client.Deployments.List(ctx, orgUUID, &terramate.DeploymentsListOptions{
+		ListOptions: terramate.ListOptions{Page: 1, PerPage: 1},
+	})
+	if err != nil {
+		return f, err
+	}
+	for _, d := range deployments.Deployments {
+		f.NewestDeploymentAt = latest(f.NewestDeploymentAt, &d.CreatedAt, d.StartedAt, d.FinishedAt)
+	}
+
+	stacks, _, err := client.Stacks.List(ctx, orgUUID, &terramate.StacksListOptions{
+		ListOptions: terramate.ListOptions{Page: 1, PerPage: driftStacksSampled},
+		DriftStatus: []string{"ok", "drifted", "failed"},
+		Sort:        []string{"-updated_at"},
+	})
+	if err != nil {
+		return f, err
+	}
+	newest := make([]*time.Time, len(stacks.Stacks))
+	err = fanout.Run(ctx, len(stacks.Stacks), func(ctx context.Context, i int) error {
+		drifts, _, err := client.Drifts.ListForStack(ctx, orgUUID, stacks.Stacks[i].StackID, &terramate.DriftsListOptions{
+			ListOptions: terramate.ListOptions{Page: 1, PerPage: 1},
+		})
+		if err != nil {
+			return err
+		}
+		for _, drift := range drifts.Drifts {
+			newest[i] = latest(newest[i], drift.StartedAt, drift.FinishedAt)
+		}
+		return nil
+	})
+	if err != nil {
+		return f, err
+	}
+	f.NewestDriftAt = latest(nil, newest...)
+
+	return f, nil
+}
+
+// latest returns the latest of the non-nil times, or current when all are nil
+// or earlier.
+func latest(current *time.Time, times ...*time.Time) *time.Time {
+	for _, t := range times {
+		if t != nil && (current == nil || t.After(*current)) {
+			v := *t
+			current = &v
+		}
+	}
+	return current
+}
+
+// freshnessResponse evaluates f against maxAge.
+func freshnessResponse(f Freshness, maxAge time.Duration) dataFreshnessResponse {
+	age := func(newest *time.Time) dataAge {
+		if newest == nil {
+			return dataAge{Stale: true}
+		}
+		d := f.CheckedAt.Sub(*newest).Truncate(time.Second)
+		seconds := int64(d.Seconds())
+		return dataAge{NewestAt: newest, Age: d.String(), AgeSeconds: &seconds, Stale: d > maxAge}
+	}
+
+	response := dataFreshnessResponse{
+		OrganizationUUID: f.OrganizationUUID,
+		CheckedAt:        f.CheckedAt.UTC().Format(time.RFC3339),
+		MaxAge:           maxAge.String(),
+		Deployments:      age(f.NewestDeploymentAt),
+		Drifts:           age(f.NewestDriftAt),
+	}
+	response.Stale = response.Deployments.Stale || response.Drifts.Stale
+	return response
+}
@@ -0,0 +1,116 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+type freshnessRecorderFunc func(Freshness)
+
+func (f freshnessRecorderFunc) RecordFreshness(freshness Freshness) { f(freshness) }
+
+func TestDataFreshness(t *testing.T) {
+	now := time.Now().UTC()
+	deployedAt := now.Add(-2 * time.Hour)
+	driftOld, driftNew := now.Add(-50*time.Hour), now.Add(-30*time.Hour)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body interface{}
+		switch r.URL.Path {
+		case "/v1/organizations/org-uuid/deployments":
+			body = terramate.DeploymentsListResponse{Deployments: []terramate.WorkflowDeploymentGroup{
+				{ID: 1, CreatedAt: deployedAt.Add(-time.Minute), FinishedAt: &deployedAt},
+			}}
+		case "/v1/stacks/org-uuid":
+			if got := r.URL.Query().Get("sort"); got != "-updated_at" {
+				t.Errorf("expected stacks sorted by -updated_at, got %q", got)
+			}
+			body = terramate.StacksListResponse{Stacks: []terramate.Stack{{StackID: 1}, {StackID: 2}}}
+		case "/v1/stacks/org-uuid/1/drifts":
+			body = terramate.DriftsListResponse{Drifts: []terramate.Drift{{ID: 10, FinishedAt: &driftOld}}}
+		case "/v1/stacks/org-uuid/2/drifts":
+			body = terramate.DriftsListResponse{Drifts: []terramate.Drift{{ID: 20, FinishedAt: &driftNew}}}
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer ts.Close()
+
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	var recorded Freshness
+	tool := DataFreshness(c, freshnessRecorderFunc(func(f Freshness) { recorded = f }))
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"organization_uuid": "org-uuid"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	textContent, _ := mcp.AsTextContent(result.Content[0])
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", textContent.Text)
+	}
+
+	var response dataFreshnessResponse
+	if err := json.Unmarshal([]byte(textContent.Text), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if response.Deployments.Stale || response.Deployments.NewestAt == nil || !response.Deployments.NewestAt.Equal(deployedAt) {
+		t.Errorf("unexpected deployments freshness: %+v", response.Deployments)
+	}
+	if !response.Drifts.Stale || response.Drifts.NewestAt == nil || !response.Drifts.NewestAt.Equal(driftNew) {
+		t.Errorf("unexpected drifts freshness: %+v", response.Drifts)
+	}
+	if !response.Stale || response.MaxAge != "24h0m0s" {
+		t.Errorf("expected stale response with the default max age, got %+v", response)
+	}
+	if recorded.OrganizationUUID != "org-uuid" || recorded.NewestDriftAt == nil || !recorded.NewestDriftAt.Equal(driftNew) {
+		t.Errorf("unexpected recorded freshness: %+v", recorded)
+	}
+}
+
+func TestFreshnessResponse(t *testing.T) {
+	checkedAt := time.Date(2026, 1, 8, 12, 0, 0, 0, time.UTC)
+	deployedAt := checkedAt.Add(-90 * time.Minute)
+
+	got := freshnessResponse(Freshness{OrganizationUUID: "org", CheckedAt: checkedAt, NewestDeploymentAt: &deployedAt}, time.Hour)
+	if !got.Deployments.Stale || got.Deployments.Age != "1h30m0s" || *got.Deployments.AgeSeconds != 5400 {
+		t.Errorf("unexpected deployments age: %+v", got.Deployments)
+	}
+	if !got.Drifts.Stale || got.Drifts.NewestAt != nil || got.Drifts.AgeSeconds != nil {
+		t.Errorf("expected missing drift data to be stale, got %+v", got.Drifts)
+	}
+
+	if got := freshnessResponse(Freshness{CheckedAt: checkedAt, NewestDeploymentAt: &deployedAt, NewestDriftAt: &deployedAt}, 2*time.Hour); got.Stale {
+		t.Errorf("expected fresh data within max age, got %+v", got)
+	}
+}
+
+func TestDataFreshness_InvalidMaxAge(t *testing.T) {
+	c, err := terramate.NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	result, err := DataFreshness(c, nil).Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"organization_uuid": "org-uuid", "max_age": "-1h"},
+		},
+	})
+	if err != nil || !result.IsError {
+		t.Fatalf("expected error result for invalid max_age, got %+v (err %v)", result, err)
+	}
+}
@@ -0,0 +1,205 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate/changeset"
+	"github.com/terramate-io/terramate-mcp-server/tools/guardrail"
+)
+
+// applyAdvice is the payload returned by tmc_advise_apply.
+type applyAdvice struct {
+	guardrail.Advice
+	ReviewRequestID int       `json:"review_request_id"`
+	Title           string    `json:"title,omitempty"`
+	URL             string    `json:"url,omitempty"`
+	EvaluatedAt     time.Time `json:"evaluated_at"`
+	Changes         int       `json:"changes"` // resource changes found in the stack previews
+}
+
+// AdviseApply creates an MCP tool that checks the planned changes of a review
+// request against the configured guardrail policy.
+func AdviseApply(client *terramate.Client, policy *guardrail.Policy) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_advise_apply",
+			Description: `Advise whether the changes of a review request (PR/MR) can be applied now.
+
+Checks the terraform plans of every stack preview of the review request against the
+organization's guardrail policy configured on the server:
+- Protected resources: resource address (and stack path) patterns that must not be destroyed,
+  replaced or otherwise changed, or only with approval
+- Change windows: the days and times applying is allowed
+
+Response includes:
+- decision: apply_now, needs_approval or blocked (the most restrictive finding decides)
+- reasons: Each finding with its decision, message, and the stack and resource address concerned
+- changes: Number of resource changes evaluated
+- review_request_id, title, url: The review request evaluated
+- evaluated_at: The time checked against the change windows
+
+Stacks whose plan is not available (pending, running, failed) need approval, since their
+changes are unknown.
+
+Workflow:
+1. tmc_list_review_requests to find the PR
+2. tmc_advise_apply to check it against the guardrails
+3. tmc_get_review_request to inspect the plans behind the reasons`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"review_request_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Review Request ID (get from tmc_list_review_requests)",
+					},
+					"at": map[string]interface{}{
+						"type":        "string",
+						"description": "Check the change windows at this RFC3339 time instead of now (e.g. 2026-01-10T09:00:00Z)",
+					},
+				},
+				Required: []string{"review_request_id"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			reviewRequestID, err := request.RequireInt("review_request_id")
+			if err != nil {
+				return mcp.NewToolResultError("Review Request ID is required and must be a number."), nil
+			}
+			if reviewRequestID <= 0 {
+				return mcp.NewToolResultError("Review Request ID must be positive."), nil
+			}
+
+			at := time.Now().UTC()
+			if value := request.GetString("at", ""); value != "" {
+				if at, err = time.Parse(time.RFC3339, value); err != nil {
+					return mcp.NewToolResultError("at must be an RFC3339 time, e.g. 2026-01-10T09:00:00Z."), nil
+				}
+			}
+
+			result, _, err := client.ReviewRequests.Get(ctx, orgUUID, reviewRequestID, nil)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					if apiErr.IsNotFound() {
+						return mcp.NewToolResultError(fmt.Sprintf("Review Request with ID %d not found.", reviewRequestID)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get review request: %v", err)), nil
+			}
+
+			advice := adviseReviewRequest(policy, result, at)
+			jsonData, err := json.MarshalIndent(advice, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// adviseReviewRequest evaluates the stack previews of a review request.
+func adviseReviewRequest(policy *guardrail.Policy, result *terramate.ReviewRequestGetResponse, at time.Time) applyAdvice {
+	var changes []guardrail.Change
+	var unknown []guardrail.Reason
+	for _, preview := range result.StackPreviews {
+		path := preview.Path
+		if preview.Stack != nil && preview.Stack.Path != "" {
+			path = preview.Stack.Path
+		}
+		switch preview.Status {
+		case "changed":
+			changes = append(changes, previewChanges(path, preview.ChangesetDetails)...)
+		case "unchanged", "canceled":
+		default: // affected, pending, running, failed
+			unknown = append(unknown, guardrail.Reason{
+				Decision: guardrail.NeedsApproval,
+				Message:  fmt.Sprintf("the plan of stack %s is not available (preview %s), so its changes cannot be checked", path, preview.Status),
+				Stack:    path,
+			})
+		}
+	}
+
+	advice := policy.Evaluate(changes, at)
+	for _, reason := range unknown {
+		advice.Add(reason)
+	}
+	return applyAdvice{
+		Advice:          advice,
+		ReviewRequestID: result.ReviewRequest.ReviewRequestID,
+		Title:           result.ReviewRequest.Title,
+		URL:             result.ReviewRequest.URL,
+		EvaluatedAt:     at,
+		Changes:         len(changes),
+	}
+}
+
+// previewChanges lists the resource changes of a stack's plan, preferring
+// the JSON plan and falling back to the ASCII one.
+func previewChanges(stackPath string, details *terramate.ChangesetDetails) []guardrail.Change {
+	if details == nil {
+		return nil
+	}
+	var changes []guardrail.Change
+	if plan, err := changeset.FromDetails(details); err == nil {
+		for _, rc := range plan.ResourceChanges {
+			if rc.Change == nil {
+				continue
+			}
+			if action := planAction(rc.Change.Actions); action != "" {
+				changes = append(changes, guardrail.Change{Stack: stackPath, Address: rc.Address, Action: action})
+			}
+		}
+		return changes
+	}
+
+	parsed := parsePlanChanges(details.ChangesetASCII)
+	add := func(action string, addresses []string) {
+		for _, address := range addresses {
+			changes = append(changes, guardrail.Change{Stack: stackPath, Address: address, Action: action})
+		}
+	}
+	add(guardrail.ActionCreate, parsed.Create)
+	add(guardrail.ActionUpdate, parsed.Update)
+	add(guardrail.ActionReplace, parsed.Replace)
+	add(guardrail.ActionDelete, parsed.Destroy)
+	return changes
+}
+
+// planAction maps the actions of a JSON plan resource change to a guardrail
+// action; no-op and read changes yield "".
+func planAction(actions changeset.Actions) string {
+	switch {
+	case actions.Replace():
+		return guardrail.ActionReplace
+	case actions.Create():
+		return guardrail.ActionCreate
+	case actions.Update():
+		return guardrail.ActionUpdate
+	case actions.Delete():
+		return guardrail.ActionDelete
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,117 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/guardrail"
+)
+
+func TestAdviseApply(t *testing.T) {
+	planJSON := `{"resource_changes": [
+		{"address": "aws_db_instance.main", "change": {"actions": ["delete", "create"]}},
+		{"address": "aws_s3_bucket.logs", "change": {"actions": ["no-op"]}}
+	]}`
+	response := terramate.ReviewRequestGetResponse{
+		ReviewRequest: terramate.ReviewRequest{ReviewRequestID: 42, Title: "Resize database", URL: "https://github.com/acme/infra/pull/7"},
+		StackPreviews: []terramate.StackPreview{
+			{Status: "changed", Stack: &terramate.Stack{Path: "/prod/db"}, ChangesetDetails: &terramate.ChangesetDetails{ChangesetJSON: planJSON}},
+			{Status: "changed", Stack: &terramate.Stack{Path: "/prod/iam"}, ChangesetDetails: &terramate.ChangesetDetails{
+				ChangesetASCII: "  # aws_iam_role.ci will be updated in-place\n",
+			}},
+			{Status: "unchanged", Stack: &terramate.Stack{Path: "/prod/app"}},
+			{Status: "failed", Stack: &terramate.Stack{Path: "/prod/cache"}},
+		},
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/review_requests/org-uuid/42" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer ts.Close()
+
+	client, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	policy := &guardrail.Policy{
+		ProtectedResources: []guardrail.ProtectedResource{
+			{Address: "aws_db_instance.*"},
+			{Address: "aws_iam_*", Actions: []string{guardrail.ActionUpdate}, Decision: guardrail.NeedsApproval},
+		},
+		ChangeWindows: []guardrail.ChangeWindow{{Start: "09:00", End: "17:00"}},
+	}
+
+	result, err := AdviseApply(client, policy).Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"organization_uuid": "org-uuid",
+				"review_request_id": float64(42),
+				"at":                "2026-01-07T10:00:00Z",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", textContent.Text)
+	}
+
+	var advice applyAdvice
+	if err := json.Unmarshal([]byte(textContent.Text), &advice); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if advice.Decision != guardrail.Blocked || advice.Changes != 2 || advice.ReviewRequestID != 42 || advice.Title != "Resize database" {
+		t.Fatalf("unexpected advice: %s", textContent.Text)
+	}
+	var decisions []string
+	for _, reason := range advice.Reasons {
+		decisions = append(decisions, reason.Stack+":"+reason.Decision)
+	}
+	want := "/prod/db:blocked /prod/iam:needs_approval /prod/cache:needs_approval"
+	if got := strings.Join(decisions, " "); got != want {
+		t.Fatalf("expected reasons %q, got %q", want, got)
+	}
+}
+
+func TestAdviseApply_InvalidArguments(t *testing.T) {
+	client, err := terramate.NewClientWithAPIKey("key")
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	tool := AdviseApply(client, &guardrail.Policy{})
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{name: "missing review request", args: map[string]interface{}{"organization_uuid": "org-uuid"}, wantErr: "Review Request ID is required"},
+		{name: "invalid time", args: map[string]interface{}{"organization_uuid": "org-uuid", "review_request_id": float64(1), "at": "tomorrow"}, wantErr: "RFC3339"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tt.args}})
+			if err != nil {
+				t.Fatalf("Handler error: %v", err)
+			}
+			textContent, _ := mcp.AsTextContent(result.Content[0])
+			if !result.IsError || !strings.Contains(textContent.Text, tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %q", tt.wantErr, textContent.Text)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/tools/index"
+)
+
+// indexedResponse wraps the rows returned by the index tools.
+type indexedResponse struct {
+	Items    any        `json:"items"`
+	Total    int        `json:"total"`
+	SyncedAt *time.Time `json:"synced_at,omitempty"` // nil until the first sync completed
+}
+
+// indexOrgParam is the organization_uuid parameter of the index tools.
+var indexOrgParam = map[string]interface{}{
+	"type":        "string",
+	"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+}
+
+// indexPagingParams are the limit/offset parameters of the index tools.
+var indexPagingParams = map[string]interface{}{
+	"limit": map[string]interface{}{
+		"type":        "number",
+		"description": fmt.Sprintf("Maximum number of items to return (default: %d, max: %d)", index.DefaultLimit, index.MaxLimit),
+	},
+	"offset": map[string]interface{}{
+		"type":        "number",
+		"description": "Number of items to skip",
+	},
+}
+
+// stringArrayParam describes an array-of-strings filter parameter.
+func stringArrayParam(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "array",
+		"description": description,
+		"items": map[string]interface{}{
+			"type": "string",
+		},
+	}
+}
+
+// indexProperties merges the common index tool parameters with props.
+func indexProperties(props map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{"organization_uuid": indexOrgParam}
+	for k, v := range indexPagingParams {
+		merged[k] = v
+	}
+	for k, v := range props {
+		merged[k] = v
+	}
+	return merged
+}
+
+// indexQueryHandler runs query against the index and wraps its result with
+// the organization's sync time.
+func indexQueryHandler(idx *index.Index, query func(ctx context.Context, orgUUID string, request mcp.CallToolRequest) (any, int, error)) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		orgUUID, err := request.RequireString("organization_uuid")
+		if err != nil {
+			return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+		}
+
+		items, total, err := query(ctx, orgUUID, request)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to query index: %v", err)), nil
+		}
+		status, err := idx.Status(ctx, orgUUID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to query index: %v", err)), nil
+		}
+
+		jsonData, err := json.MarshalIndent(indexedResponse{Items: items, Total: total, SyncedAt: status.SyncedAt}, "", "  ")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+		}
+		return mcp.NewToolResultText(string(jsonData)), nil
+	}
+}
+
+// IndexListStacks creates an MCP tool that lists stacks from the local index.
+func IndexListStacks(idx *index.Index) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_index_list_stacks",
+			Description: `List stacks from the local index, answering in milliseconds even for large organizations.
+
+The index is a local copy of stack metadata synced from Terramate Cloud in the background, so
+results may lag the live API by the sync interval (see synced_at; tmc_index_status shows sync
+health). Prefer this tool for exploration and filtering; use tmc_get_stack for live details.
+
+Supported filters:
+- repository, target, status, drift_status: Match any of the given values
+- tags: Only stacks having all of these tags
+- search: Substring of meta_id, meta_name, meta_description or path
+- is_archived: Archived stacks only (true) or active stacks only (false)
+- limit / offset: Paging
+
+Response includes:
+- items: Stacks, most recently updated first
+- total: Number of matching stacks
+- synced_at: When the index was last synced`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: indexProperties(map[string]interface{}{
+					"repository":   stringArrayParam("Filter by repository URLs"),
+					"target":       stringArrayParam("Filter by deployment target"),
+					"status":       stringArrayParam("Filter by stack status (ok, failed, drifted, canceled, unknown)"),
+					"drift_status": stringArrayParam("Filter by drift status (ok, drifted, failed, unknown)"),
+					"tags":         stringArrayParam("Only stacks having all of these tags"),
+					"search": map[string]interface{}{
+						"type":        "string",
+						"description": "Substring of meta_id, meta_name, meta_description or path",
+					},
+					"is_archived": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Archived stacks only (true) or active stacks only (false)",
+					},
+				}),
+				Required: []string{},
+			},
+		},
+		Handler: indexQueryHandler(idx, func(ctx context.Context, orgUUID string, request mcp.CallToolRequest) (any, int, error) {
+			q := index.StackQuery{
+				Repository:  request.GetStringSlice("repository", nil),
+				Target:      request.GetStringSlice("target", nil),
+				Status:      request.GetStringSlice("status", nil),
+				DriftStatus: request.GetStringSlice("drift_status", nil),
+				Tags:        request.GetStringSlice("tags", nil),
+				Search:      request.GetString("search", ""),
+				Limit:       request.GetInt("limit", 0),
+				Offset:      request.GetInt("offset", 0),
+			}
+			if archived, err := request.RequireBool("is_archived"); err == nil {
+				q.IsArchived = &archived
+			}
+			return idx.Stacks(ctx, orgUUID, q)
+		}),
+	}
+}
+
+// IndexListDrifts creates an MCP tool that lists drift runs from the local index.
+func IndexListDrifts(idx *index.Index) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_index_list_drifts",
+			Description: `List recent drift detection runs from the local index, across all stacks or for one stack.
+
+The index keeps the most recent drift runs of every stack, synced in the background. Use
+tmc_get_drift for the full plan of a run.
+
+Supported filters:
+- stack_id: Only runs of this stack
+- status: Filter by drift status (ok, drifted, failed)
+- limit / offset: Paging
+
+Response includes:
+- items: Drift runs, newest first
+- total: Number of matching runs
+- synced_at: When the index was last synced`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: indexProperties(map[string]interface{}{
+					"stack_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Only drift runs of this stack",
+					},
+					"status": stringArrayParam("Filter by drift status (ok, drifted, failed)"),
+				}),
+				Required: []string{},
+			},
+		},
+		Handler: indexQueryHandler(idx, func(ctx context.Context, orgUUID string, request mcp.CallToolRequest) (any, int, error) {
+			return idx.Drifts(ctx, orgUUID, index.DriftQuery{
+				StackID: request.GetInt("stack_id", 0),
+				Status:  request.GetStringSlice("status", nil),
+				Limit:   request.GetInt("limit", 0),
+				Offset:  request.GetInt("offset", 0),
+			})
+		}),
+	}
+}
+
+// IndexListDeployments creates an MCP tool that lists stack deployments from the local index.
+func IndexListDeployments(idx *index.Index) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_index_list_deployments",
+			Description: `List stack deployments from the local index.
+
+The index holds the stack deployments of the last 30 days at its first sync and every deployment
+since, synced in the background. Use tmc_get_stack_deployment for plan details and
+tmc_get_deployment_logs for logs.
+
+Supported filters:
+- stack_id: Only deployments of this stack
+- repository: Filter by repository URLs
+- status: Filter by status (canceled, failed, ok, pending, running)
+- limit / offset: Paging
+
+Response includes:
+- items: Stack deployments, newest first
+- total: Number of matching deployments
+- synced_at: When the index was last synced`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: indexProperties(map[string]interface{}{
+					"stack_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Only deployments of this stack",
+					},
+					"repository": stringArrayParam("Filter by repository URLs"),
+					"status":     stringArrayParam("Filter by status (canceled, failed, ok, pending, running)"),
+				}),
+				Required: []string{},
+			},
+		},
+		Handler: indexQueryHandler(idx, func(ctx context.Context, orgUUID string, request mcp.CallToolRequest) (any, int, error) {
+			return idx.Deployments(ctx, orgUUID, index.DeploymentQuery{
+				StackID:    request.GetInt("stack_id", 0),
+				Repository: request.GetStringSlice("repository", nil),
+				Status:     request.GetStringSlice("status", nil),
+				Limit:      request.GetInt("limit", 0),
+				Offset:     request.GetInt("offset", 0),
+			})
+		}),
+	}
+}
+
+// IndexStatus creates an MCP tool that reports the sync state of the local index.
+func IndexStatus(idx *index.Index) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_index_status",
+			Description: `Report the sync state of the local index for an organization.
+
+Response includes:
+- synced_at: When the last successful sync completed (absent before the first sync)
+- last_error: Error of the last sync attempt, if it failed
+- stacks, drifts, deployments: Number of indexed items
+- documents: Number of searchable items (see tmc_search)`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": indexOrgParam,
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			status, err := idx.Status(ctx, orgUUID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to query index: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
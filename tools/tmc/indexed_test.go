@@ -0,0 +1,126 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/index"
+)
+
+// newSyncedIndex returns an in-memory index synced from a fake API with two stacks.
+func newSyncedIndex(t *testing.T) *index.Index {
+	t.Helper()
+	updated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body any
+		switch {
+		case r.URL.Path == "/v1/stacks/org-uuid":
+			body = terramate.StacksListResponse{
+				Stacks: []terramate.Stack{
+					{StackID: 1, Repository: "github.com/acme/infra", Path: "/network", Status: "ok", UpdatedAt: updated},
+					{StackID: 2, Repository: "github.com/acme/apps", Path: "/web", Status: "failed", UpdatedAt: updated},
+				},
+				PaginatedResult: terramate.PaginatedResult{Total: 2, Page: 1, PerPage: 100},
+			}
+		case strings.HasSuffix(r.URL.Path, "/drifts"):
+			body = terramate.DriftsListResponse{PaginatedResult: terramate.PaginatedResult{Page: 1, PerPage: 10}}
+		default:
+			body = terramate.StackDeploymentsListResponse{PaginatedResult: terramate.PaginatedResult{Page: 1, PerPage: 100}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer ts.Close()
+
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	idx, err := index.Open(":memory:")
+	if err != nil {
+		t.Fatalf("Open error: %v", err)
+	}
+	t.Cleanup(func() { _ = idx.Close() })
+	if err := idx.Sync(context.Background(), c, "org-uuid"); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+	return idx
+}
+
+func TestIndexListStacks(t *testing.T) {
+	idx := newSyncedIndex(t)
+
+	result, err := IndexListStacks(idx).Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"organization_uuid": "org-uuid",
+				"status":            []interface{}{"failed"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", textContent.Text)
+	}
+
+	var response struct {
+		Items    []terramate.Stack `json:"items"`
+		Total    int               `json:"total"`
+		SyncedAt *time.Time        `json:"synced_at"`
+	}
+	if err := json.Unmarshal([]byte(textContent.Text), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if response.Total != 1 || response.Items[0].StackID != 2 || response.SyncedAt == nil {
+		t.Fatalf("unexpected response: %s", textContent.Text)
+	}
+}
+
+func TestIndexStatus(t *testing.T) {
+	idx := newSyncedIndex(t)
+
+	result, err := IndexStatus(idx).Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"organization_uuid": "org-uuid"},
+		},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected result: %+v, err %v", result, err)
+	}
+	textContent, _ := mcp.AsTextContent(result.Content[0])
+	var status index.Status
+	if err := json.Unmarshal([]byte(textContent.Text), &status); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if status.Stacks != 2 || status.SyncedAt == nil {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestIndexTools_RequireOrganization(t *testing.T) {
+	idx := newSyncedIndex(t)
+	tools := []server.ServerTool{IndexListStacks(idx), IndexListDrifts(idx), IndexListDeployments(idx), IndexStatus(idx), Search(idx)}
+	for _, tool := range tools {
+		result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+		if err != nil {
+			t.Fatalf("%s: Handler error: %v", tool.Tool.Name, err)
+		}
+		if !result.IsError {
+			t.Fatalf("%s: expected error result without organization", tool.Tool.Name)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// LoginToolName is the name of the tmc_login tool.
+const LoginToolName = "tmc_login"
+
+// LoginFunc starts an interactive login, returning the code the user
+// authorizes it with. The login completes in the background once authorized.
+type LoginFunc func(ctx context.Context) (*terramate.DeviceCode, error)
+
+// loginResponse is the payload returned by tmc_login.
+type loginResponse struct {
+	*terramate.DeviceCode
+	Instructions string `json:"instructions"`
+}
+
+// Login creates the tmc_login tool, which logs in to Terramate Cloud with
+// GitHub without the Terramate CLI.
+func Login(start LoginFunc) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: LoginToolName,
+			Description: `Log in to Terramate Cloud with GitHub, without the Terramate CLI.
+
+Use this tool when no credential is configured, or when tools fail because the login expired.
+Show the user the verification_uri and user_code from the response: they open the URL on any
+device, enter the code and authorize the login. The server stores the credential in the
+credential file once authorized; then call tmc_authenticate.
+
+Response includes:
+- verification_uri: Page the user opens
+- user_code: Code the user enters there
+- expires_at: When the code expires; call tmc_login again afterwards`,
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+				Required:   []string{},
+			},
+		},
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			code, err := start(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to start the login: %v", err)), nil
+			}
+			jsonData, err := json.MarshalIndent(loginResponse{
+				DeviceCode: code,
+				Instructions: fmt.Sprintf("Open %s and enter the code %s within %s to authorize the login, then call tmc_authenticate.",
+					code.VerificationURI, code.UserCode, time.Until(code.ExpiresAt).Round(time.Minute)),
+			}, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// Logout creates the tmc_logout tool, which ends the Terramate Cloud login
+// stored on the machine, calling logout to remove it.
+func Logout(logout func(ctx context.Context) error) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_logout",
+			Description: `Log out of Terramate Cloud, e.g. when done on a shared workstation.
+
+Removes the stored login: the refresh token is revoked when the server has a revocation endpoint
+configured, the tokens are cleared from memory and the credential file is deleted. This also logs
+out the Terramate CLI, which shares the credential file. Afterwards only the local tools are
+available until the user logs in again (tmc_login or 'terramate cloud login') and calls
+tmc_authenticate.
+
+Only call this tool when the user asks to log out.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+				Required:   []string{},
+			},
+		},
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if err := logout(ctx); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Logout incomplete: %v", err)), nil
+			}
+			return mcp.NewToolResultText("Logged out of Terramate Cloud: the stored credential was removed."), nil
+		},
+	}
+}
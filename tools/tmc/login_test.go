@@ -0,0 +1,47 @@
+package tmc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestLogin(t *testing.T) {
+	code := &terramate.DeviceCode{UserCode: "ABCD-1234", VerificationURI: "https://github.com/login/device", ExpiresAt: time.Now().Add(15 * time.Minute)}
+	tool := Login(func(context.Context) (*terramate.DeviceCode, error) { return code, nil })
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected error: %v %+v", err, result)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{`"user_code": "ABCD-1234"`, `"verification_uri": "https://github.com/login/device"`, "within 15m0s"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in %s", want, text)
+		}
+	}
+
+	failing := Login(func(context.Context) (*terramate.DeviceCode, error) { return nil, errors.New("unreachable") })
+	if result, _ := failing.Handler(context.Background(), mcp.CallToolRequest{}); !result.IsError {
+		t.Fatal("expected an error result when the login cannot start")
+	}
+}
+
+func TestLogout(t *testing.T) {
+	called := false
+	tool := Logout(func(context.Context) error { called = true; return nil })
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil || result.IsError || !called {
+		t.Fatalf("unexpected result: %v %+v (called %v)", err, result, called)
+	}
+
+	failing := Logout(func(context.Context) error { return errors.New("revocation failed") })
+	result, _ = failing.Handler(context.Background(), mcp.CallToolRequest{})
+	if !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "revocation failed") {
+		t.Fatalf("expected the logout error, got %+v", result)
+	}
+}
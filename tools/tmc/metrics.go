@@ -0,0 +1,111 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// GetStackMetrics creates an MCP tool that reports DORA-style delivery
+// metrics of the stack deployments of an organization.
+func GetStackMetrics(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_get_stack_metrics",
+			Description: `Get DORA-style delivery metrics of stack deployments: deployment frequency, failure rate and mean time to recovery.
+
+Use this for engineering-leadership questions such as "how often do we deploy?", "which stacks fail
+the most?" or "how long does it take us to fix a failed deployment?".
+
+Metrics cover the stack deployments created in the period (the last 30 days by default), for the
+whole organization, one stack or one repository, and can be broken down per stack or repository.
+
+Response includes:
+- from, to: The period
+- overall and groups[] (with group_by), each with:
+  - deployments, succeeded, failed, canceled: Stack deployment counts
+  - deployments_per_day: Deployment frequency
+  - failure_rate: Fraction of finished deployments that failed (0-1)
+  - recoveries, mean_time_to_recovery_seconds: Failures fixed in the period and the mean time
+    from the first failure of a stack to its fix
+  - unrecovered: Stacks still failing at the end of the period`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"stack_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Only this stack (get from tmc_list_stacks)",
+					},
+					"repository": map[string]interface{}{
+						"type":        "string",
+						"description": "Only the stacks of this repository URL",
+					},
+					"group_by": map[string]interface{}{
+						"type":        "string",
+						"description": "Break the metrics down per stack or repository",
+						"enum":        []string{terramate.DeliveryMetricsByStack, terramate.DeliveryMetricsByRepository},
+					},
+					"created_at_from": map[string]interface{}{
+						"type":        "string",
+						"description": "Start of the period, RFC3339 (default: 30 days before created_at_to)",
+					},
+					"created_at_to": map[string]interface{}{
+						"type":        "string",
+						"description": "End of the period, RFC3339 (default: now)",
+					},
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			opts := &terramate.DeliveryMetricsOptions{
+				StackID:    request.GetInt("stack_id", 0),
+				Repository: request.GetString("repository", ""),
+				GroupBy:    request.GetString("group_by", ""),
+			}
+			if result := createdAtRange(request, &opts.From, &opts.To); result != nil {
+				return result, nil
+			}
+			if opts.StackID < 0 {
+				return mcp.NewToolResultError("Stack ID must be positive."), nil
+			}
+
+			report, err := client.Metrics.GetDelivery(ctx, orgUUID, opts)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					if apiErr.IsNotFound() {
+						return mcp.NewToolResultError(fmt.Sprintf("Stack with ID %d not found.", opts.StackID)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get stack metrics: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package tmc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestGetStackMetrics(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/stacks/org-uuid/404/deployments" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Path != "/v1/stack_deployments/org-uuid" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("created_at_from") != "2026-03-01T00:00:00Z" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"stack_deployments": [
+			{"id": 2, "status": "ok", "path": "/web", "created_at": "2026-03-02T10:00:00Z", "finished_at": "2026-03-02T10:30:00Z", "stack": {"stack_id": 1, "repository": "github.com/acme/infra", "path": "/web"}},
+			{"id": 1, "status": "failed", "path": "/web", "created_at": "2026-03-02T09:00:00Z", "finished_at": "2026-03-02T09:30:00Z", "stack": {"stack_id": 1, "repository": "github.com/acme/infra", "path": "/web"}}
+		], "paginated_result": {"total": 2, "page": 1, "per_page": 100}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	call := func(args map[string]interface{}) (*mcp.CallToolResult, string) {
+		t.Helper()
+		args["organization_uuid"] = "org-uuid"
+		args["created_at_from"] = "2026-03-01T00:00:00Z"
+		args["created_at_to"] = "2026-03-11T00:00:00Z"
+		result, err := GetStackMetrics(c).Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return result, text.Text
+	}
+
+	result, text := call(map[string]interface{}{"group_by": "stack"})
+	for _, want := range []string{`"failure_rate": 0.5`, `"mean_time_to_recovery_seconds": 3600`, `"deployments_per_day": 0.2`, `"path": "/web"`} {
+		if result.IsError || !strings.Contains(text, want) {
+			t.Errorf("expected %s in: %s", want, text)
+		}
+	}
+
+	result, text = call(map[string]interface{}{"stack_id": float64(404)})
+	if !result.IsError || !strings.Contains(text, "Stack with ID 404 not found") {
+		t.Errorf("expected a not found error, got %s", text)
+	}
+
+	result, text = call(map[string]interface{}{"group_by": "team"})
+	if !result.IsError || !strings.Contains(text, "unknown group by") {
+		t.Errorf("expected a group by error, got %s", text)
+	}
+}
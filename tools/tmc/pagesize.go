@@ -0,0 +1,78 @@
+package tmc
+
+import (
+	"sync"
+)
+
+// Adaptive page sizing bounds for aggregate tools.
+const (
+	// targetPageBytes is the response size aggregate tools aim for per page.
+	targetPageBytes = 1 << 20
+	// minAdaptivePerPage keeps pages of very large objects from degrading
+	// into one round trip per object.
+	minAdaptivePerPage = 5
+	// pageSizeSmoothing weights new observations in the running average.
+	pageSizeSmoothing = 0.3
+)
+
+// pageSizer chooses per_page for aggregate tools from the average object size
+// observed per endpoint: endpoints returning large objects (e.g. previews
+// embedding their stacks) get smaller pages to bound memory, slim lists get
+// larger pages to save round trips.
+//
+// A walk over an endpoint must use the same per_page for all of its pages, so
+// observations only affect subsequent walks.
+type pageSizer struct {
+	mu          sync.Mutex
+	avgBytes    map[string]float64 // endpoint -> average bytes per object
+	targetBytes int
+	minPerPage  int
+}
+
+// pageSizes is shared by all tool invocations; object sizes are a property
+// of the endpoint, not of the client session.
+var pageSizes = newPageSizer(targetPageBytes, minAdaptivePerPage)
+
+func newPageSizer(targetBytes, minPerPage int) *pageSizer {
+	return &pageSizer{
+		avgBytes:    map[string]float64{},
+		targetBytes: targetBytes,
+		minPerPage:  minPerPage,
+	}
+}
+
+// PerPage returns the page size to use for endpoint, between the sizer's
+// minimum and maxPerPage. Without observations it returns initial.
+func (p *pageSizer) PerPage(endpoint string, initial, maxPerPage int) int {
+	p.mu.Lock()
+	avg, ok := p.avgBytes[endpoint]
+	p.mu.Unlock()
+	if !ok || avg <= 0 {
+		return initial
+	}
+
+	perPage := int(float64(p.targetBytes) / avg)
+	if perPage < p.minPerPage {
+		perPage = p.minPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+	return perPage
+}
+
+// Observe records a page of objects returned by endpoint in bodyBytes bytes.
+func (p *pageSizer) Observe(endpoint string, bodyBytes, objects int) {
+	if objects <= 0 || bodyBytes <= 0 {
+		return
+	}
+	sample := float64(bodyBytes) / float64(objects)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if avg, ok := p.avgBytes[endpoint]; ok {
+		p.avgBytes[endpoint] = avg + pageSizeSmoothing*(sample-avg)
+	} else {
+		p.avgBytes[endpoint] = sample
+	}
+}
@@ -0,0 +1,45 @@
+package tmc
+
+import "testing"
+
+func TestPageSizer_AdaptsToObjectSize(t *testing.T) {
+	sizer := newPageSizer(100_000, 5)
+
+	if got := sizer.PerPage("stacks", 100, 100); got != 100 {
+		t.Fatalf("expected initial page size without observations, got %d", got)
+	}
+
+	// Slim objects: 500 bytes each -> 200 per page, capped at 100.
+	sizer.Observe("stacks", 50_000, 100)
+	if got := sizer.PerPage("stacks", 100, 100); got != 100 {
+		t.Fatalf("expected slim list to use max page size, got %d", got)
+	}
+
+	// Large objects: 10 KB each -> 10 per page.
+	sizer.Observe("previews", 100_000, 10)
+	if got := sizer.PerPage("previews", 100, 100); got != 10 {
+		t.Fatalf("expected 10 per page for large objects, got %d", got)
+	}
+
+	// Huge objects are clamped to the minimum.
+	sizer.Observe("plans", 1_000_000, 1)
+	if got := sizer.PerPage("plans", 100, 100); got != 5 {
+		t.Fatalf("expected minimum page size, got %d", got)
+	}
+}
+
+func TestPageSizer_SmoothsObservations(t *testing.T) {
+	sizer := newPageSizer(100_000, 1)
+	sizer.Observe("previews", 10_000, 1) // 10 KB -> 10 per page
+	sizer.Observe("previews", 1_000, 1)  // avg moves to 7.3 KB -> 13 per page
+
+	if got := sizer.PerPage("previews", 100, 100); got != 13 {
+		t.Fatalf("expected smoothed page size 13, got %d", got)
+	}
+
+	// Empty pages carry no size information.
+	sizer.Observe("previews", 50, 0)
+	if got := sizer.PerPage("previews", 100, 100); got != 13 {
+		t.Fatalf("expected empty page to be ignored, got %d", got)
+	}
+}
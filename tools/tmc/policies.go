@@ -0,0 +1,112 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// GetPolicyFindings creates an MCP tool that lists the policy check findings of a stack.
+func GetPolicyFindings(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_get_policy_findings",
+			Description: `Get the policy check findings of a stack: the rules its resources failed.
+
+tmc_get_stack only reports the policy check counters (resources.policy_check.counters).
+Use this tool to see which rule failed on which resource, and why, to remediate them.
+
+Supported filters:
+- severity: Filter by severity (low, medium, high)
+- page, per_page: Pagination
+
+Response includes:
+- findings: Array of findings with rule_id, title, severity, resource_address, message and guideline
+- paginated_result: Pagination info
+
+Tip: Fetch high severity findings first.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"stack_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Stack ID (from tmc_list_stacks)",
+					},
+					"severity": map[string]interface{}{
+						"type":        "array",
+						"description": "Filter by severity (low, medium, high)",
+						"items": map[string]interface{}{
+							"type": "string",
+							"enum": []string{"low", "medium", "high"},
+						},
+					},
+					"page": map[string]interface{}{
+						"type":        "number",
+						"description": "Page number for pagination",
+					},
+					"per_page": map[string]interface{}{
+						"type":        "number",
+						"description": "Number of items per page",
+					},
+				},
+				Required: []string{"stack_id"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			stackID, err := request.RequireInt("stack_id")
+			if err != nil {
+				return mcp.NewToolResultError("Stack ID is required and must be a number."), nil
+			}
+			if stackID <= 0 {
+				return mcp.NewToolResultError("Stack ID must be positive."), nil
+			}
+
+			opts := &terramate.PolicyFindingsListOptions{
+				Severity: request.GetStringSlice("severity", nil),
+			}
+			if page := request.GetInt("page", 0); page > 0 {
+				opts.Page = page
+			}
+			if perPage := request.GetInt("per_page", 0); perPage > 0 {
+				opts.PerPage = perPage
+			}
+
+			findings, _, err := client.Stacks.ListPolicyFindings(ctx, orgUUID, stackID, opts)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					if apiErr.IsNotFound() {
+						return mcp.NewToolResultError(fmt.Sprintf("No policy check found for stack %d.", stackID)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get policy findings: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(findings, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
@@ -0,0 +1,54 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestGetPolicyFindings_Success(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/stacks/org-uuid/42/policy_check/findings" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("severity"); got != "high" {
+			t.Errorf("severity = %q, want high", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"findings": [{"rule_id": "CKV_AWS_20", "severity": "high", "resource_address": "aws_s3_bucket.logs", "message": "Bucket is public"}], "paginated_result": {"total": 1, "page": 1, "per_page": 20}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	result, err := GetPolicyFindings(c).Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"organization_uuid": "org-uuid",
+				"stack_id":          float64(42),
+				"severity":          []interface{}{"high"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", textContent.Text)
+	}
+	var decoded terramate.PolicyFindingsListResponse
+	if err := json.Unmarshal([]byte(textContent.Text), &decoded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(decoded.Findings) != 1 || decoded.Findings[0].RuleID != "CKV_AWS_20" {
+		t.Errorf("unexpected findings: %+v", decoded.Findings)
+	}
+}
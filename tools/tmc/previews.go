@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -41,7 +42,7 @@ Tip: For failed previews, fetch stderr channel first for error messages.`,
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"stack_preview_id": map[string]interface{}{
 						"type":        "number",
@@ -60,7 +61,7 @@ Tip: For failed previews, fetch stderr channel first for error messages.`,
 						"description": "Number of items per page",
 					},
 				},
-				Required: []string{"organization_uuid", "stack_preview_id"},
+				Required: []string{"stack_preview_id"},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -88,6 +89,9 @@ Tip: For failed previews, fetch stderr channel first for error messages.`,
 
 			logs, _, err := client.Previews.GetLogs(ctx, orgUUID, stackPreviewID, opts)
 			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
@@ -109,3 +113,157 @@ Tip: For failed previews, fetch stderr channel first for error messages.`,
 		},
 	}
 }
+
+// GetStackPreviewChangeset creates an MCP tool that retrieves the full plan of a stack preview.
+func GetStackPreviewChangeset(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_get_stack_preview_changeset",
+			Description: `Get the full terraform/tofu plan of a stack preview.
+
+tmc_get_review_request only reports the size of each stack preview's plan
+(changeset_ascii_size, changeset_json_size). Use this tool to read the plan itself,
+e.g. to review exactly which resources a pull request creates, changes or destroys.
+
+Formats:
+- ascii: The human-readable plan output (default)
+- json: The machine-readable plan (terraform show -json), larger but structured
+
+Plans can be large: check changeset_ascii_size first, and prefer ascii unless the
+structured plan is needed.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"stack_preview_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Stack Preview ID (from tmc_get_review_request)",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "Plan format (default: ascii)",
+						"enum":        []string{terramate.ChangesetFormatASCII, terramate.ChangesetFormatJSON},
+					},
+				},
+				Required: []string{"stack_preview_id"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			stackPreviewID, err := request.RequireInt("stack_preview_id")
+			if err != nil {
+				return mcp.NewToolResultError("Stack Preview ID is required and must be a number."), nil
+			}
+			if stackPreviewID <= 0 {
+				return mcp.NewToolResultError("Stack Preview ID must be positive."), nil
+			}
+
+			format := request.GetString("format", terramate.ChangesetFormatASCII)
+			if format != terramate.ChangesetFormatASCII && format != terramate.ChangesetFormatJSON {
+				return mcp.NewToolResultError("Format must be ascii or json."), nil
+			}
+
+			changeset, _, err := client.Previews.GetChangeset(ctx, orgUUID, stackPreviewID, format)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					if apiErr.IsNotFound() {
+						return mcp.NewToolResultError(fmt.Sprintf("Changeset of Stack Preview %d not found.", stackPreviewID)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get changeset: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(changeset, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// RetriggerStackPreview creates an MCP tool that re-runs a stack preview.
+func RetriggerStackPreview(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_retrigger_stack_preview",
+			Description: `Re-run a stack preview of a review request (PR/MR), e.g. an outdated preview after the base branch changed.
+
+The preview runs asynchronously in the CI/CD of the review request: poll tmc_get_review_request
+until its status is no longer pending or running. Requires the admin or member role.
+
+Response includes the preview: id, status (pending), stack_id, path and review_request_id.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"stack_preview_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Stack Preview ID (from tmc_get_review_request)",
+					},
+				},
+				Required: []string{"stack_preview_id"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			stackPreviewID, err := request.RequireInt("stack_preview_id")
+			if err != nil {
+				return mcp.NewToolResultError("Stack Preview ID is required and must be a number."), nil
+			}
+			if stackPreviewID <= 0 {
+				return mcp.NewToolResultError("Stack Preview ID must be positive."), nil
+			}
+
+			preview, _, err := client.Previews.Retrigger(ctx, orgUUID, stackPreviewID)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					switch {
+					case apiErr.IsUnauthorized():
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					case apiErr.IsForbidden():
+						return mcp.NewToolResultError("Previews can only be retriggered by the admins and members of an organization."), nil
+					case apiErr.IsNotFound():
+						return mcp.NewToolResultError(fmt.Sprintf("Stack Preview with ID %d not found.", stackPreviewID)), nil
+					case apiErr.StatusCode == http.StatusConflict:
+						return mcp.NewToolResultError(fmt.Sprintf("Stack Preview %d is already pending or running.", stackPreviewID)), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to retrigger stack preview: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(preview, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
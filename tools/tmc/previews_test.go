@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -247,3 +248,121 @@ func TestGetStackPreviewLogs_NotFound(t *testing.T) {
 		t.Fatal("expected error result for 404")
 	}
 }
+
+func TestGetStackPreviewChangeset_Success(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/stack_previews/org-uuid/100/changeset" || r.URL.Query().Get("format") != "json" {
+			t.Errorf("unexpected request: %s", r.URL.RequestURI())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(`{"provisioner": "terraform", "changeset_json": "{}"}`)); err != nil {
+			panic(err)
+		}
+	}))
+	defer ts.Close()
+
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	tool := GetStackPreviewChangeset(c)
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"organization_uuid": "org-uuid",
+				"stack_preview_id":  float64(100),
+				"format":            "json",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", textContent.Text)
+	}
+	var changeset terramate.ChangesetDetails
+	if err := json.Unmarshal([]byte(textContent.Text), &changeset); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if changeset.Provisioner != "terraform" || changeset.ChangesetJSON != "{}" {
+		t.Errorf("unexpected changeset: %+v", changeset)
+	}
+}
+
+func TestGetStackPreviewChangeset_InvalidFormat(t *testing.T) {
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL("http://127.0.0.1:0"))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	tool := GetStackPreviewChangeset(c)
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"organization_uuid": "org-uuid",
+				"stack_preview_id":  float64(100),
+				"format":            "html",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error result for an unknown format")
+	}
+}
+
+func TestRetriggerStackPreview(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/stack_previews/org-uuid/100/retrigger":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": 100, "status": "pending", "stack_id": 7, "review_request_id": 42}`))
+		case "/v1/stack_previews/org-uuid/101/retrigger":
+			w.WriteHeader(http.StatusConflict)
+		case "/v1/stack_previews/org-uuid/102/retrigger":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	call := func(id int) (*mcp.CallToolResult, string) {
+		t.Helper()
+		result, err := RetriggerStackPreview(c).Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]interface{}{
+				"organization_uuid": "org-uuid",
+				"stack_preview_id":  float64(id),
+			}},
+		})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return result, text.Text
+	}
+
+	result, text := call(100)
+	var preview terramate.StackPreviewV2
+	if result.IsError || json.Unmarshal([]byte(text), &preview) != nil || preview.Status != "pending" {
+		t.Fatalf("unexpected result: %s", text)
+	}
+	for id, want := range map[int]string{
+		101: "already pending or running",
+		102: "admins and members",
+		103: "not found",
+	} {
+		if result, text := call(id); !result.IsError || !strings.Contains(text, want) {
+			t.Errorf("preview %d: expected %q, got %s", id, want, text)
+		}
+	}
+}
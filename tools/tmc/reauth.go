@@ -0,0 +1,66 @@
+package tmc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// Re-authentication action types of SessionExpiredResult.
+const (
+	// ReauthActionDeviceLogin asks the user to authorize a pending device login.
+	ReauthActionDeviceLogin = "device_login"
+	// ReauthActionCLILogin asks the user to run 'terramate cloud login'.
+	ReauthActionCLILogin = "cli_login"
+)
+
+// sessionExpiredResponse is the payload of the error result of a call that
+// failed because the login session expired.
+type sessionExpiredResponse struct {
+	Error   string       `json:"error"`
+	Message string       `json:"message"`
+	Action  reauthAction `json:"action"`
+}
+
+// reauthAction is the step re-authenticating the server.
+type reauthAction struct {
+	Type            string     `json:"type"`
+	VerificationURI string     `json:"verification_uri,omitempty"`
+	UserCode        string     `json:"user_code,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	Instructions    string     `json:"instructions"`
+}
+
+// SessionExpiredResult returns the error result of a call that failed because
+// the login session expired (see terramate.ErrSessionExpired) with cause.
+// With a pending device login code, the result asks the user to authorize it,
+// which logs the server in again; otherwise it asks for 'terramate cloud login'.
+func SessionExpiredResult(cause error, code *terramate.DeviceCode) *mcp.CallToolResult {
+	response := sessionExpiredResponse{
+		Error:   "session_expired",
+		Message: fmt.Sprintf("The Terramate Cloud login session expired and the token could not be refreshed (%v).", cause),
+		Action: reauthAction{
+			Type:         ReauthActionCLILogin,
+			Instructions: "Ask the user to run 'terramate cloud login' in a terminal, then retry the call.",
+		},
+	}
+	if code != nil {
+		expiresAt := code.ExpiresAt
+		response.Action = reauthAction{
+			Type:            ReauthActionDeviceLogin,
+			VerificationURI: code.VerificationURI,
+			UserCode:        code.UserCode,
+			ExpiresAt:       &expiresAt,
+			Instructions: fmt.Sprintf("Show the user this one-time login: open %s and enter the code %s within %s. Retry the call once they authorized it.",
+				code.VerificationURI, code.UserCode, time.Until(code.ExpiresAt).Round(time.Minute)),
+		}
+	}
+	jsonData, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err))
+	}
+	return mcp.NewToolResultError(string(jsonData))
+}
@@ -0,0 +1,60 @@
+package tmc
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func sessionExpiredPayload(t *testing.T, result *mcp.CallToolResult) sessionExpiredResponse {
+	t.Helper()
+	if !result.IsError {
+		t.Fatal("expected an error result")
+	}
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	var response sessionExpiredResponse
+	if err := json.Unmarshal([]byte(textContent.Text), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	return response
+}
+
+func TestSessionExpiredResult_CLILogin(t *testing.T) {
+	response := sessionExpiredPayload(t, SessionExpiredResult(errors.New("refresh token revoked"), nil))
+	if response.Error != "session_expired" || !strings.Contains(response.Message, "refresh token revoked") {
+		t.Fatalf("expected the cause in the session_expired error, got %+v", response)
+	}
+	if response.Action.Type != ReauthActionCLILogin || !strings.Contains(response.Action.Instructions, "terramate cloud login") {
+		t.Fatalf("expected the CLI login action, got %+v", response.Action)
+	}
+	if response.Action.UserCode != "" || response.Action.ExpiresAt != nil {
+		t.Fatalf("expected no device login details, got %+v", response.Action)
+	}
+}
+
+func TestSessionExpiredResult_DeviceLogin(t *testing.T) {
+	code := &terramate.DeviceCode{
+		UserCode:        "ABCD-EFGH",
+		VerificationURI: "https://login.example/device",
+		ExpiresAt:       time.Now().Add(10 * time.Minute),
+	}
+	response := sessionExpiredPayload(t, SessionExpiredResult(errors.New("refresh token revoked"), code))
+	action := response.Action
+	if action.Type != ReauthActionDeviceLogin || action.UserCode != "ABCD-EFGH" || action.VerificationURI != "https://login.example/device" {
+		t.Fatalf("expected the device login action, got %+v", action)
+	}
+	if action.ExpiresAt == nil || !action.ExpiresAt.Equal(code.ExpiresAt) {
+		t.Fatalf("expected the code expiry, got %v", action.ExpiresAt)
+	}
+	if !strings.Contains(action.Instructions, "ABCD-EFGH") || !strings.Contains(action.Instructions, "10m0s") {
+		t.Fatalf("expected the code and its remaining time in the instructions, got %q", action.Instructions)
+	}
+}
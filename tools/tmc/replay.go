@@ -0,0 +1,125 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Names of the debug tools, which are not recorded in the call log.
+const (
+	ReplayLastToolName  = "tmc_replay_last"
+	InspectCallToolName = "tmc_inspect_call"
+)
+
+// replayLastResponse is the payload returned by tmc_replay_last.
+type replayLastResponse struct {
+	Calls []*RecordedCall `json:"calls"`
+	Kept  int             `json:"kept"`
+}
+
+// ReplayLast creates an MCP tool that shows the most recent tool calls of the
+// session from log, including the Terramate Cloud API requests they made.
+func ReplayLast(log *CallLog) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: ReplayLastToolName,
+			Description: `Show the most recent tool calls of this session: the arguments as received (including
+defaults filled in by the server), the Terramate Cloud API requests they made with their query
+parameters and status codes, and the result.
+
+Use this tool to debug a surprising result, e.g. "why did that earlier query return nothing?":
+the recorded API requests show the exact filters that were sent. Secrets are redacted and long
+results are truncated.
+
+Response includes:
+- calls: Most recent first, each with:
+  * id: Call ID for tmc_inspect_call
+  * tool, arguments, started_at, duration
+  * api_requests: method, path, query, status, duration and error of each API request
+  * is_error, error, result (result_truncated when cut short)
+- kept: How many calls the server keeps`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"tool": map[string]interface{}{
+						"type":        "string",
+						"description": "Only show calls of this tool, e.g. tmc_list_stacks",
+					},
+					"count": map[string]interface{}{
+						"type":        "number",
+						"description": fmt.Sprintf("Number of calls to show (default: 1, max: %d)", log.Size()),
+						"minimum":     1,
+						"maximum":     log.Size(),
+					},
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			count := request.GetInt("count", 1)
+			if count < 1 || count > log.Size() {
+				return mcp.NewToolResultError(fmt.Sprintf("count must be between 1 and %d.", log.Size())), nil
+			}
+			tool := request.GetString("tool", "")
+
+			calls := log.Recent(SessionFromContext(ctx), tool, count)
+			if len(calls) == 0 {
+				if tool != "" {
+					return mcp.NewToolResultError(fmt.Sprintf("No recorded calls of %s in this session.", tool)), nil
+				}
+				return mcp.NewToolResultError("No recorded tool calls in this session."), nil
+			}
+
+			resultJSON, err := json.MarshalIndent(replayLastResponse{Calls: calls, Kept: log.Size()}, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+			}
+			return mcp.NewToolResultText(string(resultJSON)), nil
+		},
+	}
+}
+
+// InspectCall creates an MCP tool that shows a recorded tool call of the
+// session from log by ID.
+func InspectCall(log *CallLog) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: InspectCallToolName,
+			Description: `Show a recorded tool call of this session by ID: the arguments, the Terramate Cloud API
+requests it made and the result, with secrets redacted.
+
+Get call IDs from tmc_replay_last. Only the most recent calls are kept.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "string",
+						"description": "Call ID, e.g. call-42 (from tmc_replay_last)",
+					},
+				},
+				Required: []string{"id"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			id, err := request.RequireString("id")
+			if err != nil || id == "" {
+				return mcp.NewToolResultError("Call ID is required and must be a string."), nil
+			}
+
+			call, ok := log.Get(SessionFromContext(ctx), id)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("No recorded call %s in this session; only the last %d calls are kept.", id, log.Size())), nil
+			}
+
+			resultJSON, err := json.MarshalIndent(call, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+			}
+			return mcp.NewToolResultText(string(resultJSON)), nil
+		},
+	}
+}
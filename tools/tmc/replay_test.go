@@ -0,0 +1,59 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestReplayLastAndInspectCall(t *testing.T) {
+	store := NewSessionStore(0)
+	ctx := WithSession(context.Background(), store.Get("a"))
+	log := NewCallLog(5)
+	call := recordCall(ctx, log, "tmc_list_stacks", map[string]any{"search": "vpc"}, func(context.Context) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(`{"stacks":[]}`), nil
+	})
+
+	result, err := ReplayLast(log).Handler(ctx, mcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Fatalf("tmc_replay_last failed: %v %+v", err, result)
+	}
+	var replay struct {
+		Calls []struct {
+			ID        string         `json:"id"`
+			Tool      string         `json:"tool"`
+			Arguments map[string]any `json:"arguments"`
+		} `json:"calls"`
+		Kept int `json:"kept"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &replay); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if len(replay.Calls) != 1 || replay.Calls[0].ID != call.ID || replay.Calls[0].Arguments["search"] != "vpc" || replay.Kept != 5 {
+		t.Fatalf("unexpected replay: %+v", replay)
+	}
+
+	inspect := func(ctx context.Context, id string) *mcp.CallToolResult {
+		result, err := InspectCall(log).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": id}}})
+		if err != nil {
+			t.Fatalf("tmc_inspect_call error: %v", err)
+		}
+		return result
+	}
+	if result := inspect(ctx, call.ID); result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, `"tool": "tmc_list_stacks"`) {
+		t.Fatalf("expected the call to be shown, got %+v", result)
+	}
+	if result := inspect(WithSession(context.Background(), store.Get("b")), call.ID); !result.IsError {
+		t.Fatal("expected another session's call to be hidden")
+	}
+
+	if result, _ := ReplayLast(log).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"tool": "tmc_get_stack"}}}); !result.IsError {
+		t.Fatal("expected no calls of an unused tool")
+	}
+	if result, _ := ReplayLast(log).Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"count": 6}}}); !result.IsError {
+		t.Fatal("expected count beyond the kept calls to be rejected")
+	}
+}
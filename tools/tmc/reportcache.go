@@ -0,0 +1,103 @@
+package tmc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultReportCacheTTL is how long report tools reuse the data they fetched.
+const DefaultReportCacheTTL = 5 * time.Minute
+
+// ReportCache keeps the datasets fetched by report tools for a short time:
+// the sections of a report share one fetch of each dataset, and repeating a
+// report, e.g. to render it in another format, does not walk the
+// organization again. Concurrent fetches of the same dataset are merged.
+// Failed fetches are not cached.
+//
+// A nil *ReportCache disables caching.
+type ReportCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*reportEntry
+}
+
+// reportEntry is a cached dataset, or one being fetched until done is closed.
+type reportEntry struct {
+	done      chan struct{}
+	value     any
+	err       error
+	fetchedAt time.Time
+}
+
+// NewReportCache creates a cache keeping datasets for ttl. A non-positive ttl
+// uses DefaultReportCacheTTL.
+func NewReportCache(ttl time.Duration) *ReportCache {
+	if ttl <= 0 {
+		ttl = DefaultReportCacheTTL
+	}
+	return &ReportCache{ttl: ttl, now: time.Now, entries: map[string]*reportEntry{}}
+}
+
+// cached returns the dataset of key from cache, calling fetch when it is not
+// cached or has expired.
+func cached[T any](ctx context.Context, cache *ReportCache, key string, fetch func(context.Context) (T, error)) (T, error) {
+	if cache == nil {
+		return fetch(ctx)
+	}
+	value, err := cache.get(ctx, key, func(ctx context.Context) (any, error) {
+		return fetch(ctx)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return value.(T), nil
+}
+
+func (c *ReportCache) get(ctx context.Context, key string, fetch func(context.Context) (any, error)) (any, error) {
+	c.mu.Lock()
+	now := c.now()
+	c.evictExpired(now)
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &reportEntry{done: make(chan struct{})}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	if ok {
+		select {
+		case <-entry.done:
+			return entry.value, entry.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	entry.value, entry.err = fetch(ctx)
+	c.mu.Lock()
+	entry.fetchedAt = c.now()
+	if entry.err != nil {
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+	close(entry.done)
+	return entry.value, entry.err
+}
+
+// evictExpired removes the datasets fetched more than ttl before now.
+// Callers must hold c.mu.
+func (c *ReportCache) evictExpired(now time.Time) {
+	for key, entry := range c.entries {
+		select {
+		case <-entry.done:
+			if now.Sub(entry.fetchedAt) > c.ttl {
+				delete(c.entries, key)
+			}
+		default: // still being fetched
+		}
+	}
+}
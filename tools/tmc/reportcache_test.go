@@ -0,0 +1,111 @@
+package tmc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReportCache(t *testing.T) {
+	cache := NewReportCache(time.Minute)
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	var fetches int
+	fetch := func(context.Context) (int, error) {
+		fetches++
+		return fetches, nil
+	}
+
+	ctx := context.Background()
+	if v, _ := cached(ctx, cache, "k", fetch); v != 1 {
+		t.Fatalf("expected the first fetch, got %d", v)
+	}
+	if v, _ := cached(ctx, cache, "k", fetch); v != 1 || fetches != 1 {
+		t.Fatalf("expected the cached value, got %d after %d fetches", v, fetches)
+	}
+	now = now.Add(2 * time.Minute)
+	if v, _ := cached(ctx, cache, "k", fetch); v != 2 {
+		t.Fatalf("expected an expired value to be fetched again, got %d", v)
+	}
+
+	failing := func(context.Context) (int, error) { return 0, fmt.Errorf("boom") }
+	if _, err := cached(ctx, cache, "f", failing); err == nil {
+		t.Fatal("expected the fetch error")
+	}
+	if v, err := cached(ctx, cache, "f", fetch); err != nil || v != 3 {
+		t.Fatalf("expected a failed fetch not to be cached, got %d, %v", v, err)
+	}
+}
+
+func TestReportCache_MergesConcurrentFetches(t *testing.T) {
+	cache := NewReportCache(time.Minute)
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	fetch := func(context.Context) (string, error) {
+		fetches.Add(1)
+		<-release
+		return "dataset", nil
+	}
+
+	const callers = 5
+	var wg sync.WaitGroup
+	results := make([]string, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = cached(context.Background(), cache, "k", fetch)
+		}()
+	}
+	// Let the callers reach the cache before the first fetch completes.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("expected concurrent callers to share 1 fetch, got %d", got)
+	}
+	for i, result := range results {
+		if result != "dataset" {
+			t.Fatalf("caller %d: expected the shared dataset, got %q", i, result)
+		}
+	}
+}
+
+func TestReportCache_WaiterHonoursContext(t *testing.T) {
+	cache := NewReportCache(time.Minute)
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	go func() {
+		_, _ = cached(context.Background(), cache, "k", func(context.Context) (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := cached(ctx, cache, "k", func(context.Context) (int, error) { return 2, nil }); err != context.Canceled {
+		t.Fatalf("expected a waiter to stop with its context, got %v", err)
+	}
+}
+
+func TestReportCache_Nil(t *testing.T) {
+	var cache *ReportCache
+	var fetches int
+	fetch := func(context.Context) (int, error) {
+		fetches++
+		return fetches, nil
+	}
+	for want := 1; want <= 2; want++ {
+		if v, err := cached(context.Background(), cache, "k", fetch); err != nil || v != want {
+			t.Fatalf("expected a nil cache to fetch every time, got %d, %v", v, err)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/internal/fanout"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// maxStacksPerPage is the largest page size accepted by the stacks endpoint.
+const maxStacksPerPage = 100
+
+// Repository health values reported by tmc_list_repositories.
+const (
+	healthOK      = "ok"
+	healthDrifted = "drifted"
+	healthFailed  = "failed"
+	healthUnknown = "unknown"
+)
+
+// repositorySummary aggregates the stacks of a single repository.
+type repositorySummary struct {
+	Repository        string         `json:"repository"`
+	StackCount        int            `json:"stack_count"`
+	Health            string         `json:"health"` // ok, drifted, failed, unknown
+	StatusCounts      map[string]int `json:"status_counts"`
+	DriftStatusCounts map[string]int `json:"drift_status_counts"`
+	Targets           []string       `json:"targets,omitempty"`
+	LastUpdatedAt     *time.Time     `json:"last_updated_at,omitempty"`
+}
+
+// repositoriesListResponse is the payload returned by tmc_list_repositories.
+type repositoriesListResponse struct {
+	Repositories      []repositorySummary `json:"repositories"`
+	TotalRepositories int                 `json:"total_repositories"`
+	TotalStacks       int                 `json:"total_stacks"`
+}
+
+// ListRepositories creates an MCP tool that lists the repositories known to an
+// organization together with stack counts and aggregate health.
+func ListRepositories(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_list_repositories",
+			Description: `List the repositories known to a Terramate Cloud organization with stack counts and aggregate health.
+
+This tool walks all stacks of the organization and groups them by repository. Use it as the
+starting point for filter workflows ("which repos do we even have?") before calling
+tmc_list_stacks, tmc_list_deployments or tmc_list_review_requests with a repository filter.
+
+Supported filters:
+- target: Only count stacks of these deployment targets
+- is_archived: Count archived stacks instead of active ones (default: active stacks only)
+
+Response includes:
+- repositories: Array sorted by repository URL, each with:
+  * stack_count: Number of stacks in the repository
+  * health: failed if any stack failed, drifted if any stack drifted, ok if all stacks are ok, unknown otherwise
+  * status_counts / drift_status_counts: Stack counts per status
+  * targets: Deployment targets used by the repository's stacks
+  * last_updated_at: Most recent stack update
+- total_repositories, total_stacks: Organization-wide totals`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"target": map[string]interface{}{
+						"type":        "array",
+						"description": "Only count stacks of these deployment targets",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"is_archived": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Count archived stacks instead of active ones",
+					},
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			opts := &terramate.StacksListOptions{
+				Target: request.GetStringSlice("target", nil),
+			}
+			if archived, archivedErr := request.RequireBool("is_archived"); archivedErr == nil {
+				opts.IsArchived = []bool{archived}
+			}
+
+			stacks, err := listAllStacks(ctx, client, orgUUID, opts)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list repositories: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(summarizeRepositories(stacks), "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// stacksListEndpoint identifies the stacks list endpoint for adaptive page sizing.
+const stacksListEndpoint = "stacks.list"
+
+// listAllStacks fetches every page of stacks matching opts. The first page
+// determines the total; the remaining pages are fetched in parallel, bounded
+// by the per-invocation concurrency limit. The page size adapts to the stack
+// sizes observed in previous walks.
+func listAllStacks(ctx context.Context, client *terramate.Client, orgUUID string, opts *terramate.StacksListOptions) ([]terramate.Stack, error) {
+	perPage := pageSizes.PerPage(stacksListEndpoint, maxStacksPerPage, maxStacksPerPage)
+	pageOpts := func(page int) *terramate.StacksListOptions {
+		o := *opts
+		o.Page = page
+		o.PerPage = perPage
+		return &o
+	}
+	listPage := func(ctx context.Context, page int) (*terramate.StacksListResponse, error) {
+		result, resp, err := client.Stacks.List(ctx, orgUUID, pageOpts(page))
+		if err != nil {
+			return nil, err
+		}
+		pageSizes.Observe(stacksListEndpoint, len(resp.Body), len(result.Stacks))
+		return result, nil
+	}
+
+	first, err := listPage(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := first.PaginatedResult.TotalPages()
+	if totalPages <= 1 {
+		return first.Stacks, nil
+	}
+
+	pages := make([][]terramate.Stack, totalPages)
+	pages[0] = first.Stacks
+	err = fanout.Run(ctx, totalPages-1, func(ctx context.Context, i int) error {
+		result, err := listPage(ctx, i+2)
+		if err != nil {
+			return err
+		}
+		pages[i+1] = result.Stacks
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stacks := make([]terramate.Stack, 0, first.PaginatedResult.Total)
+	for _, page := range pages {
+		stacks = append(stacks, page...)
+	}
+	return stacks, nil
+}
+
+// summarizeRepositories groups stacks by repository.
+func summarizeRepositories(stacks []terramate.Stack) repositoriesListResponse {
+	byRepo := map[string]*repositorySummary{}
+	targets := map[string]map[string]bool{}
+
+	for _, stack := range stacks {
+		summary, ok := byRepo[stack.Repository]
+		if !ok {
+			summary = &repositorySummary{
+				Repository:        stack.Repository,
+				StatusCounts:      map[string]int{},
+				DriftStatusCounts: map[string]int{},
+			}
+			byRepo[stack.Repository] = summary
+			targets[stack.Repository] = map[string]bool{}
+		}
+
+		summary.StackCount++
+		summary.StatusCounts[stack.Status]++
+		if stack.DriftStatus != "" {
+			summary.DriftStatusCounts[stack.DriftStatus]++
+		}
+		if stack.Target != "" {
+			targets[stack.Repository][stack.Target] = true
+		}
+		if summary.LastUpdatedAt == nil || stack.UpdatedAt.After(*summary.LastUpdatedAt) {
+			updatedAt := stack.UpdatedAt
+			summary.LastUpdatedAt = &updatedAt
+		}
+	}
+
+	response := repositoriesListResponse{
+		Repositories: make([]repositorySummary, 0, len(byRepo)),
+		TotalStacks:  len(stacks),
+	}
+	for repo, summary := range byRepo {
+		for target := range targets[repo] {
+			summary.Targets = append(summary.Targets, target)
+		}
+		sort.Strings(summary.Targets)
+		summary.Health = repositoryHealth(summary)
+		response.Repositories = append(response.Repositories, *summary)
+	}
+	sort.Slice(response.Repositories, func(i, j int) bool {
+		return response.Repositories[i].Repository < response.Repositories[j].Repository
+	})
+	response.TotalRepositories = len(response.Repositories)
+
+	return response
+}
+
+// repositoryHealth derives the worst status among a repository's stacks.
+func repositoryHealth(summary *repositorySummary) string {
+	switch {
+	case summary.StatusCounts["failed"] > 0:
+		return healthFailed
+	case summary.StatusCounts["drifted"] > 0 || summary.DriftStatusCounts["drifted"] > 0:
+		return healthDrifted
+	case summary.StatusCounts["ok"] == summary.StackCount:
+		return healthOK
+	default:
+		return healthUnknown
+	}
+}
@@ -0,0 +1,167 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestListRepositories_AggregatesAcrossPages(t *testing.T) {
+	// 3 pages of stacks: page 1 and 2 are full, page 3 has one stack.
+	const total = 2*maxStacksPerPage + 1
+	var requests atomic.Int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/v1/stacks/org-uuid" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("per_page"); got != "100" {
+			t.Errorf("expected per_page=100, got %q", got)
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		stacks := []terramate.Stack{}
+		for i := (page - 1) * maxStacksPerPage; i < page*maxStacksPerPage && i < total; i++ {
+			stack := terramate.Stack{StackID: i + 1, Repository: "github.com/acme/infra", Status: "ok", DriftStatus: "ok", Target: "prod"}
+			if i%2 == 1 {
+				stack.Repository = "github.com/acme/apps"
+			}
+			if i == total-1 {
+				stack.Repository = "github.com/acme/legacy"
+				stack.Status = "failed"
+				stack.Target = ""
+			}
+			stacks = append(stacks, stack)
+		}
+		body, _ := json.Marshal(terramate.StacksListResponse{
+			Stacks:          stacks,
+			PaginatedResult: terramate.PaginatedResult{Total: total, Page: page, PerPage: maxStacksPerPage},
+		})
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	defer ts.Close()
+
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	tool := ListRepositories(c)
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{"organization_uuid": "org-uuid"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", textContent.Text)
+	}
+
+	var response repositoriesListResponse
+	if err := json.Unmarshal([]byte(textContent.Text), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if requests.Load() != 3 {
+		t.Fatalf("expected 3 page requests, got %d", requests.Load())
+	}
+	if response.TotalStacks != total || response.TotalRepositories != 3 {
+		t.Fatalf("unexpected totals: %+v", response)
+	}
+
+	got := map[string]repositorySummary{}
+	for _, repo := range response.Repositories {
+		got[repo.Repository] = repo
+	}
+	if got["github.com/acme/legacy"].Health != healthFailed {
+		t.Fatalf("expected legacy repo to be failed, got %+v", got["github.com/acme/legacy"])
+	}
+	if infra := got["github.com/acme/infra"]; infra.Health != healthOK || infra.StackCount != maxStacksPerPage || len(infra.Targets) != 1 {
+		t.Fatalf("unexpected infra summary: %+v", infra)
+	}
+	if response.Repositories[0].Repository != "github.com/acme/apps" {
+		t.Fatalf("expected repositories sorted by name, got %s first", response.Repositories[0].Repository)
+	}
+}
+
+func TestListRepositories_PassesFilters(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("target") != "prod" || q.Get("is_archived") != "true" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"stacks":[],"paginated_result":{"total":0,"page":1,"per_page":100}}`))
+	}))
+	defer ts.Close()
+
+	c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	result, err := ListRepositories(c).Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"organization_uuid": "org-uuid",
+				"target":            []interface{}{"prod"},
+				"is_archived":       true,
+			},
+		},
+	})
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected result: %+v, err %v", result, err)
+	}
+}
+
+func TestListRepositories_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   map[string]interface{}
+		status int
+	}{
+		{name: "missing org", args: map[string]interface{}{}},
+		{name: "unauthorized", args: map[string]interface{}{"organization_uuid": "org-uuid"}, status: http.StatusUnauthorized},
+		{name: "bad request", args: map[string]interface{}{"organization_uuid": "org-uuid"}, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = fmt.Fprint(w, `{"error":"nope"}`)
+			}))
+			defer ts.Close()
+
+			c, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+			if err != nil {
+				t.Fatalf("NewClient error: %v", err)
+			}
+			result, err := ListRepositories(c).Handler(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("Handler error: %v", err)
+			}
+			if !result.IsError {
+				t.Fatal("expected error result")
+			}
+		})
+	}
+}
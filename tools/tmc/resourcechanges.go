@@ -0,0 +1,134 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// defaultResourceChangesWindow is how far back tmc_summarize_resource_changes
+// scans deployments when created_at_from is not given.
+const defaultResourceChangesWindow = 7 * 24 * time.Hour
+
+// SummarizeResourceChanges creates an MCP tool that sums the planned resource
+// changes across the open review requests or the recent deployments of an organization.
+func SummarizeResourceChanges(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_summarize_resource_changes",
+			Description: `Sum the resource changes (creates, updates, deletes, replaces, ...) planned across a Terramate Cloud organization.
+
+Use this to answer "how many destroys are pending across open PRs?" or "how much did we change this week?".
+
+Sources:
+- review_requests (default): the current previews of the matching review requests (open ones unless status is given)
+- deployments: the previews of the review requests deployed by the matching workflow deployments (the last 7 days unless created_at_from is given); deployments started outside of a review request are skipped
+
+Each review request is counted once. Outdated previews are skipped.
+
+Response includes:
+- actions: create_count, update_count, delete_count, replace_count, ... summed
+- review_request_ids: The review requests counted
+- scanned_count: Review requests or deployments scanned
+- skipped_count: Those scanned without a current preview summary`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"source": map[string]interface{}{
+						"type":        "string",
+						"description": "What to sum: review_requests (default) or deployments",
+						"enum":        []string{"review_requests", "deployments"},
+					},
+					"repository": map[string]interface{}{
+						"type":        "array",
+						"description": "Filter by repository URLs",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"status": map[string]interface{}{
+						"type":        "array",
+						"description": "Filter by status (review requests: open, merged, closed, ...; default: open. Deployments: ok, failed, processing)",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"created_at_from": map[string]interface{}{
+						"type":        "string",
+						"description": "Only count items created at or after this RFC3339 time (deployments default: 7 days ago)",
+					},
+					"created_at_to": map[string]interface{}{
+						"type":        "string",
+						"description": "Only count items created at or before this RFC3339 time",
+					},
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			var from, to *time.Time
+			if result := createdAtRange(request, &from, &to); result != nil {
+				return result, nil
+			}
+			repository := request.GetStringSlice("repository", nil)
+			status := request.GetStringSlice("status", nil)
+
+			var aggregate *terramate.ResourceChangesAggregate
+			switch source := request.GetString("source", "review_requests"); source {
+			case "review_requests":
+				aggregate, err = client.ReviewRequests.SummarizeResourceChanges(ctx, orgUUID, &terramate.ReviewRequestsListOptions{
+					Repository:    repository,
+					Status:        status,
+					CreatedAtFrom: from,
+					CreatedAtTo:   to,
+				})
+			case "deployments":
+				if from == nil {
+					since := time.Now().Add(-defaultResourceChangesWindow).UTC().Truncate(time.Second)
+					from = &since
+				}
+				aggregate, err = client.Deployments.SummarizeResourceChanges(ctx, orgUUID, &terramate.DeploymentsListOptions{
+					Repository:    repository,
+					Status:        status,
+					CreatedAtFrom: from,
+					CreatedAtTo:   to,
+				})
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("Unknown source %q: use review_requests or deployments.", source)), nil
+			}
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to summarize resource changes: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(aggregate, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
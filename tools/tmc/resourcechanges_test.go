@@ -0,0 +1,67 @@
+package tmc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestSummarizeResourceChanges(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/review_requests/org-uuid":
+			if r.URL.Query().Get("status") != "open" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"review_requests": [{"review_request_id": 1, "preview": {"status": "current", "resource_changes": {"delete_count": 2}}}, {"review_request_id": 2}], "paginated_result": {"total": 2, "page": 1, "per_page": 100}}`))
+		case "/v1/organizations/org-uuid/deployments":
+			if r.URL.Query().Get("created_at_from") == "" {
+				t.Errorf("expected a default created_at_from, got %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"deployments": [{"id": 1, "review_request": {"review_request_id": 5, "preview": {"status": "current", "resource_changes": {"create_count": 3}}}}], "paginated_result": {"total": 1, "page": 1, "per_page": 100}}`))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	call := func(args map[string]interface{}) (*mcp.CallToolResult, string) {
+		t.Helper()
+		args["organization_uuid"] = "org-uuid"
+		result, err := SummarizeResourceChanges(c).Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return result, text.Text
+	}
+
+	result, text := call(map[string]interface{}{})
+	if result.IsError || !strings.Contains(text, `"delete_count": 2`) || !strings.Contains(text, `"skipped_count": 1`) {
+		t.Fatalf("unexpected review requests result: %s", text)
+	}
+
+	result, text = call(map[string]interface{}{"source": "deployments"})
+	if result.IsError || !strings.Contains(text, `"create_count": 3`) || !strings.Contains(text, `"review_request_ids": [`) {
+		t.Fatalf("unexpected deployments result: %s", text)
+	}
+
+	result, text = call(map[string]interface{}{"source": "drifts"})
+	if !result.IsError || !strings.Contains(text, "Unknown source") {
+		t.Errorf("expected an unknown source error, got %s", text)
+	}
+
+	result, _ = call(map[string]interface{}{"created_at_from": "yesterday"})
+	if !result.IsError {
+		t.Error("expected an error for an invalid created_at_from")
+	}
+}
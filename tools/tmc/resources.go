@@ -42,7 +42,7 @@ Response includes:
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"stack_id": map[string]interface{}{
 						"type":        "number",
@@ -131,7 +131,7 @@ Response includes:
 						},
 					},
 				},
-				Required: []string{"organization_uuid"},
+				Required: []string{},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -167,6 +167,9 @@ Response includes:
 
 			result, _, err := client.Resources.List(ctx, orgUUID, opts)
 			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
@@ -200,14 +203,14 @@ Use tmc_authenticate for organization UUID and tmc_list_resources to find resour
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"resource_uuid": map[string]interface{}{
 						"type":        "string",
 						"description": "Resource UUID (from tmc_list_resources)",
 					},
 				},
-				Required: []string{"organization_uuid", "resource_uuid"},
+				Required: []string{"resource_uuid"},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -223,6 +226,9 @@ Use tmc_authenticate for organization UUID and tmc_list_resources to find resour
 
 			resource, _, err := client.Resources.Get(ctx, orgUUID, resourceUUID)
 			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
@@ -46,7 +46,7 @@ but NOT the actual terraform plans. Use tmc_get_review_request for full plans.`,
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"status": map[string]interface{}{
 						"type":        "array",
@@ -79,7 +79,7 @@ but NOT the actual terraform plans. Use tmc_get_review_request for full plans.`,
 						"description": "Number of items per page (max: 100)",
 					},
 				},
-				Required: []string{"organization_uuid"},
+				Required: []string{},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -110,6 +110,9 @@ but NOT the actual terraform plans. Use tmc_get_review_request for full plans.`,
 
 			result, _, err := client.ReviewRequests.List(ctx, orgUUID, opts)
 			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
@@ -164,7 +167,7 @@ Workflow example:
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"review_request_id": map[string]interface{}{
 						"type":        "number",
@@ -175,7 +178,7 @@ Workflow example:
 						"description": "Exclude stack previews to get only PR metadata (default: false)",
 					},
 				},
-				Required: []string{"organization_uuid", "review_request_id"},
+				Required: []string{"review_request_id"},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -199,6 +202,9 @@ Workflow example:
 
 			result, _, err := client.ReviewRequests.Get(ctx, orgUUID, reviewRequestID, opts)
 			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
@@ -0,0 +1,101 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/tools/index"
+)
+
+// searchResponse is the response of tmc_search.
+type searchResponse struct {
+	Hits     []index.Hit `json:"hits"`
+	SyncedAt *time.Time  `json:"synced_at,omitempty"` // nil until the first sync completed
+}
+
+// Search creates an MCP tool that runs a full-text search over the local index.
+func Search(idx *index.Index) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_search",
+			Description: `Find anything in an organization with one full-text search: stacks, pull/merge requests and
+recent deployment errors.
+
+Searches the local index (synced in the background, see tmc_index_status):
+- stack: name, meta_id, description, path, repository, target and tags
+- review_request: title, description, branch, repository and number
+- deployment_error: the last lines of the stderr log of recently failed deployments
+
+Every word of the query must match, as a prefix ("netw vpc" finds "network-vpc"). Use it as the
+first step when the user names something without an ID, then follow up with the live tools.
+
+Response includes:
+- hits: Best matches first, each with kind, title, a snippet with matches wrapped in [ ], a
+  relevance score, and ref with the IDs for follow-up calls:
+  - stack_id: tmc_get_stack
+  - review_request_id: tmc_get_review_request
+  - stack_deployment_id: tmc_get_stack_deployment (stack_id for tmc_get_deployment_logs)
+- synced_at: When the index was last synced`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": indexOrgParam,
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Words to search for",
+					},
+					"kinds": map[string]interface{}{
+						"type":        "array",
+						"description": "Only return hits of these kinds (default: all)",
+						"items": map[string]interface{}{
+							"type": "string",
+							"enum": index.Kinds(),
+						},
+					},
+					"limit": map[string]interface{}{
+						"type":        "number",
+						"description": fmt.Sprintf("Maximum number of hits to return (default: %d, max: %d)", index.DefaultLimit, index.MaxLimit),
+					},
+				},
+				Required: []string{"query"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+			query, err := request.RequireString("query")
+			if err != nil {
+				return mcp.NewToolResultError("Query is required and must be a string."), nil
+			}
+
+			hits, err := idx.Search(ctx, orgUUID, index.SearchQuery{
+				Text:  query,
+				Kinds: request.GetStringSlice("kinds", nil),
+				Limit: request.GetInt("limit", 0),
+			})
+			if errors.Is(err, index.ErrEmptyQuery) {
+				return mcp.NewToolResultError("Query must contain at least one word."), nil
+			}
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to search index: %v", err)), nil
+			}
+			status, err := idx.Status(ctx, orgUUID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to query index: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(searchResponse{Hits: hits, SyncedAt: status.SyncedAt}, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/tools/index"
+)
+
+func TestSearch(t *testing.T) {
+	idx := newSyncedIndex(t)
+
+	result, err := Search(idx).Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"organization_uuid": "org-uuid",
+				"query":             "netw",
+				"kinds":             []interface{}{"stack"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", textContent.Text)
+	}
+
+	var response searchResponse
+	if err := json.Unmarshal([]byte(textContent.Text), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(response.Hits) != 1 || response.Hits[0].Kind != index.KindStack || response.Hits[0].Ref.StackID != 1 || response.SyncedAt == nil {
+		t.Fatalf("unexpected response: %s", textContent.Text)
+	}
+}
+
+func TestSearch_InvalidQuery(t *testing.T) {
+	idx := newSyncedIndex(t)
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{name: "missing query", args: map[string]interface{}{"organization_uuid": "org-uuid"}, wantErr: "Query is required"},
+		{name: "no words", args: map[string]interface{}{"organization_uuid": "org-uuid", "query": "?!"}, wantErr: "at least one word"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Search(idx).Handler(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tt.args},
+			})
+			if err != nil {
+				t.Fatalf("Handler error: %v", err)
+			}
+			textContent, _ := mcp.AsTextContent(result.Content[0])
+			if !result.IsError || !strings.Contains(textContent.Text, tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %q", tt.wantErr, textContent.Text)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// ServerInfo describes the running server for tmc_server_info. It holds no
+// secrets: Credential names the kind of credential only.
+type ServerInfo struct {
+	Version    string `json:"version"`
+	Commit     string `json:"commit,omitempty"`
+	BuildTime  string `json:"build_time,omitempty"`
+	SDKVersion string `json:"sdk_version"`
+	GoVersion  string `json:"go_version"`
+	Platform   string `json:"platform"`
+	Transport  string `json:"transport"`
+	// Region is empty for a custom base URL.
+	Region  string `json:"region,omitempty"`
+	BaseURL string `json:"base_url,omitempty"`
+	// Credential is "API Key", the provider of a JWT credential (e.g.
+	// "Google"), or "none" while no credential is configured.
+	Credential string `json:"credential"`
+	// ActAs is the member or service account API requests act as, if any.
+	ActAs    string   `json:"act_as,omitempty"`
+	Toolsets []string `json:"toolsets"`
+	Demo     bool     `json:"demo,omitempty"`
+	// LocalOnly is set when the server runs without Terramate Cloud.
+	LocalOnly bool `json:"local_only,omitempty"`
+}
+
+// serverInfoResponse is the payload returned by tmc_server_info.
+type serverInfoResponse struct {
+	ServerInfo
+	OrganizationUUID string               `json:"organization_uuid,omitempty"`
+	RateLimit        *terramate.RateLimit `json:"rate_limit,omitempty"`
+}
+
+// ServerInfoTool creates an MCP tool reporting info about the server and its
+// configuration, and the API rate limit last reported to client, which may be
+// nil.
+func ServerInfoTool(info ServerInfo, client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_server_info",
+			Description: `Report the version and configuration of this MCP server.
+
+Use this tool to debug a mismatched setup, e.g. when expected tools are missing, data is
+not found in an organization, or the server should be upgraded. No API request is made.
+
+Response includes:
+- version, commit, build_time: Server build
+- sdk_version: Terramate Cloud SDK version
+- go_version, platform: Go runtime and OS/architecture
+- transport: stdio or http
+- region, base_url: Terramate Cloud API in use (region is absent for a custom base URL)
+- credential: Credential type: API Key, the JWT provider, or none
+- act_as: Member or service account API requests act as, if any
+- organization_uuid: Organization selected for this session, if any
+- rate_limit: API request quota reported by the latest response (limit, remaining, reset), if any;
+  slow down when remaining is low
+- toolsets: Enabled toolsets
+- demo: true when serving the built-in demo organization instead of Terramate Cloud
+- local_only: true when serving only the local index, debug and server info tools, without Terramate Cloud`,
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+				Required:   []string{},
+			},
+		},
+		Handler: func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			response := serverInfoResponse{
+				ServerInfo:       info,
+				OrganizationUUID: SessionFromContext(ctx).DefaultOrganization(),
+			}
+			if client != nil {
+				response.RateLimit = client.RateLimit()
+			}
+			jsonData, err := json.MarshalIndent(response, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
@@ -0,0 +1,84 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func callServerInfo(t *testing.T, ctx context.Context, info ServerInfo, client *terramate.Client) (serverInfoResponse, string) {
+	t.Helper()
+	tool := ServerInfoTool(info, client)
+	result, err := tool.Handler(ctx, mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	textContent, ok := mcp.AsTextContent(result.Content[0])
+	if !ok {
+		t.Fatal("expected TextContent")
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %v", textContent.Text)
+	}
+	var response serverInfoResponse
+	if err := json.Unmarshal([]byte(textContent.Text), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	return response, textContent.Text
+}
+
+func TestServerInfoTool(t *testing.T) {
+	info := ServerInfo{
+		Version:    "1.2.3",
+		SDKVersion: "0.4.0",
+		Transport:  "stdio",
+		Region:     "eu",
+		Credential: "Google",
+		Toolsets:   []string{"stacks", "drifts"},
+	}
+	store := NewSessionStore(0)
+	session := store.Get("a")
+	session.SetDefaultOrganization("org-uuid")
+
+	response, text := callServerInfo(t, WithSession(context.Background(), session), info, nil)
+	if response.Version != "1.2.3" || response.Credential != "Google" || strings.Join(response.Toolsets, ",") != "stacks,drifts" {
+		t.Fatalf("expected the server info, got %s", text)
+	}
+	if response.OrganizationUUID != "org-uuid" {
+		t.Fatalf("expected the session organization, got %s", text)
+	}
+	if response.RateLimit != nil || strings.Contains(text, "rate_limit") {
+		t.Fatalf("expected no rate limit without a client, got %s", text)
+	}
+}
+
+func TestServerInfoTool_ReportsRateLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(terramate.RateLimitLimitHeader, "100")
+		w.Header().Set(terramate.RateLimitRemainingHeader, "7")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+	client, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	if response, _ := callServerInfo(t, context.Background(), ServerInfo{}, client); response.RateLimit != nil {
+		t.Fatalf("expected no rate limit before any request, got %+v", response.RateLimit)
+	}
+	if _, _, err := client.Memberships.List(context.Background()); err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	response, text := callServerInfo(t, context.Background(), ServerInfo{}, client)
+	if response.RateLimit == nil || response.RateLimit.Limit != 100 || response.RateLimit.Remaining != 7 {
+		t.Fatalf("expected the rate limit of the last response, got %s", text)
+	}
+}
@@ -0,0 +1,195 @@
+package tmc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// DefaultSessionIdleTimeout is how long an idle client session is kept before
+// its state is discarded.
+const DefaultSessionIdleTimeout = time.Hour
+
+// Session holds the state of a single MCP client: the organization selected
+// with tmc_authenticate and cached lookups. In stdio mode there is exactly one
+// session; over HTTP every connected client gets its own.
+//
+// All methods are safe for concurrent use and on a nil *Session, which
+// behaves as an empty session that discards writes.
+type Session struct {
+	mu             sync.Mutex
+	defaultOrgUUID string
+	memberships    []terramate.Membership
+	features       map[string]*terramate.OrganizationFeatures // by organization UUID
+	lastUsed       time.Time
+}
+
+// DefaultOrganization returns the organization UUID selected for this session.
+func (s *Session) DefaultOrganization() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.defaultOrgUUID
+}
+
+// SetDefaultOrganization selects the organization used when a tool call omits
+// organization_uuid.
+func (s *Session) SetDefaultOrganization(orgUUID string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultOrgUUID = orgUUID
+}
+
+// Memberships returns the cached memberships of the session, if any.
+func (s *Session) Memberships() ([]terramate.Membership, bool) {
+	if s == nil {
+		return nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.memberships, s.memberships != nil
+}
+
+// SetMemberships caches the memberships returned by the API.
+func (s *Session) SetMemberships(memberships []terramate.Membership) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.memberships = memberships
+}
+
+// Features returns the cached plan features of an organization, if any.
+func (s *Session) Features(orgUUID string) (*terramate.OrganizationFeatures, bool) {
+	if s == nil {
+		return nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	features, ok := s.features[orgUUID]
+	return features, ok
+}
+
+// SetFeatures caches the plan features of an organization.
+func (s *Session) SetFeatures(orgUUID string, features *terramate.OrganizationFeatures) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.features == nil {
+		s.features = map[string]*terramate.OrganizationFeatures{}
+	}
+	s.features[orgUUID] = features
+}
+
+// SessionStore keeps sessions by MCP session ID and evicts idle ones.
+type SessionStore struct {
+	mu             sync.Mutex
+	sessions       map[string]*Session
+	idleTimeout    time.Duration
+	defaultOrgUUID string // initial default organization of new sessions
+	now            func() time.Time
+}
+
+// NewSessionStore creates a store that discards sessions idle for longer than
+// idleTimeout. A non-positive idleTimeout uses DefaultSessionIdleTimeout.
+func NewSessionStore(idleTimeout time.Duration) *SessionStore {
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultSessionIdleTimeout
+	}
+	return &SessionStore{
+		sessions:    map[string]*Session{},
+		idleTimeout: idleTimeout,
+		now:         time.Now,
+	}
+}
+
+// SetDefaultOrganization sets the organization new sessions start with, e.g.
+// from server configuration. Sessions can still select another one.
+func (st *SessionStore) SetDefaultOrganization(orgUUID string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.defaultOrgUUID = orgUUID
+}
+
+// ClearCaches discards the cached lookups (memberships, organization features)
+// of all sessions, e.g. after the credential changed. Selected organizations
+// are kept.
+func (st *SessionStore) ClearCaches() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	for _, session := range st.sessions {
+		session.mu.Lock()
+		session.memberships = nil
+		session.features = nil
+		session.mu.Unlock()
+	}
+}
+
+// Get returns the session for id, creating it if needed.
+func (st *SessionStore) Get(id string) *Session {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := st.now()
+	st.evictIdle(now)
+
+	session, ok := st.sessions[id]
+	if !ok {
+		session = &Session{defaultOrgUUID: st.defaultOrgUUID}
+		st.sessions[id] = session
+	}
+	session.mu.Lock()
+	session.lastUsed = now
+	session.mu.Unlock()
+	return session
+}
+
+// Delete discards the session for id (e.g. when the client terminates it).
+func (st *SessionStore) Delete(id string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	delete(st.sessions, id)
+}
+
+// Len returns the number of live sessions.
+func (st *SessionStore) Len() int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return len(st.sessions)
+}
+
+// evictIdle removes sessions not used within the idle timeout. Callers must hold st.mu.
+func (st *SessionStore) evictIdle(now time.Time) {
+	for id, session := range st.sessions {
+		session.mu.Lock()
+		idle := now.Sub(session.lastUsed) > st.idleTimeout
+		session.mu.Unlock()
+		if idle {
+			delete(st.sessions, id)
+		}
+	}
+}
+
+// sessionKey is the context key for the current client session.
+type sessionKey struct{}
+
+// WithSession returns a context carrying the client session.
+func WithSession(ctx context.Context, session *Session) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+// SessionFromContext returns the client session, or nil if none is attached.
+func SessionFromContext(ctx context.Context) *Session {
+	session, _ := ctx.Value(sessionKey{}).(*Session)
+	return session
+}
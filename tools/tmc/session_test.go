@@ -0,0 +1,88 @@
+package tmc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestSessionStore_IsolatesSessions(t *testing.T) {
+	store := NewSessionStore(0)
+	a, b := store.Get("a"), store.Get("b")
+	a.SetDefaultOrganization("org-a")
+	b.SetMemberships([]terramate.Membership{{OrgUUID: "org-b"}})
+
+	if got := store.Get("a").DefaultOrganization(); got != "org-a" {
+		t.Fatalf("expected org-a, got %q", got)
+	}
+	if got := store.Get("b").DefaultOrganization(); got != "" {
+		t.Fatalf("expected no default for session b, got %q", got)
+	}
+	if _, ok := store.Get("a").Memberships(); ok {
+		t.Fatal("expected session a to have no cached memberships")
+	}
+
+	store.Delete("a")
+	if store.Len() != 1 || store.Get("a").DefaultOrganization() != "" {
+		t.Fatal("expected deleted session to start empty")
+	}
+}
+
+func TestSessionStore_EvictsIdleSessions(t *testing.T) {
+	now := time.Now()
+	store := NewSessionStore(time.Minute)
+	store.now = func() time.Time { return now }
+	store.Get("idle").SetDefaultOrganization("org")
+
+	now = now.Add(2 * time.Minute)
+	store.Get("active")
+	if store.Len() != 1 {
+		t.Fatalf("expected idle session to be evicted, have %d sessions", store.Len())
+	}
+}
+
+func TestSession_NilIsEmpty(t *testing.T) {
+	session := SessionFromContext(context.Background())
+	session.SetDefaultOrganization("org")
+	if session.DefaultOrganization() != "" {
+		t.Fatal("expected nil session to discard writes")
+	}
+	if _, ok := session.Memberships(); ok {
+		t.Fatal("expected nil session to have no memberships")
+	}
+}
+
+func TestSessionStore_DefaultOrganization(t *testing.T) {
+	store := NewSessionStore(0)
+	store.SetDefaultOrganization("configured")
+
+	session := store.Get("a")
+	if got := session.DefaultOrganization(); got != "configured" {
+		t.Fatalf("expected configured default, got %q", got)
+	}
+	session.SetDefaultOrganization("selected")
+	if got := store.Get("b").DefaultOrganization(); got != "configured" {
+		t.Fatalf("expected new sessions to keep the configured default, got %q", got)
+	}
+}
+
+func TestSessionStore_ClearCaches(t *testing.T) {
+	store := NewSessionStore(0)
+	session := store.Get("a")
+	session.SetDefaultOrganization("org-a")
+	session.SetMemberships([]terramate.Membership{{OrgUUID: "org-a"}})
+	session.SetFeatures("org-a", &terramate.OrganizationFeatures{Previews: true})
+
+	store.ClearCaches()
+	if _, ok := session.Memberships(); ok {
+		t.Fatal("expected memberships to be cleared")
+	}
+	if _, ok := session.Features("org-a"); ok {
+		t.Fatal("expected features to be cleared")
+	}
+	if session.DefaultOrganization() != "org-a" {
+		t.Fatal("expected the selected organization to be kept")
+	}
+}
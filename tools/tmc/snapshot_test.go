@@ -0,0 +1,79 @@
+package tmc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/terramate-io/terramate-mcp-server/internal/golden"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+	"github.com/terramate-io/terramate-mcp-server/tools/guardrail"
+)
+
+// The snapshot tests pin the rendered output of the tools. After an
+// intended formatting change, rewrite the golden files with
+//
+//	go test ./tools/tmc/ -run Snapshot -update
+//
+// and review the diff of testdata/ along with the code.
+
+func assertJSONSnapshot(t *testing.T, name string, v interface{}) {
+	t.Helper()
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal %s: %v", name, err)
+	}
+	golden.Assert(t, name, append(data, '\n'))
+}
+
+func TestSnapshot_DriftIssue(t *testing.T) {
+	finishedAt := time.Date(2026, 1, 7, 10, 30, 0, 0, time.UTC)
+	drift := &terramate.Drift{
+		ID:           100,
+		StackID:      456,
+		Status:       "drifted",
+		FinishedAt:   &finishedAt,
+		DriftDetails: &terramate.ChangesetDetails{ChangesetASCII: driftIssuePlan},
+	}
+	stack := &terramate.Stack{StackID: 456, Repository: "github.com/acme/infra", Path: "/stacks/web", Target: "prod"}
+
+	draft := renderDriftIssue(drift, stack, "acme")
+	golden.Assert(t, "drift_issue_body", []byte(draft.Body))
+}
+
+func TestSnapshot_AdviseApply(t *testing.T) {
+	response := &terramate.ReviewRequestGetResponse{
+		ReviewRequest: terramate.ReviewRequest{ReviewRequestID: 42, Title: "Resize database", URL: "https://github.com/acme/infra/pull/7"},
+		StackPreviews: []terramate.StackPreview{
+			{Status: "changed", Stack: &terramate.Stack{Path: "/prod/db"}, ChangesetDetails: &terramate.ChangesetDetails{
+				ChangesetJSON: `{"resource_changes": [{"address": "aws_db_instance.main", "change": {"actions": ["delete", "create"]}}]}`,
+			}},
+			{Status: "changed", Stack: &terramate.Stack{Path: "/prod/iam"}, ChangesetDetails: &terramate.ChangesetDetails{
+				ChangesetASCII: "  # aws_iam_role.ci will be updated in-place\n",
+			}},
+			{Status: "failed", Stack: &terramate.Stack{Path: "/prod/cache"}},
+		},
+	}
+	policy := &guardrail.Policy{
+		ProtectedResources: []guardrail.ProtectedResource{
+			{Address: "aws_db_instance.*", Reason: "production database"},
+			{Address: "aws_iam_*", Actions: []string{guardrail.ActionUpdate}, Decision: guardrail.NeedsApproval},
+		},
+		ChangeWindows: []guardrail.ChangeWindow{{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "09:00", End: "17:00"}},
+	}
+
+	advice := adviseReviewRequest(policy, response, time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC))
+	assertJSONSnapshot(t, "advise_apply", advice)
+}
+
+func TestSnapshot_Repositories(t *testing.T) {
+	updated := time.Date(2026, 1, 7, 10, 0, 0, 0, time.UTC)
+	stacks := []terramate.Stack{
+		{Repository: "github.com/acme/infra", Target: "prod", Status: "ok", DriftStatus: "ok", UpdatedAt: updated},
+		{Repository: "github.com/acme/infra", Target: "stg", Status: "drifted", DriftStatus: "drifted", UpdatedAt: updated.Add(time.Hour)},
+		{Repository: "github.com/acme/apps", Status: "failed", UpdatedAt: updated},
+		{Repository: "github.com/acme/apps", Status: "ok", DriftStatus: "ok", UpdatedAt: updated.Add(-time.Hour)},
+	}
+
+	assertJSONSnapshot(t, "repositories", summarizeRepositories(stacks))
+}
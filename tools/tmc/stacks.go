@@ -44,7 +44,7 @@ Response includes:
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"repository": map[string]interface{}{
 						"type":        "array",
@@ -134,7 +134,7 @@ Response includes:
 						},
 					},
 				},
-				Required: []string{"organization_uuid"},
+				Required: []string{},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -184,6 +184,9 @@ Response includes:
 			// Call the API.
 			result, _, err := client.Stacks.List(ctx, orgUUID, opts)
 			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
@@ -228,14 +231,14 @@ Response includes:
 				Properties: map[string]interface{}{
 					"organization_uuid": map[string]interface{}{
 						"type":        "string",
-						"description": "Organization UUID (get from tmc_authenticate)",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
 					},
 					"stack_id": map[string]interface{}{
 						"type":        "number",
 						"description": "Stack ID to retrieve",
 					},
 				},
-				Required: []string{"organization_uuid", "stack_id"},
+				Required: []string{"stack_id"},
 			},
 		},
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -257,6 +260,9 @@ Response includes:
 			// Call the API.
 			stack, _, err := client.Stacks.Get(ctx, orgUUID, stackID)
 			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
 				if apiErr, ok := err.(*terramate.APIError); ok {
 					if apiErr.IsUnauthorized() {
 						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
@@ -0,0 +1,154 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// summaryTargetProperties are the arguments identifying the summarized drift,
+// stack deployment or stack preview.
+func summaryTargetProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"organization_uuid": map[string]interface{}{
+			"type":        "string",
+			"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+		},
+		"kind": map[string]interface{}{
+			"type":        "string",
+			"description": "What to summarize",
+			"enum":        []string{terramate.SummaryKindDrift, terramate.SummaryKindStackDeployment, terramate.SummaryKindStackPreview},
+		},
+		"id": map[string]interface{}{
+			"type":        "number",
+			"description": "Drift ID (from tmc_list_drifts), stack deployment ID (from tmc_list_deployments) or stack preview ID (from tmc_get_review_request)",
+		},
+		"stack_id": map[string]interface{}{
+			"type":        "number",
+			"description": "Stack ID of the drift (required for kind drift)",
+		},
+	}
+}
+
+// summaryTarget reads the organization and summary target arguments, or
+// returns the error result of invalid ones.
+func summaryTarget(request mcp.CallToolRequest) (string, terramate.SummaryTarget, *mcp.CallToolResult) {
+	orgUUID, err := request.RequireString("organization_uuid")
+	if err != nil {
+		return "", terramate.SummaryTarget{}, mcp.NewToolResultError("Organization UUID is required and must be a string.")
+	}
+	kind, err := request.RequireString("kind")
+	if err != nil {
+		return "", terramate.SummaryTarget{}, mcp.NewToolResultError("Kind is required and must be a string.")
+	}
+	id, err := request.RequireInt("id")
+	if err != nil {
+		return "", terramate.SummaryTarget{}, mcp.NewToolResultError("ID is required and must be a number.")
+	}
+	return orgUUID, terramate.SummaryTarget{Kind: kind, ID: id, StackID: request.GetInt("stack_id", 0)}, nil
+}
+
+// summaryErrorResult returns the error result of a failed summary call.
+func summaryErrorResult(err error, action string) *mcp.CallToolResult {
+	if result, ok := knownErrorResult(err); ok {
+		return result
+	}
+	if apiErr, ok := err.(*terramate.APIError); ok {
+		if apiErr.IsUnauthorized() {
+			return mcp.NewToolResultError(terramate.ErrAuthenticationFailed)
+		}
+		if apiErr.IsNotFound() {
+			return mcp.NewToolResultError("No summary found: request one with tmc_request_summary, or check the ID.")
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error()))
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to %s: %v", action, err))
+}
+
+// RequestSummary creates an MCP tool that starts generating the AI summary of
+// a drift, stack deployment or stack preview.
+func RequestSummary(client *terramate.Client) server.ServerTool {
+	properties := summaryTargetProperties()
+	properties["force"] = map[string]interface{}{
+		"type":        "boolean",
+		"description": "Generate the summary again even if one already exists (default: false)",
+	}
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_request_summary",
+			Description: `Request an AI-generated summary of a drift, stack deployment or stack preview.
+
+Summaries explain in plain language what changed or what went wrong. They take a while
+to generate: call tmc_get_summary with the same arguments until it returns the summary.
+
+Workflow:
+1. tmc_request_summary with kind and id (and stack_id for drifts)
+2. tmc_get_summary with the same arguments; retry while it reports "pending"`,
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"kind", "id"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, target, result := summaryTarget(request)
+			if result != nil {
+				return result, nil
+			}
+
+			if _, err := client.Summaries.Request(ctx, orgUUID, target, request.GetBool("force", false)); err != nil {
+				return summaryErrorResult(err, "request summary"), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf(
+				`{"status": "requested", "message": "The summary of %s %d is being generated. Call tmc_get_summary with the same arguments to fetch it."}`,
+				target.Kind, target.ID)), nil
+		},
+	}
+}
+
+// GetSummary creates an MCP tool that fetches the AI summary of a drift,
+// stack deployment or stack preview.
+func GetSummary(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_get_summary",
+			Description: `Get the AI-generated summary of a drift, stack deployment or stack preview
+requested with tmc_request_summary.
+
+Returns {"status": "pending"} while the summary is being generated; retry after a few
+seconds. Fails with "No summary found" if none was requested.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: summaryTargetProperties(),
+				Required:   []string{"kind", "id"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, target, result := summaryTarget(request)
+			if result != nil {
+				return result, nil
+			}
+
+			summary, _, err := client.Summaries.Get(ctx, orgUUID, target)
+			if errors.Is(err, terramate.ErrSummaryPending) {
+				return mcp.NewToolResultText(`{"status": "pending", "message": "The summary is still being generated. Retry in a few seconds."}`), nil
+			}
+			if err != nil {
+				return summaryErrorResult(err, "get summary"), nil
+			}
+
+			jsonData, err := json.MarshalIndent(summary, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
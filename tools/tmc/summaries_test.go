@@ -0,0 +1,60 @@
+package tmc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestSummaryTools(t *testing.T) {
+	generated := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/stack_previews/org-uuid/5/ai/summary" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method == http.MethodPost {
+			generated = true
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if !generated {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"summary": {"contents": ["The plan replaces the database."], "created_at": "2024-04-12T07:06:00Z"}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	args := map[string]interface{}{
+		"organization_uuid": "org-uuid",
+		"kind":              terramate.SummaryKindStackPreview,
+		"id":                5,
+	}
+	call := func(tool func(*terramate.Client) server.ServerTool) (*mcp.CallToolResult, string) {
+		t.Helper()
+		result, err := tool(c).Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return result, text.Text
+	}
+
+	if result, text := call(GetSummary); !result.IsError || !strings.Contains(text, "tmc_request_summary") {
+		t.Fatalf("expected a not found error pointing to tmc_request_summary, got %s", text)
+	}
+	if result, text := call(RequestSummary); result.IsError || !strings.Contains(text, "requested") {
+		t.Fatalf("unexpected request result: %s", text)
+	}
+	if result, text := call(GetSummary); result.IsError || !strings.Contains(text, "The plan replaces the database.") {
+		t.Fatalf("unexpected summary: %s", text)
+	}
+}
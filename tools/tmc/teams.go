@@ -0,0 +1,184 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// ListTeams creates an MCP tool that lists the teams of an organization.
+func ListTeams(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_list_teams",
+			Description: `List the teams of a Terramate Cloud organization and the stack tags they own.
+
+Use this for routing questions like "which team owns stacks tagged payments?": filter by
+stack_tag, then tmc_list_team_members to find who to contact.
+
+Supported filters:
+- stack_tag: Only teams owning stacks with these tags
+- search: Substring of the team name
+- page, per_page: Pagination (max: 100)
+
+Response includes:
+- teams: Array of teams with team_uuid, name, member_count and stack_tags
+- paginated_result: Pagination info`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"stack_tag": map[string]interface{}{
+						"type":        "array",
+						"description": "Only teams owning stacks with these tags",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"search": map[string]interface{}{
+						"type":        "string",
+						"description": "Substring of the team name",
+					},
+					"page": map[string]interface{}{
+						"type":        "number",
+						"description": "Page number for pagination",
+					},
+					"per_page": map[string]interface{}{
+						"type":        "number",
+						"description": "Number of items per page (max: 100)",
+					},
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			opts := &terramate.TeamsListOptions{
+				StackTag: request.GetStringSlice("stack_tag", nil),
+				Search:   request.GetString("search", ""),
+			}
+			if page := request.GetInt("page", 0); page > 0 {
+				opts.Page = page
+			}
+			if perPage := request.GetInt("per_page", 0); perPage > 0 {
+				if perPage > 100 {
+					return mcp.NewToolResultError("Per page value must not exceed 100."), nil
+				}
+				opts.PerPage = perPage
+			}
+
+			teams, _, err := client.Teams.List(ctx, orgUUID, opts)
+			if err != nil {
+				return teamsErrorResult(err, "list teams"), nil
+			}
+
+			jsonData, err := json.MarshalIndent(teams, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// ListTeamMembers creates an MCP tool that lists the members of a team.
+func ListTeamMembers(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_list_team_members",
+			Description: `List the members of a Terramate Cloud team.
+
+Response includes:
+- members: Array of members with email, display_name, role and status
+- paginated_result: Pagination info
+
+Get team_uuid from tmc_list_teams.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"team_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Team UUID (from tmc_list_teams)",
+					},
+					"page": map[string]interface{}{
+						"type":        "number",
+						"description": "Page number for pagination",
+					},
+					"per_page": map[string]interface{}{
+						"type":        "number",
+						"description": "Number of items per page (max: 100)",
+					},
+				},
+				Required: []string{"team_uuid"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			teamUUID, err := request.RequireString("team_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Team UUID is required and must be a string."), nil
+			}
+
+			opts := &terramate.ListOptions{}
+			if page := request.GetInt("page", 0); page > 0 {
+				opts.Page = page
+			}
+			if perPage := request.GetInt("per_page", 0); perPage > 0 {
+				if perPage > 100 {
+					return mcp.NewToolResultError("Per page value must not exceed 100."), nil
+				}
+				opts.PerPage = perPage
+			}
+
+			members, _, err := client.Teams.ListMembers(ctx, orgUUID, teamUUID, opts)
+			if err != nil {
+				if apiErr, ok := err.(*terramate.APIError); ok && apiErr.IsNotFound() {
+					return mcp.NewToolResultError(fmt.Sprintf("Team %s not found.", teamUUID)), nil
+				}
+				return teamsErrorResult(err, "list team members"), nil
+			}
+
+			jsonData, err := json.MarshalIndent(members, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// teamsErrorResult maps an error of the teams API to a tool error result;
+// action names the failed operation.
+func teamsErrorResult(err error, action string) *mcp.CallToolResult {
+	if result, ok := knownErrorResult(err); ok {
+		return result
+	}
+	if apiErr, ok := err.(*terramate.APIError); ok {
+		if apiErr.IsUnauthorized() {
+			return mcp.NewToolResultError(terramate.ErrAuthenticationFailed)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error()))
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to %s: %v", action, err))
+}
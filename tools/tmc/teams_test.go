@@ -0,0 +1,60 @@
+package tmc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestTeamTools(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/organizations/org-uuid/teams":
+			if r.URL.Query().Get("stack_tag") != "payments" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"teams": [{"team_uuid": "team-1", "name": "Payments", "member_count": 1, "stack_tags": ["payments"]}], "paginated_result": {"total": 1, "page": 1, "per_page": 20}}`))
+		case "/v1/organizations/org-uuid/teams/team-1/members":
+			_, _ = w.Write([]byte(`{"members": [{"member_id": 1, "email": "jane@example.com", "role": "member", "status": "active"}], "paginated_result": {"total": 1, "page": 1, "per_page": 20}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	call := func(tool func(*terramate.Client) server.ServerTool, args map[string]interface{}) (*mcp.CallToolResult, string) {
+		t.Helper()
+		args["organization_uuid"] = "org-uuid"
+		result, err := tool(c).Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return result, text.Text
+	}
+
+	result, text := call(ListTeams, map[string]interface{}{"stack_tag": []interface{}{"payments"}})
+	if result.IsError || !strings.Contains(text, `"name": "Payments"`) {
+		t.Fatalf("unexpected teams result: %s", text)
+	}
+
+	result, text = call(ListTeamMembers, map[string]interface{}{"team_uuid": "team-1"})
+	if result.IsError || !strings.Contains(text, `"email": "jane@example.com"`) {
+		t.Fatalf("unexpected members result: %s", text)
+	}
+
+	result, text = call(ListTeamMembers, map[string]interface{}{"team_uuid": "missing"})
+	if !result.IsError || !strings.Contains(text, "Team missing not found") {
+		t.Errorf("expected a not found error, got %s", text)
+	}
+}
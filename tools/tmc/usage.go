@@ -0,0 +1,109 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// nearLimitFraction is the fraction of a plan limit from which tmc_get_usage
+// reports the limit as near.
+const nearLimitFraction = 0.8
+
+// usageResponse is the result of tmc_get_usage: the usage with the percentage
+// of each limited metric used and the metrics near their limit.
+type usageResponse struct {
+	*terramate.OrganizationUsage
+	PercentUsed map[string]float64 `json:"percent_used"`
+	NearLimit   []string           `json:"near_limit"`
+}
+
+// newUsageResponse computes the usage percentages of usage.
+func newUsageResponse(usage *terramate.OrganizationUsage) usageResponse {
+	response := usageResponse{
+		OrganizationUsage: usage,
+		PercentUsed:       map[string]float64{},
+		NearLimit:         []string{},
+	}
+	for _, metric := range []struct {
+		name  string
+		usage terramate.UsageMetric
+	}{
+		{"stacks", usage.Stacks},
+		{"deployments", usage.Deployments},
+		{"seats", usage.Seats},
+	} {
+		if metric.usage.Limit == nil {
+			continue
+		}
+		fraction := metric.usage.UsedFraction()
+		response.PercentUsed[metric.name] = math.Round(fraction*1000) / 10
+		if fraction >= nearLimitFraction {
+			response.NearLimit = append(response.NearLimit, metric.name)
+		}
+	}
+	return response
+}
+
+// GetUsage creates an MCP tool that reports the usage of an organization against its plan limits.
+func GetUsage(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_get_usage",
+			Description: `Get the usage of a Terramate Cloud organization against the limits of its plan.
+
+Use this to answer "how close are we to our plan limits?". Requires the admin role.
+
+Response includes:
+- plan, period_start, period_end: The plan and current billing period
+- stacks, deployments, seats: used and limit (no limit when omitted)
+- percent_used: Percentage of each limit used
+- near_limit: Metrics at 80% of their limit or more`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			usage, _, err := client.Usage.Get(ctx, orgUUID)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					if apiErr.IsForbidden() {
+						return mcp.NewToolResultError("The usage of an organization is only available to its admins."), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get usage: %v", err)), nil
+			}
+
+			jsonData, err := json.MarshalIndent(newUsageResponse(usage), "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package tmc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestGetUsage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/organizations/org-uuid/usage" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"plan": "business", "stacks": {"used": 450, "limit": 500}, "deployments": {"used": 1200}, "seats": {"used": 10, "limit": 25}}`))
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	result, err := GetUsage(c).Handler(context.Background(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Arguments: map[string]interface{}{"organization_uuid": "org-uuid"}},
+	})
+	if err != nil {
+		t.Fatalf("Handler error: %v", err)
+	}
+	text, _ := mcp.AsTextContent(result.Content[0])
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", text.Text)
+	}
+	for _, want := range []string{`"plan": "business"`, `"stacks": 90`, `"seats": 40`, `"near_limit": [
+    "stacks"
+  ]`} {
+		if !strings.Contains(text.Text, want) {
+			t.Errorf("expected %s in the result:\n%s", want, text.Text)
+		}
+	}
+	if strings.Contains(text.Text, `"deployments": 0`) {
+		t.Errorf("expected no percentage for the unlimited deployments:\n%s", text.Text)
+	}
+}
@@ -0,0 +1,217 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// ListWebhooks creates an MCP tool that lists the webhook subscriptions of an organization.
+func ListWebhooks(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_list_webhooks",
+			Description: `List the webhook subscriptions of a Terramate Cloud organization. Requires the admin role.
+
+Response includes:
+- webhooks: Array of webhooks with webhook_uuid, url, events and enabled
+- paginated_result: Pagination info`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"page": map[string]interface{}{
+						"type":        "number",
+						"description": "Page number for pagination",
+					},
+					"per_page": map[string]interface{}{
+						"type":        "number",
+						"description": "Number of items per page (max: 100)",
+					},
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			opts := &terramate.ListOptions{}
+			if page := request.GetInt("page", 0); page > 0 {
+				opts.Page = page
+			}
+			if perPage := request.GetInt("per_page", 0); perPage > 0 {
+				if perPage > 100 {
+					return mcp.NewToolResultError("Per page value must not exceed 100."), nil
+				}
+				opts.PerPage = perPage
+			}
+
+			webhooks, _, err := client.Webhooks.List(ctx, orgUUID, opts)
+			if err != nil {
+				return webhooksErrorResult(err, "list webhooks"), nil
+			}
+
+			jsonData, err := json.MarshalIndent(webhooks, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// CreateWebhook creates an MCP tool that subscribes a URL to events of an organization.
+func CreateWebhook(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_create_webhook",
+			Description: `Subscribe a URL to Terramate Cloud events, e.g. to alert a chat or incident tool when a
+deployment finishes or drift is detected. Requires the admin role.
+
+Events:
+- deployment_finished: A stack deployment finished (ok, failed or canceled)
+- drift_detected: A drift check found a stack drifted
+
+Returns the created webhook with its webhook_uuid.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"url": map[string]interface{}{
+						"type":        "string",
+						"description": "HTTPS URL the events are posted to",
+					},
+					"events": map[string]interface{}{
+						"type":        "array",
+						"description": "Events to subscribe to (deployment_finished, drift_detected)",
+						"items": map[string]interface{}{
+							"type": "string",
+							"enum": []string{terramate.WebhookEventDeploymentFinished, terramate.WebhookEventDriftDetected},
+						},
+					},
+					"description": map[string]interface{}{
+						"type":        "string",
+						"description": "What the webhook is for",
+					},
+					"secret": map[string]interface{}{
+						"type":        "string",
+						"description": "Secret signing the deliveries, so the receiver can verify them",
+					},
+				},
+				Required: []string{"url", "events"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			webhookURL, err := request.RequireString("url")
+			if err != nil {
+				return mcp.NewToolResultError("URL is required and must be a string."), nil
+			}
+
+			events, err := request.RequireStringSlice("events")
+			if err != nil || len(events) == 0 {
+				return mcp.NewToolResultError("Events are required and must be an array of strings."), nil
+			}
+
+			webhook, _, err := client.Webhooks.Create(ctx, orgUUID, terramate.CreateWebhookOptions{
+				URL:         webhookURL,
+				Events:      events,
+				Description: request.GetString("description", ""),
+				Secret:      request.GetString("secret", ""),
+			})
+			if err != nil {
+				return webhooksErrorResult(err, "create webhook"), nil
+			}
+
+			jsonData, err := json.MarshalIndent(webhook, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// DeleteWebhook creates an MCP tool that deletes a webhook subscription.
+func DeleteWebhook(client *terramate.Client) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_delete_webhook",
+			Description: `Delete a Terramate Cloud webhook subscription. Requires the admin role.
+
+Get webhook_uuid from tmc_list_webhooks.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"webhook_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Webhook UUID (from tmc_list_webhooks)",
+					},
+				},
+				Required: []string{"webhook_uuid"},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			webhookUUID, err := request.RequireString("webhook_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Webhook UUID is required and must be a string."), nil
+			}
+
+			_, err = client.Webhooks.Delete(ctx, orgUUID, webhookUUID)
+			if err != nil {
+				if apiErr, ok := err.(*terramate.APIError); ok && apiErr.IsNotFound() {
+					return mcp.NewToolResultError(fmt.Sprintf("Webhook %s not found.", webhookUUID)), nil
+				}
+				return webhooksErrorResult(err, "delete webhook"), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf(`{"status": "deleted", "webhook_uuid": %q}`, webhookUUID)), nil
+		},
+	}
+}
+
+// webhooksErrorResult maps an error of the webhooks API to a tool error
+// result; action names the failed operation.
+func webhooksErrorResult(err error, action string) *mcp.CallToolResult {
+	if result, ok := knownErrorResult(err); ok {
+		return result
+	}
+	if apiErr, ok := err.(*terramate.APIError); ok {
+		if apiErr.IsUnauthorized() {
+			return mcp.NewToolResultError(terramate.ErrAuthenticationFailed)
+		}
+		if apiErr.IsForbidden() {
+			return mcp.NewToolResultError("Webhooks can only be managed by the admins of an organization.")
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error()))
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("Failed to %s: %v", action, err))
+}
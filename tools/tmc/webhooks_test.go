@@ -0,0 +1,74 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+func TestWebhookTools(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/organizations/org-uuid/webhooks":
+			_, _ = w.Write([]byte(`{"webhooks": [{"webhook_uuid": "hook-1", "url": "https://hooks.example.com/tmc", "events": ["drift_detected"], "enabled": true}], "paginated_result": {"total": 1, "page": 1, "per_page": 20}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/organizations/org-uuid/webhooks":
+			var opts terramate.CreateWebhookOptions
+			_ = json.NewDecoder(r.Body).Decode(&opts)
+			if strings.Join(opts.Events, ",") != "deployment_finished,drift_detected" {
+				t.Errorf("unexpected events: %v", opts.Events)
+			}
+			_, _ = w.Write([]byte(`{"webhook_uuid": "hook-2", "url": "https://hooks.example.com/tmc", "events": ["deployment_finished", "drift_detected"], "enabled": true}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/organizations/org-uuid/webhooks/hook-1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	call := func(tool func(*terramate.Client) server.ServerTool, args map[string]interface{}) (*mcp.CallToolResult, string) {
+		t.Helper()
+		args["organization_uuid"] = "org-uuid"
+		result, err := tool(c).Handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: args},
+		})
+		if err != nil {
+			t.Fatalf("Handler error: %v", err)
+		}
+		text, _ := mcp.AsTextContent(result.Content[0])
+		return result, text.Text
+	}
+
+	if result, text := call(ListWebhooks, map[string]interface{}{}); result.IsError || !strings.Contains(text, `"webhook_uuid": "hook-1"`) {
+		t.Errorf("unexpected list result: %s", text)
+	}
+
+	result, text := call(CreateWebhook, map[string]interface{}{
+		"url":    "https://hooks.example.com/tmc",
+		"events": []interface{}{"deployment_finished", "drift_detected"},
+	})
+	if result.IsError || !strings.Contains(text, `"webhook_uuid": "hook-2"`) {
+		t.Errorf("unexpected create result: %s", text)
+	}
+	if result, _ := call(CreateWebhook, map[string]interface{}{"url": "https://hooks.example.com/tmc"}); !result.IsError {
+		t.Error("expected an error result without events")
+	}
+
+	if result, text := call(DeleteWebhook, map[string]interface{}{"webhook_uuid": "hook-1"}); result.IsError || !strings.Contains(text, `"deleted"`) {
+		t.Errorf("unexpected delete result: %s", text)
+	}
+	if result, text := call(DeleteWebhook, map[string]interface{}{"webhook_uuid": "hook-9"}); !result.IsError || !strings.Contains(text, "admins") {
+		t.Errorf("expected the admin role error, got %s", text)
+	}
+}
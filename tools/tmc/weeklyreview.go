@@ -0,0 +1,681 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/terramate-io/terramate-mcp-server/internal/fanout"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// Formats of tmc_weekly_review.
+const (
+	reviewFormatMarkdown = "markdown"
+	reviewFormatJSON     = "json"
+)
+
+// Sections of tmc_weekly_review, named when skipped.
+const (
+	reviewSectionDrift          = "drift"
+	reviewSectionDeployments    = "deployments"
+	reviewSectionReviewRequests = "review_requests"
+	reviewSectionPolicy         = "policy"
+)
+
+// reviewWeek is the period covered by tmc_weekly_review, compared with the
+// same period before it.
+const reviewWeek = 7 * 24 * time.Hour
+
+// defaultTopFailures is how many failing stacks tmc_weekly_review lists when
+// the call does not set top_failures.
+const defaultTopFailures = 5
+
+// reviewDriftRuns is how many recent drift runs of a stack tmc_weekly_review
+// counts, enough for daily drift detection over both weeks.
+const reviewDriftRuns = 50
+
+// reviewPerPage is the page size of the walks of tmc_weekly_review.
+const reviewPerPage = 100
+
+// weekTrend compares a count of the reviewed week with the week before.
+type weekTrend struct {
+	ThisWeek     int `json:"this_week"`
+	PreviousWeek int `json:"previous_week"`
+	Change       int `json:"change"`
+}
+
+func newWeekTrend(thisWeek, previousWeek int) weekTrend {
+	return weekTrend{ThisWeek: thisWeek, PreviousWeek: previousWeek, Change: thisWeek - previousWeek}
+}
+
+// driftReview summarizes drift detection.
+type driftReview struct {
+	CurrentlyDrifted int       `json:"currently_drifted"`
+	CurrentlyFailed  int       `json:"currently_failed"`
+	DriftedStacks    weekTrend `json:"drifted_stacks"`
+	FailedStacks     weekTrend `json:"failed_stacks"`
+	DetectionRuns    weekTrend `json:"detection_runs"`
+}
+
+// deploymentReview summarizes CI/CD deployments.
+type deploymentReview struct {
+	WorkflowRuns        weekTrend `json:"workflow_runs"`
+	StackDeployments    weekTrend `json:"stack_deployments"`
+	Failed              weekTrend `json:"failed"`
+	SuccessRate         *float64  `json:"success_rate,omitempty"` // percent of finished stack deployments
+	PreviousSuccessRate *float64  `json:"previous_success_rate,omitempty"`
+}
+
+// deploymentFailure is a stack whose deployments failed in the reviewed week.
+type deploymentFailure struct {
+	StackID            int       `json:"stack_id,omitempty"`
+	Repository         string    `json:"repository,omitempty"`
+	Path               string    `json:"path"`
+	Failures           int       `json:"failures"`
+	LastFailedAt       time.Time `json:"last_failed_at"`
+	LastDeploymentUUID string    `json:"last_deployment_uuid"`
+}
+
+// stalePreview is an open review request whose preview is missing or outdated.
+type stalePreview struct {
+	ReviewRequestID int        `json:"review_request_id"`
+	Number          int        `json:"number,omitempty"`
+	Title           string     `json:"title,omitempty"`
+	Repository      string     `json:"repository,omitempty"`
+	URL             string     `json:"url,omitempty"`
+	Preview         string     `json:"preview"` // missing, outdated
+	PushedAt        *time.Time `json:"pushed_at,omitempty"`
+}
+
+// policyReview summarizes policy checks of the stacks' resources.
+type policyReview struct {
+	FailingStacks  int       `json:"failing_stacks"`
+	HighSeverity   int       `json:"high_severity"`
+	MediumSeverity int       `json:"medium_severity"`
+	LowSeverity    int       `json:"low_severity"`
+	Regressions    weekTrend `json:"regressions"` // stacks whose failing check ran in the week
+}
+
+// weeklyReview is the document assembled by tmc_weekly_review.
+type weeklyReview struct {
+	OrganizationUUID string              `json:"organization_uuid"`
+	PeriodStart      time.Time           `json:"period_start"`
+	PeriodEnd        time.Time           `json:"period_end"`
+	Drift            *driftReview        `json:"drift,omitempty"`
+	Deployments      *deploymentReview   `json:"deployments,omitempty"`
+	TopFailures      []deploymentFailure `json:"top_failures"`
+	StalePreviews    []stalePreview      `json:"stale_previews"`
+	Policy           *policyReview       `json:"policy,omitempty"`
+	Skipped          map[string]string   `json:"skipped,omitempty"` // section -> reason
+}
+
+// reviewWindow is the reviewed week and the week before it.
+type reviewWindow struct {
+	previousStart, start, end time.Time
+}
+
+// week returns 0 for times in the reviewed week, 1 for the week before and
+// -1 otherwise.
+func (w reviewWindow) week(t time.Time) int {
+	switch {
+	case !t.Before(w.start) && t.Before(w.end):
+		return 0
+	case !t.Before(w.previousStart) && t.Before(w.start):
+		return 1
+	default:
+		return -1
+	}
+}
+
+// WeeklyReview creates an MCP tool that assembles the weekly operations
+// review of an organization. Datasets are shared through cache, so sections
+// needing the same data fetch it once and repeated reviews reuse it.
+func WeeklyReview(client *terramate.Client, cache *ReportCache) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.Tool{
+			Name: "tmc_weekly_review",
+			Description: `Assemble the weekly operations review of a Terramate Cloud organization in one document.
+
+Each count of the reviewed week is compared with the week before it (this_week, previous_week,
+change). By default the week ends at the start of the current hour, so repeated calls within the
+cache lifetime (e.g. to render the same review as markdown and as JSON) reuse the fetched data.
+
+Sections:
+- drift: Stacks currently drifted or failing drift detection, stacks found drifted or failing
+  and drift detection runs per week
+- deployments: Workflow runs, stack deployments, failed deployments and success rate per week
+- top_failures: Stacks with the most failed deployments in the week
+- stale_previews: Open review requests (PRs/MRs) whose preview is missing or outdated
+- policy: Stacks failing policy checks with findings per severity, and regressions (failing
+  checks that ran in the week)
+
+Sections the credential or the organization's plan does not allow are listed in skipped
+instead of failing the review.`,
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"organization_uuid": map[string]interface{}{
+						"type":        "string",
+						"description": "Organization UUID (get from tmc_authenticate; defaults to the organization selected for this session)",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "Output format (default: markdown)",
+						"enum":        []string{reviewFormatMarkdown, reviewFormatJSON},
+					},
+					"end": map[string]interface{}{
+						"type":        "string",
+						"description": "End of the reviewed week as an RFC3339 time, e.g. 2026-01-12T09:00:00Z (default: start of the current hour)",
+					},
+					"top_failures": map[string]interface{}{
+						"type":        "number",
+						"description": fmt.Sprintf("Number of failing stacks to list (default: %d)", defaultTopFailures),
+					},
+				},
+				Required: []string{},
+			},
+		},
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			orgUUID, err := request.RequireString("organization_uuid")
+			if err != nil {
+				return mcp.NewToolResultError("Organization UUID is required and must be a string."), nil
+			}
+
+			format := request.GetString("format", reviewFormatMarkdown)
+			if format != reviewFormatMarkdown && format != reviewFormatJSON {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid format %q: must be %s or %s.", format, reviewFormatMarkdown, reviewFormatJSON)), nil
+			}
+			end := time.Now().UTC().Truncate(time.Hour)
+			if s := request.GetString("end", ""); s != "" {
+				if end, err = time.Parse(time.RFC3339, s); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Invalid end %q: must be an RFC3339 time, e.g. 2026-01-12T09:00:00Z.", s)), nil
+				}
+			}
+			top := request.GetInt("top_failures", defaultTopFailures)
+			if top < 0 {
+				return mcp.NewToolResultError("top_failures must not be negative."), nil
+			}
+
+			review, err := buildWeeklyReview(ctx, client, cache, orgUUID, end.UTC(), top)
+			if err != nil {
+				if result, ok := knownErrorResult(err); ok {
+					return result, nil
+				}
+				if apiErr, ok := err.(*terramate.APIError); ok {
+					if apiErr.IsUnauthorized() {
+						return mcp.NewToolResultError(terramate.ErrAuthenticationFailed), nil
+					}
+					return mcp.NewToolResultError(fmt.Sprintf("API error: %s", apiErr.Error())), nil
+				}
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to assemble weekly review: %v", err)), nil
+			}
+
+			if format == reviewFormatMarkdown {
+				return mcp.NewToolResultText(renderWeeklyReview(review)), nil
+			}
+			jsonData, err := json.MarshalIndent(review, "", "  ")
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal response: %v", err)), nil
+			}
+			return mcp.NewToolResultText(string(jsonData)), nil
+		},
+	}
+}
+
+// buildWeeklyReview assembles the review of the week ending at end.
+func buildWeeklyReview(ctx context.Context, client *terramate.Client, cache *ReportCache, orgUUID string, end time.Time, top int) (*weeklyReview, error) {
+	w := reviewWindow{previousStart: end.Add(-2 * reviewWeek), start: end.Add(-reviewWeek), end: end}
+	review := &weeklyReview{
+		OrganizationUUID: orgUUID,
+		PeriodStart:      w.start,
+		PeriodEnd:        w.end,
+		TopFailures:      []deploymentFailure{},
+		StalePreviews:    []stalePreview{},
+	}
+
+	features, err := OrganizationFeatures(ctx, client, orgUUID)
+	if err != nil {
+		all := allFeatures
+		features = &all // sections the organization lacks are skipped when fetched
+	}
+
+	stacks, err := cached(ctx, cache, "stacks:"+orgUUID, func(ctx context.Context) ([]terramate.Stack, error) {
+		return listAllStacks(ctx, client, orgUUID, &terramate.StacksListOptions{IsArchived: []bool{false}})
+	})
+	if err != nil {
+		if err = review.skipOnDenied(err, reviewSectionDrift, reviewSectionPolicy); err != nil {
+			return nil, err
+		}
+	} else {
+		if review.Drift, err = reviewDrift(ctx, client, cache, orgUUID, stacks, w); err != nil {
+			if err = review.skipOnDenied(err, reviewSectionDrift); err != nil {
+				return nil, err
+			}
+		}
+		if features.Policies {
+			policy := reviewPolicy(stacks, w)
+			review.Policy = &policy
+		} else {
+			review.skip(reviewSectionPolicy, "policy checks are not enabled for this organization")
+		}
+	}
+
+	if review.Deployments, review.TopFailures, err = reviewDeployments(ctx, client, cache, orgUUID, w, top); err != nil {
+		if err = review.skipOnDenied(err, reviewSectionDeployments); err != nil {
+			return nil, err
+		}
+	}
+
+	if !features.Previews {
+		review.skip(reviewSectionReviewRequests, "previews are not enabled for this organization")
+		return review, nil
+	}
+	if review.StalePreviews, err = reviewStalePreviews(ctx, client, cache, orgUUID); err != nil {
+		if err = review.skipOnDenied(err, reviewSectionReviewRequests); err != nil {
+			return nil, err
+		}
+	}
+	return review, nil
+}
+
+// skip records why section is missing from the review.
+func (r *weeklyReview) skip(section, reason string) {
+	if r.Skipped == nil {
+		r.Skipped = map[string]string{}
+	}
+	r.Skipped[section] = reason
+}
+
+// skipOnDenied skips sections when err shows the credential cannot read
+// their data, and returns any other error.
+func (r *weeklyReview) skipOnDenied(err error, sections ...string) error {
+	var reason string
+	var unavailable *terramate.FeatureUnavailableError
+	var apiErr *terramate.APIError
+	switch {
+	case errors.As(err, &unavailable):
+		reason = fmt.Sprintf("the credential cannot access the %s API", serviceNames[unavailable.Service])
+	case errors.As(err, &apiErr) && apiErr.IsForbidden():
+		reason = "the credential is not allowed to read this data"
+	default:
+		return err
+	}
+	for _, section := range sections {
+		r.skip(section, reason)
+	}
+	return nil
+}
+
+// reviewDrift counts the drift runs of the stacks updated since the start of
+// the previous week; a drift run updates its stack, so other stacks had none.
+func reviewDrift(ctx context.Context, client *terramate.Client, cache *ReportCache, orgUUID string, stacks []terramate.Stack, w reviewWindow) (*driftReview, error) {
+	review := &driftReview{}
+	var checked []terramate.Stack
+	for _, stack := range stacks {
+		switch stack.DriftStatus {
+		case "drifted":
+			review.CurrentlyDrifted++
+		case "failed":
+			review.CurrentlyFailed++
+		case "ok":
+		default:
+			continue // no drift detection
+		}
+		if !stack.UpdatedAt.Before(w.previousStart) {
+			checked = append(checked, stack)
+		}
+	}
+
+	runs := make([][]terramate.Drift, len(checked))
+	err := fanout.Run(ctx, len(checked), func(ctx context.Context, i int) error {
+		key := fmt.Sprintf("drifts:%s:%d", orgUUID, checked[i].StackID)
+		drifts, err := cached(ctx, cache, key, func(ctx context.Context) ([]terramate.Drift, error) {
+			result, _, err := client.Drifts.ListForStack(ctx, orgUUID, checked[i].StackID, &terramate.DriftsListOptions{
+				ListOptions: terramate.ListOptions{Page: 1, PerPage: reviewDriftRuns},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return result.Drifts, nil
+		})
+		runs[i] = drifts
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	countDriftRuns(review, runs, w)
+	return review, nil
+}
+
+// countDriftRuns counts the drift runs of both weeks and the stacks they
+// found drifted or failed to check.
+func countDriftRuns(review *driftReview, runs [][]terramate.Drift, w reviewWindow) {
+	var detectionRuns, drifted, failed [2]int
+	for _, stackRuns := range runs {
+		var stackDrifted, stackFailed [2]bool
+		for _, drift := range stackRuns {
+			at := latest(nil, drift.StartedAt, drift.FinishedAt)
+			if at == nil || w.week(*at) < 0 {
+				continue
+			}
+			week := w.week(*at)
+			detectionRuns[week]++
+			stackDrifted[week] = stackDrifted[week] || drift.Status == "drifted"
+			stackFailed[week] = stackFailed[week] || drift.Status == "failed"
+		}
+		for week := range 2 {
+			drifted[week] += boolCount(stackDrifted[week])
+			failed[week] += boolCount(stackFailed[week])
+		}
+	}
+	review.DriftedStacks = newWeekTrend(drifted[0], drifted[1])
+	review.FailedStacks = newWeekTrend(failed[0], failed[1])
+	review.DetectionRuns = newWeekTrend(detectionRuns[0], detectionRuns[1])
+}
+
+func boolCount(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// reviewPolicy summarizes the failing policy checks of stacks.
+func reviewPolicy(stacks []terramate.Stack, w reviewWindow) policyReview {
+	var review policyReview
+	var regressions [2]int
+	for _, stack := range stacks {
+		if stack.Resources == nil || stack.Resources.PolicyCheck == nil || stack.Resources.PolicyCheck.Passed {
+			continue
+		}
+		check := stack.Resources.PolicyCheck
+		review.FailingStacks++
+		review.HighSeverity += check.Counters.SeverityHighCount
+		review.MediumSeverity += check.Counters.SeverityMediumCount
+		review.LowSeverity += check.Counters.SeverityLowCount
+		if week := w.week(check.CreatedAt); week >= 0 {
+			regressions[week]++
+		}
+	}
+	review.Regressions = newWeekTrend(regressions[0], regressions[1])
+	return review
+}
+
+// reviewDeployments counts the workflow runs and stack deployments of both
+// weeks and ranks the stacks failing most in the reviewed week.
+func reviewDeployments(ctx context.Context, client *terramate.Client, cache *ReportCache, orgUUID string, w reviewWindow, top int) (*deploymentReview, []deploymentFailure, error) {
+	var workflowRuns [2]int
+	for week, from := range []time.Time{w.start, w.previousStart} {
+		to := from.Add(reviewWeek)
+		key := fmt.Sprintf("workflow_runs:%s:%d-%d", orgUUID, from.Unix(), to.Unix())
+		total, err := cached(ctx, cache, key, func(ctx context.Context) (int, error) {
+			result, _, err := client.Deployments.List(ctx, orgUUID, &terramate.DeploymentsListOptions{
+				ListOptions:   terramate.ListOptions{Page: 1, PerPage: 1},
+				CreatedAtFrom: &from,
+				CreatedAtTo:   &to,
+			})
+			if err != nil {
+				return 0, err
+			}
+			return result.PaginatedResult.Total, nil
+		})
+		if err != nil {
+			return nil, []deploymentFailure{}, err
+		}
+		workflowRuns[week] = total
+	}
+
+	key := fmt.Sprintf("stack_deployments:%s:%d-%d", orgUUID, w.previousStart.Unix(), w.end.Unix())
+	deployments, err := cached(ctx, cache, key, func(ctx context.Context) ([]terramate.StackDeployment, error) {
+		return listStackDeploymentsBetween(ctx, client, orgUUID, w.previousStart, w.end)
+	})
+	if err != nil {
+		return nil, []deploymentFailure{}, err
+	}
+
+	var total, failed, ok, finished [2]int
+	failures := map[string]*deploymentFailure{}
+	for _, d := range deployments {
+		week := w.week(d.CreatedAt)
+		if week < 0 {
+			continue
+		}
+		total[week]++
+		switch d.Status {
+		case "ok":
+			ok[week]++
+			finished[week]++
+		case "failed":
+			failed[week]++
+			finished[week]++
+			if week == 0 {
+				addFailure(failures, d)
+			}
+		case "canceled":
+			finished[week]++
+		}
+	}
+
+	review := &deploymentReview{
+		WorkflowRuns:        newWeekTrend(workflowRuns[0], workflowRuns[1]),
+		StackDeployments:    newWeekTrend(total[0], total[1]),
+		Failed:              newWeekTrend(failed[0], failed[1]),
+		SuccessRate:         percent(ok[0], finished[0]),
+		PreviousSuccessRate: percent(ok[1], finished[1]),
+	}
+	return review, topFailures(failures, top), nil
+}
+
+// listStackDeploymentsBetween fetches the stack deployments created in
+// [from, to).
+func listStackDeploymentsBetween(ctx context.Context, client *terramate.Client, orgUUID string, from, to time.Time) ([]terramate.StackDeployment, error) {
+	var deployments []terramate.StackDeployment
+	for page := 1; ; page++ {
+		result, _, err := client.Deployments.ListStackDeployments(ctx, orgUUID, &terramate.StackDeploymentsListOptions{
+			ListOptions:   terramate.ListOptions{Page: page, PerPage: reviewPerPage},
+			CreatedAtFrom: &from,
+			CreatedAtTo:   &to,
+		})
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, result.StackDeployments...)
+		if page >= result.PaginatedResult.TotalPages() {
+			return deployments, nil
+		}
+	}
+}
+
+// addFailure counts the failed deployment d for its stack.
+func addFailure(failures map[string]*deploymentFailure, d terramate.StackDeployment) {
+	failure := deploymentFailure{Path: d.Path}
+	if d.Stack != nil {
+		failure.StackID, failure.Repository = d.Stack.StackID, d.Stack.Repository
+	}
+	key := fmt.Sprintf("%s:%d:%s", failure.Repository, failure.StackID, failure.Path)
+	f, ok := failures[key]
+	if !ok {
+		f = &failure
+		failures[key] = f
+	}
+	f.Failures++
+	if at := latest(nil, &d.CreatedAt, d.FinishedAt); at.After(f.LastFailedAt) {
+		f.LastFailedAt, f.LastDeploymentUUID = *at, d.DeploymentUUID
+	}
+}
+
+// topFailures returns the n stacks with the most failures, most recent first
+// among equals.
+func topFailures(failures map[string]*deploymentFailure, n int) []deploymentFailure {
+	ranked := make([]deploymentFailure, 0, len(failures))
+	for _, f := range failures {
+		ranked = append(ranked, *f)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Failures != ranked[j].Failures {
+			return ranked[i].Failures > ranked[j].Failures
+		}
+		return ranked[i].LastFailedAt.After(ranked[j].LastFailedAt)
+	})
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
+
+// percent returns part as a percentage of whole, rounded to one decimal, or
+// nil when whole is zero.
+func percent(part, whole int) *float64 {
+	if whole == 0 {
+		return nil
+	}
+	p := math.Round(float64(part)*1000/float64(whole)) / 10
+	return &p
+}
+
+// reviewStalePreviews lists the open review requests whose preview is
+// missing or outdated.
+func reviewStalePreviews(ctx context.Context, client *terramate.Client, cache *ReportCache, orgUUID string) ([]stalePreview, error) {
+	open, err := cached(ctx, cache, "open_review_requests:"+orgUUID, func(ctx context.Context) ([]terramate.ReviewRequest, error) {
+		var reviewRequests []terramate.ReviewRequest
+		for page := 1; ; page++ {
+			result, _, err := client.ReviewRequests.List(ctx, orgUUID, &terramate.ReviewRequestsListOptions{
+				ListOptions: terramate.ListOptions{Page: page, PerPage: reviewPerPage},
+				Status:      []string{"open"},
+			})
+			if err != nil {
+				return nil, err
+			}
+			reviewRequests = append(reviewRequests, result.ReviewRequests...)
+			if page >= result.PaginatedResult.TotalPages() {
+				return reviewRequests, nil
+			}
+		}
+	})
+	if err != nil {
+		return []stalePreview{}, err
+	}
+
+	stale := []stalePreview{}
+	for _, rr := range open {
+		preview := "missing"
+		if rr.Preview != nil {
+			if rr.Preview.Status != "outdated" {
+				continue
+			}
+			preview = "outdated"
+		}
+		stale = append(stale, stalePreview{
+			ReviewRequestID: rr.ReviewRequestID,
+			Number:          rr.Number,
+			Title:           rr.Title,
+			Repository:      rr.Repository,
+			URL:             rr.URL,
+			Preview:         preview,
+			PushedAt:        rr.PlatformPushedAt,
+		})
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		if stale[i].Repository != stale[j].Repository {
+			return stale[i].Repository < stale[j].Repository
+		}
+		return stale[i].Number < stale[j].Number
+	})
+	return stale, nil
+}
+
+// renderWeeklyReview renders review as markdown.
+func renderWeeklyReview(review *weeklyReview) string {
+	var b strings.Builder
+	const day = "2006-01-02 15:04 MST"
+	fmt.Fprintf(&b, "# Weekly ops review\n\nOrganization `%s`, %s to %s, compared with the week before.\n",
+		review.OrganizationUUID, review.PeriodStart.Format(day), review.PeriodEnd.Format(day))
+
+	if d := review.Drift; d != nil {
+		fmt.Fprintf(&b, "\n## Drift\n\nCurrently %d stacks drifted and %d failing drift detection.\n\n", d.CurrentlyDrifted, d.CurrentlyFailed)
+		writeTrends(&b, []string{"Stacks found drifted", "Stacks failing drift detection", "Drift detection runs"},
+			[]weekTrend{d.DriftedStacks, d.FailedStacks, d.DetectionRuns})
+	}
+
+	if d := review.Deployments; d != nil {
+		b.WriteString("\n## Deployments\n\n")
+		writeTrends(&b, []string{"Workflow runs", "Stack deployments", "Failed stack deployments"},
+			[]weekTrend{d.WorkflowRuns, d.StackDeployments, d.Failed})
+		fmt.Fprintf(&b, "\nSuccess rate: %s (previous week: %s).\n", formatPercent(d.SuccessRate), formatPercent(d.PreviousSuccessRate))
+
+		b.WriteString("\n## Top failures\n\n")
+		if len(review.TopFailures) == 0 {
+			b.WriteString("No failed deployments this week.\n")
+		} else {
+			b.WriteString("| Stack | Repository | Failures | Last failed | Deployment |\n| --- | --- | ---: | --- | --- |\n")
+			for _, f := range review.TopFailures {
+				fmt.Fprintf(&b, "| `%s` | %s | %d | %s | `%s` |\n", f.Path, f.Repository, f.Failures, f.LastFailedAt.UTC().Format(day), f.LastDeploymentUUID)
+			}
+		}
+	}
+
+	if _, skipped := review.Skipped[reviewSectionReviewRequests]; !skipped {
+		b.WriteString("\n## Open PRs with stale previews\n\n")
+		if len(review.StalePreviews) == 0 {
+			b.WriteString("Every open PR has a current preview.\n")
+		} else {
+			b.WriteString("| PR | Repository | Preview | Last push |\n| --- | --- | --- | --- |\n")
+			for _, p := range review.StalePreviews {
+				pushed := "-"
+				if p.PushedAt != nil {
+					pushed = p.PushedAt.UTC().Format(day)
+				}
+				fmt.Fprintf(&b, "| [#%d %s](%s) | %s | %s | %s |\n", p.Number, markdownCell(p.Title), p.URL, p.Repository, p.Preview, pushed)
+			}
+		}
+	}
+
+	if p := review.Policy; p != nil {
+		fmt.Fprintf(&b, "\n## Policy\n\n%d stacks failing policy checks, with %d high, %d medium and %d low severity findings.\n\n",
+			p.FailingStacks, p.HighSeverity, p.MediumSeverity, p.LowSeverity)
+		writeTrends(&b, []string{"Regressions (failing checks)"}, []weekTrend{p.Regressions})
+	}
+
+	if len(review.Skipped) > 0 {
+		b.WriteString("\n## Skipped sections\n\n")
+		sections := make([]string, 0, len(review.Skipped))
+		for section := range review.Skipped {
+			sections = append(sections, section)
+		}
+		sort.Strings(sections)
+		for _, section := range sections {
+			fmt.Fprintf(&b, "- %s: %s\n", section, review.Skipped[section])
+		}
+	}
+	return b.String()
+}
+
+// writeTrends writes a table comparing the labeled trends.
+func writeTrends(b *strings.Builder, labels []string, trends []weekTrend) {
+	b.WriteString("| | This week | Previous week | Change |\n| --- | ---: | ---: | ---: |\n")
+	for i, t := range trends {
+		fmt.Fprintf(b, "| %s | %d | %d | %+d |\n", labels[i], t.ThisWeek, t.PreviousWeek, t.Change)
+	}
+}
+
+func formatPercent(p *float64) string {
+	if p == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.1f%%", *p)
+}
+
+// markdownCell escapes text for a markdown table cell.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
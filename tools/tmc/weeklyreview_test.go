@@ -0,0 +1,165 @@
+package tmc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/terramate-io/terramate-mcp-server/sdk/terramate"
+)
+
+// weeklyReviewAPI serves an organization reviewed for the week ending
+// 2026-01-12T09:00:00Z, counting the requests it receives.
+func weeklyReviewAPI(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/organizations/org-uuid/features":
+			w.WriteHeader(http.StatusNotFound)
+		case "/v1/stacks/org-uuid":
+			_, _ = w.Write([]byte(`{"stacks":[
+				{"stack_id":1,"repository":"github.com/acme/infra","path":"/a","drift_status":"drifted","updated_at":"2026-01-10T00:00:00Z",
+				 "resources":{"count":3,"policy_check":{"created_at":"2026-01-09T00:00:00Z","passed":false,"counters":{"severity_high_count":2}}}},
+				{"stack_id":2,"repository":"github.com/acme/infra","path":"/b","drift_status":"ok","updated_at":"2026-01-03T00:00:00Z",
+				 "resources":{"count":1,"policy_check":{"created_at":"2026-01-02T00:00:00Z","passed":false,"counters":{"severity_medium_count":1}}}},
+				{"stack_id":3,"repository":"github.com/acme/infra","path":"/c","drift_status":"unknown","updated_at":"2026-01-11T00:00:00Z"}
+			],"paginated_result":{"total":3,"page":1,"per_page":100}}`))
+		case "/v1/stacks/org-uuid/1/drifts":
+			_, _ = w.Write([]byte(`{"drifts":[
+				{"id":11,"stack_id":1,"status":"drifted","finished_at":"2026-01-10T00:00:00Z"},
+				{"id":10,"stack_id":1,"status":"ok","finished_at":"2026-01-04T00:00:00Z"}
+			],"paginated_result":{"total":2,"page":1,"per_page":50}}`))
+		case "/v1/stacks/org-uuid/2/drifts":
+			_, _ = w.Write([]byte(`{"drifts":[{"id":20,"stack_id":2,"status":"failed","finished_at":"2026-01-03T00:00:00Z"}],"paginated_result":{"total":1,"page":1,"per_page":50}}`))
+		case "/v1/organizations/org-uuid/deployments":
+			total := 2
+			if r.URL.Query().Get("created_at_from") == "2026-01-05T09:00:00Z" {
+				total = 4
+			}
+			fmt.Fprintf(w, `{"deployments":[],"paginated_result":{"total":%d,"page":1,"per_page":1}}`, total)
+		case "/v1/stack_deployments/org-uuid":
+			_, _ = w.Write([]byte(`{"stack_deployments":[
+				{"id":1,"deployment_uuid":"d-1","path":"/a","status":"failed","created_at":"2026-01-08T00:00:00Z","stack":{"stack_id":1,"repository":"github.com/acme/infra","path":"/a"}},
+				{"id":2,"deployment_uuid":"d-2","path":"/a","status":"failed","created_at":"2026-01-11T00:00:00Z","stack":{"stack_id":1,"repository":"github.com/acme/infra","path":"/a"}},
+				{"id":3,"deployment_uuid":"d-3","path":"/b","status":"ok","created_at":"2026-01-09T00:00:00Z","stack":{"stack_id":2,"repository":"github.com/acme/infra","path":"/b"}},
+				{"id":4,"deployment_uuid":"d-4","path":"/b","status":"ok","created_at":"2026-01-01T00:00:00Z","stack":{"stack_id":2,"repository":"github.com/acme/infra","path":"/b"}}
+			],"paginated_result":{"total":4,"page":1,"per_page":100}}`))
+		case "/v1/review_requests/org-uuid":
+			if got := r.URL.Query().Get("status"); got != "open" {
+				t.Errorf("expected open review requests to be listed, got status %q", got)
+			}
+			_, _ = w.Write([]byte(`{"review_requests":[
+				{"review_request_id":1,"number":7,"title":"Resize | database","repository":"github.com/acme/infra","url":"https://github.com/acme/infra/pull/7","preview":{"id":1,"status":"outdated"}},
+				{"review_request_id":2,"number":8,"repository":"github.com/acme/infra","preview":{"id":2,"status":"current"}},
+				{"review_request_id":3,"number":9,"repository":"github.com/acme/infra"}
+			],"paginated_result":{"total":3,"page":1,"per_page":100}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func callWeeklyReview(t *testing.T, tool func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) string {
+	t.Helper()
+	args["organization_uuid"] = "org-uuid"
+	args["end"] = "2026-01-12T09:00:00Z"
+	result, err := tool(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	if err != nil {
+		t.Fatalf("tmc_weekly_review error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("tmc_weekly_review failed: %s", text)
+	}
+	return text
+}
+
+func TestWeeklyReview(t *testing.T) {
+	var requests atomic.Int32
+	ts := weeklyReviewAPI(t, &requests)
+	defer ts.Close()
+	client, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+	tool := WeeklyReview(client, NewReportCache(0)).Handler
+
+	var review weeklyReview
+	if err := json.Unmarshal([]byte(callWeeklyReview(t, tool, map[string]any{"format": "json"})), &review); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	if d := review.Drift; d == nil || d.CurrentlyDrifted != 1 || d.DriftedStacks != newWeekTrend(1, 0) ||
+		d.FailedStacks != newWeekTrend(0, 1) || d.DetectionRuns != newWeekTrend(1, 2) {
+		t.Fatalf("unexpected drift section: %+v", review.Drift)
+	}
+	if d := review.Deployments; d == nil || d.WorkflowRuns != newWeekTrend(4, 2) || d.StackDeployments != newWeekTrend(3, 1) ||
+		d.Failed != newWeekTrend(2, 0) || d.SuccessRate == nil || *d.SuccessRate != 33.3 || *d.PreviousSuccessRate != 100 {
+		t.Fatalf("unexpected deployments section: %+v", review.Deployments)
+	}
+	if len(review.TopFailures) != 1 || review.TopFailures[0].Failures != 2 || review.TopFailures[0].LastDeploymentUUID != "d-2" {
+		t.Fatalf("unexpected top failures: %+v", review.TopFailures)
+	}
+	if len(review.StalePreviews) != 2 || review.StalePreviews[0].Preview != "outdated" || review.StalePreviews[1].Preview != "missing" {
+		t.Fatalf("unexpected stale previews: %+v", review.StalePreviews)
+	}
+	if p := review.Policy; p == nil || p.FailingStacks != 2 || p.HighSeverity != 2 || p.Regressions != newWeekTrend(1, 1) {
+		t.Fatalf("unexpected policy section: %+v", review.Policy)
+	}
+
+	// Rendering the same review again reuses the cached datasets
+	fetched := requests.Load()
+	markdown := callWeeklyReview(t, tool, map[string]any{})
+	if n := requests.Load() - fetched; n > 1 { // organization features are not cached without a session
+		t.Fatalf("expected the second review to reuse the cached data, got %d requests", n)
+	}
+	for _, want := range []string{"## Drift", "| Failed stack deployments | 2 | 0 | +2 |", "[#7 Resize \\| database](https://github.com/acme/infra/pull/7)", "Success rate: 33.3% (previous week: 100.0%)"} {
+		if !strings.Contains(markdown, want) {
+			t.Fatalf("expected %q in the markdown review:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestWeeklyReview_SkipsUnavailableSections(t *testing.T) {
+	var requests atomic.Int32
+	api := weeklyReviewAPI(t, &requests)
+	defer api.Close()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/review_requests/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path == "/v1/organizations/org-uuid/features" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"previews":true,"policies":false,"targets":false}`))
+			return
+		}
+		api.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+	client, err := terramate.NewClientWithAPIKey("key", terramate.WithBaseURL(ts.URL))
+	if err != nil {
+		t.Fatalf("NewClient error: %v", err)
+	}
+
+	var review weeklyReview
+	text := callWeeklyReview(t, WeeklyReview(client, nil).Handler, map[string]any{"format": "json"})
+	if err := json.Unmarshal([]byte(text), &review); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+	if review.Policy != nil || review.Skipped[reviewSectionPolicy] == "" || review.Skipped[reviewSectionReviewRequests] == "" {
+		t.Fatalf("expected the policy and review request sections to be skipped, got %s", text)
+	}
+	if review.Drift == nil || review.Deployments == nil {
+		t.Fatalf("expected the other sections to be kept, got %s", text)
+	}
+}
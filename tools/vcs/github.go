@@ -0,0 +1,121 @@
+package vcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultGitHubAPIURL = "https://api.github.com"
+
+// GitHub files issues through the GitHub REST API.
+type GitHub struct {
+	token      string
+	apiURL     string
+	httpClient *http.Client
+}
+
+// GitHubOption configures a GitHub tracker.
+type GitHubOption func(*GitHub)
+
+// WithGitHubAPIURL sets the REST API base URL (for GitHub Enterprise Server).
+func WithGitHubAPIURL(apiURL string) GitHubOption {
+	return func(g *GitHub) {
+		g.apiURL = strings.TrimSuffix(apiURL, "/")
+	}
+}
+
+// WithGitHubHTTPClient sets the HTTP client used for API calls.
+func WithGitHubHTTPClient(httpClient *http.Client) GitHubOption {
+	return func(g *GitHub) {
+		g.httpClient = httpClient
+	}
+}
+
+// NewGitHub creates a GitHub issue tracker authenticated with token.
+func NewGitHub(token string, opts ...GitHubOption) (*GitHub, error) {
+	if token == "" {
+		return nil, fmt.Errorf("GitHub token is required")
+	}
+	g := &GitHub{
+		token:      token,
+		apiURL:     defaultGitHubAPIURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g, nil
+}
+
+// CreateIssue implements IssueTracker.
+//
+// POST /repos/{owner}/{repo}/issues
+func (g *GitHub) CreateIssue(ctx context.Context, repository string, issue Issue) (*CreatedIssue, error) {
+	owner, repo, err := parseGitHubRepository(repository)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(issue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode issue: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/issues", g.apiURL, owner, repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+g.token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(body, &apiErr)
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return nil, fmt.Errorf("GitHub API error (status %d): %s", resp.StatusCode, apiErr.Message)
+	}
+
+	var created struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.Unmarshal(body, &created); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &CreatedIssue{Number: created.Number, URL: created.HTMLURL}, nil
+}
+
+// parseGitHubRepository splits "github.com/owner/repo" into owner and repo.
+func parseGitHubRepository(repository string) (string, string, error) {
+	path, ok := strings.CutPrefix(repository, "github.com/")
+	if !ok {
+		return "", "", fmt.Errorf("%w: %s", ErrUnsupportedRepository, repository)
+	}
+	owner, repo, ok := strings.Cut(strings.TrimSuffix(path, ".git"), "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", fmt.Errorf("%w: %s", ErrUnsupportedRepository, repository)
+	}
+	return owner, repo, nil
+}
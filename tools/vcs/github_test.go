@@ -0,0 +1,90 @@
+package vcs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitHub_CreateIssue(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/acme/infra/issues" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer gh-token" {
+			t.Errorf("unexpected Authorization header: %q", got)
+		}
+		var issue Issue
+		if err := json.NewDecoder(r.Body).Decode(&issue); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		if issue.Title != "Drift" || len(issue.Labels) != 1 {
+			t.Errorf("unexpected issue: %+v", issue)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number":42,"html_url":"https://github.com/acme/infra/issues/42"}`))
+	}))
+	defer ts.Close()
+
+	g, err := NewGitHub("gh-token", WithGitHubAPIURL(ts.URL+"/"))
+	if err != nil {
+		t.Fatalf("NewGitHub error: %v", err)
+	}
+	created, err := g.CreateIssue(context.Background(), "github.com/acme/infra", Issue{Title: "Drift", Body: "body", Labels: []string{"drift"}})
+	if err != nil {
+		t.Fatalf("CreateIssue error: %v", err)
+	}
+	if created.Number != 42 || created.URL != "https://github.com/acme/infra/issues/42" {
+		t.Fatalf("unexpected result: %+v", created)
+	}
+}
+
+func TestGitHub_CreateIssueAPIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+		_, _ = w.Write([]byte(`{"message":"Issues are disabled for this repo"}`))
+	}))
+	defer ts.Close()
+
+	g, _ := NewGitHub("gh-token", WithGitHubAPIURL(ts.URL))
+	_, err := g.CreateIssue(context.Background(), "github.com/acme/infra", Issue{Title: "Drift"})
+	if err == nil || err.Error() != "GitHub API error (status 410): Issues are disabled for this repo" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParseGitHubRepository(t *testing.T) {
+	tests := []struct {
+		repository string
+		owner      string
+		repo       string
+		wantErr    bool
+	}{
+		{repository: "github.com/acme/infra", owner: "acme", repo: "infra"},
+		{repository: "github.com/acme/infra.git", owner: "acme", repo: "infra"},
+		{repository: "gitlab.com/acme/infra", wantErr: true},
+		{repository: "github.com/acme", wantErr: true},
+		{repository: "github.com/acme/infra/sub", wantErr: true},
+	}
+	for _, tt := range tests {
+		owner, repo, err := parseGitHubRepository(tt.repository)
+		if tt.wantErr {
+			if !errors.Is(err, ErrUnsupportedRepository) {
+				t.Errorf("%s: expected ErrUnsupportedRepository, got %v", tt.repository, err)
+			}
+			continue
+		}
+		if err != nil || owner != tt.owner || repo != tt.repo {
+			t.Errorf("%s: got %q/%q, %v", tt.repository, owner, repo, err)
+		}
+	}
+}
+
+func TestNewGitHub_RequiresToken(t *testing.T) {
+	if _, err := NewGitHub(""); err == nil {
+		t.Fatal("expected error for empty token")
+	}
+}
@@ -0,0 +1,32 @@
+// Package vcs contains integrations with version control providers used by
+// tools that write back to the repositories managed in Terramate Cloud.
+package vcs
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrUnsupportedRepository is returned when a tracker cannot file issues for
+// the given repository (e.g. a GitLab repository passed to the GitHub tracker).
+var ErrUnsupportedRepository = errors.New("repository is not supported by this issue tracker")
+
+// Issue is an issue ready to be filed.
+type Issue struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+// CreatedIssue identifies an issue filed by an IssueTracker.
+type CreatedIssue struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+}
+
+// IssueTracker files issues against a repository.
+//
+// repository uses the Terramate Cloud notation, e.g. "github.com/acme/infra".
+type IssueTracker interface {
+	CreateIssue(ctx context.Context, repository string, issue Issue) (*CreatedIssue, error)
+}